@@ -0,0 +1,172 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// AccountKeyProvider provides the public keys of accounts, which are used to
+// verify the signatures of transactions before they are executed.
+type AccountKeyProvider interface {
+	// AccountKeys returns the public keys of the account with the given address.
+	AccountKeys(address flow.Address) ([]flow.AccountPublicKey, error)
+}
+
+// ExecutionNodeAccountKeys provides account keys by querying an execution node
+// for the account at the latest sealed block. Keys added to an account since
+// then are not known yet.
+type ExecutionNodeAccountKeys struct {
+	state        protocol.State
+	executionRPC execproto.ExecutionAPIClient
+	timeout      time.Duration
+}
+
+// NewExecutionNodeAccountKeys returns a provider of account keys querying the
+// given execution node client, giving up on requests after the given timeout.
+func NewExecutionNodeAccountKeys(state protocol.State, executionRPC execproto.ExecutionAPIClient, timeout time.Duration) *ExecutionNodeAccountKeys {
+	return &ExecutionNodeAccountKeys{
+		state:        state,
+		executionRPC: executionRPC,
+		timeout:      timeout,
+	}
+}
+
+func (e *ExecutionNodeAccountKeys) AccountKeys(address flow.Address) ([]flow.AccountPublicKey, error) {
+	sealed, err := e.state.Sealed().Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest sealed header: %w", err)
+	}
+	blockID := sealed.ID()
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+
+	res, err := e.executionRPC.GetAccountAtBlockID(ctx, &execproto.GetAccountAtBlockIDRequest{
+		Address: address.Bytes(),
+		BlockId: blockID[:],
+	})
+	if status.Code(err) == codes.NotFound {
+		// an account which does not exist has no keys
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get account from execution node: %w", err)
+	}
+
+	account, err := convert.MessageToAccount(res.GetAccount())
+	if err != nil {
+		return nil, fmt.Errorf("could not convert account message: %w", err)
+	}
+
+	return account.Keys, nil
+}
+
+// CachedAccountKeys caches the account keys of another provider for a limited
+// time. As keys are rarely modified, this avoids querying the keys of busy
+// accounts for every transaction. Cached keys may have outdated sequence
+// numbers, which are lower than the current ones, so that some stale
+// transactions are let through. Keys added to an account are only known once
+// its cached keys have expired.
+type CachedAccountKeys struct {
+	provider AccountKeyProvider
+	ttl      time.Duration
+	now      func() time.Time
+	cache    *lru.Cache // safe for concurrent use
+}
+
+type cachedKeys struct {
+	keys    []flow.AccountPublicKey
+	fetched time.Time
+}
+
+// NewCachedAccountKeys returns a provider caching the keys of up to size
+// accounts from the given provider for the given time.
+func NewCachedAccountKeys(provider AccountKeyProvider, size int, ttl time.Duration) (*CachedAccountKeys, error) {
+	cache, err := lru.New(size)
+	if err != nil {
+		return nil, fmt.Errorf("could not create account keys cache: %w", err)
+	}
+	return &CachedAccountKeys{
+		provider: provider,
+		ttl:      ttl,
+		now:      time.Now,
+		cache:    cache,
+	}, nil
+}
+
+func (c *CachedAccountKeys) AccountKeys(address flow.Address) ([]flow.AccountPublicKey, error) {
+	now := c.now()
+	cached, ok := c.cache.Get(address)
+	if ok && now.Sub(cached.(cachedKeys).fetched) < c.ttl {
+		return cached.(cachedKeys).keys, nil
+	}
+
+	keys, err := c.provider.AccountKeys(address)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Add(address, cachedKeys{keys: keys, fetched: now})
+
+	return keys, nil
+}
+
+// AccountKeyWeightThreshold is the total weight of keys required to sign for
+// an account, as enforced during execution.
+const AccountKeyWeightThreshold = flow.AccountKeyWeightThreshold
+
+// errUnverifiable indicates that a transaction signature can not be verified,
+// as the keys of the account are not available or do not include the key yet.
+var errUnverifiable = errors.New("account key is not available")
+
+// accountKeyLookup fetches the keys of every account at most once while
+// validating a transaction.
+type accountKeyLookup struct {
+	provider AccountKeyProvider
+	accounts map[flow.Address][]flow.AccountPublicKey
+}
+
+func newAccountKeyLookup(provider AccountKeyProvider) *accountKeyLookup {
+	return &accountKeyLookup{
+		provider: provider,
+		accounts: make(map[flow.Address][]flow.AccountPublicKey),
+	}
+}
+
+// key returns the key of the account with the given index, or errUnverifiable
+// if the keys of the account can not be retrieved or do not include the index,
+// which may have been added since. A revoked key remains revoked, so its use is
+// an error.
+func (l *accountKeyLookup) key(address flow.Address, index uint64) (*flow.AccountPublicKey, error) {
+	keys, ok := l.accounts[address]
+	if !ok {
+		var err error
+		keys, err = l.provider.AccountKeys(address)
+		if err != nil {
+			return nil, errUnverifiable
+		}
+		l.accounts[address] = keys
+	}
+
+	for i := range keys {
+		if uint64(keys[i].Index) != index {
+			continue
+		}
+		if keys[i].Revoked {
+			return nil, RevokedAccountKeyError{Address: address, KeyIndex: index}
+		}
+		return &keys[i], nil
+	}
+
+	return nil, errUnverifiable
+}
@@ -2,11 +2,14 @@ package access
 
 import (
 	"context"
+	"strings"
 
 	"github.com/onflow/flow/protobuf/go/flow/access"
 	"github.com/onflow/flow/protobuf/go/flow/entities"
 
+	accessext "github.com/onflow/flow-go/access/protobuf"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -22,16 +25,21 @@ type API interface {
 	GetLatestBlock(ctx context.Context, isSealed bool) (*flow.Block, error)
 	GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error)
 	GetBlockByID(ctx context.Context, id flow.Identifier) (*flow.Block, error)
+	SubscribeBlocks(ctx context.Context, startHeight uint64, isSealed bool) (<-chan *flow.Block, error)
 
 	GetCollectionByID(ctx context.Context, id flow.Identifier) (*flow.LightCollection, error)
 
 	SendTransaction(ctx context.Context, tx *flow.TransactionBody) error
 	GetTransaction(ctx context.Context, id flow.Identifier) (*flow.TransactionBody, error)
 	GetTransactionResult(ctx context.Context, id flow.Identifier) (*TransactionResult, error)
+	SubscribeTransactionStatus(ctx context.Context, id flow.Identifier) (<-chan *TransactionResult, error)
+	EstimateTransaction(ctx context.Context, tx *flow.TransactionBody, blockID flow.Identifier, opts EstimateOptions) (*TransactionEstimate, error)
 
 	GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error)
 	GetAccountAtLatestBlock(ctx context.Context, address flow.Address) (*flow.Account, error)
 	GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error)
+	GetAccountWithProof(ctx context.Context, address flow.Address, blockID flow.Identifier) (*AccountWithProof, error)
+	GetRegistersWithProof(ctx context.Context, blockID flow.Identifier, registerIDs []flow.RegisterID) (*RegistersWithProof, error)
 
 	ExecuteScriptAtLatestBlock(ctx context.Context, script []byte, arguments [][]byte) ([]byte, error)
 	ExecuteScriptAtBlockHeight(ctx context.Context, blockHeight uint64, script []byte, arguments [][]byte) ([]byte, error)
@@ -39,14 +47,31 @@ type API interface {
 
 	GetEventsForHeightRange(ctx context.Context, eventType string, startHeight, endHeight uint64) ([]flow.BlockEvents, error)
 	GetEventsForBlockIDs(ctx context.Context, eventType string, blockIDs []flow.Identifier) ([]flow.BlockEvents, error)
+	SubscribeEvents(ctx context.Context, filter EventFilter, startHeight uint64) (<-chan flow.BlockEvents, error)
 }
 
 // TODO: Combine this with flow.TransactionResult?
 type TransactionResult struct {
-	Status       flow.TransactionStatus
-	StatusCode   uint
-	Events       []flow.Event
-	ErrorMessage string
+	Status        flow.TransactionStatus
+	StatusCode    uint
+	ErrorCategory TransactionErrorCategory
+	Events        []flow.Event
+	ErrorMessage  string
+}
+
+// EstimateOptions selects the checks that are skipped when estimating a transaction.
+type EstimateOptions struct {
+	SkipSignatureVerification bool
+	SkipSequenceNumberCheck   bool
+}
+
+// TransactionEstimate is the outcome of executing a transaction without committing its effects.
+type TransactionEstimate struct {
+	ComputationUsed uint64
+	Events          []flow.Event
+	Logs            []string
+	StatusCode      uint
+	ErrorMessage    string
 }
 
 func TransactionResultToMessage(result *TransactionResult) *access.TransactionResultResponse {
@@ -58,6 +83,71 @@ func TransactionResultToMessage(result *TransactionResult) *access.TransactionRe
 	}
 }
 
+// TransactionResultToExtensionMessage converts the result of a transaction to the message of the
+// access extension API, which includes the category of the error the transaction failed with.
+func TransactionResultToExtensionMessage(result *TransactionResult) *accessext.TransactionResultWithCategoryResponse {
+	return &accessext.TransactionResultWithCategoryResponse{
+		Result:        TransactionResultToMessage(result),
+		ErrorCategory: result.ErrorCategory.String(),
+	}
+}
+
+// EventFilter selects the events delivered by an event subscription.
+type EventFilter struct {
+	// EventTypes lists the event types to include, at least one is required.
+	EventTypes []flow.EventType
+	// Addresses optionally restricts events to those emitted by contracts deployed
+	// at one of the given addresses. An empty list matches every address.
+	Addresses []flow.Address
+}
+
+// Match returns true if the given event of one of the filter's types passes the address filter.
+func (f EventFilter) Match(event flow.Event) bool {
+	if len(f.Addresses) == 0 {
+		return true
+	}
+
+	// contract event types are of the form A.<address>.<contract>.<event>
+	parts := strings.Split(string(event.Type), ".")
+	if len(parts) < 4 || parts[0] != "A" {
+		return false
+	}
+
+	address := flow.HexToAddress(parts[1])
+	for _, a := range f.Addresses {
+		if a == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RegistersWithProof contains register values read from the execution state sealed as of a block,
+// together with a proof of the values against the sealed state commitment.
+type RegistersWithProof struct {
+	// BlockID is the block the registers were requested at.
+	BlockID flow.Identifier
+	// SealedBlockID is the latest block sealed as of BlockID, the values are read from its state.
+	SealedBlockID flow.Identifier
+	// Commitment is the state commitment of the seal for SealedBlockID.
+	Commitment flow.StateCommitment
+	// RegisterIDs and Values are the registers and their values, in the same order. The value of
+	// a register which is not set is empty.
+	RegisterIDs []flow.RegisterID
+	Values      []flow.RegisterValue
+	// Proof contains a proof per register, in the same order as the registers.
+	Proof *ledger.TrieBatchProof
+}
+
+// AccountWithProof contains an account decoded from the execution state sealed as of a block,
+// together with the registers it was decoded from and their proof. The balance of the account
+// is not stored in these registers, so the balance of the account is not set.
+type AccountWithProof struct {
+	Account *flow.Account
+	RegistersWithProof
+}
+
 // NetworkParameters contains the network-wide parameters for the Flow blockchain.
 type NetworkParameters struct {
 	ChainID flow.ChainID
@@ -80,3 +80,59 @@ type InvalidTxByteSizeError struct {
 func (e InvalidTxByteSizeError) Error() string {
 	return fmt.Sprintf("transaction byte size (%d) exceeds the maximum byte size allowed for a transaction (%d)", e.Actual, e.Maximum)
 }
+
+// RevokedAccountKeyError indicates that a transaction is signed with, or proposed
+// by, a key which is revoked.
+type RevokedAccountKeyError struct {
+	Address  flow.Address
+	KeyIndex uint64
+}
+
+func (e RevokedAccountKeyError) Error() string {
+	return fmt.Sprintf("revoked key %d of account %s", e.KeyIndex, e.Address)
+}
+
+// SignatureVerificationError indicates that a transaction signature is not valid
+// for the account key it claims to be signed with.
+type SignatureVerificationError struct {
+	Address  flow.Address
+	KeyIndex uint64
+}
+
+func (e SignatureVerificationError) Error() string {
+	return fmt.Sprintf("invalid signature with key %d of account %s", e.KeyIndex, e.Address)
+}
+
+// MissingSignatureError indicates that a transaction is not signed with
+// sufficient key weight by an authorizer or the payer.
+type MissingSignatureError struct {
+	Address flow.Address
+}
+
+func (e MissingSignatureError) Error() string {
+	return fmt.Sprintf("account %s does not have sufficient signatures", e.Address)
+}
+
+// MissingProposalKeySignatureError indicates that a transaction is not signed
+// with its proposal key.
+type MissingProposalKeySignatureError struct {
+	Address  flow.Address
+	KeyIndex uint64
+}
+
+func (e MissingProposalKeySignatureError) Error() string {
+	return fmt.Sprintf("proposal key %d of account %s does not have a signature", e.KeyIndex, e.Address)
+}
+
+// StaleSequenceNumberError indicates that the proposal key sequence number of a
+// transaction has already been used.
+type StaleSequenceNumberError struct {
+	Address  flow.Address
+	KeyIndex uint64
+	Current  uint64
+	Provided uint64
+}
+
+func (e StaleSequenceNumberError) Error() string {
+	return fmt.Sprintf("stale sequence number for key %d of account %s: current=%d provided=%d", e.KeyIndex, e.Address, e.Current, e.Provided)
+}
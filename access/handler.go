@@ -9,7 +9,9 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	accessext "github.com/onflow/flow-go/access/protobuf"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/ledger/common/encoding"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -134,6 +136,32 @@ func (h *Handler) GetBlockByID(
 	return blockResponse(block)
 }
 
+// SubscribeBlocks streams every finalized (or sealed) block in order of height, starting at the
+// requested height, until the client disconnects.
+func (h *Handler) SubscribeBlocks(
+	req *accessext.SubscribeBlocksRequest,
+	stream accessext.AccessExtensionAPI_SubscribeBlocksServer,
+) error {
+	blocks, err := h.api.SubscribeBlocks(stream.Context(), req.GetStartHeight(), req.GetIsSealed())
+	if err != nil {
+		return err
+	}
+
+	for block := range blocks {
+		msg, err := blockResponse(block)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		err = stream.Send(msg)
+		if err != nil {
+			return err
+		}
+	}
+
+	return stream.Context().Err()
+}
+
 // GetCollectionByID gets a collection by ID.
 func (h *Handler) GetCollectionByID(
 	ctx context.Context,
@@ -183,6 +211,42 @@ func (h *Handler) SendTransaction(
 	}, nil
 }
 
+// EstimateTransaction executes a transaction against the state at the given block without committing
+// its effects, and reports the computation used, the emitted events and logs, and the error the
+// transaction would fail with, if any.
+func (h *Handler) EstimateTransaction(
+	ctx context.Context,
+	req *accessext.EstimateTransactionRequest,
+) (*accessext.EstimateTransactionResponse, error) {
+	tx, err := convert.MessageToTransaction(req.GetTransaction(), h.chain)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	opts := EstimateOptions{
+		SkipSignatureVerification: req.GetSkipSignatureVerification(),
+		SkipSequenceNumberCheck:   req.GetSkipSequenceNumberCheck(),
+	}
+
+	estimate, err := h.api.EstimateTransaction(ctx, &tx, blockID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accessext.EstimateTransactionResponse{
+		ComputationUsed: estimate.ComputationUsed,
+		Events:          convert.EventsToMessages(estimate.Events),
+		Logs:            estimate.Logs,
+		StatusCode:      uint32(estimate.StatusCode),
+		ErrorMessage:    estimate.ErrorMessage,
+	}, nil
+}
+
 // GetTransaction gets a transaction by ID.
 func (h *Handler) GetTransaction(
 	ctx context.Context,
@@ -221,6 +285,51 @@ func (h *Handler) GetTransactionResult(
 	return TransactionResultToMessage(result), nil
 }
 
+// GetTransactionResultWithCategory gets the result of a transaction by ID, together with the category
+// of the error the transaction failed with.
+func (h *Handler) GetTransactionResultWithCategory(
+	ctx context.Context,
+	req *access.GetTransactionRequest,
+) (*accessext.TransactionResultWithCategoryResponse, error) {
+	id, err := convert.TransactionID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.api.GetTransactionResult(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return TransactionResultToExtensionMessage(result), nil
+}
+
+// SubscribeTransactionStatus streams the result of a transaction every time its status changes,
+// until the transaction is sealed or expired, or the client disconnects.
+func (h *Handler) SubscribeTransactionStatus(
+	req *access.GetTransactionRequest,
+	stream accessext.AccessExtensionAPI_SubscribeTransactionStatusServer,
+) error {
+	id, err := convert.TransactionID(req.GetId())
+	if err != nil {
+		return err
+	}
+
+	results, err := h.api.SubscribeTransactionStatus(stream.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	for result := range results {
+		err = stream.Send(TransactionResultToExtensionMessage(result))
+		if err != nil {
+			return err
+		}
+	}
+
+	return stream.Context().Err()
+}
+
 // GetAccount returns an account by address at the latest sealed block.
 func (h *Handler) GetAccount(
 	ctx context.Context,
@@ -292,6 +401,62 @@ func (h *Handler) GetAccountAtBlockHeight(
 	}, nil
 }
 
+// GetRegistersWithProof returns the values of registers in the execution state sealed as of the given
+// block, together with a proof of the values against the sealed state commitment.
+func (h *Handler) GetRegistersWithProof(
+	ctx context.Context,
+	req *accessext.GetRegistersWithProofRequest,
+) (*accessext.RegistersWithProofResponse, error) {
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	registerIDs := make([]flow.RegisterID, 0, len(req.GetRegisterIds()))
+	for _, id := range req.GetRegisterIds() {
+		registerIDs = append(registerIDs, flow.NewRegisterID(string(id.GetOwner()), string(id.GetController()), string(id.GetKey())))
+	}
+
+	registers, err := h.api.GetRegistersWithProof(ctx, blockID, registerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return registersWithProofToMessage(registers), nil
+}
+
+// GetAccountWithProof returns an account from the execution state sealed as of the given block, together
+// with the registers it is decoded from and a proof of their values against the sealed state commitment.
+func (h *Handler) GetAccountWithProof(
+	ctx context.Context,
+	req *accessext.GetAccountWithProofRequest,
+) (*accessext.AccountWithProofResponse, error) {
+	address, err := convert.Address(req.GetAddress(), h.chain)
+	if err != nil {
+		return nil, err
+	}
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := h.api.GetAccountWithProof(ctx, address, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	accountMsg, err := convert.AccountToMessage(account.Account)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &accessext.AccountWithProofResponse{
+		Account:   accountMsg,
+		Registers: registersWithProofToMessage(&account.RegistersWithProof),
+	}, nil
+}
+
 // ExecuteScriptAtLatestBlock executes a script at a the latest block.
 func (h *Handler) ExecuteScriptAtLatestBlock(
 	ctx context.Context,
@@ -405,6 +570,80 @@ func (h *Handler) GetEventsForBlockIDs(
 	}, nil
 }
 
+// SubscribeEvents streams the events matching the requested filter for every sealed block in order
+// of height, starting at the requested height, until the client disconnects.
+func (h *Handler) SubscribeEvents(
+	req *accessext.SubscribeEventsRequest,
+	stream accessext.AccessExtensionAPI_SubscribeEventsServer,
+) error {
+	var filter EventFilter
+	for _, eventType := range req.GetEventTypes() {
+		_, err := convert.EventType(eventType)
+		if err != nil {
+			return err
+		}
+		filter.EventTypes = append(filter.EventTypes, flow.EventType(eventType))
+	}
+	for _, rawAddress := range req.GetAddresses() {
+		address, err := convert.Address(rawAddress, h.chain)
+		if err != nil {
+			return err
+		}
+		filter.Addresses = append(filter.Addresses, address)
+	}
+
+	results, err := h.api.SubscribeEvents(stream.Context(), filter, req.GetStartHeight())
+	if err != nil {
+		return err
+	}
+
+	for result := range results {
+		msg, err := blockEventsToMessage(result)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		err = stream.Send(&access.EventsResponse{
+			Results: []*access.EventsResponse_Result{msg},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return stream.Context().Err()
+}
+
+func registersWithProofToMessage(registers *RegistersWithProof) *accessext.RegistersWithProofResponse {
+	registerIDs := make([]*accessext.RegisterID, 0, len(registers.RegisterIDs))
+	for _, id := range registers.RegisterIDs {
+		registerIDs = append(registerIDs, &accessext.RegisterID{
+			Owner:      []byte(id.Owner),
+			Controller: []byte(id.Controller),
+			Key:        []byte(id.Key),
+		})
+	}
+
+	values := make([][]byte, 0, len(registers.Values))
+	for _, value := range registers.Values {
+		values = append(values, value)
+	}
+
+	var proof []byte
+	if registers.Proof != nil {
+		proof = encoding.EncodeTrieBatchProof(registers.Proof)
+	}
+
+	return &accessext.RegistersWithProofResponse{
+		BlockId:       convert.IdentifierToMessage(registers.BlockID),
+		SealedBlockId: convert.IdentifierToMessage(registers.SealedBlockID),
+		Commitment:    registers.Commitment,
+		RegisterIds:   registerIDs,
+		Values:        values,
+		Proof:         proof,
+	}
+}
+
 func blockResponse(block *flow.Block) (*access.BlockResponse, error) {
 	msg, err := convert.BlockToMessage(block)
 	if err != nil {
@@ -0,0 +1,392 @@
+// Package lightclient follows the chain from a trusted root without trusting
+// the access node serving the blocks. Each block is checked against the QC
+// of its child, signed by the consensus committee of the block's epoch, and
+// blocks are finalized by the same 3-chain rule as HotStuff. Seals included in
+// finalized blocks provide trusted state commitments, which register and
+// account proofs can be verified against with the access/verifier package.
+//
+// Service events are not part of the seal ID in this protocol version, so they
+// are not covered by the payload hash, and the light client can not tell them
+// apart from service events inserted by the access node. The light client
+// therefore does not follow epoch transitions from the EpochSetup and
+// EpochCommit service events in the chain. Instead, each next epoch must be
+// added with AddEpoch from a trusted source, such as a protocol state snapshot
+// of a trusted node, and blocks of epochs which were not added are rejected
+// with ErrUnknownEpoch.
+package lightclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/consensus/hotstuff/verification"
+	"github.com/onflow/flow-go/model/encoding"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/signature"
+)
+
+// DefaultCacheSize is the default number of finalized blocks and sealed state
+// commitments remembered by the light client.
+const DefaultCacheSize = 1000
+
+// Source provides finalized blocks by height, such as the Access API.
+type Source interface {
+	GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error)
+}
+
+// Option configures the light client.
+type Option func(*Client)
+
+// WithVerifierFactory sets the factory of the verifiers of QCs. By default, the
+// combined staking and random beacon signatures of the consensus committee are
+// verified.
+func WithVerifierFactory(factory VerifierFactory) Option {
+	return func(c *Client) {
+		c.verifierFactory = factory
+	}
+}
+
+// WithCacheSize sets the number of finalized blocks and sealed state
+// commitments remembered by the light client.
+func WithCacheSize(size int) Option {
+	return func(c *Client) {
+		c.cacheSize = size
+	}
+}
+
+// CombinedVerifierFactory creates verifiers of QCs with combined staking and
+// random beacon signatures, as signed by the main consensus committee.
+func CombinedVerifierFactory(committee hotstuff.Committee) (hotstuff.Verifier, error) {
+	staking := signature.NewAggregationVerifier(encoding.ConsensusVoteTag)
+	beacon := signature.NewThresholdVerifier(encoding.RandomBeaconTag)
+	merger := signature.NewCombiner()
+	return verification.NewCombinedVerifier(committee, staking, beacon, merger), nil
+}
+
+// Client is a light client verifying the chain from a trusted root. It is safe
+// for concurrent use.
+type Client struct {
+	verifierFactory VerifierFactory
+	cacheSize       int
+
+	mu           sync.RWMutex
+	epochs       []*epoch     // trusted epochs, ordered by view, starting with the epoch of the finalized block
+	finalized    *flow.Header // latest finalized block
+	pending      []*extension // verified descendants of the finalized block, all but the last one are certified
+	latestSeal   *flow.Seal   // latest seal as of the finalized block
+	sealedHeight uint64       // height of the block sealed by the latest seal
+	finalizedIDs *lru.Cache   // height -> ID of finalized blocks
+	commitments  *lru.Cache   // block ID -> sealed state commitment
+}
+
+// extension is a verified block together with the latest seal as of the block.
+type extension struct {
+	block        *flow.Block
+	latestSeal   *flow.Seal
+	sealedHeight uint64
+}
+
+// New creates a new light client starting from the given trusted root.
+func New(root *Root, opts ...Option) (*Client, error) {
+
+	c := &Client{
+		verifierFactory: CombinedVerifierFactory,
+		cacheSize:       DefaultCacheSize,
+		finalized:       root.Header,
+		latestSeal:      root.Seal,
+		sealedHeight:    root.SealedHeader.Height,
+	}
+	for _, apply := range opts {
+		apply(c)
+	}
+
+	if root.Seal.BlockID != root.SealedHeader.ID() {
+		return nil, fmt.Errorf("root seal is not for the sealed root block")
+	}
+	if root.SealedHeader.Height > root.Header.Height {
+		return nil, fmt.Errorf("sealed root block is above root block")
+	}
+
+	var err error
+	c.finalizedIDs, err = lru.New(c.cacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not create finalized block cache: %w", err)
+	}
+	c.commitments, err = lru.New(c.cacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not create commitment cache: %w", err)
+	}
+	c.finalizedIDs.Add(root.Header.Height, root.Header.ID())
+	c.finalizedIDs.Add(root.SealedHeader.Height, root.SealedHeader.ID())
+	c.commitments.Add(root.Seal.BlockID, root.Seal.FinalState)
+
+	if len(root.Epochs) == 0 {
+		return nil, fmt.Errorf("root has no epochs")
+	}
+	for i, rootEpoch := range root.Epochs {
+		e, err := newEpoch(rootEpoch.Setup, rootEpoch.Commit, c.verifierFactory)
+		if err != nil {
+			return nil, fmt.Errorf("invalid root epoch %d: %w", rootEpoch.Setup.Counter, err)
+		}
+		if i > 0 {
+			previous := c.epochs[i-1]
+			if e.counter != previous.counter+1 || e.firstView != previous.finalView+1 {
+				return nil, fmt.Errorf("root epoch %d does not follow epoch %d", e.counter, previous.counter)
+			}
+		}
+		c.epochs = append(c.epochs, e)
+	}
+	_, ok := findEpoch(c.epochs, root.Header.View)
+	if !ok {
+		return nil, fmt.Errorf("root block is not within the root epochs")
+	}
+
+	return c, nil
+}
+
+// Extend verifies the given block as the child of the latest block known to
+// the light client. As the QC of the new block certifies its parent, blocks
+// are finalized once they are followed by a 3-chain of blocks with consecutive
+// views. Blocks failing verification are rejected with an InvalidBlockError,
+// and blocks of an epoch which was not added yet with ErrUnknownEpoch.
+func (c *Client) Extend(block *flow.Block) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	header := block.Header
+	parent := c.finalized
+	parentSeal, parentSealedHeight := c.latestSeal, c.sealedHeight
+	if len(c.pending) > 0 {
+		tip := c.pending[len(c.pending)-1]
+		parent = tip.block.Header
+		parentSeal, parentSealedHeight = tip.latestSeal, tip.sealedHeight
+	}
+
+	if !block.Valid() {
+		return newInvalidBlockError(header, fmt.Errorf("payload does not match payload hash"))
+	}
+	if header.ChainID != parent.ChainID {
+		return newInvalidBlockError(header, fmt.Errorf("chain %s does not match parent chain %s", header.ChainID, parent.ChainID))
+	}
+	if header.ParentID != parent.ID() {
+		return newInvalidBlockError(header, fmt.Errorf("parent %x is not the latest block %x", header.ParentID, parent.ID()))
+	}
+	if header.Height != parent.Height+1 {
+		return newInvalidBlockError(header, fmt.Errorf("height %d does not follow parent height %d", header.Height, parent.Height))
+	}
+	if header.View <= parent.View {
+		return newInvalidBlockError(header, fmt.Errorf("view %d is not above parent view %d", header.View, parent.View))
+	}
+
+	// the QC for the parent is signed by the committee of the parent's epoch
+	parentEpoch, ok := findEpoch(c.epochs, parent.View)
+	if !ok {
+		return fmt.Errorf("no epoch for view %d of verified block", parent.View)
+	}
+	qc := &flow.QuorumCertificate{
+		View:      parent.View,
+		BlockID:   parent.ID(),
+		SignerIDs: header.ParentVoterIDs,
+		SigData:   header.ParentVoterSig,
+	}
+	err := parentEpoch.validator.ValidateQC(qc, &model.Block{BlockID: parent.ID(), View: parent.View})
+	if model.IsInvalidBlockError(err) {
+		return newInvalidBlockError(header, fmt.Errorf("invalid QC for parent: %w", err))
+	}
+	if err != nil {
+		return fmt.Errorf("could not validate QC for parent: %w", err)
+	}
+
+	// the block is verified by the committee of its epoch once it is certified,
+	// which must be known from a trusted source
+	_, ok = findEpoch(c.epochs, header.View)
+	if !ok {
+		return fmt.Errorf("could not extend with block %x at view %d: %w", block.ID(), header.View, ErrUnknownEpoch)
+	}
+
+	latestSeal, sealedHeight, err := c.verifySeals(block, parentSeal, parentSealedHeight)
+	if err != nil {
+		return newInvalidBlockError(header, fmt.Errorf("invalid seals: %w", err))
+	}
+
+	c.pending = append(c.pending, &extension{
+		block:        block,
+		latestSeal:   latestSeal,
+		sealedHeight: sealedHeight,
+	})
+	c.finalize()
+
+	return nil
+}
+
+// AddEpoch adds the epoch following the latest epoch known to the light client.
+// The epoch must be obtained from a trusted source, as its consensus committee
+// is trusted to certify the blocks of the epoch.
+func (c *Client) AddEpoch(next Epoch) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, err := newEpoch(next.Setup, next.Commit, c.verifierFactory)
+	if err != nil {
+		return fmt.Errorf("invalid epoch %d: %w", next.Setup.Counter, err)
+	}
+	latest := c.epochs[len(c.epochs)-1]
+	if e.counter != latest.counter+1 || e.firstView != latest.finalView+1 {
+		return fmt.Errorf("epoch %d does not follow epoch %d", e.counter, latest.counter)
+	}
+	c.epochs = append(c.epochs, e)
+
+	return nil
+}
+
+// Sync extends the light client with the blocks following its latest block,
+// until the source has no further blocks. Once the blocks reach an epoch which
+// was not added yet, Sync fails with ErrUnknownEpoch, and can be resumed after
+// adding the epoch.
+func (c *Client) Sync(ctx context.Context, source Source) error {
+	for {
+		height := c.Height() + 1
+		block, err := source.GetBlockByHeight(ctx, height)
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not get block at height %d: %w", height, err)
+		}
+		err = c.Extend(block)
+		if err != nil {
+			return fmt.Errorf("could not extend with block at height %d: %w", height, err)
+		}
+	}
+}
+
+// Height returns the height of the latest verified block, which is not
+// necessarily finalized.
+func (c *Client) Height() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.pending) > 0 {
+		return c.pending[len(c.pending)-1].block.Header.Height
+	}
+	return c.finalized.Height
+}
+
+// Finalized returns the latest finalized block.
+func (c *Client) Finalized() *flow.Header {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.finalized
+}
+
+// LatestSeal returns the latest seal as of the latest finalized block.
+func (c *Client) LatestSeal() *flow.Seal {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.latestSeal
+}
+
+// Epoch returns the counter of the epoch of the latest finalized block.
+func (c *Client) Epoch() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	e, _ := findEpoch(c.epochs, c.finalized.View)
+	return e.counter
+}
+
+// Commitment returns the state commitment of the given block, if the block is
+// sealed by a finalized seal which is still remembered by the light client.
+func (c *Client) Commitment(blockID flow.Identifier) (flow.StateCommitment, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	commitment, ok := c.commitments.Get(blockID)
+	if !ok {
+		return nil, false
+	}
+	return commitment.(flow.StateCommitment), true
+}
+
+// verifySeals checks that the seals of the block seal the blocks following the
+// latest sealed block in order, and returns the latest seal as of the block.
+func (c *Client) verifySeals(block *flow.Block, latestSeal *flow.Seal, sealedHeight uint64) (*flow.Seal, uint64, error) {
+
+	for _, seal := range block.Payload.Seals {
+		height := sealedHeight + 1
+		if height >= block.Header.Height {
+			return nil, 0, fmt.Errorf("seal for block %x is not for an ancestor", seal.BlockID)
+		}
+		blockID, ok := c.blockIDByHeight(height)
+		if ok && seal.BlockID != blockID {
+			return nil, 0, fmt.Errorf("seal for block %x is not for the next unsealed block %x", seal.BlockID, blockID)
+		}
+		latestSeal, sealedHeight = seal, height
+	}
+
+	return latestSeal, sealedHeight, nil
+}
+
+// blockIDByHeight returns the ID of the verified block at the given height. Old
+// finalized blocks may have been evicted from the cache.
+func (c *Client) blockIDByHeight(height uint64) (flow.Identifier, bool) {
+	if height > c.finalized.Height {
+		index := height - c.finalized.Height - 1
+		if index < uint64(len(c.pending)) {
+			return c.pending[index].block.ID(), true
+		}
+		return flow.ZeroID, false
+	}
+	blockID, ok := c.finalizedIDs.Get(height)
+	if !ok {
+		return flow.ZeroID, false
+	}
+	return blockID.(flow.Identifier), true
+}
+
+// finalize finalizes the pending blocks up to the latest block which is
+// followed by two blocks with consecutive views, the last of them certified.
+func (c *Client) finalize() {
+
+	last := -1
+	// the last pending block is not certified yet
+	for i := 0; i+3 < len(c.pending); i++ {
+		first, second, third := c.pending[i].block.Header, c.pending[i+1].block.Header, c.pending[i+2].block.Header
+		if second.View == first.View+1 && third.View == second.View+1 {
+			last = i
+		}
+	}
+	if last < 0 {
+		return
+	}
+
+	for _, finalized := range c.pending[:last+1] {
+		header := finalized.block.Header
+		c.finalized = header
+		c.finalizedIDs.Add(header.Height, header.ID())
+		c.latestSeal, c.sealedHeight = finalized.latestSeal, finalized.sealedHeight
+		for _, seal := range finalized.block.Payload.Seals {
+			c.commitments.Add(seal.BlockID, seal.FinalState)
+		}
+	}
+	c.pending = c.pending[last+1:]
+
+	// forget the epochs which ended before the finalized block
+	for len(c.epochs) > 1 && c.epochs[0].finalView < c.finalized.View {
+		c.epochs = c.epochs[1:]
+	}
+}
+
+// findEpoch returns the epoch containing the given view.
+func findEpoch(epochs []*epoch, view uint64) (*epoch, bool) {
+	for _, e := range epochs {
+		if view >= e.firstView && view <= e.finalView {
+			return e, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,348 @@
+package lightclient_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/lightclient"
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// idVerifier accepts QCs whose signature is the ID of the certified block, so
+// that tests do not depend on the signature schemes.
+type idVerifier struct{}
+
+func (idVerifier) VerifyVote(_ flow.Identifier, sigData []byte, block *model.Block) (bool, error) {
+	return bytes.Equal(sigData, block.BlockID[:]), nil
+}
+
+func (idVerifier) VerifyQC(_ []flow.Identifier, sigData []byte, block *model.Block) (bool, error) {
+	return bytes.Equal(sigData, block.BlockID[:]), nil
+}
+
+func (idVerifier) VerifyTimeout(flow.Identifier, []byte, uint64, uint64, flow.Identifier, flow.Identifier) (bool, error) {
+	return false, nil
+}
+
+func (idVerifier) VerifyTC([]flow.Identifier, []byte, uint64, []uint64, []flow.Identifier, flow.Identifier) (bool, error) {
+	return false, nil
+}
+
+func idVerifierFactory(hotstuff.Committee) (hotstuff.Verifier, error) {
+	return idVerifier{}, nil
+}
+
+func epochFixture(counter uint64, firstView uint64, finalView uint64, participants flow.IdentityList) lightclient.Epoch {
+	setup := unittest.EpochSetupFixture(
+		unittest.SetupWithCounter(counter),
+		unittest.WithParticipants(participants),
+		unittest.WithFinalView(finalView),
+	)
+	setup.FirstView = firstView
+	commit := &flow.EpochCommit{
+		Counter:         counter,
+		DKGParticipants: make(map[flow.Identifier]flow.DKGParticipant),
+	}
+	for i, participant := range participants {
+		commit.DKGParticipants[participant.NodeID] = flow.DKGParticipant{Index: uint(i)}
+	}
+	return lightclient.Epoch{Setup: setup, Commit: commit}
+}
+
+// child returns a child block of the given parent with a QC for the parent
+// signed by the given signers.
+func child(parent *flow.Header, view uint64, signers flow.IdentityList, seals ...*flow.Seal) *flow.Block {
+	parentID := parent.ID()
+	block := &flow.Block{
+		Header: &flow.Header{
+			ChainID:        parent.ChainID,
+			ParentID:       parentID,
+			Height:         parent.Height + 1,
+			View:           view,
+			ParentVoterIDs: signers.NodeIDs(),
+			ParentVoterSig: parentID[:],
+		},
+	}
+	block.SetPayload(flow.Payload{Seals: seals})
+	return block
+}
+
+func sealFixture(block *flow.Block, events ...flow.ServiceEvent) *flow.Seal {
+	return &flow.Seal{
+		BlockID:       block.ID(),
+		ResultID:      unittest.IdentifierFixture(),
+		FinalState:    unittest.StateCommitmentFixture(),
+		ServiceEvents: events,
+	}
+}
+
+type fixture struct {
+	root         *lightclient.Root
+	participants flow.IdentityList
+}
+
+func newFixture() *fixture {
+	participants := unittest.IdentityListFixture(4, unittest.WithRole(flow.RoleConsensus))
+	root := &flow.Header{
+		ChainID: flow.Testnet,
+		Height:  100,
+		View:    100,
+	}
+	return &fixture{
+		root: &lightclient.Root{
+			Header:       root,
+			SealedHeader: root,
+			Seal:         &flow.Seal{BlockID: root.ID(), FinalState: unittest.StateCommitmentFixture()},
+			Epochs:       []lightclient.Epoch{epochFixture(1, 0, 200, participants)},
+		},
+		participants: participants,
+	}
+}
+
+func (f *fixture) client(t *testing.T) *lightclient.Client {
+	client, err := lightclient.New(f.root, lightclient.WithVerifierFactory(idVerifierFactory))
+	require.NoError(t, err)
+	return client
+}
+
+func TestFinalization(t *testing.T) {
+	f := newFixture()
+
+	t.Run("consecutive views", func(t *testing.T) {
+		client := f.client(t)
+
+		b1 := child(f.root.Header, 101, f.participants)
+		b2 := child(b1.Header, 102, f.participants, sealFixture(b1))
+		b3 := child(b2.Header, 103, f.participants)
+		b4 := child(b3.Header, 104, f.participants)
+		b5 := child(b4.Header, 105, f.participants)
+
+		for _, block := range []*flow.Block{b1, b2, b3} {
+			require.NoError(t, client.Extend(block))
+			assert.Equal(t, f.root.Header.ID(), client.Finalized().ID())
+		}
+
+		// b4 certifies b3, completing the 3-chain b1 <- b2 <- b3
+		require.NoError(t, client.Extend(b4))
+		assert.Equal(t, b1.ID(), client.Finalized().ID())
+		_, ok := client.Commitment(b1.ID())
+		assert.False(t, ok)
+
+		// b2 is finalized, and with it the seal of b1
+		require.NoError(t, client.Extend(b5))
+		assert.Equal(t, b2.ID(), client.Finalized().ID())
+		assert.Equal(t, b5.Header.Height, client.Height())
+		assert.Equal(t, b2.Payload.Seals[0], client.LatestSeal())
+		commitment, ok := client.Commitment(b1.ID())
+		require.True(t, ok)
+		assert.Equal(t, b2.Payload.Seals[0].FinalState, commitment)
+	})
+
+	t.Run("view gaps", func(t *testing.T) {
+		client := f.client(t)
+
+		b1 := child(f.root.Header, 101, f.participants)
+		b2 := child(b1.Header, 103, f.participants)
+		b3 := child(b2.Header, 104, f.participants)
+		b4 := child(b3.Header, 105, f.participants)
+		b5 := child(b4.Header, 106, f.participants)
+
+		for _, block := range []*flow.Block{b1, b2, b3, b4} {
+			require.NoError(t, client.Extend(block))
+			assert.Equal(t, f.root.Header.ID(), client.Finalized().ID())
+		}
+
+		// finalizing b2 finalizes its ancestor b1 as well
+		require.NoError(t, client.Extend(b5))
+		assert.Equal(t, b2.ID(), client.Finalized().ID())
+	})
+}
+
+func TestInvalidBlocks(t *testing.T) {
+	f := newFixture()
+	b1 := child(f.root.Header, 101, f.participants)
+
+	unknown := unittest.IdentityListFixture(1, unittest.WithRole(flow.RoleConsensus))
+
+	cases := map[string]func() *flow.Block{
+		"forged QC": func() *flow.Block {
+			block := child(b1.Header, 102, f.participants)
+			block.Header.ParentVoterSig = unittest.SignatureFixture()
+			return block
+		},
+		"insufficient stake": func() *flow.Block {
+			return child(b1.Header, 102, f.participants[:2])
+		},
+		"unknown signer": func() *flow.Block {
+			signers := append(flow.IdentityList{}, f.participants[:3]...)
+			return child(b1.Header, 102, append(signers, unknown...))
+		},
+		"tampered payload": func() *flow.Block {
+			block := child(b1.Header, 102, f.participants)
+			block.Payload.Seals = []*flow.Seal{sealFixture(b1)}
+			return block
+		},
+		"wrong parent": func() *flow.Block {
+			return child(f.root.Header, 102, f.participants)
+		},
+		"view not increasing": func() *flow.Block {
+			return child(b1.Header, 101, f.participants)
+		},
+		"other chain": func() *flow.Block {
+			block := child(b1.Header, 102, f.participants)
+			block.Header.ChainID = flow.Mainnet
+			return block
+		},
+		"seal of unknown block": func() *flow.Block {
+			other := child(f.root.Header, 101, f.participants[1:])
+			return child(b1.Header, 102, f.participants, sealFixture(other))
+		},
+		"seal of non-ancestor": func() *flow.Block {
+			// the parent must be sealed before its child can seal itself
+			return child(b1.Header, 102, f.participants, sealFixture(b1), &flow.Seal{BlockID: unittest.IdentifierFixture()})
+		},
+	}
+
+	for name, block := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := f.client(t)
+			require.NoError(t, client.Extend(b1))
+
+			err := client.Extend(block())
+			require.Error(t, err)
+			assert.True(t, lightclient.IsInvalidBlockError(err))
+			assert.Equal(t, b1.Header.Height, client.Height())
+		})
+	}
+}
+
+func TestEpochTransition(t *testing.T) {
+	f := newFixture()
+	f.root.Epochs = []lightclient.Epoch{epochFixture(1, 0, 110, f.participants)}
+
+	next := unittest.IdentityListFixture(4, unittest.WithRole(flow.RoleConsensus))
+	nextEpoch := epochFixture(2, 111, 300, next)
+
+	// extend returns a client with blocks up to view 110, the final view of
+	// the first epoch, with the given service events sealed in the second block
+	extend := func(t *testing.T, events ...flow.ServiceEvent) (*lightclient.Client, *flow.Block) {
+		client := f.client(t)
+		b1 := child(f.root.Header, 101, f.participants)
+		require.NoError(t, client.Extend(b1))
+		parent := child(b1.Header, 102, f.participants, sealFixture(b1, events...))
+		require.NoError(t, client.Extend(parent))
+		for view := uint64(103); view <= 110; view++ {
+			block := child(parent.Header, view, f.participants)
+			require.NoError(t, client.Extend(block))
+			parent = block
+		}
+		return client, parent
+	}
+
+	t.Run("added epoch", func(t *testing.T) {
+		client, last := extend(t)
+		require.NoError(t, client.AddEpoch(nextEpoch))
+
+		// the first block of the next epoch is certified by the next committee
+		first := child(last.Header, 111, f.participants)
+		require.NoError(t, client.Extend(first))
+
+		err := client.Extend(child(first.Header, 112, f.participants))
+		assert.True(t, lightclient.IsInvalidBlockError(err))
+
+		parent := first
+		for view := uint64(112); view <= 114; view++ {
+			block := child(parent.Header, view, next)
+			require.NoError(t, client.Extend(block))
+			parent = block
+		}
+		assert.Equal(t, first.ID(), client.Finalized().ID())
+		assert.Equal(t, uint64(2), client.Epoch())
+	})
+
+	t.Run("unknown epoch", func(t *testing.T) {
+		client, last := extend(t)
+
+		err := client.Extend(child(last.Header, 111, f.participants))
+		assert.True(t, errors.Is(err, lightclient.ErrUnknownEpoch))
+		assert.False(t, lightclient.IsInvalidBlockError(err))
+
+		// the block is accepted once the epoch is added
+		require.NoError(t, client.AddEpoch(nextEpoch))
+		require.NoError(t, client.Extend(child(last.Header, 111, f.participants)))
+	})
+
+	t.Run("service events are not trusted", func(t *testing.T) {
+		client, last := extend(t, nextEpoch.Setup.ServiceEvent(), nextEpoch.Commit.ServiceEvent())
+
+		err := client.Extend(child(last.Header, 111, f.participants))
+		assert.True(t, errors.Is(err, lightclient.ErrUnknownEpoch))
+	})
+
+	t.Run("epoch not following", func(t *testing.T) {
+		client := f.client(t)
+
+		skipped := epochFixture(3, 111, 300, next)
+		assert.Error(t, client.AddEpoch(skipped))
+
+		gap := epochFixture(2, 112, 300, next)
+		assert.Error(t, client.AddEpoch(gap))
+	})
+}
+
+// source serves blocks by height, as the Access API does.
+type source map[uint64]*flow.Block
+
+func (s source) GetBlockByHeight(_ context.Context, height uint64) (*flow.Block, error) {
+	block, ok := s[height]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no block at height %d", height)
+	}
+	return block, nil
+}
+
+func TestSync(t *testing.T) {
+	f := newFixture()
+
+	blocks := make(source)
+	parent := f.root.Header
+	for view := uint64(101); view <= 110; view++ {
+		block := child(parent, view, f.participants)
+		blocks[block.Header.Height] = block
+		parent = block.Header
+	}
+
+	t.Run("valid blocks", func(t *testing.T) {
+		client := f.client(t)
+		require.NoError(t, client.Sync(context.Background(), blocks))
+		assert.Equal(t, uint64(110), client.Height())
+		assert.Equal(t, blocks[107].ID(), client.Finalized().ID())
+	})
+
+	t.Run("invalid block", func(t *testing.T) {
+		invalid := make(source)
+		for height, block := range blocks {
+			invalid[height] = block
+		}
+		forged := *blocks[105]
+		forgedHeader := *forged.Header
+		forgedHeader.ParentVoterSig = unittest.SignatureFixture()
+		forged.Header = &forgedHeader
+		invalid[105] = &forged
+
+		client := f.client(t)
+		err := client.Sync(context.Background(), invalid)
+		assert.True(t, lightclient.IsInvalidBlockError(err))
+		assert.Equal(t, uint64(104), client.Height())
+	})
+}
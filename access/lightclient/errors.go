@@ -0,0 +1,42 @@
+package lightclient
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ErrUnknownEpoch is returned when a block is in an epoch which was not added
+// to the light client from a trusted source.
+var ErrUnknownEpoch = errors.New("unknown epoch")
+
+// InvalidBlockError is returned when a block can not be verified as a valid
+// extension of the chain followed by the light client.
+type InvalidBlockError struct {
+	BlockID flow.Identifier
+	Height  uint64
+	Err     error
+}
+
+func newInvalidBlockError(header *flow.Header, err error) error {
+	return InvalidBlockError{
+		BlockID: header.ID(),
+		Height:  header.Height,
+		Err:     err,
+	}
+}
+
+func (e InvalidBlockError) Error() string {
+	return fmt.Sprintf("invalid block %x at height %d: %s", e.BlockID, e.Height, e.Err.Error())
+}
+
+func (e InvalidBlockError) Unwrap() error {
+	return e.Err
+}
+
+// IsInvalidBlockError returns whether an error is InvalidBlockError
+func IsInvalidBlockError(err error) bool {
+	var e InvalidBlockError
+	return errors.As(err, &e)
+}
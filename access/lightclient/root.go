@@ -0,0 +1,78 @@
+package lightclient
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/committees"
+	"github.com/onflow/flow-go/consensus/hotstuff/validator"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+)
+
+// Root is the trusted starting point of the light client, such as the root
+// block and seal of a spork or a snapshot obtained from a trusted node.
+type Root struct {
+	Header       *flow.Header // finalized block the client starts from
+	SealedHeader *flow.Header // block sealed by the latest seal as of the root block
+	Seal         *flow.Seal   // latest seal as of the root block
+	Epochs       []Epoch      // epoch of the root block, followed by the next epoch if it is committed
+}
+
+// Epoch is an epoch as defined by its setup and commit service events. The
+// first view of the epoch must be set on the setup event.
+type Epoch struct {
+	Setup  *flow.EpochSetup
+	Commit *flow.EpochCommit
+}
+
+// epoch is an epoch together with the validator of QCs signed by its
+// consensus committee.
+type epoch struct {
+	counter   uint64
+	firstView uint64
+	finalView uint64
+	validator *validator.Validator
+}
+
+// VerifierFactory creates the verifier of QCs signed by the given committee.
+type VerifierFactory func(committee hotstuff.Committee) (hotstuff.Verifier, error)
+
+func newEpoch(setup *flow.EpochSetup, commit *flow.EpochCommit, verifierFactory VerifierFactory) (*epoch, error) {
+
+	if setup.Counter != commit.Counter {
+		return nil, fmt.Errorf("epoch commit counter (%d) does not match epoch setup counter (%d)", commit.Counter, setup.Counter)
+	}
+	if setup.FinalView < setup.FirstView {
+		return nil, fmt.Errorf("final view (%d) is before first view (%d)", setup.FinalView, setup.FirstView)
+	}
+
+	participants := setup.Participants.Filter(filter.IsVotingConsensusCommitteeMember)
+	if len(participants) == 0 {
+		return nil, fmt.Errorf("epoch has no consensus participants")
+	}
+	for _, participant := range participants {
+		_, ok := commit.DKGParticipants[participant.NodeID]
+		if !ok {
+			return nil, fmt.Errorf("consensus participant %x is missing from DKG", participant.NodeID)
+		}
+	}
+
+	// the light client is not part of the committee, so it has no own identity
+	committee, err := committees.NewStaticCommittee(participants, flow.ZeroID, commit.DKGParticipants, commit.DKGGroupKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not create committee: %w", err)
+	}
+	verifier, err := verifierFactory(committee)
+	if err != nil {
+		return nil, fmt.Errorf("could not create verifier: %w", err)
+	}
+
+	e := &epoch{
+		counter:   setup.Counter,
+		firstView: setup.FirstView,
+		finalView: setup.FinalView,
+		validator: validator.New(committee, nil, verifier),
+	}
+	return e, nil
+}
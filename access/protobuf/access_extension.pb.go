@@ -0,0 +1,1465 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        (unknown)
+// source: access_extension.proto
+
+package accessext
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	access "github.com/onflow/flow/protobuf/go/flow/access"
+	entities "github.com/onflow/flow/protobuf/go/flow/entities"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type TransactionResultWithCategoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *access.TransactionResultResponse `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// error_category is the kind of problem the transaction failed with, derived
+	// from its status code: one of none, unknown, signature, sequence_number,
+	// cadence_runtime, storage_limit and event_limit.
+	ErrorCategory string `protobuf:"bytes,2,opt,name=error_category,json=errorCategory,proto3" json:"error_category,omitempty"`
+}
+
+func (x *TransactionResultWithCategoryResponse) Reset() {
+	*x = TransactionResultWithCategoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extension_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionResultWithCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionResultWithCategoryResponse) ProtoMessage() {}
+
+func (x *TransactionResultWithCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extension_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionResultWithCategoryResponse.ProtoReflect.Descriptor instead.
+func (*TransactionResultWithCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_access_extension_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TransactionResultWithCategoryResponse) GetResult() *access.TransactionResultResponse {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *TransactionResultWithCategoryResponse) GetErrorCategory() string {
+	if x != nil {
+		return x.ErrorCategory
+	}
+	return ""
+}
+
+type SubscribeBlocksRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartHeight uint64 `protobuf:"varint,1,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	IsSealed    bool   `protobuf:"varint,2,opt,name=is_sealed,json=isSealed,proto3" json:"is_sealed,omitempty"`
+}
+
+func (x *SubscribeBlocksRequest) Reset() {
+	*x = SubscribeBlocksRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extension_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeBlocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeBlocksRequest) ProtoMessage() {}
+
+func (x *SubscribeBlocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extension_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeBlocksRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeBlocksRequest) Descriptor() ([]byte, []int) {
+	return file_access_extension_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubscribeBlocksRequest) GetStartHeight() uint64 {
+	if x != nil {
+		return x.StartHeight
+	}
+	return 0
+}
+
+func (x *SubscribeBlocksRequest) GetIsSealed() bool {
+	if x != nil {
+		return x.IsSealed
+	}
+	return false
+}
+
+type SubscribeEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// event_types lists the event types to include, at least one is required.
+	EventTypes []string `protobuf:"bytes,1,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	// addresses optionally restricts the events to those emitted by contracts
+	// deployed at one of the addresses.
+	Addresses   [][]byte `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	StartHeight uint64   `protobuf:"varint,3,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+}
+
+func (x *SubscribeEventsRequest) Reset() {
+	*x = SubscribeEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extension_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEventsRequest) ProtoMessage() {}
+
+func (x *SubscribeEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extension_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsRequest) Descriptor() ([]byte, []int) {
+	return file_access_extension_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubscribeEventsRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *SubscribeEventsRequest) GetAddresses() [][]byte {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *SubscribeEventsRequest) GetStartHeight() uint64 {
+	if x != nil {
+		return x.StartHeight
+	}
+	return 0
+}
+
+type RegisterID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Owner      []byte `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Controller []byte `protobuf:"bytes,2,opt,name=controller,proto3" json:"controller,omitempty"`
+	Key        []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *RegisterID) Reset() {
+	*x = RegisterID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extension_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegisterID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterID) ProtoMessage() {}
+
+func (x *RegisterID) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extension_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterID.ProtoReflect.Descriptor instead.
+func (*RegisterID) Descriptor() ([]byte, []int) {
+	return file_access_extension_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RegisterID) GetOwner() []byte {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *RegisterID) GetController() []byte {
+	if x != nil {
+		return x.Controller
+	}
+	return nil
+}
+
+func (x *RegisterID) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type GetRegistersWithProofRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId     []byte        `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	RegisterIds []*RegisterID `protobuf:"bytes,2,rep,name=register_ids,json=registerIds,proto3" json:"register_ids,omitempty"`
+}
+
+func (x *GetRegistersWithProofRequest) Reset() {
+	*x = GetRegistersWithProofRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extension_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRegistersWithProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRegistersWithProofRequest) ProtoMessage() {}
+
+func (x *GetRegistersWithProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extension_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRegistersWithProofRequest.ProtoReflect.Descriptor instead.
+func (*GetRegistersWithProofRequest) Descriptor() ([]byte, []int) {
+	return file_access_extension_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRegistersWithProofRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *GetRegistersWithProofRequest) GetRegisterIds() []*RegisterID {
+	if x != nil {
+		return x.RegisterIds
+	}
+	return nil
+}
+
+type RegistersWithProofResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId []byte `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	// sealed_block_id is the latest block sealed as of block_id, the values are
+	// read from its state.
+	SealedBlockId []byte `protobuf:"bytes,2,opt,name=sealed_block_id,json=sealedBlockId,proto3" json:"sealed_block_id,omitempty"`
+	Commitment    []byte `protobuf:"bytes,3,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	// register_ids and values are the registers and their values, in the same
+	// order.
+	RegisterIds []*RegisterID `protobuf:"bytes,4,rep,name=register_ids,json=registerIds,proto3" json:"register_ids,omitempty"`
+	Values      [][]byte      `protobuf:"bytes,5,rep,name=values,proto3" json:"values,omitempty"`
+	// proof is the encoded batch proof of the registers.
+	Proof []byte `protobuf:"bytes,6,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *RegistersWithProofResponse) Reset() {
+	*x = RegistersWithProofResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extension_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegistersWithProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistersWithProofResponse) ProtoMessage() {}
+
+func (x *RegistersWithProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extension_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistersWithProofResponse.ProtoReflect.Descriptor instead.
+func (*RegistersWithProofResponse) Descriptor() ([]byte, []int) {
+	return file_access_extension_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RegistersWithProofResponse) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *RegistersWithProofResponse) GetSealedBlockId() []byte {
+	if x != nil {
+		return x.SealedBlockId
+	}
+	return nil
+}
+
+func (x *RegistersWithProofResponse) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+func (x *RegistersWithProofResponse) GetRegisterIds() []*RegisterID {
+	if x != nil {
+		return x.RegisterIds
+	}
+	return nil
+}
+
+func (x *RegistersWithProofResponse) GetValues() [][]byte {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *RegistersWithProofResponse) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+type GetAccountWithProofRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	BlockId []byte `protobuf:"bytes,2,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+}
+
+func (x *GetAccountWithProofRequest) Reset() {
+	*x = GetAccountWithProofRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extension_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAccountWithProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountWithProofRequest) ProtoMessage() {}
+
+func (x *GetAccountWithProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extension_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountWithProofRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountWithProofRequest) Descriptor() ([]byte, []int) {
+	return file_access_extension_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetAccountWithProofRequest) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *GetAccountWithProofRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+type AccountWithProofResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Account   *entities.Account           `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Registers *RegistersWithProofResponse `protobuf:"bytes,2,opt,name=registers,proto3" json:"registers,omitempty"`
+}
+
+func (x *AccountWithProofResponse) Reset() {
+	*x = AccountWithProofResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extension_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountWithProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountWithProofResponse) ProtoMessage() {}
+
+func (x *AccountWithProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extension_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountWithProofResponse.ProtoReflect.Descriptor instead.
+func (*AccountWithProofResponse) Descriptor() ([]byte, []int) {
+	return file_access_extension_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AccountWithProofResponse) GetAccount() *entities.Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *AccountWithProofResponse) GetRegisters() *RegistersWithProofResponse {
+	if x != nil {
+		return x.Registers
+	}
+	return nil
+}
+
+type EstimateTransactionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transaction               *entities.Transaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	BlockId                   []byte                `protobuf:"bytes,2,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	SkipSignatureVerification bool                  `protobuf:"varint,3,opt,name=skip_signature_verification,json=skipSignatureVerification,proto3" json:"skip_signature_verification,omitempty"`
+	SkipSequenceNumberCheck   bool                  `protobuf:"varint,4,opt,name=skip_sequence_number_check,json=skipSequenceNumberCheck,proto3" json:"skip_sequence_number_check,omitempty"`
+}
+
+func (x *EstimateTransactionRequest) Reset() {
+	*x = EstimateTransactionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extension_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EstimateTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateTransactionRequest) ProtoMessage() {}
+
+func (x *EstimateTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extension_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateTransactionRequest.ProtoReflect.Descriptor instead.
+func (*EstimateTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_access_extension_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EstimateTransactionRequest) GetTransaction() *entities.Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *EstimateTransactionRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *EstimateTransactionRequest) GetSkipSignatureVerification() bool {
+	if x != nil {
+		return x.SkipSignatureVerification
+	}
+	return false
+}
+
+func (x *EstimateTransactionRequest) GetSkipSequenceNumberCheck() bool {
+	if x != nil {
+		return x.SkipSequenceNumberCheck
+	}
+	return false
+}
+
+type EstimateTransactionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ComputationUsed uint64            `protobuf:"varint,1,opt,name=computation_used,json=computationUsed,proto3" json:"computation_used,omitempty"`
+	Events          []*entities.Event `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	Logs            []string          `protobuf:"bytes,3,rep,name=logs,proto3" json:"logs,omitempty"`
+	StatusCode      uint32            `protobuf:"varint,4,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	ErrorMessage    string            `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (x *EstimateTransactionResponse) Reset() {
+	*x = EstimateTransactionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_access_extension_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EstimateTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateTransactionResponse) ProtoMessage() {}
+
+func (x *EstimateTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_access_extension_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateTransactionResponse.ProtoReflect.Descriptor instead.
+func (*EstimateTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_access_extension_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EstimateTransactionResponse) GetComputationUsed() uint64 {
+	if x != nil {
+		return x.ComputationUsed
+	}
+	return 0
+}
+
+func (x *EstimateTransactionResponse) GetEvents() []*entities.Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *EstimateTransactionResponse) GetLogs() []string {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *EstimateTransactionResponse) GetStatusCode() uint32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *EstimateTransactionResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+var File_access_extension_proto protoreflect.FileDescriptor
+
+var file_access_extension_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x65, 0x78, 0x74, 0x1a, 0x18, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x2f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x66,
+	0x6c, 0x6f, 0x77, 0x2f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2f, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x66, 0x6c, 0x6f, 0x77,
+	0x2f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8e, 0x01, 0x0a, 0x25, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x57, 0x69, 0x74, 0x68,
+	0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3e, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x2e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x25, 0x0a, 0x0e, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f,
+	0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x22, 0x58, 0x0a, 0x16, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x73, 0x65, 0x61, 0x6c, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x53, 0x65, 0x61, 0x6c, 0x65,
+	0x64, 0x22, 0x7a, 0x0a, 0x16, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x54, 0x0a,
+	0x0a, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x22, 0x73, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x73, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x38,
+	0x0a, 0x0c, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x78, 0x74,
+	0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x49, 0x44, 0x52, 0x0b, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22, 0xe7, 0x01, 0x0a, 0x1a, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x5f, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x73, 0x65, 0x61,
+	0x6c, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x38, 0x0a, 0x0c, 0x72, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x49, 0x44, 0x52, 0x0b, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x49, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x22, 0x51, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x49, 0x64, 0x22, 0x91, 0x01, 0x0a, 0x18, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x07, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x43, 0x0a, 0x09, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x57, 0x69, 0x74,
+	0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x09,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x22, 0xf2, 0x01, 0x0a, 0x1a, 0x45, 0x73,
+	0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49,
+	0x64, 0x12, 0x3e, 0x0a, 0x1b, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x19, 0x73, 0x6b, 0x69, 0x70, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x3b, 0x0a, 0x1a, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x73, 0x6b, 0x69, 0x70, 0x53, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x22, 0xd0,
+	0x01, 0x0a, 0x1b, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29,
+	0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x73,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x73, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x06, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66, 0x6c, 0x6f, 0x77,
+	0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52,
+	0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x32, 0xdf, 0x05, 0x0a, 0x12, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x45, 0x78, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x50, 0x49, 0x12, 0x74, 0x0a, 0x1a, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x22, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x61, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x61, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x65, 0x78, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x57, 0x69, 0x74, 0x68, 0x43, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x78,
+	0x0a, 0x20, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x57, 0x69, 0x74, 0x68, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f,
+	0x72, 0x79, 0x12, 0x22, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65,
+	0x78, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x57, 0x69, 0x74, 0x68, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0f, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x21, 0x2e, 0x61, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a,
+	0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x2e, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x53, 0x0a, 0x0f,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x21, 0x2e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x78, 0x74, 0x2e, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
+	0x01, 0x12, 0x67, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x73, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x27, 0x2e, 0x61, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x65, 0x78, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x73, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x78, 0x74, 0x2e,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x13, 0x47, 0x65,
+	0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x12, 0x25, 0x2e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x78, 0x74, 0x2e, 0x47, 0x65,
+	0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x65, 0x78, 0x74, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x57, 0x69, 0x74, 0x68,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a,
+	0x13, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x2e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x78, 0x74,
+	0x2e, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x61, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x65, 0x78, 0x74, 0x2e, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6f, 0x6e, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x66, 0x6c, 0x6f, 0x77, 0x2d, 0x67, 0x6f,
+	0x2f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x3b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x78, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_access_extension_proto_rawDescOnce sync.Once
+	file_access_extension_proto_rawDescData = file_access_extension_proto_rawDesc
+)
+
+func file_access_extension_proto_rawDescGZIP() []byte {
+	file_access_extension_proto_rawDescOnce.Do(func() {
+		file_access_extension_proto_rawDescData = protoimpl.X.CompressGZIP(file_access_extension_proto_rawDescData)
+	})
+	return file_access_extension_proto_rawDescData
+}
+
+var file_access_extension_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_access_extension_proto_goTypes = []interface{}{
+	(*TransactionResultWithCategoryResponse)(nil), // 0: accessext.TransactionResultWithCategoryResponse
+	(*SubscribeBlocksRequest)(nil),                // 1: accessext.SubscribeBlocksRequest
+	(*SubscribeEventsRequest)(nil),                // 2: accessext.SubscribeEventsRequest
+	(*RegisterID)(nil),                            // 3: accessext.RegisterID
+	(*GetRegistersWithProofRequest)(nil),          // 4: accessext.GetRegistersWithProofRequest
+	(*RegistersWithProofResponse)(nil),            // 5: accessext.RegistersWithProofResponse
+	(*GetAccountWithProofRequest)(nil),            // 6: accessext.GetAccountWithProofRequest
+	(*AccountWithProofResponse)(nil),              // 7: accessext.AccountWithProofResponse
+	(*EstimateTransactionRequest)(nil),            // 8: accessext.EstimateTransactionRequest
+	(*EstimateTransactionResponse)(nil),           // 9: accessext.EstimateTransactionResponse
+	(*access.TransactionResultResponse)(nil),      // 10: flow.access.TransactionResultResponse
+	(*entities.Account)(nil),                      // 11: flow.entities.Account
+	(*entities.Transaction)(nil),                  // 12: flow.entities.Transaction
+	(*entities.Event)(nil),                        // 13: flow.entities.Event
+	(*access.GetTransactionRequest)(nil),          // 14: flow.access.GetTransactionRequest
+	(*access.BlockResponse)(nil),                  // 15: flow.access.BlockResponse
+	(*access.EventsResponse)(nil),                 // 16: flow.access.EventsResponse
+}
+var file_access_extension_proto_depIdxs = []int32{
+	10, // 0: accessext.TransactionResultWithCategoryResponse.result:type_name -> flow.access.TransactionResultResponse
+	3,  // 1: accessext.GetRegistersWithProofRequest.register_ids:type_name -> accessext.RegisterID
+	3,  // 2: accessext.RegistersWithProofResponse.register_ids:type_name -> accessext.RegisterID
+	11, // 3: accessext.AccountWithProofResponse.account:type_name -> flow.entities.Account
+	5,  // 4: accessext.AccountWithProofResponse.registers:type_name -> accessext.RegistersWithProofResponse
+	12, // 5: accessext.EstimateTransactionRequest.transaction:type_name -> flow.entities.Transaction
+	13, // 6: accessext.EstimateTransactionResponse.events:type_name -> flow.entities.Event
+	14, // 7: accessext.AccessExtensionAPI.SubscribeTransactionStatus:input_type -> flow.access.GetTransactionRequest
+	14, // 8: accessext.AccessExtensionAPI.GetTransactionResultWithCategory:input_type -> flow.access.GetTransactionRequest
+	1,  // 9: accessext.AccessExtensionAPI.SubscribeBlocks:input_type -> accessext.SubscribeBlocksRequest
+	2,  // 10: accessext.AccessExtensionAPI.SubscribeEvents:input_type -> accessext.SubscribeEventsRequest
+	4,  // 11: accessext.AccessExtensionAPI.GetRegistersWithProof:input_type -> accessext.GetRegistersWithProofRequest
+	6,  // 12: accessext.AccessExtensionAPI.GetAccountWithProof:input_type -> accessext.GetAccountWithProofRequest
+	8,  // 13: accessext.AccessExtensionAPI.EstimateTransaction:input_type -> accessext.EstimateTransactionRequest
+	0,  // 14: accessext.AccessExtensionAPI.SubscribeTransactionStatus:output_type -> accessext.TransactionResultWithCategoryResponse
+	0,  // 15: accessext.AccessExtensionAPI.GetTransactionResultWithCategory:output_type -> accessext.TransactionResultWithCategoryResponse
+	15, // 16: accessext.AccessExtensionAPI.SubscribeBlocks:output_type -> flow.access.BlockResponse
+	16, // 17: accessext.AccessExtensionAPI.SubscribeEvents:output_type -> flow.access.EventsResponse
+	5,  // 18: accessext.AccessExtensionAPI.GetRegistersWithProof:output_type -> accessext.RegistersWithProofResponse
+	7,  // 19: accessext.AccessExtensionAPI.GetAccountWithProof:output_type -> accessext.AccountWithProofResponse
+	9,  // 20: accessext.AccessExtensionAPI.EstimateTransaction:output_type -> accessext.EstimateTransactionResponse
+	14, // [14:21] is the sub-list for method output_type
+	7,  // [7:14] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_access_extension_proto_init() }
+func file_access_extension_proto_init() {
+	if File_access_extension_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_access_extension_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionResultWithCategoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extension_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeBlocksRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extension_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extension_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extension_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRegistersWithProofRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extension_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegistersWithProofResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extension_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAccountWithProofRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extension_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccountWithProofResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extension_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EstimateTransactionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_access_extension_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EstimateTransactionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_access_extension_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_access_extension_proto_goTypes,
+		DependencyIndexes: file_access_extension_proto_depIdxs,
+		MessageInfos:      file_access_extension_proto_msgTypes,
+	}.Build()
+	File_access_extension_proto = out.File
+	file_access_extension_proto_rawDesc = nil
+	file_access_extension_proto_goTypes = nil
+	file_access_extension_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// AccessExtensionAPIClient is the client API for AccessExtensionAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AccessExtensionAPIClient interface {
+	// SubscribeTransactionStatus streams the result of a transaction every time
+	// its status changes, until the transaction is sealed or expired.
+	SubscribeTransactionStatus(ctx context.Context, in *access.GetTransactionRequest, opts ...grpc.CallOption) (AccessExtensionAPI_SubscribeTransactionStatusClient, error)
+	// GetTransactionResultWithCategory gets the result of a transaction, together
+	// with the category of the error the transaction failed with.
+	GetTransactionResultWithCategory(ctx context.Context, in *access.GetTransactionRequest, opts ...grpc.CallOption) (*TransactionResultWithCategoryResponse, error)
+	// SubscribeBlocks streams every finalized block, or every sealed block if
+	// is_sealed is set, in order of height starting at the given height.
+	SubscribeBlocks(ctx context.Context, in *SubscribeBlocksRequest, opts ...grpc.CallOption) (AccessExtensionAPI_SubscribeBlocksClient, error)
+	// SubscribeEvents streams the events matching the filter for every sealed
+	// block, in order of height starting at the given height. A response with a
+	// single result is sent for every block, even if it contains no matching
+	// events, so that clients can resume the stream after the last block.
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (AccessExtensionAPI_SubscribeEventsClient, error)
+	// GetRegistersWithProof gets the values of registers in the execution state
+	// sealed as of the given block, together with a proof of the values against
+	// the sealed state commitment.
+	GetRegistersWithProof(ctx context.Context, in *GetRegistersWithProofRequest, opts ...grpc.CallOption) (*RegistersWithProofResponse, error)
+	// GetAccountWithProof gets an account from the execution state sealed as of
+	// the given block, together with the registers it is decoded from and a
+	// proof of their values against the sealed state commitment.
+	GetAccountWithProof(ctx context.Context, in *GetAccountWithProofRequest, opts ...grpc.CallOption) (*AccountWithProofResponse, error)
+	// EstimateTransaction executes a transaction against the state at the given
+	// block without committing its effects, and reports the computation used,
+	// the emitted events and logs, and the error the transaction would fail
+	// with, if any.
+	EstimateTransaction(ctx context.Context, in *EstimateTransactionRequest, opts ...grpc.CallOption) (*EstimateTransactionResponse, error)
+}
+
+type accessExtensionAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAccessExtensionAPIClient(cc grpc.ClientConnInterface) AccessExtensionAPIClient {
+	return &accessExtensionAPIClient{cc}
+}
+
+func (c *accessExtensionAPIClient) SubscribeTransactionStatus(ctx context.Context, in *access.GetTransactionRequest, opts ...grpc.CallOption) (AccessExtensionAPI_SubscribeTransactionStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AccessExtensionAPI_serviceDesc.Streams[0], "/accessext.AccessExtensionAPI/SubscribeTransactionStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &accessExtensionAPISubscribeTransactionStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AccessExtensionAPI_SubscribeTransactionStatusClient interface {
+	Recv() (*TransactionResultWithCategoryResponse, error)
+	grpc.ClientStream
+}
+
+type accessExtensionAPISubscribeTransactionStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *accessExtensionAPISubscribeTransactionStatusClient) Recv() (*TransactionResultWithCategoryResponse, error) {
+	m := new(TransactionResultWithCategoryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *accessExtensionAPIClient) GetTransactionResultWithCategory(ctx context.Context, in *access.GetTransactionRequest, opts ...grpc.CallOption) (*TransactionResultWithCategoryResponse, error) {
+	out := new(TransactionResultWithCategoryResponse)
+	err := c.cc.Invoke(ctx, "/accessext.AccessExtensionAPI/GetTransactionResultWithCategory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessExtensionAPIClient) SubscribeBlocks(ctx context.Context, in *SubscribeBlocksRequest, opts ...grpc.CallOption) (AccessExtensionAPI_SubscribeBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AccessExtensionAPI_serviceDesc.Streams[1], "/accessext.AccessExtensionAPI/SubscribeBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &accessExtensionAPISubscribeBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AccessExtensionAPI_SubscribeBlocksClient interface {
+	Recv() (*access.BlockResponse, error)
+	grpc.ClientStream
+}
+
+type accessExtensionAPISubscribeBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *accessExtensionAPISubscribeBlocksClient) Recv() (*access.BlockResponse, error) {
+	m := new(access.BlockResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *accessExtensionAPIClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (AccessExtensionAPI_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AccessExtensionAPI_serviceDesc.Streams[2], "/accessext.AccessExtensionAPI/SubscribeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &accessExtensionAPISubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AccessExtensionAPI_SubscribeEventsClient interface {
+	Recv() (*access.EventsResponse, error)
+	grpc.ClientStream
+}
+
+type accessExtensionAPISubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *accessExtensionAPISubscribeEventsClient) Recv() (*access.EventsResponse, error) {
+	m := new(access.EventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *accessExtensionAPIClient) GetRegistersWithProof(ctx context.Context, in *GetRegistersWithProofRequest, opts ...grpc.CallOption) (*RegistersWithProofResponse, error) {
+	out := new(RegistersWithProofResponse)
+	err := c.cc.Invoke(ctx, "/accessext.AccessExtensionAPI/GetRegistersWithProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessExtensionAPIClient) GetAccountWithProof(ctx context.Context, in *GetAccountWithProofRequest, opts ...grpc.CallOption) (*AccountWithProofResponse, error) {
+	out := new(AccountWithProofResponse)
+	err := c.cc.Invoke(ctx, "/accessext.AccessExtensionAPI/GetAccountWithProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessExtensionAPIClient) EstimateTransaction(ctx context.Context, in *EstimateTransactionRequest, opts ...grpc.CallOption) (*EstimateTransactionResponse, error) {
+	out := new(EstimateTransactionResponse)
+	err := c.cc.Invoke(ctx, "/accessext.AccessExtensionAPI/EstimateTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AccessExtensionAPIServer is the server API for AccessExtensionAPI service.
+type AccessExtensionAPIServer interface {
+	// SubscribeTransactionStatus streams the result of a transaction every time
+	// its status changes, until the transaction is sealed or expired.
+	SubscribeTransactionStatus(*access.GetTransactionRequest, AccessExtensionAPI_SubscribeTransactionStatusServer) error
+	// GetTransactionResultWithCategory gets the result of a transaction, together
+	// with the category of the error the transaction failed with.
+	GetTransactionResultWithCategory(context.Context, *access.GetTransactionRequest) (*TransactionResultWithCategoryResponse, error)
+	// SubscribeBlocks streams every finalized block, or every sealed block if
+	// is_sealed is set, in order of height starting at the given height.
+	SubscribeBlocks(*SubscribeBlocksRequest, AccessExtensionAPI_SubscribeBlocksServer) error
+	// SubscribeEvents streams the events matching the filter for every sealed
+	// block, in order of height starting at the given height. A response with a
+	// single result is sent for every block, even if it contains no matching
+	// events, so that clients can resume the stream after the last block.
+	SubscribeEvents(*SubscribeEventsRequest, AccessExtensionAPI_SubscribeEventsServer) error
+	// GetRegistersWithProof gets the values of registers in the execution state
+	// sealed as of the given block, together with a proof of the values against
+	// the sealed state commitment.
+	GetRegistersWithProof(context.Context, *GetRegistersWithProofRequest) (*RegistersWithProofResponse, error)
+	// GetAccountWithProof gets an account from the execution state sealed as of
+	// the given block, together with the registers it is decoded from and a
+	// proof of their values against the sealed state commitment.
+	GetAccountWithProof(context.Context, *GetAccountWithProofRequest) (*AccountWithProofResponse, error)
+	// EstimateTransaction executes a transaction against the state at the given
+	// block without committing its effects, and reports the computation used,
+	// the emitted events and logs, and the error the transaction would fail
+	// with, if any.
+	EstimateTransaction(context.Context, *EstimateTransactionRequest) (*EstimateTransactionResponse, error)
+}
+
+// UnimplementedAccessExtensionAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedAccessExtensionAPIServer struct {
+}
+
+func (*UnimplementedAccessExtensionAPIServer) SubscribeTransactionStatus(*access.GetTransactionRequest, AccessExtensionAPI_SubscribeTransactionStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeTransactionStatus not implemented")
+}
+func (*UnimplementedAccessExtensionAPIServer) GetTransactionResultWithCategory(context.Context, *access.GetTransactionRequest) (*TransactionResultWithCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionResultWithCategory not implemented")
+}
+func (*UnimplementedAccessExtensionAPIServer) SubscribeBlocks(*SubscribeBlocksRequest, AccessExtensionAPI_SubscribeBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBlocks not implemented")
+}
+func (*UnimplementedAccessExtensionAPIServer) SubscribeEvents(*SubscribeEventsRequest, AccessExtensionAPI_SubscribeEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (*UnimplementedAccessExtensionAPIServer) GetRegistersWithProof(context.Context, *GetRegistersWithProofRequest) (*RegistersWithProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRegistersWithProof not implemented")
+}
+func (*UnimplementedAccessExtensionAPIServer) GetAccountWithProof(context.Context, *GetAccountWithProofRequest) (*AccountWithProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountWithProof not implemented")
+}
+func (*UnimplementedAccessExtensionAPIServer) EstimateTransaction(context.Context, *EstimateTransactionRequest) (*EstimateTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateTransaction not implemented")
+}
+
+func RegisterAccessExtensionAPIServer(s *grpc.Server, srv AccessExtensionAPIServer) {
+	s.RegisterService(&_AccessExtensionAPI_serviceDesc, srv)
+}
+
+func _AccessExtensionAPI_SubscribeTransactionStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(access.GetTransactionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AccessExtensionAPIServer).SubscribeTransactionStatus(m, &accessExtensionAPISubscribeTransactionStatusServer{stream})
+}
+
+type AccessExtensionAPI_SubscribeTransactionStatusServer interface {
+	Send(*TransactionResultWithCategoryResponse) error
+	grpc.ServerStream
+}
+
+type accessExtensionAPISubscribeTransactionStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *accessExtensionAPISubscribeTransactionStatusServer) Send(m *TransactionResultWithCategoryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AccessExtensionAPI_GetTransactionResultWithCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(access.GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessExtensionAPIServer).GetTransactionResultWithCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/accessext.AccessExtensionAPI/GetTransactionResultWithCategory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessExtensionAPIServer).GetTransactionResultWithCategory(ctx, req.(*access.GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessExtensionAPI_SubscribeBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeBlocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AccessExtensionAPIServer).SubscribeBlocks(m, &accessExtensionAPISubscribeBlocksServer{stream})
+}
+
+type AccessExtensionAPI_SubscribeBlocksServer interface {
+	Send(*access.BlockResponse) error
+	grpc.ServerStream
+}
+
+type accessExtensionAPISubscribeBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *accessExtensionAPISubscribeBlocksServer) Send(m *access.BlockResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AccessExtensionAPI_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AccessExtensionAPIServer).SubscribeEvents(m, &accessExtensionAPISubscribeEventsServer{stream})
+}
+
+type AccessExtensionAPI_SubscribeEventsServer interface {
+	Send(*access.EventsResponse) error
+	grpc.ServerStream
+}
+
+type accessExtensionAPISubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *accessExtensionAPISubscribeEventsServer) Send(m *access.EventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AccessExtensionAPI_GetRegistersWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRegistersWithProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessExtensionAPIServer).GetRegistersWithProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/accessext.AccessExtensionAPI/GetRegistersWithProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessExtensionAPIServer).GetRegistersWithProof(ctx, req.(*GetRegistersWithProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessExtensionAPI_GetAccountWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountWithProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessExtensionAPIServer).GetAccountWithProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/accessext.AccessExtensionAPI/GetAccountWithProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessExtensionAPIServer).GetAccountWithProof(ctx, req.(*GetAccountWithProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessExtensionAPI_EstimateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessExtensionAPIServer).EstimateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/accessext.AccessExtensionAPI/EstimateTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessExtensionAPIServer).EstimateTransaction(ctx, req.(*EstimateTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AccessExtensionAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "accessext.AccessExtensionAPI",
+	HandlerType: (*AccessExtensionAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTransactionResultWithCategory",
+			Handler:    _AccessExtensionAPI_GetTransactionResultWithCategory_Handler,
+		},
+		{
+			MethodName: "GetRegistersWithProof",
+			Handler:    _AccessExtensionAPI_GetRegistersWithProof_Handler,
+		},
+		{
+			MethodName: "GetAccountWithProof",
+			Handler:    _AccessExtensionAPI_GetAccountWithProof_Handler,
+		},
+		{
+			MethodName: "EstimateTransaction",
+			Handler:    _AccessExtensionAPI_EstimateTransaction_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeTransactionStatus",
+			Handler:       _AccessExtensionAPI_SubscribeTransactionStatus_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeBlocks",
+			Handler:       _AccessExtensionAPI_SubscribeBlocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _AccessExtensionAPI_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "access_extension.proto",
+}
@@ -0,0 +1,74 @@
+package access
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// TransactionErrorCategory groups the error codes reported for failed transactions
+// by the kind of problem that caused the failure.
+type TransactionErrorCategory int
+
+const (
+	// TransactionErrorNone indicates that the transaction did not fail.
+	TransactionErrorNone TransactionErrorCategory = iota
+	// TransactionErrorUnknown indicates a failure with an unrecognized error code.
+	TransactionErrorUnknown
+	// TransactionErrorSignature indicates missing or invalid signatures, payers or keys.
+	TransactionErrorSignature
+	// TransactionErrorSequenceNumber indicates that the proposal key sequence number did not match.
+	TransactionErrorSequenceNumber
+	// TransactionErrorCadenceRuntime indicates that the Cadence runtime failed to execute the transaction.
+	TransactionErrorCadenceRuntime
+	// TransactionErrorStorageLimit indicates that the transaction exceeded a storage limit of an account.
+	TransactionErrorStorageLimit
+	// TransactionErrorEventLimit indicates that the transaction emitted too many events.
+	TransactionErrorEventLimit
+)
+
+// categories of the errors reported for failed transactions, keyed by their codes
+var errorCategories = map[uint32]TransactionErrorCategory{
+	flow.TransactionErrCodeMissingSignature:                        TransactionErrorSignature,
+	flow.TransactionErrCodeMissingPayer:                            TransactionErrorSignature,
+	flow.TransactionErrCodeInvalidSignaturePublicKeyDoesNotExist:   TransactionErrorSignature,
+	flow.TransactionErrCodeInvalidSignaturePublicKeyRevoked:        TransactionErrorSignature,
+	flow.TransactionErrCodeInvalidSignatureVerification:            TransactionErrorSignature,
+	flow.TransactionErrCodeInvalidProposalKeyPublicKeyDoesNotExist: TransactionErrorSignature,
+	flow.TransactionErrCodeInvalidProposalKeyPublicKeyRevoked:      TransactionErrorSignature,
+	flow.TransactionErrCodeInvalidProposalKeyMissingSignature:      TransactionErrorSignature,
+	flow.TransactionErrCodeInvalidHashAlgorithm:                    TransactionErrorSignature,
+	flow.TransactionErrCodeInvalidProposalKeySequenceNumber:        TransactionErrorSequenceNumber,
+	flow.TransactionErrCodeEventLimitExceeded:                      TransactionErrorEventLimit,
+	flow.TransactionErrCodeStorageCapacityExceeded:                 TransactionErrorStorageLimit,
+	flow.TransactionErrCodeExecution:                               TransactionErrorCadenceRuntime,
+}
+
+// TransactionErrorCategoryForCode returns the category of the given transaction status code.
+func TransactionErrorCategoryForCode(code uint) TransactionErrorCategory {
+	if code == 0 {
+		return TransactionErrorNone
+	}
+	category, ok := errorCategories[uint32(code)]
+	if !ok {
+		return TransactionErrorUnknown
+	}
+	return category
+}
+
+func (c TransactionErrorCategory) String() string {
+	switch c {
+	case TransactionErrorNone:
+		return "none"
+	case TransactionErrorSignature:
+		return "signature"
+	case TransactionErrorSequenceNumber:
+		return "sequence_number"
+	case TransactionErrorCadenceRuntime:
+		return "cadence_runtime"
+	case TransactionErrorStorageLimit:
+		return "storage_limit"
+	case TransactionErrorEventLimit:
+		return "event_limit"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,39 @@
+package access
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// TestTransactionErrorCategoryForCode verifies that the codes of the errors reported by the
+// virtual machine are mapped to their categories.
+func TestTransactionErrorCategoryForCode(t *testing.T) {
+	cases := []struct {
+		err      fvm.Error
+		category TransactionErrorCategory
+	}{
+		{&fvm.MissingSignatureError{}, TransactionErrorSignature},
+		{&fvm.MissingPayerError{}, TransactionErrorSignature},
+		{&fvm.InvalidSignaturePublicKeyDoesNotExistError{}, TransactionErrorSignature},
+		{&fvm.InvalidSignaturePublicKeyRevokedError{}, TransactionErrorSignature},
+		{&fvm.InvalidSignatureVerificationError{}, TransactionErrorSignature},
+		{&fvm.InvalidProposalKeyPublicKeyDoesNotExistError{}, TransactionErrorSignature},
+		{&fvm.InvalidProposalKeyPublicKeyRevokedError{}, TransactionErrorSignature},
+		{&fvm.InvalidProposalKeyMissingSignatureError{}, TransactionErrorSignature},
+		{&fvm.InvalidHashAlgorithmError{}, TransactionErrorSignature},
+		{&fvm.InvalidProposalKeySequenceNumberError{}, TransactionErrorSequenceNumber},
+		{&fvm.EventLimitExceededError{}, TransactionErrorEventLimit},
+		{&fvm.StorageCapacityExceededError{}, TransactionErrorStorageLimit},
+		{&fvm.ExecutionError{}, TransactionErrorCadenceRuntime},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.category, TransactionErrorCategoryForCode(uint(c.err.Code())), "code %d", c.err.Code())
+	}
+
+	assert.Equal(t, TransactionErrorNone, TransactionErrorCategoryForCode(0))
+	assert.Equal(t, TransactionErrorUnknown, TransactionErrorCategoryForCode(uint(flow.TransactionErrCodeUnknown)))
+}
@@ -55,6 +55,10 @@ type TransactionValidationOptions struct {
 	// maximum. A zero value indicates no address checking.
 	MaxAddressIndex uint64
 	MaxTxSizeLimit  uint64
+	// AccountKeys provides the account keys used to verify the signatures,
+	// the key weights and the proposal key sequence number of transactions.
+	// A nil provider indicates only the format of signatures is checked.
+	AccountKeys AccountKeyProvider
 }
 
 type TransactionValidator struct {
@@ -110,7 +114,11 @@ func (v *TransactionValidator) Validate(tx *flow.TransactionBody) (err error) {
 	if err != nil {
 		return err
 	}
-	// TODO replace checkSignatureFormat by verifying the account/payer signatures
+
+	err = v.checkSignatures(tx)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -268,6 +276,87 @@ func (v *TransactionValidator) checkSignatureFormat(tx *flow.TransactionBody) er
 	return nil
 }
 
+// checkSignatures verifies the signatures of a transaction against the keys of
+// the signing accounts, following the rules applied during execution: the
+// proposal key must have signed, and the payer (envelope) and authorizers
+// (payload) must have signed with keys of sufficient total weight. In addition,
+// the proposal key sequence number must not be lower than the current one.
+// Transactions are accepted if any of the keys they are signed with can not be
+// retrieved, so that they are verified on execution rather than rejected while
+// the key provider is unavailable or behind.
+func (v *TransactionValidator) checkSignatures(tx *flow.TransactionBody) error {
+	if v.options.AccountKeys == nil {
+		return nil
+	}
+
+	err := v.verifySignatures(tx)
+	if errors.Is(err, errUnverifiable) {
+		return nil
+	}
+	return err
+}
+
+// verifySignatures verifies the signatures of a transaction, and returns
+// errUnverifiable if any of the keys can not be retrieved.
+func (v *TransactionValidator) verifySignatures(tx *flow.TransactionBody) error {
+	keys := newAccountKeyLookup(v.options.AccountKeys)
+
+	weights, err := flow.AggregateTransactionSignatures(tx, func(signature flow.TransactionSignature, message []byte) (*flow.AccountPublicKey, error) {
+		return verifySignature(keys, signature, message)
+	})
+	if err != nil {
+		return err
+	}
+
+	if !weights.ProposalKeySigned {
+		return MissingProposalKeySignatureError{
+			Address:  tx.ProposalKey.Address,
+			KeyIndex: tx.ProposalKey.KeyIndex,
+		}
+	}
+
+	// the payer only signs the envelope, even if it is an authorizer
+	if address, unsigned := weights.UnsignedAccount(tx, AccountKeyWeightThreshold); unsigned {
+		return MissingSignatureError{Address: address}
+	}
+
+	proposalKey, err := keys.key(tx.ProposalKey.Address, tx.ProposalKey.KeyIndex)
+	if err != nil {
+		return err
+	}
+	if tx.ProposalKey.SequenceNumber < proposalKey.SeqNumber {
+		return StaleSequenceNumberError{
+			Address:  tx.ProposalKey.Address,
+			KeyIndex: tx.ProposalKey.KeyIndex,
+			Current:  proposalKey.SeqNumber,
+			Provided: tx.ProposalKey.SequenceNumber,
+		}
+	}
+
+	return nil
+}
+
+// verifySignature verifies the given signature of the message and returns the
+// key it is signed with.
+func verifySignature(keys *accountKeyLookup, signature flow.TransactionSignature, message []byte) (*flow.AccountPublicKey, error) {
+	key, err := keys.key(signature.Address, signature.KeyIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher, err := flow.NewAccountKeyHasher(key.HashAlgo)
+	if err != nil {
+		return nil, SignatureVerificationError{Address: signature.Address, KeyIndex: signature.KeyIndex}
+	}
+
+	valid, err := key.PublicKey.Verify(signature.Signature, message, hasher)
+	if err != nil || !valid {
+		return nil, SignatureVerificationError{Address: signature.Address, KeyIndex: signature.KeyIndex}
+	}
+
+	return key, nil
+}
+
 func remove(s []string, r string) []string {
 	for i, v := range s {
 		if v == r {
@@ -0,0 +1,172 @@
+// Package verifier checks the register and account proofs returned by the Access API against a
+// sealed state commitment, so that clients do not have to trust the access node serving them.
+//
+// The state commitment a proof is checked against must be obtained from a trusted source, such
+// as a seal verified by a light client. The commitment carried in the proof itself is only what
+// the access node claims.
+package verifier
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/execution/state"
+	fvmState "github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// VerifyRegisters checks that the register values are proven by the proof against the given
+// sealed state commitment.
+func VerifyRegisters(commitment flow.StateCommitment, registers *access.RegistersWithProof) error {
+
+	if registers.Proof == nil {
+		return fmt.Errorf("missing proof")
+	}
+	if len(registers.Values) != len(registers.RegisterIDs) {
+		return fmt.Errorf("number of values (%d) does not match number of registers (%d)", len(registers.Values), len(registers.RegisterIDs))
+	}
+	if len(registers.Proof.Proofs) != len(registers.RegisterIDs) {
+		return fmt.Errorf("number of proofs (%d) does not match number of registers (%d)", len(registers.Proof.Proofs), len(registers.RegisterIDs))
+	}
+
+	for i, registerID := range registers.RegisterIDs {
+		err := verifyRegister(commitment, registerID, registers.Values[i], registers.Proof.Proofs[i])
+		if err != nil {
+			return fmt.Errorf("invalid proof for register %s: %w", registerID.String(), err)
+		}
+	}
+
+	return nil
+}
+
+// VerifyAccount checks that the registers of the account are proven by the proof against the
+// given sealed state commitment, and that the account with the given address decodes from them.
+func VerifyAccount(commitment flow.StateCommitment, address flow.Address, account *access.AccountWithProof) error {
+
+	if account.Account == nil {
+		return fmt.Errorf("missing account")
+	}
+	if account.Account.Address != address {
+		return fmt.Errorf("account address %s does not match requested address %s", account.Account.Address, address)
+	}
+
+	err := VerifyRegisters(commitment, &account.RegistersWithProof)
+	if err != nil {
+		return err
+	}
+
+	// decode the account from the proven registers only, a register missing from the proof
+	// makes decoding fail
+	proven := newProvenLedger(account.RegisterIDs, account.Values)
+	decoded, err := fvmState.NewAccounts(proven).Get(address)
+	if err != nil {
+		return fmt.Errorf("could not decode account from proven registers: %w", err)
+	}
+
+	return compareAccounts(decoded, account.Account)
+}
+
+// verifyRegister checks that the proof is an inclusion proof of the register with the given
+// value against the commitment. A register which is not set is proven by an inclusion proof
+// of an empty value, which hashes like an empty subtree.
+func verifyRegister(commitment flow.StateCommitment, registerID flow.RegisterID, value flow.RegisterValue, proof *ledger.TrieProof) error {
+
+	key := state.RegisterIDToKey(registerID)
+	path, err := pathfinder.KeyToPath(key, complete.DefaultPathFinderVersion)
+	if err != nil {
+		return fmt.Errorf("could not compute path: %w", err)
+	}
+	if !bytes.Equal(proof.Path, path) {
+		return fmt.Errorf("proof path does not match register path")
+	}
+
+	if !proof.Inclusion || !common.VerifyTrieProof(proof, ledger.State(commitment)) {
+		return fmt.Errorf("proof does not match state commitment")
+	}
+
+	if !bytes.Equal(proof.Payload.Value, value) {
+		return fmt.Errorf("value does not match proven value")
+	}
+	if len(value) > 0 && !proof.Payload.Key.Equals(&key) {
+		return fmt.Errorf("proven payload key does not match register key")
+	}
+
+	return nil
+}
+
+// compareAccounts checks that the account returned by the access node matches the account decoded
+// from the proven registers. The balance is not compared, as it is not part of the registers.
+func compareAccounts(expected *flow.Account, actual *flow.Account) error {
+
+	if len(actual.Keys) != len(expected.Keys) {
+		return fmt.Errorf("number of account keys (%d) does not match proven number (%d)", len(actual.Keys), len(expected.Keys))
+	}
+	for i, key := range expected.Keys {
+		expectedKey, err := flow.EncodeAccountPublicKey(key)
+		if err != nil {
+			return fmt.Errorf("could not encode proven account key %d: %w", i, err)
+		}
+		actualKey, err := flow.EncodeAccountPublicKey(actual.Keys[i])
+		if err != nil {
+			return fmt.Errorf("could not encode account key %d: %w", i, err)
+		}
+		if actual.Keys[i].Index != key.Index || !bytes.Equal(actualKey, expectedKey) {
+			return fmt.Errorf("account key %d does not match proven key", i)
+		}
+	}
+
+	if len(actual.Contracts) != len(expected.Contracts) {
+		return fmt.Errorf("number of contracts (%d) does not match proven number (%d)", len(actual.Contracts), len(expected.Contracts))
+	}
+	for name, code := range expected.Contracts {
+		actualCode, ok := actual.Contracts[name]
+		if !ok || !bytes.Equal(actualCode, code) {
+			return fmt.Errorf("contract %s does not match proven contract", name)
+		}
+	}
+
+	return nil
+}
+
+// provenLedger is a read-only ledger serving proven register values only.
+type provenLedger struct {
+	values map[string]flow.RegisterValue
+}
+
+func newProvenLedger(registerIDs []flow.RegisterID, values []flow.RegisterValue) *provenLedger {
+	l := &provenLedger{
+		values: make(map[string]flow.RegisterValue, len(registerIDs)),
+	}
+	for i, registerID := range registerIDs {
+		l.values[registerID.String()] = values[i]
+	}
+	return l
+}
+
+func (l *provenLedger) Get(owner, controller, key string) (flow.RegisterValue, error) {
+	registerID := flow.NewRegisterID(owner, controller, key)
+	value, ok := l.values[registerID.String()]
+	if !ok {
+		return nil, fmt.Errorf("register %s is not proven", registerID.String())
+	}
+	return value, nil
+}
+
+func (l *provenLedger) Set(owner, controller, key string, value flow.RegisterValue) {
+	panic("proven ledger is read-only")
+}
+
+func (l *provenLedger) Touch(owner, controller, key string) {}
+
+func (l *provenLedger) Delete(owner, controller, key string) {
+	panic("proven ledger is read-only")
+}
+
+func (l *provenLedger) RegisterUpdates() ([]flow.RegisterID, []flow.RegisterValue) {
+	return nil, nil
+}
@@ -0,0 +1,188 @@
+package verifier_test
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/verifier"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	fvmState "github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// withAccount runs the given function with a ledger containing an account with two keys and a contract.
+func withAccount(t *testing.T, f func(led *complete.Ledger, commitment flow.StateCommitment, account *flow.Account)) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		led, err := complete.NewLedger(dir, 100, &metrics.NoopCollector{}, zerolog.Nop(), nil, complete.DefaultPathFinderVersion)
+		require.NoError(t, err)
+
+		keys := make([]flow.AccountPublicKey, 2)
+		for i := range keys {
+			key, err := unittest.AccountKeyFixture()
+			require.NoError(t, err)
+			keys[i] = key.PublicKey(1000)
+			keys[i].Index = i
+		}
+		address := flow.HexToAddress("01")
+
+		view := delta.NewView(state.LedgerGetRegister(led, flow.StateCommitment(led.InitialState())))
+		accounts := fvmState.NewAccounts(view)
+		require.NoError(t, accounts.Create(keys, address))
+		require.NoError(t, accounts.SetContract("Test", address, []byte("pub contract Test {}")))
+
+		commitment, err := state.CommitDelta(led, view.Delta(), flow.StateCommitment(led.InitialState()))
+		require.NoError(t, err)
+
+		account, err := fvmState.NewAccounts(delta.NewView(state.LedgerGetRegister(led, commitment))).Get(address)
+		require.NoError(t, err)
+
+		f(led, commitment, account)
+	})
+}
+
+// prove returns the given registers with their proof, as served by the access node.
+func prove(t *testing.T, led *complete.Ledger, commitment flow.StateCommitment, registerIDs []flow.RegisterID) *access.RegistersWithProof {
+	query, err := ledger.NewQuery(ledger.State(commitment), state.RegisterIDSToKeys(registerIDs))
+	require.NoError(t, err)
+	encoded, err := led.Prove(query)
+	require.NoError(t, err)
+	proof, err := encoding.DecodeTrieBatchProof(encoded)
+	require.NoError(t, err)
+
+	values := make([]flow.RegisterValue, len(proof.Proofs))
+	for i, p := range proof.Proofs {
+		values[i] = p.Payload.Value
+	}
+
+	return &access.RegistersWithProof{
+		Commitment:  commitment,
+		RegisterIDs: registerIDs,
+		Values:      values,
+		Proof:       proof,
+	}
+}
+
+// accountRegisters returns the registers read to decode the account.
+func accountRegisters(t *testing.T, led *complete.Ledger, commitment flow.StateCommitment, address flow.Address) []flow.RegisterID {
+	view := delta.NewView(state.LedgerGetRegister(led, commitment))
+	_, err := fvmState.NewAccounts(view).Get(address)
+	require.NoError(t, err)
+	return view.Interactions().Reads
+}
+
+func TestVerifyRegisters(t *testing.T) {
+	withAccount(t, func(led *complete.Ledger, commitment flow.StateCommitment, account *flow.Account) {
+		owner := string(account.Address.Bytes())
+		registerIDs := []flow.RegisterID{
+			flow.NewRegisterID(owner, "", "exists"),
+			flow.NewRegisterID(owner, owner, "code.Test"),
+			flow.NewRegisterID(owner, "", "unset"),
+		}
+
+		t.Run("valid proof", func(t *testing.T) {
+			registers := prove(t, led, commitment, registerIDs)
+			require.NoError(t, verifier.VerifyRegisters(commitment, registers))
+			assert.Equal(t, flow.RegisterValue{1}, registers.Values[0])
+			assert.Equal(t, flow.RegisterValue("pub contract Test {}"), registers.Values[1])
+			assert.Empty(t, registers.Values[2])
+		})
+
+		t.Run("wrong commitment", func(t *testing.T) {
+			registers := prove(t, led, commitment, registerIDs)
+			err := verifier.VerifyRegisters(flow.StateCommitment(led.InitialState()), registers)
+			assert.Error(t, err)
+		})
+
+		t.Run("tampered value", func(t *testing.T) {
+			registers := prove(t, led, commitment, registerIDs)
+			registers.Values[1] = []byte("pub contract Fake {}")
+			assert.Error(t, verifier.VerifyRegisters(commitment, registers))
+		})
+
+		t.Run("tampered proven value", func(t *testing.T) {
+			registers := prove(t, led, commitment, registerIDs)
+			registers.Values[1] = []byte("pub contract Fake {}")
+			registers.Proof.Proofs[1].Payload.Value = registers.Values[1]
+			assert.Error(t, verifier.VerifyRegisters(commitment, registers))
+		})
+
+		t.Run("unset value claimed", func(t *testing.T) {
+			registers := prove(t, led, commitment, registerIDs)
+			registers.Values[2] = []byte{1}
+			assert.Error(t, verifier.VerifyRegisters(commitment, registers))
+		})
+
+		t.Run("proof of another register", func(t *testing.T) {
+			registers := prove(t, led, commitment, registerIDs)
+			registers.Proof.Proofs[0], registers.Proof.Proofs[1] = registers.Proof.Proofs[1], registers.Proof.Proofs[0]
+			registers.Values[0], registers.Values[1] = registers.Values[1], registers.Values[0]
+			assert.Error(t, verifier.VerifyRegisters(commitment, registers))
+		})
+
+		t.Run("missing proof", func(t *testing.T) {
+			registers := prove(t, led, commitment, registerIDs)
+			registers.Proof.Proofs = registers.Proof.Proofs[:2]
+			assert.Error(t, verifier.VerifyRegisters(commitment, registers))
+		})
+	})
+}
+
+func TestVerifyAccount(t *testing.T) {
+	withAccount(t, func(led *complete.Ledger, commitment flow.StateCommitment, account *flow.Account) {
+		address := account.Address
+		registerIDs := accountRegisters(t, led, commitment, address)
+
+		proven := func() *access.AccountWithProof {
+			copied := *account
+			copied.Keys = append([]flow.AccountPublicKey(nil), account.Keys...)
+			return &access.AccountWithProof{
+				Account:            &copied,
+				RegistersWithProof: *prove(t, led, commitment, registerIDs),
+			}
+		}
+
+		t.Run("valid proof", func(t *testing.T) {
+			assert.NoError(t, verifier.VerifyAccount(commitment, address, proven()))
+		})
+
+		t.Run("other address", func(t *testing.T) {
+			assert.Error(t, verifier.VerifyAccount(commitment, flow.HexToAddress("02"), proven()))
+		})
+
+		t.Run("omitted key", func(t *testing.T) {
+			result := proven()
+			result.Account.Keys = result.Account.Keys[:1]
+			assert.Error(t, verifier.VerifyAccount(commitment, address, result))
+		})
+
+		t.Run("revocation hidden", func(t *testing.T) {
+			result := proven()
+			result.Account.Keys[1].Revoked = !result.Account.Keys[1].Revoked
+			assert.Error(t, verifier.VerifyAccount(commitment, address, result))
+		})
+
+		t.Run("tampered contract", func(t *testing.T) {
+			result := proven()
+			result.Account.Contracts = map[string][]byte{"Test": []byte("pub contract Fake {}")}
+			assert.Error(t, verifier.VerifyAccount(commitment, address, result))
+		})
+
+		t.Run("register missing from proof", func(t *testing.T) {
+			result := proven()
+			result.RegisterIDs = result.RegisterIDs[1:]
+			result.Values = result.Values[1:]
+			result.Proof.Proofs = result.Proof.Proofs[1:]
+			assert.Error(t, verifier.VerifyAccount(commitment, address, result))
+		})
+	})
+}
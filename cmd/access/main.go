@@ -19,15 +19,18 @@ import (
 	"github.com/onflow/flow-go/engine/access/ingestion"
 	pingeng "github.com/onflow/flow-go/engine/access/ping"
 	"github.com/onflow/flow-go/engine/access/rpc"
+	"github.com/onflow/flow-go/engine/access/rpc/backend"
 	followereng "github.com/onflow/flow-go/engine/common/follower"
 	"github.com/onflow/flow-go/engine/common/requester"
 	synceng "github.com/onflow/flow-go/engine/common/synchronization"
+	executionext "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	"github.com/onflow/flow-go/model/encoding"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/buffer"
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
+	"github.com/onflow/flow-go/module/grpcauth"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/module/signature"
@@ -45,6 +48,8 @@ func main() {
 		collectionLimit              uint
 		receiptLimit                 uint
 		collectionGRPCPort           uint
+		executionGRPCPort            uint
+		grpcTLS                      bool
 		pingEnabled                  bool
 		nodeInfoFile                 string
 		followerState                protocol.MutableState
@@ -56,6 +61,7 @@ func main() {
 		rpcEng                       *rpc.Engine
 		collectionRPC                access.AccessAPIClient
 		executionRPC                 execution.ExecutionAPIClient
+		executionExtRPC              executionext.ExecutionExtensionAPIClient
 		err                          error
 		conCache                     *buffer.PendingBlocks // pending block cache for follower
 		transactionTimings           *stdmap.TransactionTimings
@@ -63,11 +69,18 @@ func main() {
 		collectionsToMarkExecuted    *stdmap.Times
 		blocksToMarkExecuted         *stdmap.Times
 		transactionMetrics           module.TransactionMetrics
+		scriptMetrics                module.ScriptMetrics
 		pingMetrics                  module.PingMetrics
 		logTxTimeToFinalized         bool
 		logTxTimeToExecuted          bool
 		logTxTimeToFinalizedExecuted bool
 		retryEnabled                 bool
+
+		// configurations of the mempools timing transactions
+		transactionTimingsConf         = cmd.NewMempoolConfig(1500*300, stdmap.EjectionPolicyRandom) // assume 1500 TPS * 300 seconds
+		collectionsToMarkFinalizedConf = cmd.NewMempoolConfig(50*300, stdmap.EjectionPolicyRandom)   // assume 50 collection nodes * 300 seconds
+		collectionsToMarkExecutedConf  = cmd.NewMempoolConfig(50*300, stdmap.EjectionPolicyRandom)   // assume 50 collection nodes * 300 seconds
+		blocksToMarkExecutedConf       = cmd.NewMempoolConfig(1*300, stdmap.EjectionPolicyRandom)    // assume 1 block per second * 300 seconds
 	)
 
 	cmd.FlowNode(flow.RoleAccess.String()).
@@ -75,7 +88,17 @@ func main() {
 			flags.UintVar(&receiptLimit, "receipt-limit", 1000, "maximum number of execution receipts in the memory pool")
 			flags.UintVar(&collectionLimit, "collection-limit", 1000, "maximum number of collections in the memory pool")
 			flags.UintVar(&blockLimit, "block-limit", 1000, "maximum number of result blocks in the memory pool")
+			transactionTimingsConf.AddFlags(flags, "transaction-timings", "transaction timings", false)
+			collectionsToMarkFinalizedConf.AddFlags(flags, "collections-to-mark-finalized", "collection times to mark finalized", false)
+			collectionsToMarkExecutedConf.AddFlags(flags, "collections-to-mark-executed", "collection times to mark executed", false)
+			blocksToMarkExecutedConf.AddFlags(flags, "blocks-to-mark-executed", "block times to mark executed", false)
 			flags.UintVar(&collectionGRPCPort, "collection-ingress-port", 9000, "the grpc ingress port for all collection nodes")
+			flags.UintVar(&executionGRPCPort, "execution-grpc-port", 0, "the grpc port for all execution nodes (if set, scripts and accounts are queried from staked execution nodes instead of the script-addr)")
+			flags.DurationVar(&rpcConf.ConnectionPool.IdleTimeout, "connection-idle-timeout", backend.DefaultConnectionPoolConfig().IdleTimeout, "how long unused connections to collection and execution nodes are kept open")
+			flags.DurationVar(&rpcConf.ConnectionPool.HealthCheckInterval, "connection-health-check-interval", backend.DefaultConnectionPoolConfig().HealthCheckInterval, "interval between health checks of connections to collection and execution nodes")
+			flags.DurationVar(&rpcConf.ConnectionPool.HealthCheckTimeout, "connection-health-check-timeout", backend.DefaultConnectionPoolConfig().HealthCheckTimeout, "timeout of health checks of connections to collection and execution nodes")
+			flags.UintVar(&rpcConf.ScriptCrossCheck, "script-cross-check", 0, "number of execution nodes scripts are executed on to cross-check their results, requires execution-grpc-port (0 or 1 to disable)")
+			flags.BoolVar(&grpcTLS, "grpc-tls", false, "whether to use mutual TLS for gRPC links to collection and execution nodes")
 			flags.StringVarP(&rpcConf.GRPCListenAddr, "rpc-addr", "r", "localhost:9000", "the address the gRPC server listens on")
 			flags.StringVarP(&rpcConf.HTTPListenAddr, "http-addr", "h", "localhost:8000", "the address the http proxy server listens on")
 			flags.StringVarP(&rpcConf.CollectionAddr, "static-collection-ingress-addr", "", "", "the address (of the collection node) to send transactions to")
@@ -103,6 +126,17 @@ func main() {
 			)
 			return err
 		}).
+		Module("grpc credentials", func(node *cmd.FlowNodeBuilder) error {
+			if !grpcTLS {
+				return nil
+			}
+			cert, err := grpcauth.NodeCertificate(node.NetworkKey())
+			if err != nil {
+				return fmt.Errorf("could not create TLS certificate: %w", err)
+			}
+			rpcConf.ConnectionPool.Credentials = grpcauth.NodeCredentials(node.State, cert)
+			return nil
+		}).
 		Module("collection node client", func(node *cmd.FlowNodeBuilder) error {
 			// collection node address is optional (if not specified, collection nodes will be chosen at random)
 			if strings.TrimSpace(rpcConf.CollectionAddr) == "" {
@@ -110,10 +144,14 @@ func main() {
 			}
 			node.Logger.Info().Err(err).Msgf("Collection node Addr: %s", rpcConf.CollectionAddr)
 
+			security, err := dialSecurity(rpcConf.ConnectionPool.Credentials, rpcConf.CollectionAddr)
+			if err != nil {
+				return err
+			}
 			collectionRPCConn, err := grpc.Dial(
 				rpcConf.CollectionAddr,
 				grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutils.DefaultMaxMsgSize)),
-				grpc.WithInsecure())
+				security)
 			if err != nil {
 				return err
 			}
@@ -123,14 +161,19 @@ func main() {
 		Module("execution node client", func(node *cmd.FlowNodeBuilder) error {
 			node.Logger.Info().Err(err).Msgf("Execution node Addr: %s", rpcConf.ExecutionAddr)
 
+			security, err := dialSecurity(rpcConf.ConnectionPool.Credentials, rpcConf.ExecutionAddr)
+			if err != nil {
+				return err
+			}
 			executionRPCConn, err := grpc.Dial(
 				rpcConf.ExecutionAddr,
 				grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutils.DefaultMaxMsgSize)),
-				grpc.WithInsecure())
+				security)
 			if err != nil {
 				return err
 			}
 			executionRPC = execution.NewExecutionAPIClient(executionRPCConn)
+			executionExtRPC = executionext.NewExecutionExtensionAPIClient(executionRPCConn)
 			return nil
 		}).
 		Module("block cache", func(node *cmd.FlowNodeBuilder) error {
@@ -142,22 +185,38 @@ func main() {
 			return err
 		}).
 		Module("transaction timing mempools", func(node *cmd.FlowNodeBuilder) error {
-			transactionTimings, err = stdmap.NewTransactionTimings(1500 * 300) // assume 1500 TPS * 300 seconds
+			opts, err := transactionTimingsConf.Options(metrics.ResourceTransactionTimings, node.Metrics.Mempool, nil, nil)
+			if err != nil {
+				return fmt.Errorf("invalid transaction timings mempool configuration: %w", err)
+			}
+			transactionTimings, err = stdmap.NewTransactionTimings(transactionTimingsConf.Limit, opts...)
 			if err != nil {
 				return err
 			}
 
-			collectionsToMarkFinalized, err = stdmap.NewTimes(50 * 300) // assume 50 collection nodes * 300 seconds
+			opts, err = collectionsToMarkFinalizedConf.Options(metrics.ResourceCollectionsToFinalize, node.Metrics.Mempool, nil, nil)
+			if err != nil {
+				return fmt.Errorf("invalid collections to mark finalized mempool configuration: %w", err)
+			}
+			collectionsToMarkFinalized, err = stdmap.NewTimes(collectionsToMarkFinalizedConf.Limit, opts...)
 			if err != nil {
 				return err
 			}
 
-			collectionsToMarkExecuted, err = stdmap.NewTimes(50 * 300) // assume 50 collection nodes * 300 seconds
+			opts, err = collectionsToMarkExecutedConf.Options(metrics.ResourceCollectionsToExecute, node.Metrics.Mempool, nil, nil)
+			if err != nil {
+				return fmt.Errorf("invalid collections to mark executed mempool configuration: %w", err)
+			}
+			collectionsToMarkExecuted, err = stdmap.NewTimes(collectionsToMarkExecutedConf.Limit, opts...)
 			if err != nil {
 				return err
 			}
 
-			blocksToMarkExecuted, err = stdmap.NewTimes(1 * 300) // assume 1 block per second * 300 seconds
+			opts, err = blocksToMarkExecutedConf.Options(metrics.ResourceBlocksToExecute, node.Metrics.Mempool, nil, nil)
+			if err != nil {
+				return fmt.Errorf("invalid blocks to mark executed mempool configuration: %w", err)
+			}
+			blocksToMarkExecuted, err = stdmap.NewTimes(blocksToMarkExecutedConf.Limit, opts...)
 			return err
 		}).
 		Module("transaction metrics", func(node *cmd.FlowNodeBuilder) error {
@@ -165,6 +224,10 @@ func main() {
 				logTxTimeToExecuted, logTxTimeToFinalizedExecuted)
 			return nil
 		}).
+		Module("script metrics", func(node *cmd.FlowNodeBuilder) error {
+			scriptMetrics = metrics.NewScriptCollector()
+			return nil
+		}).
 		Module("ping metrics", func(node *cmd.FlowNodeBuilder) error {
 			pingMetrics = metrics.NewPingCollector()
 			return nil
@@ -175,14 +238,18 @@ func main() {
 				node.State,
 				rpcConf,
 				executionRPC,
+				executionExtRPC,
 				collectionRPC,
 				node.Storage.Blocks,
 				node.Storage.Headers,
 				node.Storage.Collections,
 				node.Storage.Transactions,
+				node.Storage.Seals,
 				node.RootChainID,
 				transactionMetrics,
+				scriptMetrics,
 				collectionGRPCPort,
+				executionGRPCPort,
 				retryEnabled,
 			)
 			return rpcEng, nil
@@ -302,3 +369,16 @@ func main() {
 		}).
 		Run()
 }
+
+// dialSecurity returns the dial option securing a connection to the node at the
+// given address with the given credentials, or an insecure connection if none.
+func dialSecurity(creds backend.CredentialsFunc, address string) (grpc.DialOption, error) {
+	if creds == nil {
+		return grpc.WithInsecure(), nil
+	}
+	transport, err := creds(address)
+	if err != nil {
+		return nil, fmt.Errorf("could not get credentials for %s: %w", address, err)
+	}
+	return grpc.WithTransportCredentials(transport), nil
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/onflow/flow/protobuf/go/flow/execution"
 	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
 
+	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/consensus"
 	"github.com/onflow/flow-go/consensus/hotstuff/committees"
@@ -29,8 +32,10 @@ import (
 	builder "github.com/onflow/flow-go/module/builder/collection"
 	"github.com/onflow/flow-go/module/epochs"
 	confinalizer "github.com/onflow/flow-go/module/finalizer/consensus"
+	"github.com/onflow/flow-go/module/grpcauth"
 	"github.com/onflow/flow-go/module/ingress"
 	"github.com/onflow/flow-go/module/mempool"
+	badgerpool "github.com/onflow/flow-go/module/mempool/badger"
 	epochpool "github.com/onflow/flow-go/module/mempool/epochs"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
@@ -40,24 +45,32 @@ import (
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
 	"github.com/onflow/flow-go/state/protocol/events/gadgets"
 	storagekv "github.com/onflow/flow-go/storage/badger"
+	grpcutils "github.com/onflow/flow-go/utils/grpc"
 )
 
 func main() {
 
 	var (
 		txLimit                                uint
+		persistentTxPool                       bool
 		maxCollectionSize                      uint
 		maxCollectionByteSize                  uint64
 		maxCollectionTotalGas                  uint64
 		builderExpiryBuffer                    uint
 		builderPayerRateLimit                  float64
 		builderUnlimitedPayers                 []string
+		builderPriority                        string
 		hotstuffTimeout                        time.Duration
 		hotstuffMinTimeout                     time.Duration
 		hotstuffTimeoutIncreaseFactor          float64
 		hotstuffTimeoutDecreaseFactor          float64
 		hotstuffTimeoutVoteAggregationFraction float64
 		blockRateDelay                         time.Duration
+		accountKeysAddr                        string
+		accountKeysTimeout                     time.Duration
+		accountKeysCacheSize                   int
+		accountKeysCacheTTL                    time.Duration
+		grpcTLS                                bool
 
 		followerState protocol.MutableState
 		ingestConf    ingest.Config
@@ -78,8 +91,12 @@ func main() {
 		ExtraFlags(func(flags *pflag.FlagSet) {
 			flags.UintVar(&txLimit, "tx-limit", 50000,
 				"maximum number of transactions in the memory pool")
+			flags.BoolVar(&persistentTxPool, "persistent-tx-pool", false,
+				"whether to persist pending transactions in the database, so that they survive restarts")
 			flags.StringVarP(&ingressConf.ListenAddr, "ingress-addr", "i", "localhost:9000",
 				"the address the ingress server listens on")
+			flags.BoolVar(&grpcTLS, "grpc-tls", false,
+				"whether to use mutual TLS for gRPC links to other nodes, in which case the ingress server only accepts staked access nodes")
 			flags.Uint64Var(&ingestConf.MaxGasLimit, "ingest-max-gas-limit", flow.DefaultMaxGasLimit,
 				"maximum per-transaction gas limit")
 			flags.BoolVar(&ingestConf.CheckScriptsParse, "ingest-check-scripts-parse", true,
@@ -90,12 +107,34 @@ func main() {
 				"how many additional cluster members we propagate transactions to")
 			flags.Uint64Var(&ingestConf.MaxAddressIndex, "ingest-max-address-index", 1_000_000,
 				"the maximum address index allowed in transactions")
+			flags.Float64Var(&ingestConf.PayerQuota.Rate, "ingest-payer-rate", 0, // no payer quota
+				"rate of transactions admitted per payer (transactions/second)")
+			flags.UintVar(&ingestConf.PayerQuota.Burst, "ingest-payer-burst", 10,
+				"maximum burst of transactions admitted per payer")
+			flags.Float64Var(&ingestConf.ProposerQuota.Rate, "ingest-proposer-rate", 0, // no proposer quota
+				"rate of transactions admitted per proposal key (transactions/second)")
+			flags.UintVar(&ingestConf.ProposerQuota.Burst, "ingest-proposer-burst", 10,
+				"maximum burst of transactions admitted per proposal key")
+			flags.Float64Var(&ingestConf.OriginQuota.Rate, "ingest-origin-rate", 0, // no origin quota
+				"rate of transactions admitted per ingress client address (transactions/second)")
+			flags.UintVar(&ingestConf.OriginQuota.Burst, "ingest-origin-burst", 100,
+				"maximum burst of transactions admitted per ingress client address")
+			flags.StringVar(&accountKeysAddr, "ingest-account-keys-addr", "", // no signature verification
+				"the address of the execution node queried for account keys to verify transaction signatures")
+			flags.DurationVar(&accountKeysTimeout, "ingest-account-keys-timeout", 5*time.Second,
+				"timeout for account key requests to the execution node")
+			flags.IntVar(&accountKeysCacheSize, "ingest-account-keys-cache-size", 10000,
+				"maximum number of accounts whose keys are cached")
+			flags.DurationVar(&accountKeysCacheTTL, "ingest-account-keys-cache-ttl", 10*time.Second,
+				"how long account keys are cached")
 			flags.UintVar(&builderExpiryBuffer, "builder-expiry-buffer", 25,
 				"expiry buffer for transactions in proposed collections")
 			flags.Float64Var(&builderPayerRateLimit, "builder-rate-limit", 0, // no rate limiting
 				"rate limit for each payer (transactions/collection)")
 			flags.StringSliceVar(&builderUnlimitedPayers, "builder-unlimited-payers", []string{}, // no unlimited payers
 				"set of payer addresses which are omitted from rate limiting")
+			flags.StringVar(&builderPriority, "builder-priority", builder.PriorityNone,
+				"order in which transactions are selected for collections (none, gas-limit)")
 			flags.UintVar(&maxCollectionSize, "builder-max-collection-size", 200,
 				"maximum number of transactions in proposed collections")
 			flags.Uint64Var(&maxCollectionByteSize, "builder-max-collection-byte-size", 1000000,
@@ -135,11 +174,57 @@ func main() {
 			return err
 		}).
 		Module("transactions mempool", func(node *cmd.FlowNodeBuilder) error {
-			create := func() mempool.Transactions { return stdmap.NewTransactions(txLimit) }
-			pools = epochpool.NewTransactionPools(create)
+			create := func(_ uint64) (mempool.Transactions, error) { return stdmap.NewTransactions(txLimit), nil }
+			var teardown func(uint64, mempool.Transactions)
+			if persistentTxPool {
+				create = func(epoch uint64) (mempool.Transactions, error) {
+					pool, err := badgerpool.NewTransactions(node.Logger, node.DB, node.Storage.Headers, epoch, txLimit, builderExpiryBuffer)
+					if err != nil {
+						return nil, fmt.Errorf("could not load persistent transaction pool: %w", err)
+					}
+					// prune expired transactions as the main chain is finalized
+					node.ProtocolEvents.AddConsumer(pool)
+					return pool, nil
+				}
+				teardown = func(_ uint64, pool mempool.Transactions) {
+					node.ProtocolEvents.RemoveConsumer(pool.(*badgerpool.Transactions))
+				}
+			}
+			pools = epochpool.NewTransactionPools(create, teardown)
 			err := node.Metrics.Mempool.Register(metrics.ResourceTransaction, pools.CombinedSize)
 			return err
 		}).
+		Module("account keys", func(node *cmd.FlowNodeBuilder) error {
+			if accountKeysAddr == "" {
+				return nil
+			}
+
+			node.Logger.Info().Msgf("Execution node address for account keys: %s", accountKeysAddr)
+
+			security := grpc.WithInsecure()
+			if grpcTLS {
+				cert, err := grpcauth.NodeCertificate(node.NetworkKey())
+				if err != nil {
+					return fmt.Errorf("could not create TLS certificate: %w", err)
+				}
+				creds, err := grpcauth.NodeCredentials(node.State, cert)(accountKeysAddr)
+				if err != nil {
+					return fmt.Errorf("could not get credentials of execution node: %w", err)
+				}
+				security = grpc.WithTransportCredentials(creds)
+			}
+
+			executionRPCConn, err := grpc.Dial(
+				accountKeysAddr,
+				grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutils.DefaultMaxMsgSize)),
+				security)
+			if err != nil {
+				return fmt.Errorf("could not connect to execution node: %w", err)
+			}
+			provider := access.NewExecutionNodeAccountKeys(node.State, execution.NewExecutionAPIClient(executionRPCConn), accountKeysTimeout)
+			ingestConf.AccountKeys, err = access.NewCachedAccountKeys(provider, accountKeysCacheSize, accountKeysCacheTTL)
+			return err
+		}).
 		Module("pending block cache", func(node *cmd.FlowNodeBuilder) error {
 			followerBuffer = buffer.NewPendingBlocks()
 			return nil
@@ -256,6 +341,13 @@ func main() {
 			return ing, err
 		}).
 		Component("transaction ingress server", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
+			if grpcTLS {
+				cert, err := grpcauth.NodeCertificate(node.NetworkKey())
+				if err != nil {
+					return nil, fmt.Errorf("could not create TLS certificate: %w", err)
+				}
+				ingressConf.Credentials = grpcauth.ServerCredentials(cert, grpcauth.StakedNodes(node.State, flow.RoleAccess))
+			}
 			server := ingress.New(ingressConf, ing, node.RootChainID)
 			return server, nil
 		}).
@@ -299,6 +391,11 @@ func main() {
 				unlimitedPayers = append(unlimitedPayers, payerAddr)
 			}
 
+			priority, err := builder.NamedPriority(builderPriority)
+			if err != nil {
+				return nil, fmt.Errorf("invalid builder priority: %w", err)
+			}
+
 			builderFactory, err := factories.NewBuilderFactory(
 				node.DB,
 				node.Storage.Headers,
@@ -311,6 +408,7 @@ func main() {
 				builder.WithExpiryBuffer(builderExpiryBuffer),
 				builder.WithMaxPayerTransactionRate(builderPayerRateLimit),
 				builder.WithUnlimitedPayers(unlimitedPayers...),
+				builder.WithPriority(priority),
 			)
 			if err != nil {
 				return nil, err
@@ -26,6 +26,7 @@ import (
 	"github.com/onflow/flow-go/engine/consensus/ingestion"
 	"github.com/onflow/flow-go/engine/consensus/matching"
 	"github.com/onflow/flow-go/engine/consensus/provider"
+	"github.com/onflow/flow-go/engine/consensus/slashing"
 	"github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/model/encoding"
 	"github.com/onflow/flow-go/model/flow"
@@ -44,6 +45,7 @@ import (
 	"github.com/onflow/flow-go/module/synchronization"
 	"github.com/onflow/flow-go/state/protocol"
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
+	"github.com/onflow/flow-go/storage"
 	bstorage "github.com/onflow/flow-go/storage/badger"
 	"github.com/onflow/flow-go/utils/io"
 )
@@ -66,8 +68,15 @@ func main() {
 		hotstuffTimeoutDecreaseFactor          float64
 		hotstuffTimeoutVoteAggregationFraction float64
 		blockRateDelay                         time.Duration
+		blockRateTarget                        time.Duration
+		blockRateMinDelay                      time.Duration
+		blockRateMaxDelay                      time.Duration
+		blockRateGain                          float64
+		epochEndTime                           string
+		epochDuration                          time.Duration
 		requireOneApproval                     bool
 		chunkAlpha                             uint
+		adminAddr                              string
 
 		err            error
 		mutableState   protocol.MutableState
@@ -83,6 +92,8 @@ func main() {
 		comp           *compliance.Engine
 		conMetrics     module.ConsensusMetrics
 		mainMetrics    module.HotstuffMetrics
+		evidence       storage.SlashingEvidence
+		slashingEng    *slashing.Engine
 	)
 
 	cmd.FlowNode(flow.RoleConsensus.String()).
@@ -102,8 +113,15 @@ func main() {
 			flags.Float64Var(&hotstuffTimeoutDecreaseFactor, "hotstuff-timeout-decrease-factor", timeout.DefaultConfig.TimeoutDecrease, "multiplicative decrease of timeout value in case of progress")
 			flags.Float64Var(&hotstuffTimeoutVoteAggregationFraction, "hotstuff-timeout-vote-aggregation-fraction", 0.6, "additional fraction of replica timeout that the primary will wait for votes")
 			flags.DurationVar(&blockRateDelay, "block-rate-delay", 500*time.Millisecond, "the delay to broadcast block proposal in order to control block production rate")
+			flags.DurationVar(&blockRateTarget, "block-rate-target", 0, "the target view duration for adjusting the block rate delay at runtime; zero keeps the block rate delay fixed")
+			flags.DurationVar(&blockRateMinDelay, "block-rate-min-delay", 0, "the lower bound for the adjusted block rate delay")
+			flags.DurationVar(&blockRateMaxDelay, "block-rate-max-delay", 2*time.Second, "the upper bound for the adjusted block rate delay")
+			flags.Float64Var(&blockRateGain, "block-rate-gain", timeout.DefaultBlockRateConfig.Gain, "fraction of the deviation from the target view duration by which the block rate delay is adjusted per view")
+			flags.StringVar(&epochEndTime, "epoch-end-time", "", "the time (RFC3339) at which the final view of the current epoch should be reached when adjusting the block rate delay")
+			flags.DurationVar(&epochDuration, "epoch-duration", 0, "the targeted duration of each epoch, from which the end time of the next epoch is derived on epoch transitions when adjusting the block rate delay; zero disables updating the target")
 			flags.BoolVar(&requireOneApproval, "require-one-approval", false, "require one approval per chunk when sealing execution results")
 			flags.UintVar(&chunkAlpha, "chunk-alpha", chmodule.DefaultChunkAssignmentAlpha, "number of verifiers that should be assigned to each chunk")
+			flags.StringVar(&adminAddr, "admin-addr", "", "address of the admin API serving evidence of slashable offences, the admin API is disabled if empty")
 		}).
 		Module("mutable follower state", func(node *cmd.FlowNodeBuilder) error {
 			// For now, we only support state implementations from package badger.
@@ -121,6 +139,10 @@ func main() {
 			)
 			return err
 		}).
+		Module("slashing evidence storage", func(node *cmd.FlowNodeBuilder) error {
+			evidence = bstorage.NewSlashingEvidence(node.DB)
+			return nil
+		}).
 		Module("random beacon key", func(node *cmd.FlowNodeBuilder) error {
 			privateDKGData, err = loadDKGPrivateData(node.BaseConfig.BootstrapDir, node.NodeID)
 			return err
@@ -244,6 +266,36 @@ func main() {
 			)
 			return ing, err
 		}).
+		Component("slashing engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
+
+			// verify the signatures of offenders like the signatures of votes and proposals
+			staking := signature.NewAggregationVerifier(encoding.ConsensusVoteTag)
+			beacon := signature.NewThresholdVerifier(encoding.RandomBeaconTag)
+			merger := signature.NewCombiner()
+			newVerifier := func(committee hotstuff.Committee) hotstuff.Verifier {
+				return verification.NewCombinedVerifier(committee, staking, beacon, merger)
+			}
+
+			var err error
+			slashingEng, err = slashing.New(
+				node.Logger,
+				node.Metrics.Engine,
+				node.Network,
+				node.Me,
+				node.State,
+				node.Storage.Headers,
+				newVerifier,
+				evidence,
+			)
+			return slashingEng, err
+		}).
+		Component("admin server", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
+			if adminAddr == "" {
+				node.Logger.Info().Msg("admin server disabled")
+				return &module.NoopReadyDoneAware{}, nil
+			}
+			return slashing.NewAdminServer(node.Logger, adminAddr, evidence), nil
+		}).
 		Component("consensus components", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
 
 			// TODO: we should probably find a way to initialize mutually dependent engines separately
@@ -337,7 +389,7 @@ func main() {
 			signer = verification.NewMetricsWrapper(signer, mainMetrics) // wrapper for measuring time spent with crypto-related operations
 
 			// initialize a logging notifier for hotstuff
-			notifier := createNotifier(node.Logger, mainMetrics, node.Tracer, node.Storage.Index, node.RootChainID)
+			notifier := createNotifier(node.Logger, mainMetrics, node.Tracer, node.Storage.Index, node.RootChainID, slashingEng)
 			// initialize the persister
 			persist := persister.New(node.DB, node.RootChainID)
 
@@ -347,6 +399,37 @@ func main() {
 				return nil, fmt.Errorf("could not find latest finalized block and pending blocks: %w", err)
 			}
 
+			// configure the pacemaker, with the block rate controller optionally aiming for the end of the current epoch
+			// and, if an epoch duration is configured, for the end of every following epoch
+			options := []consensus.Option{
+				consensus.WithInitialTimeout(hotstuffTimeout),
+				consensus.WithMinTimeout(hotstuffMinTimeout),
+				consensus.WithVoteAggregationTimeoutFraction(hotstuffTimeoutVoteAggregationFraction),
+				consensus.WithTimeoutIncreaseFactor(hotstuffTimeoutIncreaseFactor),
+				consensus.WithTimeoutDecreaseFactor(hotstuffTimeoutDecreaseFactor),
+				consensus.WithBlockRateDelay(blockRateDelay),
+				consensus.WithBlockRateTarget(blockRateTarget),
+				consensus.WithBlockRateBounds(blockRateMinDelay, blockRateMaxDelay),
+				consensus.WithBlockRateGain(blockRateGain),
+			}
+			var endTime time.Time
+			if epochEndTime != "" {
+				endTime, err = time.Parse(time.RFC3339, epochEndTime)
+				if err != nil {
+					return nil, fmt.Errorf("could not parse epoch end time: %w", err)
+				}
+				finalView, err := node.State.Final().Epochs().Current().FinalView()
+				if err != nil {
+					return nil, fmt.Errorf("could not get final view of current epoch: %w", err)
+				}
+				options = append(options, consensus.WithEpochEndTarget(finalView, endTime))
+			}
+			if epochDuration > 0 {
+				epochTargets := consensus.NewEpochTargets(node.Logger, node.State, endTime, epochDuration)
+				node.ProtocolEvents.AddConsumer(epochTargets)
+				options = append(options, consensus.WithEpochTargets(epochTargets))
+			}
+
 			// initialize hotstuff consensus algorithm
 			hot, err := consensus.NewParticipant(
 				node.Logger,
@@ -363,12 +446,7 @@ func main() {
 				node.RootQC,
 				finalized,
 				pending,
-				consensus.WithInitialTimeout(hotstuffTimeout),
-				consensus.WithMinTimeout(hotstuffMinTimeout),
-				consensus.WithVoteAggregationTimeoutFraction(hotstuffTimeoutVoteAggregationFraction),
-				consensus.WithTimeoutIncreaseFactor(hotstuffTimeoutIncreaseFactor),
-				consensus.WithTimeoutDecreaseFactor(hotstuffTimeoutDecreaseFactor),
-				consensus.WithBlockRateDelay(blockRateDelay),
+				options...,
 			)
 			if err != nil {
 				return nil, fmt.Errorf("could not initialize hotstuff engine: %w", err)
@@ -14,6 +14,7 @@ import (
 )
 
 func createNotifier(log zerolog.Logger, metrics module.HotstuffMetrics, tracer module.Tracer, index storage.Index, chain flow.ChainID,
+	slashingConsumer hotstuff.Consumer,
 ) hotstuff.Consumer {
 	telemetryConsumer := notifications.NewTelemetryConsumer(log, chain)
 	tracingConsumer := notifications.NewConsensusTracingConsumer(log, tracer, index)
@@ -22,5 +23,6 @@ func createNotifier(log zerolog.Logger, metrics module.HotstuffMetrics, tracer m
 	dis.AddConsumer(telemetryConsumer)
 	dis.AddConsumer(tracingConsumer)
 	dis.AddConsumer(metricsConsumer)
+	dis.AddConsumer(slashingConsumer)
 	return dis
 }
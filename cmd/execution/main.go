@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/dgraph-io/badger/v2"
 	"github.com/onflow/cadence/runtime"
 	"github.com/spf13/pflag"
 
@@ -22,13 +23,18 @@ import (
 	"github.com/onflow/flow-go/engine/common/requester"
 	"github.com/onflow/flow-go/engine/common/synchronization"
 	"github.com/onflow/flow-go/engine/execution/computation"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
 	exeprovider "github.com/onflow/flow-go/engine/execution/provider"
 	"github.com/onflow/flow-go/engine/execution/rpc"
 	"github.com/onflow/flow-go/engine/execution/state"
 	"github.com/onflow/flow-go/engine/execution/state/bootstrap"
 	"github.com/onflow/flow-go/fvm"
+	ledgerstate "github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/archive"
 	ledger "github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/pager"
 	wal "github.com/onflow/flow-go/ledger/complete/wal"
 	bootstrapFilenames "github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/model/encoding"
@@ -37,12 +43,15 @@ import (
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/buffer"
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
+	"github.com/onflow/flow-go/module/grpcauth"
+	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/module/signature"
 	chainsync "github.com/onflow/flow-go/module/synchronization"
 	"github.com/onflow/flow-go/state/protocol"
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
 	storage "github.com/onflow/flow-go/storage/badger"
+	sutil "github.com/onflow/flow-go/storage/util"
 )
 
 func main() {
@@ -50,6 +59,7 @@ func main() {
 	var (
 		followerState         protocol.MutableState
 		ledgerStorage         *ledger.Ledger
+		executionLedger       ledgerstate.Ledger
 		events                *storage.Events
 		txResults             *storage.TransactionResults
 		results               *storage.ExecutionResults
@@ -64,19 +74,26 @@ func main() {
 		collectionRequester   *requester.Engine
 		ingestionEng          *ingestion.Engine
 		rpcConf               rpc.Config
+		grpcTLS               bool
 		err                   error
 		executionState        state.ExecutionState
 		triedir               string
+		archiveDir            string
 		collector             module.ExecutionMetrics
 		mTrieCacheSize        uint32
+		mTrieNodeStoreDir     string
+		mTrieNodeCacheSize    uint
+		mTrieResidentDepth    uint
 		checkpointDistance    uint
-		stateDeltasLimit      uint
 		requestInterval       time.Duration
 		preferredExeNodeIDStr string
 		syncByBlocks          bool
 		syncFast              bool
 		syncThreshold         int
 		extensiveLog          bool
+		parallelExecution     int
+		storagePerFLOW        uint64
+		stateDeltasConfig     = cmd.NewMempoolConfig(1000, stdmap.EjectionPolicyRandom)
 	)
 
 	cmd.FlowNode(flow.RoleExecution.String()).
@@ -85,16 +102,23 @@ func main() {
 			datadir := filepath.Join(homedir, ".flow", "execution")
 
 			flags.StringVarP(&rpcConf.ListenAddr, "rpc-addr", "i", "localhost:9000", "the address the gRPC server listens on")
+			flags.BoolVar(&grpcTLS, "grpc-tls", false, "whether the gRPC server requires mutual TLS from staked access and collection nodes")
 			flags.StringVar(&triedir, "triedir", datadir, "directory to store the execution State")
+			flags.StringVar(&archiveDir, "archive-dir", "", "directory to archive the execution state in, so it can be queried at any height (disabled if empty)")
 			flags.Uint32Var(&mTrieCacheSize, "mtrie-cache-size", 1000, "cache size for MTrie")
+			flags.StringVar(&mTrieNodeStoreDir, "mtrie-node-store-dir", "", "directory to page cold MTrie nodes out to (paging is disabled if empty)")
+			flags.UintVar(&mTrieNodeCacheSize, "mtrie-node-cache-size", 100000, "number of paged out MTrie nodes cached in memory")
+			flags.UintVar(&mTrieResidentDepth, "mtrie-resident-depth", 16, "depth up to which MTrie nodes are kept in memory when paging is enabled")
 			flags.UintVar(&checkpointDistance, "checkpoint-distance", 10, "number of WAL segments between checkpoints")
-			flags.UintVar(&stateDeltasLimit, "state-deltas-limit", 1000, "maximum number of state deltas in the memory pool")
+			stateDeltasConfig.AddFlags(flags, "state-deltas", "state deltas", true)
 			flags.DurationVar(&requestInterval, "request-interval", 60*time.Second, "the interval between requests for the requester engine")
 			flags.StringVar(&preferredExeNodeIDStr, "preferred-exe-node-id", "", "node ID for preferred execution node used for state sync")
 			flags.BoolVar(&syncByBlocks, "sync-by-blocks", true, "deprecated, sync by blocks instead of execution state deltas")
 			flags.BoolVar(&syncFast, "sync-fast", false, "fast sync allows execution node to skip fetching collection during state syncing, and rely on state syncing to catch up")
 			flags.IntVar(&syncThreshold, "sync-threshold", 100, "the maximum number of sealed and unexecuted blocks before triggering state syncing")
 			flags.BoolVar(&extensiveLog, "extensive-logging", false, "extensive logging logs tx contents and block headers")
+			flags.IntVar(&parallelExecution, "parallel-execution-workers", 0, "number of workers executing transactions of a collection in parallel, transactions are executed serially if lower than 2")
+			flags.Uint64Var(&storagePerFLOW, "storage-capacity-per-flow", 0, "number of storage bytes granted to an account for each FLOW it holds, must match the verification nodes (storage is not limited if zero)")
 		}).
 		Module("mutable follower state", func(node *cmd.FlowNodeBuilder) error {
 			// For now, we only support state implementations from package badger.
@@ -116,6 +140,7 @@ func main() {
 			rt := runtime.NewInterpreterRuntime()
 
 			vm := fvm.New(rt)
+			node.FvmOptions = append(node.FvmOptions, fvm.WithStorageCapacityPerFLOW(storagePerFLOW))
 			vmCtx := fvm.NewContext(node.Logger, node.FvmOptions...)
 
 			manager, err := computation.New(
@@ -126,6 +151,7 @@ func main() {
 				node.State,
 				vm,
 				vmCtx,
+				computer.WithParallelExecution(parallelExecution),
 			)
 			computationManager = manager
 
@@ -149,8 +175,26 @@ func main() {
 			return nil
 		}).
 		Module("state deltas mempool", func(node *cmd.FlowNodeBuilder) error {
-			deltas, err = ingestion.NewDeltas(stateDeltasLimit)
-			return err
+			opts, err := stateDeltasConfig.Options(metrics.ResourceStateDeltas, node.Metrics.Mempool, ingestion.DeltaSize, ingestion.DeltaHeight)
+			if err != nil {
+				return fmt.Errorf("invalid state deltas mempool configuration: %w", err)
+			}
+
+			deltas, err = ingestion.NewDeltas(stateDeltasConfig.Limit, opts...)
+			if err != nil {
+				return err
+			}
+
+			// registers size methods of backend for metrics
+			err = node.Metrics.Mempool.Register(metrics.ResourceStateDeltas, deltas.Size)
+			if err != nil {
+				return fmt.Errorf("could not register backend metric: %w", err)
+			}
+			err = node.Metrics.Mempool.RegisterBytes(metrics.ResourceStateDeltas, deltas.ByteSize)
+			if err != nil {
+				return fmt.Errorf("could not register backend metric: %w", err)
+			}
+			return nil
 		}).
 		Component("execution state ledger", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
 
@@ -187,8 +231,66 @@ func main() {
 				}
 			}
 
-			ledgerStorage, err = ledger.NewLedger(triedir, int(mTrieCacheSize), collector, node.Logger.With().Str("subcomponent", "ledger").Logger(), node.MetricsRegisterer, ledger.DefaultPathFinderVersion)
-			return ledgerStorage, err
+			var archiveDB *badger.DB
+			if archiveDir != "" {
+				err = os.MkdirAll(archiveDir, 0700)
+				if err != nil {
+					return nil, fmt.Errorf("could not create archive directory: %w", err)
+				}
+				archiveDB, err = badger.Open(badger.DefaultOptions(archiveDir).WithLogger(sutil.NewLogger(node.Logger)))
+				if err != nil {
+					return nil, fmt.Errorf("could not open archive database: %w", err)
+				}
+
+				// the archive is caught up with the history available in the write-ahead log, which
+				// populates a new archive and restores the states a crash prevented from being archived,
+				// afterwards it is kept up to date by archiving every ledger update
+				node.Logger.Info().Str("archive_dir", archiveDir).Msg("importing write-ahead log into the execution state archive")
+				err = archive.ImportWAL(archiveDB, triedir, int(mTrieCacheSize), collector, ledger.DefaultPathFinderVersion)
+				if err != nil {
+					return nil, fmt.Errorf("could not import write-ahead log into archive: %w", err)
+				}
+			}
+
+			var forestOpts []mtrie.ForestOption
+			if mTrieNodeStoreDir != "" {
+				// paged out nodes are only an extension of memory, tries are restored from the
+				// write-ahead log and checkpoints, so nodes left over by a previous run are dropped
+				err = os.RemoveAll(mTrieNodeStoreDir)
+				if err != nil {
+					return nil, fmt.Errorf("could not clean up node store directory: %w", err)
+				}
+				err = os.MkdirAll(mTrieNodeStoreDir, 0700)
+				if err != nil {
+					return nil, fmt.Errorf("could not create node store directory: %w", err)
+				}
+				nodeDB, err := badger.Open(badger.DefaultOptions(mTrieNodeStoreDir).WithLogger(sutil.NewLogger(node.Logger)))
+				if err != nil {
+					return nil, fmt.Errorf("could not open node store: %w", err)
+				}
+				nodePager, err := pager.New(nodeDB, int(mTrieNodeCacheSize), int(mTrieResidentDepth), collector)
+				if err != nil {
+					return nil, fmt.Errorf("could not create node pager: %w", err)
+				}
+				forestOpts = append(forestOpts, mtrie.WithPager(nodePager))
+			}
+
+			ledgerStorage, err = ledger.NewLedger(triedir, int(mTrieCacheSize), collector, node.Logger.With().Str("subcomponent", "ledger").Logger(), node.MetricsRegisterer, ledger.DefaultPathFinderVersion, forestOpts...)
+			if err != nil {
+				return nil, err
+			}
+
+			if archiveDB == nil {
+				executionLedger = ledgerStorage
+				return ledgerStorage, nil
+			}
+
+			archiveLedger, err := archive.NewLedger(archiveDB, ledgerStorage, collector, node.Logger.With().Str("subcomponent", "ledger").Logger(), ledger.DefaultPathFinderVersion)
+			if err != nil {
+				return nil, fmt.Errorf("could not create archival ledger: %w", err)
+			}
+			executionLedger = archiveLedger
+			return archiveLedger, nil
 		}).
 		Component("execution state ledger WAL compactor", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
 
@@ -205,7 +307,7 @@ func main() {
 			stateCommitments := storage.NewCommits(node.Metrics.Cache, node.DB)
 
 			executionState = state.NewExecutionState(
-				ledgerStorage,
+				executionLedger,
 				stateCommitments,
 				node.Storage.Blocks,
 				node.Storage.Collections,
@@ -377,6 +479,13 @@ func main() {
 			return syncEngine, nil
 		}).
 		Component("grpc server", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
+			if grpcTLS {
+				cert, err := grpcauth.NodeCertificate(node.NetworkKey())
+				if err != nil {
+					return nil, fmt.Errorf("could not create TLS certificate: %w", err)
+				}
+				rpcConf.Credentials = grpcauth.ServerCredentials(cert, grpcauth.StakedNodes(node.State, flow.RoleAccess, flow.RoleCollection))
+			}
 			rpcEng := rpc.New(node.Logger, rpcConf, ingestionEng, node.Storage.Blocks, events, results, txResults, node.RootChainID)
 			return rpcEng, nil
 		}).Run()
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/mempool/stdmap"
+)
+
+// MempoolConfig is the configuration of a memory pool of a node, set through its flags.
+type MempoolConfig struct {
+	Limit     uint          // maximum number of entities in the memory pool
+	ByteLimit uint64        // maximum total size of the entities in bytes, no limit if zero
+	Ejection  string        // name of the policy ejecting entities from the memory pool
+	TTL       time.Duration // time after which entities expire, with the ttl ejection policy
+}
+
+// NewMempoolConfig returns the default configuration of a memory pool with the given limit and ejection policy.
+func NewMempoolConfig(limit uint, ejection string) MempoolConfig {
+	return MempoolConfig{
+		Limit:    limit,
+		Ejection: ejection,
+		TTL:      10 * time.Minute,
+	}
+}
+
+// AddFlags registers the flags configuring the memory pool of the given name, holding the given entities.
+// The byte limit can only be set for memory pools whose entities are measured by a sizer.
+func (c *MempoolConfig) AddFlags(flags *pflag.FlagSet, name string, entities string, measured bool) {
+	flags.UintVar(&c.Limit, name+"-limit", c.Limit, fmt.Sprintf("maximum number of %s in the memory pool", entities))
+	if measured {
+		flags.Uint64Var(&c.ByteLimit, name+"-byte-limit", c.ByteLimit, fmt.Sprintf("maximum total size in bytes of the %s in the memory pool (no limit if zero)", entities))
+	}
+	flags.StringVar(&c.Ejection, name+"-ejection", c.Ejection, fmt.Sprintf("policy ejecting %s from the memory pool: random, lru, lfu, ttl or height", entities))
+	flags.DurationVar(&c.TTL, name+"-ttl", c.TTL, fmt.Sprintf("time after which %s expire from the memory pool, with the ttl ejection policy", entities))
+}
+
+// Options returns the options of a memory pool applying the configuration, besides its limit, which memory
+// pools take on creation. Ejected entities are reported under the given resource. The sizer and the height
+// function may be nil for memory pools that do not support byte limits or the height ejection policy.
+func (c *MempoolConfig) Options(resource string, metrics module.MempoolMetrics, sizer stdmap.EntitySizer, height stdmap.HeightFunc) ([]stdmap.OptionFunc, error) {
+	ejection, err := stdmap.WithNamedEjectionPolicy(c.Ejection, c.TTL, height)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ejection policy: %w", err)
+	}
+	opts := []stdmap.OptionFunc{
+		ejection,
+		stdmap.WithEjectionMetrics(resource, metrics),
+	}
+	if sizer != nil {
+		opts = append(opts, stdmap.WithByteLimit(c.ByteLimit, sizer))
+	}
+	return opts, nil
+}
@@ -656,6 +656,12 @@ func (fnb *FlowNodeBuilder) Component(name string, f func(*FlowNodeBuilder) (mod
 	return fnb
 }
 
+// NetworkKey returns the private networking key of the node, which also
+// authenticates the node on gRPC links to other nodes.
+func (fnb *FlowNodeBuilder) NetworkKey() crypto.PrivateKey {
+	return fnb.networkKey
+}
+
 func (fnb *FlowNodeBuilder) PostInit(f func(node *FlowNodeBuilder)) *FlowNodeBuilder {
 	fnb.postInitFns = append(fnb.postInitFns, f)
 	return fnb
@@ -1,11 +1,14 @@
 package checkpoint_list_tries
 
 import (
+	"bufio"
 	"fmt"
+	"os"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
 	"github.com/onflow/flow-go/ledger/complete/wal"
 )
 
@@ -28,12 +31,22 @@ func init() {
 
 func run(*cobra.Command, []string) {
 
-	flattenedForest, err := wal.LoadCheckpoint(flagCheckpoint)
+	file, err := os.Open(flagCheckpoint)
 	if err != nil {
-		log.Fatal().Err(err).Msg("error while loading checkpoint")
+		log.Fatal().Err(err).Msg("cannot open checkpoint file")
 	}
-
-	for _, trie := range flattenedForest.Tries {
-		fmt.Printf("%x\n", trie.RootHash)
+	defer file.Close()
+
+	// nodes are skipped, only tries are of interest
+	err = wal.ReadCheckpoint(bufio.NewReader(file),
+		func(*flattener.StorableNode) error {
+			return nil
+		},
+		func(trie *flattener.StorableTrie) error {
+			fmt.Printf("%x\n", trie.RootHash)
+			return nil
+		})
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while loading checkpoint")
 	}
 }
@@ -0,0 +1,152 @@
+package checkpoint_verify
+
+import (
+	"io"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+)
+
+var (
+	flagExecutionStateDir string
+	flagCheckpoint        int
+	flagRepair            bool
+)
+
+var Cmd = &cobra.Command{
+	Use:   "checkpoint-verify",
+	Short: "Verifies a checkpoint against its content and the WAL, and optionally rebuilds it",
+	Long: `Verifies the structure and checksums of a checkpoint, recomputes the root hash of every trie it contains
+and replays the WAL segments following it on top of its tries.
+With --repair, a corrupted latest checkpoint is discarded (together with any corrupted checkpoint before it)
+and rebuilt from the last good checkpoint and the WAL.`,
+	Run: run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagExecutionStateDir, "execution-state-dir", "",
+		"directory of the execution state (WAL segments and checkpoints)")
+	_ = Cmd.MarkFlagRequired("execution-state-dir")
+
+	Cmd.Flags().IntVar(&flagCheckpoint, "checkpoint", -1,
+		"number of the checkpoint to verify, defaults to the latest checkpoint")
+
+	Cmd.Flags().BoolVar(&flagRepair, "repair", false,
+		"rebuild the latest checkpoint from the last good checkpoint and the WAL if it is corrupted")
+}
+
+func run(*cobra.Command, []string) {
+
+	w, err := wal.NewWAL(nil, nil, flagExecutionStateDir, complete.DefaultCacheSize, pathfinder.PathByteSize, wal.SegmentSize)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create WAL")
+	}
+	defer w.Close()
+
+	checkpointer, err := w.NewCheckpointer()
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create checkpointer")
+	}
+
+	checkpoints, err := checkpointer.Checkpoints()
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot list checkpoints")
+	}
+	if len(checkpoints) == 0 {
+		log.Info().Msg("no checkpoints to verify")
+		return
+	}
+
+	latest := checkpoints[len(checkpoints)-1]
+	target := flagCheckpoint
+	if target == -1 {
+		target = latest
+	}
+	if flagRepair && target != latest {
+		log.Fatal().Int("checkpoint", target).Int("latest", latest).Msg("only the latest checkpoint can be repaired")
+	}
+
+	index := -1
+	for i, checkpoint := range checkpoints {
+		if checkpoint == target {
+			index = i
+		}
+	}
+	if index == -1 {
+		log.Fatal().Int("checkpoint", target).Msg("checkpoint does not exist")
+	}
+
+	tries, err := checkpointer.VerifyCheckpoint(target)
+	if err == nil {
+		log.Info().Int("checkpoint", target).Int("tries", len(tries)).Msg("checkpoint is valid")
+		verifySegments(checkpointer, target, tries)
+		return
+	}
+
+	log.Error().Err(err).Int("checkpoint", target).Msg("checkpoint is corrupted")
+	if !flagRepair {
+		log.Fatal().Msg("checkpoint verification failed, run with --repair to rebuild it")
+	}
+
+	// find the last good checkpoint, discarding corrupted ones, so that the checkpointer starts from it
+	corrupted := []int{target}
+	good := -1
+	for i := index - 1; i >= 0; i-- {
+		_, err := checkpointer.VerifyCheckpoint(checkpoints[i])
+		if err == nil {
+			good = checkpoints[i]
+			break
+		}
+		log.Error().Err(err).Int("checkpoint", checkpoints[i]).Msg("checkpoint is corrupted")
+		corrupted = append(corrupted, checkpoints[i])
+	}
+
+	for _, checkpoint := range corrupted {
+		err = checkpointer.DiscardCheckpoint(checkpoint)
+		if err != nil {
+			log.Fatal().Err(err).Int("checkpoint", checkpoint).Msg("cannot discard corrupted checkpoint")
+		}
+	}
+
+	if good == -1 {
+		log.Info().Int("checkpoint", target).Msg("no good checkpoint found, rebuilding checkpoint from the beginning of the WAL")
+	} else {
+		log.Info().Int("checkpoint", target).Int("from", good).Msg("rebuilding checkpoint from the last good checkpoint")
+	}
+
+	err = checkpointer.Checkpoint(target, func() (io.WriteCloser, error) {
+		return checkpointer.CheckpointWriter(target)
+	})
+	if err != nil {
+		log.Fatal().Err(err).Int("checkpoint", target).Msg("cannot rebuild checkpoint")
+	}
+
+	tries, err = checkpointer.VerifyCheckpoint(target)
+	if err != nil {
+		log.Fatal().Err(err).Int("checkpoint", target).Msg("rebuilt checkpoint is corrupted")
+	}
+	log.Info().Int("checkpoint", target).Int("tries", len(tries)).Msg("checkpoint has been rebuilt")
+
+	verifySegments(checkpointer, target, tries)
+}
+
+// verifySegments cross-checks the checkpoint against the WAL segments following it
+func verifySegments(checkpointer *wal.Checkpointer, checkpoint int, tries []*trie.MTrie) {
+
+	from, to, err := checkpointer.NotCheckpointedSegments()
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot get segments which are not checkpointed")
+	}
+	log.Info().Int("from", from).Int("to", to).Msg("segments not checkpointed yet")
+
+	last, err := checkpointer.VerifySegments(checkpoint, tries)
+	if err != nil {
+		log.Fatal().Err(err).Int("checkpoint", checkpoint).Msg("checkpoint does not match the WAL")
+	}
+	log.Info().Int("checkpoint", checkpoint).Int("last_segment", last).Msg("WAL segments apply on top of the checkpoint")
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
 	"github.com/onflow/flow-go/ledger/complete"
-	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
 	"github.com/onflow/flow-go/ledger/complete/wal"
 )
 
@@ -45,17 +44,17 @@ func run(*cobra.Command, []string) {
 		log.Fatal().Err(err).Msg("error while creating WAL")
 	}
 
+	// only the records of the segments are listed, checkpoints are not loaded
+	updates, deletes := 0, 0
 	err = w.ReplayLogsOnly(
-		func(forestSequencing *flattener.FlattenedForest) error {
-			fmt.Printf("forest sequencing \n")
-			return nil
-		},
 		func(update *ledger.TrieUpdate) error {
 			fmt.Printf("trie update to root hash (%s) \n", update.RootHash.String())
+			updates++
 			return nil
 		},
 		func(rootHash ledger.RootHash) error {
 			fmt.Printf("remove trie with root hash (%s) \n", rootHash.String())
+			deletes++
 			return nil
 		},
 	)
@@ -65,5 +64,9 @@ func run(*cobra.Command, []string) {
 
 	duration := time.Since(startTime)
 
-	log.Info().Float64("total_time_s", duration.Seconds()).Msg("finished")
+	log.Info().
+		Int("updates", updates).
+		Int("deletes", deletes).
+		Float64("total_time_s", duration.Seconds()).
+		Msg("finished")
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/viper"
 
 	checkpoint_list_tries "github.com/onflow/flow-go/cmd/util/cmd/checkpoint-list-tries"
+	checkpoint_verify "github.com/onflow/flow-go/cmd/util/cmd/checkpoint-verify"
 	export "github.com/onflow/flow-go/cmd/util/cmd/exec-data-json-export"
 	extract "github.com/onflow/flow-go/cmd/util/cmd/execution-state-extract"
 	"github.com/onflow/flow-go/cmd/util/cmd/find-block"
@@ -53,6 +54,7 @@ func addCommands() {
 	rootCmd.AddCommand(find.Cmd)
 	rootCmd.AddCommand(read.Cmd)
 	rootCmd.AddCommand(checkpoint_list_tries.Cmd)
+	rootCmd.AddCommand(checkpoint_verify.Cmd)
 	rootCmd.AddCommand(truncate_database.Cmd)
 }
 
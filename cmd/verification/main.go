@@ -55,9 +55,10 @@ func main() {
 	var (
 		followerState       protocol.MutableState
 		err                 error
-		receiptLimit        uint                       // size of execution-receipt/result related mempools
+		receiptLimit        uint                       // size of execution-receipt/result identifier related mempools
 		chunkAlpha          uint                       // number of verifiers assigned per chunk
 		chunkLimit          uint                       // size of chunk-related mempools
+		storagePerFLOW      uint64                     // storage bytes granted per FLOW when verifying chunks
 		cachedReceipts      *stdmap.ReceiptDataPacks   // used in finder engine
 		pendingReceipts     *stdmap.ReceiptDataPacks   // used in finder engine
 		readyReceipts       *stdmap.ReceiptDataPacks   // used in finder engine
@@ -76,13 +77,24 @@ func main() {
 		matchEng            *match.Engine              // the match engine
 		followerEng         *followereng.Engine        // the follower engine
 		collector           module.VerificationMetrics // used to collect metrics of all engines
+
+		// configurations of the mempools holding execution receipts and results
+		cachedReceiptsConf  = cmd.NewMempoolConfig(1000, stdmap.EjectionPolicyLRU)
+		pendingReceiptsConf = cmd.NewMempoolConfig(1000, stdmap.EjectionPolicyLRU)
+		readyReceiptsConf   = cmd.NewMempoolConfig(1000, stdmap.EjectionPolicyLRU)
+		pendingResultsConf  = cmd.NewMempoolConfig(1000, stdmap.EjectionPolicyLRU)
 	)
 
 	cmd.FlowNode(flow.RoleVerification.String()).
 		ExtraFlags(func(flags *pflag.FlagSet) {
-			flags.UintVar(&receiptLimit, "receipt-limit", 1000, "maximum number of execution receipts in the memory pool")
+			flags.UintVar(&receiptLimit, "receipt-limit", 1000, "maximum number of execution receipt and result identifiers in the memory pools")
+			cachedReceiptsConf.AddFlags(flags, "cached-receipts", "cached execution receipts", false)
+			pendingReceiptsConf.AddFlags(flags, "pending-receipts", "pending execution receipts", false)
+			readyReceiptsConf.AddFlags(flags, "ready-receipts", "ready execution receipts", false)
+			pendingResultsConf.AddFlags(flags, "pending-results", "pending execution results", false)
 			flags.UintVar(&chunkLimit, "chunk-limit", 10000, "maximum number of chunk states in the memory pool")
 			flags.UintVar(&chunkAlpha, "chunk-alpha", chunks.DefaultChunkAssignmentAlpha, "number of verifiers that should be assigned to each chunk")
+			flags.Uint64Var(&storagePerFLOW, "storage-capacity-per-flow", 0, "number of storage bytes granted to an account for each FLOW it holds, must match the execution nodes (storage is not limited if zero)")
 		}).
 		Module("mutable follower state", func(node *cmd.FlowNodeBuilder) error {
 			// For now, we only support state implementations from package badger.
@@ -105,7 +117,12 @@ func main() {
 			return nil
 		}).
 		Module("cached execution receipts mempool", func(node *cmd.FlowNodeBuilder) error {
-			cachedReceipts, err = stdmap.NewReceiptDataPacks(receiptLimit)
+			opts, err := cachedReceiptsConf.Options(metrics.ResourceCachedReceipt, node.Metrics.Mempool, nil, nil)
+			if err != nil {
+				return fmt.Errorf("invalid cached execution receipts mempool configuration: %w", err)
+			}
+
+			cachedReceipts, err = stdmap.NewReceiptDataPacks(cachedReceiptsConf.Limit, opts...)
 			if err != nil {
 				return err
 			}
@@ -118,7 +135,12 @@ func main() {
 			return nil
 		}).
 		Module("pending execution receipts mempool", func(node *cmd.FlowNodeBuilder) error {
-			pendingReceipts, err = stdmap.NewReceiptDataPacks(receiptLimit)
+			opts, err := pendingReceiptsConf.Options(metrics.ResourcePendingReceipt, node.Metrics.Mempool, nil, nil)
+			if err != nil {
+				return fmt.Errorf("invalid pending execution receipts mempool configuration: %w", err)
+			}
+
+			pendingReceipts, err = stdmap.NewReceiptDataPacks(pendingReceiptsConf.Limit, opts...)
 			if err != nil {
 				return err
 			}
@@ -131,7 +153,12 @@ func main() {
 			return nil
 		}).
 		Module("ready execution receipts mempool", func(node *cmd.FlowNodeBuilder) error {
-			readyReceipts, err = stdmap.NewReceiptDataPacks(receiptLimit)
+			opts, err := readyReceiptsConf.Options(metrics.ResourceReceipt, node.Metrics.Mempool, nil, nil)
+			if err != nil {
+				return fmt.Errorf("invalid ready execution receipts mempool configuration: %w", err)
+			}
+
+			readyReceipts, err = stdmap.NewReceiptDataPacks(readyReceiptsConf.Limit, opts...)
 			if err != nil {
 				return err
 			}
@@ -200,7 +227,12 @@ func main() {
 			return nil
 		}).
 		Module("pending results mempool", func(node *cmd.FlowNodeBuilder) error {
-			pendingResults = stdmap.NewResultDataPacks(receiptLimit)
+			opts, err := pendingResultsConf.Options(metrics.ResourcePendingResult, node.Metrics.Mempool, nil, nil)
+			if err != nil {
+				return fmt.Errorf("invalid pending execution results mempool configuration: %w", err)
+			}
+
+			pendingResults = stdmap.NewResultDataPacks(pendingResultsConf.Limit, opts...)
 
 			// registers size method of backend for metrics
 			err = node.Metrics.Mempool.Register(metrics.ResourcePendingResult, pendingResults.Size)
@@ -255,6 +287,7 @@ func main() {
 			rt := runtime.NewInterpreterRuntime()
 
 			vm := fvm.New(rt)
+			node.FvmOptions = append(node.FvmOptions, fvm.WithStorageCapacityPerFLOW(storagePerFLOW))
 			vmCtx := fvm.NewContext(node.Logger, node.FvmOptions...)
 
 			chunkVerifier := chunks.NewChunkVerifier(vm, vmCtx)
@@ -113,6 +113,14 @@ func (e *ColdStuff) SubmitVote(originID, blockID flow.Identifier, view uint64, s
 	}
 }
 
+func (e *ColdStuff) SubmitTimeout(originID flow.Identifier, view uint64, highestQC *flow.QuorumCertificate, sigData []byte) {
+	// ColdStuff has a fixed leader and does not time out
+	_ = originID
+	_ = view
+	_ = highestQC
+	_ = sigData
+}
+
 func (e *ColdStuff) SubmitCommit(commit *model.Commit) {
 	e.commits <- commit
 }
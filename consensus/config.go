@@ -11,6 +11,13 @@ type ParticipantConfig struct {
 	TimeoutIncreaseFactor      float64       // the factor at which the timeout grows when timeouts occur
 	TimeoutDecreaseFactor      float64       // the factor at which the timeout grows when timeouts occur
 	BlockRateDelay             time.Duration // a delay to broadcast block proposal in order to control the block production rate
+	BlockRateTarget            time.Duration // the target view duration for adjusting the block rate delay at runtime; zero keeps the delay fixed
+	BlockRateMinDelay          time.Duration // the lower bound for the adjusted block rate delay
+	BlockRateMaxDelay          time.Duration // the upper bound for the adjusted block rate delay
+	BlockRateGain              float64       // the fraction of the deviation from the target view duration by which the delay is adjusted per view
+	EpochFinalView             uint64        // the final view of the epoch, which the block rate controller aims to reach at EpochEndTime
+	EpochEndTime               time.Time     // the time at which the epoch should end; zero disables aiming for the epoch end
+	EpochTargets               *EpochTargets // the consumer updating the epoch target on epoch transitions; nil keeps the target of the current epoch
 }
 
 type Option func(*ParticipantConfig)
@@ -50,3 +57,35 @@ func WithBlockRateDelay(delay time.Duration) Option {
 		cfg.BlockRateDelay = delay
 	}
 }
+
+func WithBlockRateTarget(target time.Duration) Option {
+	return func(cfg *ParticipantConfig) {
+		cfg.BlockRateTarget = target
+	}
+}
+
+func WithBlockRateBounds(minDelay time.Duration, maxDelay time.Duration) Option {
+	return func(cfg *ParticipantConfig) {
+		cfg.BlockRateMinDelay = minDelay
+		cfg.BlockRateMaxDelay = maxDelay
+	}
+}
+
+func WithBlockRateGain(gain float64) Option {
+	return func(cfg *ParticipantConfig) {
+		cfg.BlockRateGain = gain
+	}
+}
+
+func WithEpochEndTarget(finalView uint64, endTime time.Time) Option {
+	return func(cfg *ParticipantConfig) {
+		cfg.EpochFinalView = finalView
+		cfg.EpochEndTime = endTime
+	}
+}
+
+func WithEpochTargets(targets *EpochTargets) Option {
+	return func(cfg *ParticipantConfig) {
+		cfg.EpochTargets = targets
+	}
+}
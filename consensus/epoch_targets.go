@@ -0,0 +1,79 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/events"
+)
+
+// EpochTargets is a protocol events consumer that sets the epoch target of the
+// block rate controller on every epoch transition, so that each epoch aims to
+// end the configured epoch duration after the end of the previous one.
+type EpochTargets struct {
+	events.Noop
+	log       zerolog.Logger
+	state     protocol.State
+	duration  time.Duration
+	mu        sync.Mutex
+	endTime   time.Time
+	blockRate epochTargetSetter
+}
+
+// epochTargetSetter sets the epoch target of a block rate controller.
+type epochTargetSetter interface {
+	SetEpochTarget(finalView uint64, endTime time.Time)
+}
+
+// NewEpochTargets returns a new epoch targets consumer. The end time is the
+// targeted end of the current epoch, from which the end of the next epoch is
+// derived; if it is zero, the next epoch's end is derived from its first block.
+func NewEpochTargets(log zerolog.Logger, state protocol.State, endTime time.Time, duration time.Duration) *EpochTargets {
+	targets := &EpochTargets{
+		log:      log.With().Str("component", "epoch_targets").Logger(),
+		state:    state,
+		duration: duration,
+		endTime:  endTime,
+	}
+	return targets
+}
+
+// attach sets the block rate controller whose epoch target is updated.
+func (t *EpochTargets) attach(blockRate epochTargetSetter) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.blockRate = blockRate
+}
+
+// EpochTransition handles epoch transition protocol events, targeting the end
+// of the new epoch one epoch duration after the end of the previous epoch.
+func (t *EpochTargets) EpochTransition(newEpoch uint64, first *flow.Header) {
+	finalView, err := t.state.AtBlockID(first.ID()).Epochs().Current().FinalView()
+	if err != nil {
+		t.log.Error().Err(err).Uint64("epoch", newEpoch).Msg("could not get final view of new epoch")
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	start := t.endTime
+	if start.IsZero() {
+		start = first.Timestamp
+	}
+	t.endTime = start.Add(t.duration)
+
+	if t.blockRate != nil {
+		t.blockRate.SetEpochTarget(finalView, t.endTime)
+	}
+
+	t.log.Info().
+		Uint64("epoch", newEpoch).
+		Uint64("final_view", finalView).
+		Time("end_time", t.endTime).
+		Msg("block rate controller targeting end of new epoch")
+}
@@ -0,0 +1,73 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/model/flow"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// epochTargetRecorder records the epoch targets it is set to.
+type epochTargetRecorder struct {
+	finalView uint64
+	endTime   time.Time
+}
+
+func (r *epochTargetRecorder) SetEpochTarget(finalView uint64, endTime time.Time) {
+	r.finalView = finalView
+	r.endTime = endTime
+}
+
+// TestEpochTargets checks that the end of every new epoch is targeted one epoch
+// duration after the end of the previous one, or after the first block of the
+// new epoch if the end of the previous epoch is unknown.
+func TestEpochTargets(t *testing.T) {
+
+	duration := 24 * time.Hour
+
+	// expect returns the first block of a new epoch with the given final view
+	expect := func(state *protocol.State, finalView uint64) *flow.Header {
+		first := unittest.BlockHeaderFixture()
+		epoch := new(protocol.Epoch)
+		epoch.On("FinalView").Return(finalView, nil)
+		epochs := new(protocol.EpochQuery)
+		epochs.On("Current").Return(epoch)
+		snapshot := new(protocol.Snapshot)
+		snapshot.On("Epochs").Return(epochs)
+		state.On("AtBlockID", first.ID()).Return(snapshot)
+		return &first
+	}
+
+	t.Run("from previous epoch end", func(t *testing.T) {
+		state := new(protocol.State)
+		endTime := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+		targets := NewEpochTargets(zerolog.Nop(), state, endTime, duration)
+		recorder := &epochTargetRecorder{}
+		targets.attach(recorder)
+
+		targets.EpochTransition(2, expect(state, 2000))
+		assert.Equal(t, uint64(2000), recorder.finalView)
+		assert.Equal(t, endTime.Add(duration), recorder.endTime)
+
+		targets.EpochTransition(3, expect(state, 3000))
+		assert.Equal(t, uint64(3000), recorder.finalView)
+		assert.Equal(t, endTime.Add(2*duration), recorder.endTime)
+	})
+
+	t.Run("from first block", func(t *testing.T) {
+		state := new(protocol.State)
+		targets := NewEpochTargets(zerolog.Nop(), state, time.Time{}, duration)
+		recorder := &epochTargetRecorder{}
+		targets.attach(recorder)
+
+		first := expect(state, 2000)
+		targets.EpochTransition(2, first)
+		assert.Equal(t, uint64(2000), recorder.finalView)
+		assert.Equal(t, first.Timestamp.Add(duration), recorder.endTime)
+	})
+}
@@ -11,16 +11,17 @@ import (
 
 // BlockProducer is responsible for producing new block proposals
 type BlockProducer struct {
-	signer    hotstuff.SignerVerifier
+	voter     hotstuff.Voter
 	committee hotstuff.Committee
 	builder   module.Builder
 }
 
 // New creates a new BlockProducer which wraps the chain compliance layer block builder
-// to provide hotstuff with block proposals.
-func New(signer hotstuff.SignerVerifier, committee hotstuff.Committee, builder module.Builder) (*BlockProducer, error) {
+// to provide hotstuff with block proposals. Proposals are signed by the voter, which
+// ensures that we never propose in a view we have already voted in.
+func New(voter hotstuff.Voter, committee hotstuff.Committee, builder module.Builder) (*BlockProducer, error) {
 	bp := &BlockProducer{
-		signer:    signer,
+		voter:     voter,
 		committee: committee,
 		builder:   builder,
 	}
@@ -48,7 +49,7 @@ func (bp *BlockProducer) MakeBlockProposal(qc *flow.QuorumCertificate, view uint
 		}
 
 		// then sign the proposal
-		proposal, err := bp.signer.CreateProposal(&block)
+		proposal, err := bp.voter.ProduceProposal(&block, view)
 		if err != nil {
 			return fmt.Errorf("could not sign block proposal: %w", err)
 		}
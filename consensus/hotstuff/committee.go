@@ -69,3 +69,10 @@ func ComputeStakeThresholdForBuildingQC(totalStake uint64) uint64 {
 	}
 	return res
 }
+
+// ComputeStakeThresholdForViewSync returns the stake that is minimally required to exceed a
+// third of the total stake. Any set of replicas with this stake contains an honest replica.
+func ComputeStakeThresholdForViewSync(totalStake uint64) uint64 {
+	// Given totalStake, we need smallest integer t such that totalStake / 3 < t
+	return totalStake/3 + 1
+}
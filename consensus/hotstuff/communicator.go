@@ -13,6 +13,10 @@ type Communicator interface {
 	// SendVote sends a vote for the given parameters to the specified recipient.
 	SendVote(blockID flow.Identifier, view uint64, sigData []byte, recipientID flow.Identifier) error
 
+	// BroadcastTimeout broadcasts a timeout for the given parameters to all
+	// actors of the consensus process.
+	BroadcastTimeout(view uint64, highestQC *flow.QuorumCertificate, sigData []byte) error
+
 	// BroadcastProposal broadcasts the given block proposal to all actors of
 	// the consensus process.
 	BroadcastProposal(proposal *flow.Header) error
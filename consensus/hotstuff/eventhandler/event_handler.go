@@ -173,6 +173,50 @@ func (e *EventHandler) OnReceiveProposal(proposal *model.Proposal) error {
 	return nil
 }
 
+// OnReceiveTimeout processes a timeout of another replica. Timeouts are aggregated into
+// timeout certificates, which allow the replica to move to the view after the timed
+// out view. Independently of that, the replica follows replicas with more than a third
+// of the stake to the highest view they have timed out in, to resynchronize views
+// after network partitions.
+func (e *EventHandler) OnReceiveTimeout(timeout *model.Timeout) error {
+	curView := e.paceMaker.CurView()
+	log := e.log.With().
+		Uint64("cur_view", curView).
+		Uint64("timeout_view", timeout.View).
+		Uint64("highest_qc_view", timeout.HighestQC.View).
+		Hex("signer", timeout.SignerID[:]).
+		Logger()
+
+	defer e.notifier.OnEventProcessed()
+	log.Debug().Msg("timeout forwarded from compliance engine")
+
+	// timeouts for finalized view or older should be dropped:
+	if timeout.View <= e.forks.FinalizedView() {
+		log.Debug().Msg("skipping timeout view equal or below finalized view")
+		return nil
+	}
+
+	err := e.processTimeout(timeout)
+	if err != nil {
+		return fmt.Errorf("failed processing timeout: %w", err)
+	}
+
+	// view synchronization: follow the replicas which have timed out in a later view
+	_, viewChanged := e.paceMaker.UpdateCurViewWithTimeouts(e.voteAggregator.HighestTimedOutView())
+	if viewChanged {
+		log.Debug().Msg("timeouts triggered view change, starting new view now")
+		err = e.startNewView()
+		if err != nil {
+			return fmt.Errorf("could not start new view: %w", err)
+		}
+	}
+
+	newView := e.paceMaker.CurView() // in case we skipped ahead
+	log.Debug().Uint64("new_view", newView).Msg("timeout processed")
+
+	return nil
+}
+
 // TimeoutChannel returns the channel for subscribing the waiting timeout on receiving
 // block or votes for the current view.
 func (e *EventHandler) TimeoutChannel() <-chan time.Time {
@@ -184,6 +228,13 @@ func (e *EventHandler) TimeoutChannel() <-chan time.Time {
 func (e *EventHandler) OnLocalTimeout() error {
 
 	curView := e.paceMaker.CurView()
+
+	// produce our timeout with the highest QC we know before leaving the view
+	timeout, err := e.voter.ProduceTimeout(curView, e.forks.HighestQC())
+	if err != nil {
+		return fmt.Errorf("could not produce timeout: %w", err)
+	}
+
 	newView := e.paceMaker.OnTimeout()
 	defer e.notifier.OnEventProcessed()
 
@@ -198,8 +249,21 @@ func (e *EventHandler) OnLocalTimeout() error {
 		return fmt.Errorf("OnLocalTimeout should guarantee that the pacemaker should go to next view, but didn't: (curView: %v, newView: %v)", curView, newView.View)
 	}
 
+	// broadcast our timeout, so other replicas can build a TC for the view
+	err = e.communicator.BroadcastTimeout(timeout.View, timeout.HighestQC, timeout.SigData)
+	if err != nil {
+		log.Warn().Err(err).Msg("could not forward timeout")
+	}
+
+	// store our own timeout; as we already left the timed out view, a TC built
+	// with it can not trigger a view change
+	_, _, err = e.voteAggregator.StoreTimeoutAndBuildTC(timeout, e.forks.FinalizedBlock())
+	if err != nil {
+		return fmt.Errorf("could not store own timeout: %w", err)
+	}
+
 	// current view has changed, go to new view
-	err := e.startNewView()
+	err = e.startNewView()
 	if err != nil {
 		return fmt.Errorf("could not start new view: %w", err)
 	}
@@ -255,6 +319,12 @@ func (e *EventHandler) startNewView() error {
 		}
 
 		proposal, err := e.blockProducer.MakeBlockProposal(qc, curView)
+		if model.IsNoVoteError(err) {
+			// we have already voted in the current view, possibly for our own proposal
+			// before a restart, so proposing again could equivocate
+			log.Warn().Err(err).Msg("not proposing in a view we have already voted in")
+			return nil
+		}
 		if err != nil {
 			return fmt.Errorf("can not make block proposal for curView %v: %w", curView, err)
 		}
@@ -524,6 +594,59 @@ func (e *EventHandler) processVote(vote *model.Vote) error {
 	return e.processQC(qc)
 }
 
+// processTimeout stores the timeout and checks whether a TC can be built.
+// If a TC is built, then process the TC.
+func (e *EventHandler) processTimeout(timeout *model.Timeout) error {
+
+	log := e.log.With().
+		Uint64("timeout_view", timeout.View).
+		Hex("signer", timeout.SignerID[:]).
+		Logger()
+
+	// the signers of timeouts are determined at the latest finalized block
+	tc, built, err := e.voteAggregator.StoreTimeoutAndBuildTC(timeout, e.forks.FinalizedBlock())
+	if err != nil {
+		return fmt.Errorf("building tc for view failed: %w", err)
+	}
+	// if we don't have enough timeouts to build TC for this view:
+	// nothing more to do for processing timeout
+	if !built {
+		log.Debug().Msg("insufficient timeouts for TC, waiting for more")
+		return nil
+	}
+	log.Debug().Msg("enough timeouts for TC collected")
+
+	return e.processTC(tc)
+}
+
+// processTC stores the highest QC of the TC and checks whether the TC will trigger view change.
+// If triggered, then go to the new view.
+func (e *EventHandler) processTC(tc *flow.TimeoutCertificate) error {
+
+	log := e.log.With().
+		Uint64("timeout_view", tc.View).
+		Uint64("highest_qc_view", tc.HighestQC.View).
+		Int("signers", len(tc.SignerIDs)).
+		Logger()
+
+	// the highest QC of the TC has been validated with the timeouts; adding
+	// it lets the leader of the next view extend the newest block
+	err := e.forks.AddQC(tc.HighestQC)
+	if err != nil {
+		return fmt.Errorf("cannot add highest QC of TC to forks: %w", err)
+	}
+
+	_, viewChanged := e.paceMaker.UpdateCurViewWithTC(tc)
+	if !viewChanged {
+		log.Debug().Msg("TC didn't trigger view change, nothing to do")
+		return nil
+	}
+	log.Debug().Msg("TC triggered view change, starting new view now")
+
+	// current view has changed, go to new view
+	return e.startNewView()
+}
+
 // processQC stores the QC and check whether the QC will trigger view change.
 // If triggered, then go to the new view.
 func (e *EventHandler) processQC(qc *flow.QuorumCertificate) error {
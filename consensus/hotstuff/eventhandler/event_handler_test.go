@@ -57,6 +57,20 @@ func (p *TestPaceMaker) UpdateCurViewWithBlock(block *model.Block, isLeaderForNe
 	return newView, changed
 }
 
+func (p *TestPaceMaker) UpdateCurViewWithTC(tc *flow.TimeoutCertificate) (*model.NewViewEvent, bool) {
+	oldView := p.CurView()
+	newView, changed := p.PaceMaker.UpdateCurViewWithTC(tc)
+	p.t.Logf("pacemaker.UpdateCurViewWithTC old view: %v, new view: %v\n", oldView, p.CurView())
+	return newView, changed
+}
+
+func (p *TestPaceMaker) UpdateCurViewWithTimeouts(timedOutView uint64) (*model.NewViewEvent, bool) {
+	oldView := p.CurView()
+	newView, changed := p.PaceMaker.UpdateCurViewWithTimeouts(timedOutView)
+	p.t.Logf("pacemaker.UpdateCurViewWithTimeouts old view: %v, new view: %v\n", oldView, p.CurView())
+	return newView, changed
+}
+
 func (p *TestPaceMaker) OnTimeout() *model.NewViewEvent {
 	oldView := p.CurView()
 	newView := p.PaceMaker.OnTimeout()
@@ -80,6 +94,8 @@ func initPaceMaker(t *testing.T, view uint64) hotstuff.PaceMaker {
 	pm := NewTestPaceMaker(t, view, timeout.NewController(tc), notifier)
 	notifier.On("OnStartingTimeout", mock.Anything).Return()
 	notifier.On("OnQcTriggeredViewChange", mock.Anything, mock.Anything).Return()
+	notifier.On("OnTcTriggeredViewChange", mock.Anything, mock.Anything).Return()
+	notifier.On("OnViewSyncTriggeredViewChange", mock.Anything, mock.Anything).Return()
 	notifier.On("OnReachedTimeout", mock.Anything).Return()
 	pm.Start()
 	return pm
@@ -89,12 +105,17 @@ func initPaceMaker(t *testing.T, view uint64) hotstuff.PaceMaker {
 type VoteAggregator struct {
 	// if a blockID exists in qcs field, then a vote can be made into a QC
 	qcs map[flow.Identifier]*flow.QuorumCertificate
-	t   *testing.T
+	// if a view exists in tcs field, then a timeout can be made into a TC
+	tcs map[uint64]*flow.TimeoutCertificate
+	// the view returned as the highest view replicas have timed out in
+	highestTimedOutView uint64
+	t                   *testing.T
 }
 
 func NewVoteAggregator(t *testing.T) *VoteAggregator {
 	return &VoteAggregator{
 		qcs: make(map[flow.Identifier]*flow.QuorumCertificate),
+		tcs: make(map[uint64]*flow.TimeoutCertificate),
 		t:   t,
 	}
 }
@@ -121,6 +142,17 @@ func (v *VoteAggregator) BuildQCOnReceivedBlock(block *model.Block) (*flow.Quoru
 	return qc, ok, nil
 }
 
+func (v *VoteAggregator) StoreTimeoutAndBuildTC(timeout *model.Timeout, refBlock *model.Block) (*flow.TimeoutCertificate, bool, error) {
+	tc, ok := v.tcs[timeout.View]
+	v.t.Logf("voteaggregator.StoreTimeoutAndBuildTC, tc built: %v, for view: %v\n", ok, timeout.View)
+
+	return tc, ok, nil
+}
+
+func (v *VoteAggregator) HighestTimedOutView() uint64 {
+	return v.highestTimedOutView
+}
+
 func (v *VoteAggregator) PruneByView(view uint64) {
 	v.t.Logf("pruned at view:%v\n", view)
 }
@@ -173,6 +205,14 @@ func (v *Voter) ProduceVoteIfVotable(block *model.Block, curView uint64) (*model
 	return createVote(block), nil
 }
 
+func (v *Voter) ProduceProposal(block *model.Block, curView uint64) (*model.Proposal, error) {
+	return &model.Proposal{Block: block}, nil
+}
+
+func (v *Voter) ProduceTimeout(curView uint64, highestQC *flow.QuorumCertificate) (*model.Timeout, error) {
+	return createTimeout(curView, highestQC), nil
+}
+
 // Forks mock allows to customize the Add QC and AddBlock function by specifying the addQC and addBlock callbacks
 type Forks struct {
 	mocks.Forks
@@ -223,6 +263,17 @@ func (f *Forks) FinalizedView() uint64 {
 	return f.finalized
 }
 
+func (f *Forks) FinalizedBlock() *model.Block {
+	return &model.Block{View: f.finalized}
+}
+
+func (f *Forks) HighestQC() *flow.QuorumCertificate {
+	if f.qc == nil {
+		return &flow.QuorumCertificate{View: f.finalized}
+	}
+	return f.qc
+}
+
 func (f *Forks) GetBlock(blockID flow.Identifier) (*model.Block, bool) {
 	b, ok := f.blocks[blockID]
 	var view uint64
@@ -257,10 +308,17 @@ func (f *Forks) MakeForkChoice(curView uint64) (*flow.QuorumCertificate, *model.
 	return f.qc, block, nil
 }
 
-// BlockProducer mock will always make a valid block
-type BlockProducer struct{}
+// BlockProducer mock will always make a valid block, unless the view exists in the
+// votedViews field's key, as the voter refuses to sign proposals for views it has voted in
+type BlockProducer struct {
+	votedViews map[uint64]struct{}
+}
 
 func (b *BlockProducer) MakeBlockProposal(qc *flow.QuorumCertificate, view uint64) (*model.Proposal, error) {
+	_, voted := b.votedViews[view]
+	if voted {
+		return nil, fmt.Errorf("could not build header: %w", model.NoVoteError{Msg: "not above the last voted view"})
+	}
 	return createProposal(view, qc.View), nil
 }
 
@@ -338,10 +396,11 @@ func (es *EventHandlerSuite) SetupTest() {
 	es.forks = NewForks(es.T(), finalized)
 	es.persist = &mocks.Persister{}
 	es.persist.On("PutStarted", mock.Anything).Return(nil)
-	es.blockProducer = &BlockProducer{}
+	es.blockProducer = &BlockProducer{votedViews: make(map[uint64]struct{})}
 	es.communicator = &mocks.Communicator{}
 	es.communicator.On("BroadcastProposalWithDelay", mock.Anything, mock.Anything).Return(nil)
 	es.communicator.On("SendVote", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	es.communicator.On("BroadcastTimeout", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	es.committee = NewCommittee()
 	es.voteAggregator = NewVoteAggregator(es.T())
 	es.voter = NewVoter(es.T(), finalized)
@@ -714,6 +773,23 @@ func (es *EventHandlerSuite) TestOnReceiveProposal_ForCurView_NoVote_IsNextLeade
 	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
 }
 
+// received a valid proposal for cur view, a qc can be built for the block, trigged view change,
+// and I'm the leader of the next view, but I have already voted in it before a restart
+func (es *EventHandlerSuite) TestOnReceiveProposal_ForCurView_QCBuilt_ViewChange_AlreadyVoted() {
+	proposal := createProposal(es.initView, es.initView-1)
+	es.committee.leaders[es.initView+1] = struct{}{}
+	es.voteAggregator.qcs[proposal.Block.BlockID] = createQC(proposal.Block)
+	es.endView++
+	es.blockProducer.votedViews[es.endView] = struct{}{}
+
+	err := es.eventhandler.OnReceiveProposal(proposal)
+	require.NoError(es.T(), err)
+
+	// no proposal is broadcast for the view
+	es.communicator.AssertNotCalled(es.T(), "BroadcastProposalWithDelay", mock.Anything, mock.Anything)
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+}
+
 // received a unverifiable proposal for future view, no view change
 func (es *EventHandlerSuite) TestOnReceiveProposal_Unverifiable() {
 	// qc.View is below the finalized view
@@ -733,6 +809,58 @@ func (es *EventHandlerSuite) TestOnTimeout() {
 	es.endView++
 	require.NoError(es.T(), err)
 	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+	// the timeout for the view we left is broadcast with the highest QC
+	es.communicator.AssertCalled(es.T(), "BroadcastTimeout", es.initView, es.forks.HighestQC(), mock.Anything)
+}
+
+// timeouts for the finalized view or older are dropped
+func (es *EventHandlerSuite) TestTimeoutEqualFinalView() {
+	timeout := createTimeout(es.forks.finalized, es.qc)
+	es.voteAggregator.tcs[timeout.View] = createTC(timeout.View, es.qc)
+	es.voteAggregator.highestTimedOutView = es.initView + 10
+	err := es.eventhandler.OnReceiveTimeout(timeout)
+	require.NoError(es.T(), err)
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+}
+
+// not enough timeouts for a TC and for view synchronization, no view change
+func (es *EventHandlerSuite) TestTimeoutNoTC() {
+	timeout := createTimeout(es.initView, es.qc)
+	err := es.eventhandler.OnReceiveTimeout(timeout)
+	require.NoError(es.T(), err)
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+}
+
+// a TC for the current view triggers a view change to the next view,
+// and its highest QC is added to forks
+func (es *EventHandlerSuite) TestTimeoutTCBuiltViewChanged() {
+	es.forks.blocks[es.votingBlock.BlockID] = es.votingBlock
+	timeout := createTimeout(es.initView+1, es.qc)
+	es.voteAggregator.tcs[timeout.View] = createTC(timeout.View, es.qc)
+	err := es.eventhandler.OnReceiveTimeout(timeout)
+	require.NoError(es.T(), err)
+	es.endView += 2
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+	require.Equal(es.T(), es.qc, es.forks.HighestQC())
+}
+
+// a TC for an older view doesn't change the view
+func (es *EventHandlerSuite) TestTimeoutTCBuiltNoViewChange() {
+	timeout := createTimeout(es.initView-1, es.qc)
+	es.voteAggregator.tcs[timeout.View] = createTC(timeout.View, &flow.QuorumCertificate{View: es.forks.finalized})
+	err := es.eventhandler.OnReceiveTimeout(timeout)
+	require.NoError(es.T(), err)
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+}
+
+// replicas with enough stake have timed out in a later view, follow them
+func (es *EventHandlerSuite) TestTimeoutViewSync() {
+	timeout := createTimeout(es.initView+10, es.qc)
+	es.voteAggregator.highestTimedOutView = es.initView + 10
+	err := es.eventhandler.OnReceiveTimeout(timeout)
+	require.NoError(es.T(), err)
+	es.endView += 11
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
 }
 
 func (es *EventHandlerSuite) Test100Timeout() {
@@ -846,3 +974,21 @@ func createProposal(view uint64, qcview uint64) *model.Proposal {
 		SigData: nil,
 	}
 }
+
+func createTimeout(view uint64, highestQC *flow.QuorumCertificate) *model.Timeout {
+	return &model.Timeout{
+		View:      view,
+		HighestQC: highestQC,
+		SignerID:  flow.ZeroID,
+		SigData:   nil,
+	}
+}
+
+func createTC(view uint64, highestQC *flow.QuorumCertificate) *flow.TimeoutCertificate {
+	return &flow.TimeoutCertificate{
+		View:      view,
+		HighestQC: highestQC,
+		SignerIDs: nil,
+		SigData:   nil,
+	}
+}
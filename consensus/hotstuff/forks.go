@@ -51,6 +51,12 @@ type Forks interface {
 	// Note that tracking the view of the newest qc is for safety purposes
 	// and _independent_ of the fork-choice rule.
 	MakeForkChoice(curView uint64) (*flow.QuorumCertificate, *model.Block, error)
+
+	// HighestQC returns the QC with the largest view number known to Forks.
+	HighestQC() *flow.QuorumCertificate
+
+	// LockedQC returns the QC for the block Forks is locked on.
+	LockedQC() *flow.QuorumCertificate
 }
 
 // ForksReader only reads the forks' state
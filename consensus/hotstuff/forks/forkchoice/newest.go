@@ -106,6 +106,11 @@ func (fc *NewestForkChoice) AddQC(qc *flow.QuorumCertificate) error {
 	return nil
 }
 
+// HighestQC returns the newest QC, which is the QC for the preferred parent.
+func (fc *NewestForkChoice) HighestQC() *flow.QuorumCertificate {
+	return fc.preferredParent.QC
+}
+
 func (fc *NewestForkChoice) ensureBlockStored(qc *flow.QuorumCertificate) (*model.Block, error) {
 	block, haveBlock := fc.finalizer.GetBlock(qc.BlockID)
 	if !haveBlock {
@@ -76,6 +76,16 @@ func (f *Forks) MakeForkChoice(curView uint64) (*flow.QuorumCertificate, *model.
 	return f.forkchoice.MakeForkChoice(curView)
 }
 
+// HighestQC returns the QC with the largest view number known to Forks
+func (f *Forks) HighestQC() *flow.QuorumCertificate {
+	return f.forkchoice.HighestQC()
+}
+
+// LockedQC returns the QC for the block the finalizer is locked on
+func (f *Forks) LockedQC() *flow.QuorumCertificate {
+	return f.finalizer.LockedBlockQC()
+}
+
 // AddQC gives the QC to the forkchoice for updating the preferred parent block
 func (f *Forks) AddQC(qc *flow.QuorumCertificate) error {
 	return f.forkchoice.AddQC(qc) // forkchoice ensures that block referenced by qc is known
@@ -50,3 +50,13 @@ func BlockProposalsBy(proposerID flow.Identifier) ProposalFilter {
 		return proposal.Block.ProposerID == proposerID
 	}
 }
+
+type TimeoutFilter func(*model.Timeout) bool
+
+func BlockNoTimeouts(*model.Timeout) bool {
+	return false
+}
+
+func BlockAllTimeouts(*model.Timeout) bool {
+	return true
+}
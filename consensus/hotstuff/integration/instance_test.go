@@ -38,6 +38,8 @@ type Instance struct {
 	blockVoteOut VoteFilter
 	blockPropIn  ProposalFilter
 	blockPropOut ProposalFilter
+	timeoutIn    TimeoutFilter
+	timeoutOut   TimeoutFilter
 	stop         Condition
 
 	// instance data
@@ -54,6 +56,7 @@ type Instance struct {
 	communicator *mocks.Communicator
 
 	// real dependencies
+	controller *timeout.Controller
 	pacemaker  hotstuff.PaceMaker
 	producer   *blockproducer.BlockProducer
 	forks      *forks.Forks
@@ -73,13 +76,18 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 	// initialize the default configuration
 	cfg := Config{
 		Root:              DefaultRoot(),
+		StartView:         DefaultStart(),
+		SafetyData:        &model.SafetyData{LastVotedView: DefaultVoted()},
 		Participants:      flow.IdentityList{identity},
 		LocalID:           identity.NodeID,
 		Timeouts:          timeout.DefaultConfig,
+		LogLevel:          zerolog.DebugLevel,
 		IncomingVotes:     BlockNoVotes,
 		OutgoingVotes:     BlockNoVotes,
 		IncomingProposals: BlockNoProposals,
 		OutgoingProposals: BlockNoProposals,
+		IncomingTimeouts:  BlockNoTimeouts,
+		OutgoingTimeouts:  BlockNoTimeouts,
 		StopCondition:     RightAway,
 	}
 
@@ -110,6 +118,8 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 		blockVoteOut: cfg.OutgoingVotes,
 		blockPropIn:  cfg.IncomingProposals,
 		blockPropOut: cfg.OutgoingProposals,
+		timeoutIn:    cfg.IncomingTimeouts,
+		timeoutOut:   cfg.OutgoingTimeouts,
 		stop:         cfg.StopCondition,
 
 		// instance data
@@ -174,7 +184,7 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 
 	// check on stop condition, stop the tests as soon as entering a certain view
 	in.persist.On("PutStarted", mock.Anything).Return(nil)
-	in.persist.On("PutVoted", mock.Anything).Return(nil)
+	in.persist.On("PutSafetyData", mock.Anything).Return(nil)
 
 	// program the hotstuff signer behaviour
 	in.signer.On("CreateProposal", mock.Anything).Return(
@@ -215,10 +225,44 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 		},
 		nil,
 	)
+	in.signer.On("CreateTimeout", mock.Anything, mock.Anything).Return(
+		func(view uint64, highestQC *flow.QuorumCertificate) *model.Timeout {
+			timeout := &model.Timeout{
+				View:      view,
+				HighestQC: highestQC,
+				SignerID:  in.localID,
+				SigData:   nil,
+			}
+			return timeout
+		},
+		nil,
+	)
+	in.signer.On("CreateTC", mock.Anything).Return(
+		func(timeouts []*model.Timeout) *flow.TimeoutCertificate {
+			signerIDs := make([]flow.Identifier, 0, len(timeouts))
+			highestQC := timeouts[0].HighestQC
+			for _, timeout := range timeouts {
+				signerIDs = append(signerIDs, timeout.SignerID)
+				if timeout.HighestQC.View > highestQC.View {
+					highestQC = timeout.HighestQC
+				}
+			}
+			tc := &flow.TimeoutCertificate{
+				View:      timeouts[0].View,
+				HighestQC: highestQC,
+				SignerIDs: signerIDs,
+				SigData:   nil,
+			}
+			return tc
+		},
+		nil,
+	)
 
 	// program the hotstuff verifier behaviour
 	in.verifier.On("VerifyVote", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 	in.verifier.On("VerifyQC", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	in.verifier.On("VerifyTimeout", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	in.verifier.On("VerifyTC", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 
 	// program the hotstuff communicator behaviour
 	in.communicator.On("BroadcastProposalWithDelay", mock.Anything, mock.Anything).Return(
@@ -246,6 +290,7 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 		},
 	)
 	in.communicator.On("SendVote", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	in.communicator.On("BroadcastTimeout", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	// program the finalizer module behaviour
 	in.finalizer.On("MakeFinal", mock.Anything).Return(
@@ -282,16 +327,12 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 	// initialize error handling and logging
 	var err error
 	zerolog.TimestampFunc = func() time.Time { return time.Now().UTC() }
-	log := zerolog.New(os.Stderr).Level(zerolog.DebugLevel).With().Timestamp().Uint("index", index).Hex("local_id", in.localID[:]).Logger()
+	log := zerolog.New(os.Stderr).Level(cfg.LogLevel).With().Timestamp().Uint("index", index).Hex("local_id", in.localID[:]).Logger()
 	notifier := notifications.NewLogConsumer(log)
 
 	// initialize the pacemaker
-	controller := timeout.NewController(cfg.Timeouts)
-	in.pacemaker, err = pacemaker.New(DefaultStart(), controller, notifier)
-	require.NoError(t, err)
-
-	// initialize the block producer
-	in.producer, err = blockproducer.New(in.signer, in.committee, in.builder)
+	in.controller = timeout.NewController(cfg.Timeouts)
+	in.pacemaker, err = pacemaker.New(cfg.StartView, in.controller, notifier)
 	require.NoError(t, err)
 
 	// initialize the finalizer
@@ -319,7 +360,11 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 	in.aggregator = voteaggregator.New(notifier, DefaultPruned(), in.committee, in.validator, in.signer)
 
 	// initialize the voter
-	in.voter = voter.New(in.signer, in.forks, in.persist, DefaultVoted())
+	in.voter = voter.New(in.signer, in.forks, in.persist, cfg.SafetyData)
+
+	// initialize the block producer
+	in.producer, err = blockproducer.New(in.voter, in.committee, in.builder)
+	require.NoError(t, err)
 
 	// initialize the event handler
 	in.handler, err = eventhandler.New(log, in.pacemaker, in.producer, in.forks, in.persist, in.communicator, in.committee, in.aggregator, in.voter, in.validator, notifier)
@@ -378,6 +423,11 @@ func (in *Instance) Run() error {
 				if err != nil {
 					return fmt.Errorf("could not process vote: %w", err)
 				}
+			case *model.Timeout:
+				err := in.handler.OnReceiveTimeout(m)
+				if err != nil {
+					return fmt.Errorf("could not process timeout: %w", err)
+				}
 			}
 		}
 
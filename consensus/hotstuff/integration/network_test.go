@@ -0,0 +1,68 @@
+package integration
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Delay is a distribution of message delivery delays. It draws all randomness
+// from the given source, so that simulations with the same seed are reproducible.
+type Delay func(rng *rand.Rand) time.Duration
+
+// FixedDelay delivers every message after the same delay.
+func FixedDelay(delay time.Duration) Delay {
+	return func(*rand.Rand) time.Duration {
+		return delay
+	}
+}
+
+// UniformDelay delivers messages after a delay drawn uniformly from [min, max).
+func UniformDelay(min time.Duration, max time.Duration) Delay {
+	return func(rng *rand.Rand) time.Duration {
+		if max <= min {
+			return min
+		}
+		return min + time.Duration(rng.Int63n(int64(max-min)))
+	}
+}
+
+// ExponentialDelay delivers messages after a minimum delay plus an exponentially
+// distributed delay with the given mean, which results in a long tail of slow messages.
+func ExponentialDelay(min time.Duration, mean time.Duration) Delay {
+	return func(rng *rand.Rand) time.Duration {
+		return min + time.Duration(rng.ExpFloat64()*float64(mean))
+	}
+}
+
+// Network models the delivery of messages between simulated replicas.
+type Network struct {
+	// Delay is the distribution of the delivery delay of each message.
+	Delay Delay
+	// Drop is the probability that a message is lost.
+	Drop float64
+	// Reorder is the probability that a message is held back for a second
+	// delivery delay, letting messages sent after it overtake it.
+	Reorder float64
+}
+
+// DefaultNetwork delivers all messages in order after 50 milliseconds.
+func DefaultNetwork() Network {
+	return Network{
+		Delay:   FixedDelay(50 * time.Millisecond),
+		Drop:    0,
+		Reorder: 0,
+	}
+}
+
+// deliver returns the delay after which a message is delivered, or false if
+// the message is lost.
+func (n Network) deliver(rng *rand.Rand) (time.Duration, bool) {
+	if rng.Float64() < n.Drop {
+		return 0, false
+	}
+	delay := n.Delay(rng)
+	if rng.Float64() < n.Reorder {
+		delay += n.Delay(rng)
+	}
+	return delay, true
+}
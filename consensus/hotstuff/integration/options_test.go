@@ -3,6 +3,9 @@ package integration
 import (
 	"errors"
 
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
 	"github.com/onflow/flow-go/model/flow"
 )
@@ -13,13 +16,18 @@ type Option func(*Config)
 
 type Config struct {
 	Root              *flow.Header
+	StartView         uint64
+	SafetyData        *model.SafetyData
 	Participants      flow.IdentityList
 	LocalID           flow.Identifier
 	Timeouts          timeout.Config
+	LogLevel          zerolog.Level
 	IncomingVotes     VoteFilter
 	OutgoingVotes     VoteFilter
 	IncomingProposals ProposalFilter
 	OutgoingProposals ProposalFilter
+	IncomingTimeouts  TimeoutFilter
+	OutgoingTimeouts  TimeoutFilter
 	StopCondition     Condition
 }
 
@@ -29,6 +37,18 @@ func WithRoot(root *flow.Header) Option {
 	}
 }
 
+func WithStartView(view uint64) Option {
+	return func(cfg *Config) {
+		cfg.StartView = view
+	}
+}
+
+func WithSafetyData(data *model.SafetyData) Option {
+	return func(cfg *Config) {
+		cfg.SafetyData = data
+	}
+}
+
 func WithParticipants(participants flow.IdentityList) Option {
 	return func(cfg *Config) {
 		cfg.Participants = participants
@@ -47,6 +67,12 @@ func WithTimeouts(timeouts timeout.Config) Option {
 	}
 }
 
+func WithLogLevel(level zerolog.Level) Option {
+	return func(cfg *Config) {
+		cfg.LogLevel = level
+	}
+}
+
 func WithIncomingVotes(Filter VoteFilter) Option {
 	return func(cfg *Config) {
 		cfg.IncomingVotes = Filter
@@ -71,6 +97,18 @@ func WithOutgoingProposals(Filter ProposalFilter) Option {
 	}
 }
 
+func WithIncomingTimeouts(Filter TimeoutFilter) Option {
+	return func(cfg *Config) {
+		cfg.IncomingTimeouts = Filter
+	}
+}
+
+func WithOutgoingTimeouts(Filter TimeoutFilter) Option {
+	return func(cfg *Config) {
+		cfg.OutgoingTimeouts = Filter
+	}
+}
+
 func WithStopCondition(stop Condition) Option {
 	return func(cfg *Config) {
 		cfg.StopCondition = stop
@@ -0,0 +1,111 @@
+package integration
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Report is the outcome of a simulation.
+type Report struct {
+	Seed      int64
+	Duration  time.Duration
+	Events    int
+	Delivered int
+	Dropped   int
+	// ConflictingVotes counts the votes and proposals of replicas for a different
+	// block than they voted for or proposed before in the same view.
+	ConflictingVotes int
+	Behaviours       map[flow.Identifier]Behaviour
+	Finalized        map[flow.Identifier][]FinalizedBlock
+}
+
+// FinalizedBlock is a block finalized by a replica, with the virtual time that
+// passed between the proposal of the block and its finalization by the replica.
+type FinalizedBlock struct {
+	BlockID flow.Identifier
+	View    uint64
+	Height  uint64
+	Latency time.Duration
+}
+
+// Latencies returns the finalization latencies of all blocks on all replicas, sorted.
+func (r *Report) Latencies() []time.Duration {
+	var latencies []time.Duration
+	for _, blocks := range r.Finalized {
+		for _, block := range blocks {
+			latencies = append(latencies, block.Latency)
+		}
+	}
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+	return latencies
+}
+
+// LatencyPercentile returns the finalization latency below which the given
+// percentage of blocks was finalized.
+func (r *Report) LatencyPercentile(percentile float64) time.Duration {
+	latencies := r.Latencies()
+	if len(latencies) == 0 {
+		return 0
+	}
+	index := int(percentile / 100 * float64(len(latencies)-1))
+	return latencies[index]
+}
+
+// MeanLatency returns the mean finalization latency.
+func (r *Report) MeanLatency() time.Duration {
+	latencies := r.Latencies()
+	if len(latencies) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, latency := range latencies {
+		total += latency
+	}
+	return total / time.Duration(len(latencies))
+}
+
+func (r *Report) String() string {
+	return fmt.Sprintf("seed %d: %d events over %s, %d messages delivered, %d dropped, finalization latency mean %s, p50 %s, p99 %s",
+		r.Seed, r.Events, r.Duration, r.Delivered, r.Dropped, r.MeanLatency(), r.LatencyPercentile(50), r.LatencyPercentile(99))
+}
+
+// AssertSafety checks that no two replicas have finalized different blocks at the
+// same height, and that no replica has voted for conflicting blocks.
+func AssertSafety(t *testing.T, report *Report) {
+	assert.Zero(t, report.ConflictingVotes, "replicas voted for conflicting blocks (%s)", report)
+
+	finalized := make(map[uint64]flow.Identifier)
+	for nodeID, blocks := range report.Finalized {
+		for _, block := range blocks {
+			blockID, ok := finalized[block.Height]
+			if !ok {
+				finalized[block.Height] = block.BlockID
+				continue
+			}
+			assert.Equal(t, blockID, block.BlockID, "replica %x finalized conflicting block at height %d (%s)", nodeID, block.Height, report)
+		}
+	}
+}
+
+// AssertLiveness checks that all honest replicas have finalized a block at or
+// above the given view.
+func AssertLiveness(t *testing.T, report *Report, view uint64) {
+	for nodeID, behaviour := range report.Behaviours {
+		if behaviour != Honest {
+			continue
+		}
+		blocks := report.Finalized[nodeID]
+		if !assert.NotEmpty(t, blocks, "replica %x didn't finalize any block (%s)", nodeID, report) {
+			continue
+		}
+		assert.GreaterOrEqual(t, blocks[len(blocks)-1].View, view, "replica %x didn't finalize the view (%s)", nodeID, report)
+	}
+}
@@ -0,0 +1,167 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// The same participants and seed should always lead to the same simulation,
+// even with a network that drops and reorders messages.
+func TestSimulationReproducible(t *testing.T) {
+
+	participants := unittest.IdentityListFixture(4)
+	network := Network{
+		Delay:   UniformDelay(10*time.Millisecond, 300*time.Millisecond),
+		Drop:    0.05,
+		Reorder: 0.1,
+	}
+
+	first, err := NewSimulator(t, participants, WithSeed(42), WithNetwork(network)).Run()
+	require.NoError(t, err)
+	second, err := NewSimulator(t, participants, WithSeed(42), WithNetwork(network)).Run()
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second, "simulations with the same seed should be identical")
+}
+
+// Seven replicas on a network with random delays, lost and reordered messages
+// should keep finalizing the same blocks for any seed.
+func TestSimulationUnreliableNetwork(t *testing.T) {
+
+	participants := unittest.IdentityListFixture(7)
+	network := Network{
+		Delay:   ExponentialDelay(10*time.Millisecond, 100*time.Millisecond),
+		Drop:    0.05,
+		Reorder: 0.2,
+	}
+	finalView := uint64(30)
+
+	for seed := int64(1); seed <= 5; seed++ {
+		report, err := NewSimulator(t, participants,
+			WithSeed(seed),
+			WithNetwork(network),
+			WithFinalView(finalView),
+		).Run()
+		require.NoError(t, err)
+		t.Log(report)
+
+		AssertSafety(t, report)
+		AssertLiveness(t, report, finalView)
+	}
+}
+
+// Two of seven replicas have crashed; the others have to leave the views of the
+// crashed leaders through timeouts. Finalization needs four consecutive views with
+// honest leaders, so the crashed replicas lead consecutive views.
+func TestSimulationCrashedReplicas(t *testing.T) {
+
+	participants := unittest.IdentityListFixture(7)
+	finalView := uint64(30)
+
+	report, err := NewSimulator(t, participants,
+		WithBehaviour(participants[5].NodeID, Crashed),
+		WithBehaviour(participants[6].NodeID, Crashed),
+		WithFinalView(finalView),
+	).Run()
+	require.NoError(t, err)
+	t.Log(report)
+
+	AssertSafety(t, report)
+	AssertLiveness(t, report, finalView)
+}
+
+// An equivocating leader sends conflicting proposals to different replicas, which
+// should never lead to conflicting blocks being finalized.
+func TestSimulationEquivocatingLeader(t *testing.T) {
+
+	participants := unittest.IdentityListFixture(4)
+	finalView := uint64(30)
+
+	for seed := int64(1); seed <= 3; seed++ {
+		report, err := NewSimulator(t, participants,
+			WithSeed(seed),
+			WithNetwork(Network{Delay: UniformDelay(10*time.Millisecond, 100*time.Millisecond)}),
+			WithBehaviour(participants[1].NodeID, Equivocating),
+			WithFinalView(finalView),
+		).Run()
+		require.NoError(t, err)
+		t.Log(report)
+
+		AssertSafety(t, report)
+		AssertLiveness(t, report, finalView)
+	}
+}
+
+// Two of seven leaders withhold their proposals, so their views can only end
+// with a timeout. As with crashed replicas, they lead consecutive views.
+func TestSimulationWithholdingLeaders(t *testing.T) {
+
+	participants := unittest.IdentityListFixture(7)
+	finalView := uint64(30)
+
+	report, err := NewSimulator(t, participants,
+		WithBehaviour(participants[5].NodeID, Withholding),
+		WithBehaviour(participants[6].NodeID, Withholding),
+		WithFinalView(finalView),
+	).Run()
+	require.NoError(t, err)
+	t.Log(report)
+
+	AssertSafety(t, report)
+	AssertLiveness(t, report, finalView)
+}
+
+// Replicas that crash and restart only keep the state they persisted and the blocks
+// they received before the crash. They have to recover from it and rejoin, without
+// ever voting for a block conflicting with one they voted for before the crash.
+func TestSimulationCrashRestart(t *testing.T) {
+
+	participants := unittest.IdentityListFixture(4)
+	network := Network{
+		Delay:   UniformDelay(10*time.Millisecond, 200*time.Millisecond),
+		Drop:    0.02,
+		Reorder: 0.1,
+	}
+	finalView := uint64(40)
+
+	for seed := int64(1); seed <= 3; seed++ {
+		report, err := NewSimulator(t, participants,
+			WithSeed(seed),
+			WithNetwork(network),
+			WithRestart(participants[1].NodeID, 2*time.Second, 3*time.Second),
+			WithRestart(participants[2].NodeID, 7*time.Second, 100*time.Millisecond),
+			WithRestart(participants[1].NodeID, 12*time.Second, 0),
+			WithFinalView(finalView),
+		).Run()
+		require.NoError(t, err)
+		t.Log(report)
+
+		AssertSafety(t, report)
+		AssertLiveness(t, report, finalView)
+	}
+}
+
+// The simulation should stop at the deadline if the replicas can't make progress,
+// which is the case when more than a third of them have crashed.
+func TestSimulationDeadline(t *testing.T) {
+
+	participants := unittest.IdentityListFixture(4)
+	deadline := time.Minute
+
+	report, err := NewSimulator(t, participants,
+		WithBehaviour(participants[0].NodeID, Crashed),
+		WithBehaviour(participants[1].NodeID, Crashed),
+		WithDeadline(deadline),
+	).Run()
+	require.NoError(t, err)
+
+	assert.LessOrEqual(t, int64(report.Duration), int64(deadline))
+	for _, blocks := range report.Finalized {
+		assert.Empty(t, blocks)
+	}
+}
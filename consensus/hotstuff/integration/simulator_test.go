@@ -0,0 +1,659 @@
+package integration
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/mocks"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
+	"github.com/onflow/flow-go/consensus/recovery"
+	"github.com/onflow/flow-go/model/flow"
+	module "github.com/onflow/flow-go/module/mock"
+)
+
+// simEpoch is the wall-clock time at which the virtual clock of every simulation
+// starts; it is only used for block timestamps.
+var simEpoch = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Behaviour describes how a simulated replica behaves.
+type Behaviour int
+
+const (
+	// Honest replicas follow the protocol.
+	Honest Behaviour = iota
+	// Equivocating replicas propose two conflicting blocks for each view they
+	// lead and send each of them to half of the other replicas.
+	Equivocating
+	// Withholding replicas build proposals for the views they lead, but never
+	// send them to the other replicas.
+	Withholding
+	// Crashed replicas don't run at all.
+	Crashed
+)
+
+type SimOption func(*SimConfig)
+
+type SimConfig struct {
+	Seed       int64
+	Timeouts   timeout.Config
+	Network    Network
+	Behaviours map[flow.Identifier]Behaviour
+	FinalView  uint64
+	Deadline   time.Duration
+	Restarts   []Restart
+}
+
+// Restart crashes a replica at the given virtual time and restarts it after the
+// downtime. The restarted replica only keeps what it persisted and the blocks it
+// received; it loses all messages sent to it while it was down.
+type Restart struct {
+	NodeID   flow.Identifier
+	At       time.Duration
+	Downtime time.Duration
+}
+
+func WithSeed(seed int64) SimOption {
+	return func(cfg *SimConfig) {
+		cfg.Seed = seed
+	}
+}
+
+func WithSimTimeouts(timeouts timeout.Config) SimOption {
+	return func(cfg *SimConfig) {
+		cfg.Timeouts = timeouts
+	}
+}
+
+func WithNetwork(network Network) SimOption {
+	return func(cfg *SimConfig) {
+		cfg.Network = network
+	}
+}
+
+func WithBehaviour(nodeID flow.Identifier, behaviour Behaviour) SimOption {
+	return func(cfg *SimConfig) {
+		cfg.Behaviours[nodeID] = behaviour
+	}
+}
+
+func WithRestart(nodeID flow.Identifier, at time.Duration, downtime time.Duration) SimOption {
+	return func(cfg *SimConfig) {
+		cfg.Restarts = append(cfg.Restarts, Restart{NodeID: nodeID, At: at, Downtime: downtime})
+	}
+}
+
+func WithFinalView(view uint64) SimOption {
+	return func(cfg *SimConfig) {
+		cfg.FinalView = view
+	}
+}
+
+func WithDeadline(deadline time.Duration) SimOption {
+	return func(cfg *SimConfig) {
+		cfg.Deadline = deadline
+	}
+}
+
+// Simulator is a discrete-event simulation of HotStuff replicas. It drives the
+// event handlers of the replicas directly, from a single goroutine and with a
+// virtual clock: messages and local timeouts are events scheduled at a virtual
+// time, and the events are processed in order of their time. All randomness is
+// drawn from a single seeded source, so a simulation with the same participants
+// and seed always produces the same result, regardless of the machine it runs on.
+type Simulator struct {
+	t            require.TestingT
+	cfg          SimConfig
+	participants flow.IdentityList
+	root         *flow.Header
+	rng          *rand.Rand
+	now          time.Duration
+	seq          uint64
+	events       eventQueue
+	nodes        []*simNode
+	lookup       map[flow.Identifier]*simNode
+
+	// all blocks ever proposed, used to let replicas sync missing blocks
+	headers   map[flow.Identifier]*flow.Header
+	proposals map[flow.Identifier]*model.Proposal
+	proposed  map[flow.Identifier]time.Duration
+
+	report *Report
+}
+
+type simNode struct {
+	in        *Instance
+	behaviour Behaviour
+	down      bool
+
+	// the state persisted by the replica, which survives a restart
+	started    uint64
+	safetyData *model.SafetyData
+	received   []flow.Identifier
+
+	// the blocks the replica voted for or proposed in each view, across restarts
+	votes map[uint64]flow.Identifier
+
+	// the timer of the pacemaker which was last scheduled on the virtual clock
+	timer *model.TimerInfo
+
+	// proposals processed by the event handler, and proposals waiting on a missing parent
+	processed map[flow.Identifier]struct{}
+	pending   map[flow.Identifier][]*model.Proposal
+	requested map[flow.Identifier]struct{}
+}
+
+// simCrash and simRestart are the events crashing and restarting a replica.
+type simCrash struct{}
+type simRestart struct{}
+
+// simEvent is the delivery of a message to a replica, or the expiry of a replica's
+// timer, at a given virtual time. Events at the same time are processed in the
+// order they were scheduled in.
+type simEvent struct {
+	at   time.Duration
+	seq  uint64
+	node *simNode
+	msg  interface{}
+}
+
+type eventQueue []*simEvent
+
+func (q eventQueue) Len() int { return len(q) }
+
+func (q eventQueue) Less(i, j int) bool {
+	if q[i].at != q[j].at {
+		return q[i].at < q[j].at
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *eventQueue) Push(x interface{}) { *q = append(*q, x.(*simEvent)) }
+
+func (q *eventQueue) Pop() interface{} {
+	old := *q
+	event := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return event
+}
+
+func NewSimulator(t require.TestingT, participants flow.IdentityList, options ...SimOption) *Simulator {
+
+	// the default timeouts leave enough time for a round trip at the default network delay
+	timeouts, err := timeout.NewConfig(time.Second, time.Second, 0.5, 1.5, 0.85, 0)
+	require.NoError(t, err)
+
+	// initialize the default configuration
+	cfg := SimConfig{
+		Seed:       1,
+		Timeouts:   timeouts,
+		Network:    DefaultNetwork(),
+		Behaviours: make(map[flow.Identifier]Behaviour),
+		FinalView:  20,
+		Deadline:   time.Hour,
+	}
+
+	// apply the custom options
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	s := Simulator{
+		t:            t,
+		cfg:          cfg,
+		participants: participants,
+		rng:          rand.New(rand.NewSource(cfg.Seed)),
+		lookup:       make(map[flow.Identifier]*simNode),
+		headers:      make(map[flow.Identifier]*flow.Header),
+		proposals:    make(map[flow.Identifier]*model.Proposal),
+		proposed:     make(map[flow.Identifier]time.Duration),
+		report: &Report{
+			Seed:       cfg.Seed,
+			Behaviours: make(map[flow.Identifier]Behaviour),
+			Finalized:  make(map[flow.Identifier][]FinalizedBlock),
+		},
+	}
+
+	// the root block is derived from the seed as well
+	root := &flow.Header{
+		ChainID:     "chain",
+		ParentID:    flow.ZeroID,
+		Height:      0,
+		PayloadHash: s.randomID(),
+		Timestamp:   simEpoch,
+	}
+	s.headers[root.ID()] = root
+	s.root = root
+
+	// set up an instance for each replica that didn't crash
+	for _, participant := range participants {
+		behaviour := cfg.Behaviours[participant.NodeID]
+		s.report.Behaviours[participant.NodeID] = behaviour
+		if behaviour == Crashed {
+			continue
+		}
+
+		node := &simNode{
+			behaviour:  behaviour,
+			started:    DefaultStart() - 1,
+			safetyData: &model.SafetyData{LastVotedView: DefaultVoted()},
+			votes:      make(map[uint64]flow.Identifier),
+		}
+		s.boot(node, participant.NodeID)
+		s.nodes = append(s.nodes, node)
+		s.lookup[participant.NodeID] = node
+	}
+
+	// schedule the crashes and restarts
+	for _, restart := range cfg.Restarts {
+		node, ok := s.lookup[restart.NodeID]
+		require.True(t, ok, "can only restart running replicas")
+		s.schedule(restart.At, node, simCrash{})
+		s.schedule(restart.At+restart.Downtime, node, simRestart{})
+	}
+
+	return &s
+}
+
+// boot sets up a new instance for the replica from the state it persisted, and
+// wires it up with the simulated network and clock.
+func (s *Simulator) boot(node *simNode, nodeID flow.Identifier) {
+	node.in = NewInstance(s.t,
+		WithRoot(s.root),
+		WithParticipants(s.participants),
+		WithLocalID(nodeID),
+		WithTimeouts(s.cfg.Timeouts),
+		WithStartView(node.started+1),
+		WithSafetyData(node.safetyData),
+		WithLogLevel(zerolog.WarnLevel),
+	)
+	node.down = false
+	node.timer = nil
+	node.processed = map[flow.Identifier]struct{}{s.root.ID(): {}}
+	node.pending = make(map[flow.Identifier][]*model.Proposal)
+	node.requested = make(map[flow.Identifier]struct{})
+	s.wire(node)
+}
+
+// restart boots a crashed replica again and recovers the blocks it received
+// before the crash, like the consensus participant does on startup.
+func (s *Simulator) restart(node *simNode) error {
+	s.boot(node, node.in.localID)
+
+	// only recover blocks whose parent is recovered as well; stale blocks
+	// the replica received might be missing their ancestors
+	recovered := make(map[flow.Identifier]struct{})
+	recovered[s.root.ID()] = struct{}{}
+	pending := make([]*flow.Header, 0, len(node.received))
+	for _, blockID := range node.received {
+		header := s.headers[blockID]
+		if _, ok := recovered[header.ParentID]; !ok {
+			continue
+		}
+		recovered[blockID] = struct{}{}
+		pending = append(pending, header)
+	}
+
+	in := node.in
+	err := recovery.Participant(zerolog.Nop(), in.forks, in.aggregator, in.validator, s.root, pending)
+	if err != nil {
+		return fmt.Errorf("could not recover replica: %w", err)
+	}
+	node.processed = recovered
+
+	err = in.handler.Start()
+	if err != nil {
+		return fmt.Errorf("could not start replica: %w", err)
+	}
+	return nil
+}
+
+// wire replaces the block building, communication and finalization of the
+// instance, so that they run on the virtual clock and the simulated network.
+func (s *Simulator) wire(node *simNode) {
+	in := node.in
+
+	*in.builder = module.Builder{}
+	in.builder.On("BuildOn", mock.Anything, mock.Anything).Return(
+		func(parentID flow.Identifier, setter func(*flow.Header) error) *flow.Header {
+			parent, ok := s.headers[parentID]
+			if !ok {
+				return nil
+			}
+			header := &flow.Header{
+				ChainID:     parent.ChainID,
+				ParentID:    parentID,
+				Height:      parent.Height + 1,
+				PayloadHash: s.randomID(),
+				Timestamp:   simEpoch.Add(s.now),
+			}
+			setter(header)
+
+			// the order of the voters depends on map iteration, which would
+			// make block IDs differ between runs with the same seed
+			sort.Slice(header.ParentVoterIDs, func(i, j int) bool {
+				return string(header.ParentVoterIDs[i][:]) < string(header.ParentVoterIDs[j][:])
+			})
+
+			return header
+		},
+		func(parentID flow.Identifier, setter func(*flow.Header) error) error {
+			_, ok := s.headers[parentID]
+			if !ok {
+				return fmt.Errorf("parent block not found (parent: %x)", parentID)
+			}
+			return nil
+		},
+	)
+
+	*in.communicator = mocks.Communicator{}
+	in.communicator.On("BroadcastProposalWithDelay", mock.Anything, mock.Anything).Return(
+		func(header *flow.Header, delay time.Duration) error {
+			parent, ok := s.headers[header.ParentID]
+			if !ok {
+				return fmt.Errorf("parent for proposal not found (sender: %x, parent: %x)", in.localID, header.ParentID)
+			}
+
+			// loop our own proposal back to the sender
+			proposal := s.register(header, parent)
+			s.vote(node, proposal.Block.View, proposal.Block.BlockID)
+			s.schedule(delay, node, proposal)
+
+			switch node.behaviour {
+			case Withholding:
+				return nil
+			case Equivocating:
+				conflicting := *header
+				conflicting.PayloadHash = s.randomID()
+				other := s.register(&conflicting, parent)
+				for i, receiver := range s.others(node) {
+					if i%2 == 0 {
+						s.send(receiver, proposal, delay)
+					} else {
+						s.send(receiver, other, delay)
+					}
+				}
+			default:
+				for _, receiver := range s.others(node) {
+					s.send(receiver, proposal, delay)
+				}
+			}
+
+			return nil
+		},
+	)
+	in.communicator.On("SendVote", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(blockID flow.Identifier, view uint64, sigData []byte, recipientID flow.Identifier) error {
+			if recipientID == in.localID {
+				return fmt.Errorf("can't send to self (sender: %x)", in.localID)
+			}
+
+			// votes for crashed replicas are lost
+			receiver, ok := s.lookup[recipientID]
+			if !ok {
+				return nil
+			}
+
+			s.vote(node, view, blockID)
+			vote := model.VoteFromFlow(in.localID, blockID, view, sigData)
+			s.send(receiver, vote, 0)
+
+			return nil
+		},
+	)
+	in.communicator.On("BroadcastTimeout", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(view uint64, highestQC *flow.QuorumCertificate, sigData []byte) error {
+			timeout := model.TimeoutFromFlow(in.localID, view, highestQC, sigData)
+			for _, receiver := range s.others(node) {
+				s.send(receiver, timeout, 0)
+			}
+			return nil
+		},
+	)
+
+	*in.persist = mocks.Persister{}
+	in.persist.On("PutStarted", mock.Anything).Return(
+		func(view uint64) error {
+			node.started = view
+			return nil
+		},
+	)
+	in.persist.On("PutSafetyData", mock.Anything).Return(
+		func(data *model.SafetyData) error {
+			node.safetyData = data
+			return nil
+		},
+	)
+
+	*in.finalizer = module.Finalizer{}
+	in.finalizer.On("MakeFinal", mock.Anything).Return(
+		func(blockID flow.Identifier) error {
+			header, ok := s.headers[blockID]
+			if !ok {
+				return fmt.Errorf("finalized block not found (block: %x)", blockID)
+			}
+
+			// blocks finalized again while recovering after a restart
+			finalized := s.report.Finalized[in.localID]
+			if len(finalized) > 0 && finalized[len(finalized)-1].Height >= header.Height {
+				return nil
+			}
+
+			block := FinalizedBlock{
+				BlockID: blockID,
+				View:    header.View,
+				Height:  header.Height,
+				Latency: s.now - s.proposed[blockID],
+			}
+			s.report.Finalized[in.localID] = append(finalized, block)
+			return nil
+		},
+	)
+	in.finalizer.On("MakeValid", mock.Anything).Return(nil)
+}
+
+// Run starts all replicas and processes events until all honest replicas have
+// finalized the final view, the deadline is reached or no more events are left.
+// It only returns an error if a replica fails; safety and liveness have to be
+// checked on the report.
+func (s *Simulator) Run() (*Report, error) {
+
+	for _, node := range s.nodes {
+		err := node.in.handler.Start()
+		if err != nil {
+			return s.report, fmt.Errorf("could not start replica %x: %w", node.in.localID, err)
+		}
+		s.scheduleTimer(node)
+	}
+
+	for s.events.Len() > 0 && !s.done() {
+		event := heap.Pop(&s.events).(*simEvent)
+		if event.at > s.cfg.Deadline {
+			break
+		}
+		s.now = event.at
+		s.report.Events++
+
+		err := s.process(event.node, event.msg)
+		if err != nil {
+			return s.report, fmt.Errorf("replica %x failed at %s: %w", event.node.in.localID, s.now, err)
+		}
+		s.scheduleTimer(event.node)
+	}
+
+	s.report.Duration = s.now
+	return s.report, nil
+}
+
+// done checks whether all honest replicas have finalized the final view.
+func (s *Simulator) done() bool {
+	for _, node := range s.nodes {
+		if node.behaviour != Honest {
+			continue
+		}
+		if node.in.forks.FinalizedView() < s.cfg.FinalView {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Simulator) process(node *simNode, msg interface{}) error {
+	switch msg.(type) {
+	case simCrash:
+		node.down = true
+		return nil
+	case simRestart:
+		return s.restart(node)
+	}
+
+	// a replica that is down loses all messages and timers
+	if node.down {
+		return nil
+	}
+
+	switch m := msg.(type) {
+	case *model.TimerInfo:
+		// the pacemaker has started another timer since
+		if m != node.in.controller.TimerInfo() {
+			return nil
+		}
+		return node.in.handler.OnLocalTimeout()
+	case *model.Proposal:
+		return s.processProposal(node, m)
+	case *model.Vote:
+		return node.in.handler.OnReceiveVote(m)
+	case *model.Timeout:
+		return node.in.handler.OnReceiveTimeout(m)
+	default:
+		return fmt.Errorf("invalid event type (%T)", msg)
+	}
+}
+
+// processProposal hands a proposal to the event handler once its parent is known,
+// like the compliance engine does: proposals with a missing parent are cached and
+// the parent is requested from the other replicas.
+func (s *Simulator) processProposal(node *simNode, proposal *model.Proposal) error {
+	block := proposal.Block
+	if _, ok := node.processed[block.BlockID]; ok {
+		return nil
+	}
+
+	_, parentKnown := node.processed[block.QC.BlockID]
+	finalizedView := node.in.forks.FinalizedView()
+	if !parentKnown && block.View >= finalizedView && block.QC.View >= finalizedView {
+		node.pending[block.QC.BlockID] = append(node.pending[block.QC.BlockID], proposal)
+		s.request(node, block.QC.BlockID)
+		return nil
+	}
+
+	err := node.in.handler.OnReceiveProposal(proposal)
+	if err != nil {
+		return err
+	}
+	node.processed[block.BlockID] = struct{}{}
+	node.received = append(node.received, block.BlockID)
+
+	children := node.pending[block.BlockID]
+	delete(node.pending, block.BlockID)
+	for _, child := range children {
+		err := s.processProposal(node, child)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// request syncs a missing block to the replica after a round trip on the
+// network. Sync requests are retried until they succeed, so they are never lost.
+func (s *Simulator) request(node *simNode, blockID flow.Identifier) {
+	if _, ok := node.requested[blockID]; ok {
+		return
+	}
+	node.requested[blockID] = struct{}{}
+	proposal, ok := s.proposals[blockID]
+	if !ok {
+		return
+	}
+	s.schedule(s.cfg.Network.Delay(s.rng)+s.cfg.Network.Delay(s.rng), node, proposal)
+}
+
+// register stores a new block proposal and the time it was proposed at.
+func (s *Simulator) register(header *flow.Header, parent *flow.Header) *model.Proposal {
+	header.ChainID = parent.ChainID
+	header.Height = parent.Height + 1
+	proposal := model.ProposalFromFlow(header, parent.View)
+	s.headers[proposal.Block.BlockID] = header
+	s.proposals[proposal.Block.BlockID] = proposal
+	s.proposed[proposal.Block.BlockID] = s.now
+	return proposal
+}
+
+// vote records the block the replica voted for or proposed in a view, and counts
+// conflicting votes in the same view.
+func (s *Simulator) vote(node *simNode, view uint64, blockID flow.Identifier) {
+	votedID, voted := node.votes[view]
+	if voted && votedID != blockID {
+		s.report.ConflictingVotes++
+	}
+	node.votes[view] = blockID
+}
+
+// send delivers a message to the receiver after the given delay plus the delay of
+// the network, unless the network loses it.
+func (s *Simulator) send(receiver *simNode, msg interface{}, delay time.Duration) {
+	latency, delivered := s.cfg.Network.deliver(s.rng)
+	if !delivered {
+		s.report.Dropped++
+		return
+	}
+	s.report.Delivered++
+	s.schedule(delay+latency, receiver, msg)
+}
+
+// scheduleTimer puts the current timer of the replica's pacemaker on the virtual
+// clock, if it wasn't scheduled yet.
+func (s *Simulator) scheduleTimer(node *simNode) {
+	if node.down {
+		return
+	}
+	info := node.in.controller.TimerInfo()
+	if info == nil || info == node.timer {
+		return
+	}
+	node.timer = info
+	s.schedule(info.Duration, node, info)
+}
+
+func (s *Simulator) schedule(delay time.Duration, node *simNode, msg interface{}) {
+	s.seq++
+	heap.Push(&s.events, &simEvent{at: s.now + delay, seq: s.seq, node: node, msg: msg})
+}
+
+// others returns the running replicas other than the given one.
+func (s *Simulator) others(node *simNode) []*simNode {
+	others := make([]*simNode, 0, len(s.nodes)-1)
+	for _, other := range s.nodes {
+		if other != node {
+			others = append(others, other)
+		}
+	}
+	return others
+}
+
+func (s *Simulator) randomID() flow.Identifier {
+	var id flow.Identifier
+	_, _ = s.rng.Read(id[:])
+	return id
+}
@@ -0,0 +1,137 @@
+package integration
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// a pacemaker timeout short enough to resynchronize views within the test,
+// but long enough for a round of proposal and votes on CI
+const syncTimeout = 200 * time.Millisecond
+
+// Two of four replicas start twenty views ahead of the others, as they would
+// after a network partition. Neither half can build QCs on its own, so the
+// replicas behind jump ahead with the timeouts of the replicas ahead instead of
+// timing out locally through all the views in between.
+func TestViewSync(t *testing.T) {
+
+	// test parameters
+	num := 4
+	aheadView := uint64(21)
+	finalView := aheadView + 10
+
+	// generate the four hotstuff participants
+	participants := unittest.IdentityListFixture(num)
+	instances := make([]*Instance, 0, num)
+	root := DefaultRoot()
+	timeouts, err := timeout.NewConfig(syncTimeout, syncTimeout, 0.5, 1.5, safeDecreaseFactor, 0)
+	require.NoError(t, err)
+
+	// set up two instances starting at the default view and two far ahead
+	for n := 0; n < num; n++ {
+		startView := DefaultStart()
+		if n%2 == 1 {
+			startView = aheadView
+		}
+		in := NewInstance(t,
+			WithRoot(root),
+			WithParticipants(participants),
+			WithLocalID(participants[n].NodeID),
+			WithStartView(startView),
+			WithTimeouts(timeouts),
+			WithStopCondition(ViewFinalized(finalView)),
+		)
+		instances = append(instances, in)
+	}
+
+	// connect the communicators of the instances together
+	Connect(instances)
+
+	// start the instances and wait for them to finish
+	var wg sync.WaitGroup
+	for _, in := range instances {
+		wg.Add(1)
+		go func(in *Instance) {
+			err := in.Run()
+			require.True(t, errors.Is(err, errStopCondition), "should run until stop condition")
+			wg.Done()
+		}(in)
+	}
+	wg.Wait()
+
+	// check that all instances have finalized the same blocks after the views were synchronized
+	ref := instances[0]
+	assert.GreaterOrEqual(t, ref.forks.FinalizedBlock().View, finalView, "instance 0 should have made enough progress")
+	for i := 1; i < num; i++ {
+		assert.Equal(t, ref.forks.FinalizedBlock(), instances[i].forks.FinalizedBlock(), "instance %d should have same finalized block as first instance", i)
+		assert.Equal(t, FinalizedViews(ref), FinalizedViews(instances[i]), "instance %d should have same finalized views as first instance", i)
+	}
+}
+
+// Two of seven replicas have crashed, so the five remaining replicas all have to
+// vote to build a QC. One of them is slow and never times out locally. In the views
+// of the crashed leaders, it has to leave the view through the timeouts of the
+// other replicas, otherwise it would not vote anymore and no more QCs could be built.
+func TestTimeoutCertificate(t *testing.T) {
+
+	// test parameters
+	numAlive := 5
+	numCrashed := 2
+	finalView := uint64(30)
+
+	// generate the seven hotstuff participants; crashed replicas have no instance
+	participants := unittest.IdentityListFixture(numAlive + numCrashed)
+	instances := make([]*Instance, 0, numAlive)
+	root := DefaultRoot()
+	timeouts, err := timeout.NewConfig(syncTimeout, syncTimeout, 0.5, 1.5, safeDecreaseFactor, 0)
+	require.NoError(t, err)
+	slowTimeouts, err := timeout.NewConfig(time.Hour, time.Hour, 0.5, 1.5, safeDecreaseFactor, 0)
+	require.NoError(t, err)
+
+	// set up the replicas which are alive, the last one being slow
+	for n := 0; n < numAlive; n++ {
+		replicaTimeouts := timeouts
+		if n == numAlive-1 {
+			replicaTimeouts = slowTimeouts
+		}
+		in := NewInstance(t,
+			WithRoot(root),
+			WithParticipants(participants),
+			WithLocalID(participants[n].NodeID),
+			WithTimeouts(replicaTimeouts),
+			WithStopCondition(ViewFinalized(finalView)),
+		)
+		instances = append(instances, in)
+	}
+
+	// connect the communicators of the instances together
+	Connect(instances)
+
+	// start the instances and wait for them to finish
+	var wg sync.WaitGroup
+	for _, in := range instances {
+		wg.Add(1)
+		go func(in *Instance) {
+			err := in.Run()
+			require.True(t, errors.Is(err, errStopCondition), "should run until stop condition")
+			wg.Done()
+		}(in)
+	}
+	wg.Wait()
+
+	// check that all instances, including the slow one, have the same finalized blocks
+	ref := instances[0]
+	assert.GreaterOrEqual(t, ref.forks.FinalizedBlock().View, finalView, "instance 0 should have made enough progress")
+	for i := 1; i < numAlive; i++ {
+		assert.Equal(t, ref.forks.FinalizedBlock(), instances[i].forks.FinalizedBlock(), "instance %d should have same finalized block as first instance", i)
+		assert.Equal(t, FinalizedViews(ref), FinalizedViews(instances[i]), "instance %d should have same finalized views as first instance", i)
+	}
+}
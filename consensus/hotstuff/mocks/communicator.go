@@ -43,6 +43,20 @@ func (_m *Communicator) BroadcastProposalWithDelay(proposal *flow.Header, delay
 	return r0
 }
 
+// BroadcastTimeout provides a mock function with given fields: view, highestQC, sigData
+func (_m *Communicator) BroadcastTimeout(view uint64, highestQC *flow.QuorumCertificate, sigData []byte) error {
+	ret := _m.Called(view, highestQC, sigData)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, *flow.QuorumCertificate, []byte) error); ok {
+		r0 = rf(view, highestQC, sigData)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendVote provides a mock function with given fields: blockID, view, sigData, recipientID
 func (_m *Communicator) SendVote(blockID flow.Identifier, view uint64, sigData []byte, recipientID flow.Identifier) error {
 	ret := _m.Called(blockID, view, sigData, recipientID)
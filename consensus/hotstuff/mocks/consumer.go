@@ -95,6 +95,16 @@ func (_m *Consumer) OnStartingTimeout(_a0 *model.TimerInfo) {
 	_m.Called(_a0)
 }
 
+// OnTcTriggeredViewChange provides a mock function with given fields: tc, newView
+func (_m *Consumer) OnTcTriggeredViewChange(tc *flow.TimeoutCertificate, newView uint64) {
+	_m.Called(tc, newView)
+}
+
+// OnViewSyncTriggeredViewChange provides a mock function with given fields: timedOutView, newView
+func (_m *Consumer) OnViewSyncTriggeredViewChange(timedOutView uint64, newView uint64) {
+	_m.Called(timedOutView, newView)
+}
+
 // OnVoting provides a mock function with given fields: vote
 func (_m *Consumer) OnVoting(vote *model.Vote) {
 	_m.Called(vote)
@@ -127,3 +127,49 @@ func (_m *PaceMaker) UpdateCurViewWithQC(qc *flow.QuorumCertificate) (*model.New
 
 	return r0, r1
 }
+
+// UpdateCurViewWithTC provides a mock function with given fields: tc
+func (_m *PaceMaker) UpdateCurViewWithTC(tc *flow.TimeoutCertificate) (*model.NewViewEvent, bool) {
+	ret := _m.Called(tc)
+
+	var r0 *model.NewViewEvent
+	if rf, ok := ret.Get(0).(func(*flow.TimeoutCertificate) *model.NewViewEvent); ok {
+		r0 = rf(tc)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.NewViewEvent)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(*flow.TimeoutCertificate) bool); ok {
+		r1 = rf(tc)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// UpdateCurViewWithTimeouts provides a mock function with given fields: timedOutView
+func (_m *PaceMaker) UpdateCurViewWithTimeouts(timedOutView uint64) (*model.NewViewEvent, bool) {
+	ret := _m.Called(timedOutView)
+
+	var r0 *model.NewViewEvent
+	if rf, ok := ret.Get(0).(func(uint64) *model.NewViewEvent); ok {
+		r0 = rf(timedOutView)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.NewViewEvent)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(uint64) bool); ok {
+		r1 = rf(timedOutView)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
@@ -2,22 +2,28 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	model "github.com/onflow/flow-go/consensus/hotstuff/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // Persister is an autogenerated mock type for the Persister type
 type Persister struct {
 	mock.Mock
 }
 
-// GetStarted provides a mock function with given fields:
-func (_m *Persister) GetStarted() (uint64, error) {
+// GetSafetyData provides a mock function with given fields:
+func (_m *Persister) GetSafetyData() (*model.SafetyData, error) {
 	ret := _m.Called()
 
-	var r0 uint64
-	if rf, ok := ret.Get(0).(func() uint64); ok {
+	var r0 *model.SafetyData
+	if rf, ok := ret.Get(0).(func() *model.SafetyData); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(uint64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SafetyData)
+		}
 	}
 
 	var r1 error
@@ -30,8 +36,8 @@ func (_m *Persister) GetStarted() (uint64, error) {
 	return r0, r1
 }
 
-// GetVoted provides a mock function with given fields:
-func (_m *Persister) GetVoted() (uint64, error) {
+// GetStarted provides a mock function with given fields:
+func (_m *Persister) GetStarted() (uint64, error) {
 	ret := _m.Called()
 
 	var r0 uint64
@@ -51,13 +57,13 @@ func (_m *Persister) GetVoted() (uint64, error) {
 	return r0, r1
 }
 
-// PutStarted provides a mock function with given fields: view
-func (_m *Persister) PutStarted(view uint64) error {
-	ret := _m.Called(view)
+// PutSafetyData provides a mock function with given fields: data
+func (_m *Persister) PutSafetyData(data *model.SafetyData) error {
+	ret := _m.Called(data)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64) error); ok {
-		r0 = rf(view)
+	if rf, ok := ret.Get(0).(func(*model.SafetyData) error); ok {
+		r0 = rf(data)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -65,8 +71,8 @@ func (_m *Persister) PutStarted(view uint64) error {
 	return r0
 }
 
-// PutVoted provides a mock function with given fields: view
-func (_m *Persister) PutVoted(view uint64) error {
+// PutStarted provides a mock function with given fields: view
+func (_m *Persister) PutStarted(view uint64) error {
 	ret := _m.Called(view)
 
 	var r0 error
@@ -43,6 +43,29 @@ func (_m *Validator) ValidateQC(qc *flow.QuorumCertificate, block *model.Block)
 	return r0
 }
 
+// ValidateTimeout provides a mock function with given fields: timeout, refBlock
+func (_m *Validator) ValidateTimeout(timeout *model.Timeout, refBlock *model.Block) (*flow.Identity, error) {
+	ret := _m.Called(timeout, refBlock)
+
+	var r0 *flow.Identity
+	if rf, ok := ret.Get(0).(func(*model.Timeout, *model.Block) *flow.Identity); ok {
+		r0 = rf(timeout, refBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Identity)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Timeout, *model.Block) error); ok {
+		r1 = rf(timeout, refBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ValidateVote provides a mock function with given fields: vote, block
 func (_m *Validator) ValidateVote(vote *model.Vote, block *model.Block) (*flow.Identity, error) {
 	ret := _m.Called(vote, block)
@@ -36,6 +36,48 @@ func (_m *Verifier) VerifyQC(voterIDs []flow.Identifier, sigData []byte, block *
 	return r0, r1
 }
 
+// VerifyTC provides a mock function with given fields: signerIDs, sigData, view, highestQCViews, highestQCIDs, refBlockID
+func (_m *Verifier) VerifyTC(signerIDs []flow.Identifier, sigData []byte, view uint64, highestQCViews []uint64, highestQCIDs []flow.Identifier, refBlockID flow.Identifier) (bool, error) {
+	ret := _m.Called(signerIDs, sigData, view, highestQCViews, highestQCIDs, refBlockID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func([]flow.Identifier, []byte, uint64, []uint64, []flow.Identifier, flow.Identifier) bool); ok {
+		r0 = rf(signerIDs, sigData, view, highestQCViews, highestQCIDs, refBlockID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]flow.Identifier, []byte, uint64, []uint64, []flow.Identifier, flow.Identifier) error); ok {
+		r1 = rf(signerIDs, sigData, view, highestQCViews, highestQCIDs, refBlockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyTimeout provides a mock function with given fields: signerID, sigData, view, highestQCView, highestQCID, refBlockID
+func (_m *Verifier) VerifyTimeout(signerID flow.Identifier, sigData []byte, view uint64, highestQCView uint64, highestQCID flow.Identifier, refBlockID flow.Identifier) (bool, error) {
+	ret := _m.Called(signerID, sigData, view, highestQCView, highestQCID, refBlockID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(flow.Identifier, []byte, uint64, uint64, flow.Identifier, flow.Identifier) bool); ok {
+		r0 = rf(signerID, sigData, view, highestQCView, highestQCID, refBlockID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(flow.Identifier, []byte, uint64, uint64, flow.Identifier, flow.Identifier) error); ok {
+		r1 = rf(signerID, sigData, view, highestQCView, highestQCID, refBlockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // VerifyVote provides a mock function with given fields: voterID, sigData, block
 func (_m *Verifier) VerifyVote(voterID flow.Identifier, sigData []byte, block *model.Block) (bool, error) {
 	ret := _m.Called(voterID, sigData, block)
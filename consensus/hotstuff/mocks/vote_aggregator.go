@@ -45,6 +45,20 @@ func (_m *VoteAggregator) BuildQCOnReceivedBlock(block *model.Block) (*flow.Quor
 	return r0, r1, r2
 }
 
+// HighestTimedOutView provides a mock function with given fields:
+func (_m *VoteAggregator) HighestTimedOutView() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
 // PruneByView provides a mock function with given fields: view
 func (_m *VoteAggregator) PruneByView(view uint64) {
 	_m.Called(view)
@@ -85,6 +99,36 @@ func (_m *VoteAggregator) StoreProposerVote(vote *model.Vote) bool {
 	return r0
 }
 
+// StoreTimeoutAndBuildTC provides a mock function with given fields: timeout, refBlock
+func (_m *VoteAggregator) StoreTimeoutAndBuildTC(timeout *model.Timeout, refBlock *model.Block) (*flow.TimeoutCertificate, bool, error) {
+	ret := _m.Called(timeout, refBlock)
+
+	var r0 *flow.TimeoutCertificate
+	if rf, ok := ret.Get(0).(func(*model.Timeout, *model.Block) *flow.TimeoutCertificate); ok {
+		r0 = rf(timeout, refBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TimeoutCertificate)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(*model.Timeout, *model.Block) bool); ok {
+		r1 = rf(timeout, refBlock)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*model.Timeout, *model.Block) error); ok {
+		r2 = rf(timeout, refBlock)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // StoreVoteAndBuildQC provides a mock function with given fields: vote, block
 func (_m *VoteAggregator) StoreVoteAndBuildQC(vote *model.Vote, block *model.Block) (*flow.QuorumCertificate, bool, error) {
 	ret := _m.Called(vote, block)
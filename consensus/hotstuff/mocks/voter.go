@@ -3,8 +3,11 @@
 package mocks
 
 import (
-	model "github.com/onflow/flow-go/consensus/hotstuff/model"
+	flow "github.com/onflow/flow-go/model/flow"
+
 	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/onflow/flow-go/consensus/hotstuff/model"
 )
 
 // Voter is an autogenerated mock type for the Voter type
@@ -12,6 +15,52 @@ type Voter struct {
 	mock.Mock
 }
 
+// ProduceProposal provides a mock function with given fields: block, curView
+func (_m *Voter) ProduceProposal(block *model.Block, curView uint64) (*model.Proposal, error) {
+	ret := _m.Called(block, curView)
+
+	var r0 *model.Proposal
+	if rf, ok := ret.Get(0).(func(*model.Block, uint64) *model.Proposal); ok {
+		r0 = rf(block, curView)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Proposal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Block, uint64) error); ok {
+		r1 = rf(block, curView)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProduceTimeout provides a mock function with given fields: curView, highestQC
+func (_m *Voter) ProduceTimeout(curView uint64, highestQC *flow.QuorumCertificate) (*model.Timeout, error) {
+	ret := _m.Called(curView, highestQC)
+
+	var r0 *model.Timeout
+	if rf, ok := ret.Get(0).(func(uint64, *flow.QuorumCertificate) *model.Timeout); ok {
+		r0 = rf(curView, highestQC)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Timeout)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64, *flow.QuorumCertificate) error); ok {
+		r1 = rf(curView, highestQC)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ProduceVoteIfVotable provides a mock function with given fields: block, curView
 func (_m *Voter) ProduceVoteIfVotable(block *model.Block, curView uint64) (*model.Vote, error) {
 	ret := _m.Called(block, curView)
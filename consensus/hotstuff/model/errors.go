@@ -21,6 +21,7 @@ func IsNoVoteError(err error) bool {
 }
 
 var ErrUnverifiableBlock = errors.New("block proposal can't be verified, because its view is above the finalized view, but its QC is below the finalized view")
+var ErrUnverifiableTimeout = errors.New("timeout can't be verified, because the block of its highest QC is unknown")
 var ErrInvalidSigner = errors.New("invalid signer(s)")
 var ErrInvalidSignature = errors.New("invalid signature")
 
@@ -85,6 +86,26 @@ func (e InvalidVoteError) Unwrap() error {
 	return e.Err
 }
 
+type InvalidTimeoutError struct {
+	TimeoutID flow.Identifier
+	View      uint64
+	Err       error
+}
+
+func (e InvalidTimeoutError) Error() string {
+	return fmt.Sprintf("invalid timeout %x for view %d: %s", e.TimeoutID, e.View, e.Err.Error())
+}
+
+// IsInvalidTimeoutError returns whether an error is InvalidTimeoutError
+func IsInvalidTimeoutError(err error) bool {
+	var e InvalidTimeoutError
+	return errors.As(err, &e)
+}
+
+func (e InvalidTimeoutError) Unwrap() error {
+	return e.Err
+}
+
 // ByzantineThresholdExceededError is raised if HotStuff detects malicious conditions which
 // prove a Byzantine threshold of consensus replicas has been exceeded.
 // Per definition, the byzantine threshold is exceeded is there are byzantine consensus
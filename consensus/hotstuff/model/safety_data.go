@@ -0,0 +1,22 @@
+package model
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// SafetyData is the state a replica persists before it signs a vote, so that
+// it never signs a vote conflicting with an earlier one after a restart, even
+// if it can't recover all the blocks it knew about before.
+type SafetyData struct {
+	// LastVotedView is the view the replica last voted in; it never votes in
+	// this view or any lower view again.
+	LastVotedView uint64
+	// LastVotedBlockID is the block the replica voted for in the last voted view.
+	LastVotedBlockID flow.Identifier
+	// LockedQC is the QC for the block the replica was locked on when it last
+	// voted; nil if the replica hasn't voted since the database was bootstrapped.
+	LockedQC *flow.QuorumCertificate
+	// HighestQC is the highest QC the replica knew of when it last voted or
+	// timed out; nil if the replica hasn't done either yet.
+	HighestQC *flow.QuorumCertificate
+}
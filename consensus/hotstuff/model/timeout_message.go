@@ -0,0 +1,32 @@
+package model
+
+import (
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Timeout is the HotStuff algorithm's concept of a replica giving up on a view.
+// It carries the highest QC known to the replica, so that a timeout certificate
+// for the view also conveys the newest QC of its signers.
+type Timeout struct {
+	View      uint64
+	HighestQC *flow.QuorumCertificate
+	SignerID  flow.Identifier
+	SigData   []byte
+}
+
+// ID returns the identifier for the timeout.
+func (t *Timeout) ID() flow.Identifier {
+	return flow.MakeID(t)
+}
+
+// TimeoutFromFlow turns the timeout parameters into a timeout struct.
+func TimeoutFromFlow(signerID flow.Identifier, view uint64, highestQC *flow.QuorumCertificate, sig crypto.Signature) *Timeout {
+	timeout := Timeout{
+		View:      view,
+		HighestQC: highestQC,
+		SignerID:  signerID,
+		SigData:   sig,
+	}
+	return &timeout
+}
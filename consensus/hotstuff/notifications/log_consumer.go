@@ -74,6 +74,22 @@ func (lc *LogConsumer) OnQcTriggeredViewChange(qc *flow.QuorumCertificate, newVi
 		Msg("QC triggered view change")
 }
 
+func (lc *LogConsumer) OnTcTriggeredViewChange(tc *flow.TimeoutCertificate, newView uint64) {
+	lc.log.Debug().
+		Uint64("tc_view", tc.View).
+		Uint64("highest_qc_view", tc.HighestQC.View).
+		Int("signers", len(tc.SignerIDs)).
+		Uint64("new_view", newView).
+		Msg("TC triggered view change")
+}
+
+func (lc *LogConsumer) OnViewSyncTriggeredViewChange(timedOutView uint64, newView uint64) {
+	lc.log.Debug().
+		Uint64("timed_out_view", timedOutView).
+		Uint64("new_view", newView).
+		Msg("view sync triggered view change")
+}
+
 func (lc *LogConsumer) OnProposingBlock(block *model.Proposal) {
 	lc.logBasicBlockData(lc.log.Debug(), block.Block).
 		Msg("proposing block")
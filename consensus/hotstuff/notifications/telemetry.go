@@ -119,6 +119,21 @@ func (t *TelemetryConsumer) OnQcTriggeredViewChange(qc *flow.QuorumCertificate,
 		Msg("OnQcTriggeredViewChange")
 }
 
+func (t *TelemetryConsumer) OnTcTriggeredViewChange(tc *flow.TimeoutCertificate, newView uint64) {
+	t.pathHandler.NextStep().
+		Uint64("tc_view", tc.View).
+		Uint64("next_view", newView).
+		Uint64("highest_qc_view", tc.HighestQC.View).
+		Msg("OnTcTriggeredViewChange")
+}
+
+func (t *TelemetryConsumer) OnViewSyncTriggeredViewChange(timedOutView uint64, newView uint64) {
+	t.pathHandler.NextStep().
+		Uint64("timed_out_view", timedOutView).
+		Uint64("next_view", newView).
+		Msg("OnViewSyncTriggeredViewChange")
+}
+
 func (t *TelemetryConsumer) OnProposingBlock(proposal *model.Proposal) {
 	block := proposal.Block
 	step := t.pathHandler.NextStep()
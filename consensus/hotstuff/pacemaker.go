@@ -53,6 +53,15 @@ type PaceMaker interface {
 	// True corresponds to this replica being the next primary.
 	UpdateCurViewWithBlock(block *model.Block, isLeaderForNextView bool) (*model.NewViewEvent, bool)
 
+	// UpdateCurViewWithTC will check if the given TC will allow PaceMaker to fast
+	// forward to TC.view+1. If PaceMaker incremented the current View, a NewViewEvent will be returned.
+	UpdateCurViewWithTC(tc *flow.TimeoutCertificate) (*model.NewViewEvent, bool)
+
+	// UpdateCurViewWithTimeouts will check if the given view, which replicas with more than
+	// a third of the stake have timed out in, will allow PaceMaker to fast forward to view+1.
+	// If PaceMaker incremented the current View, a NewViewEvent will be returned.
+	UpdateCurViewWithTimeouts(timedOutView uint64) (*model.NewViewEvent, bool)
+
 	// TimeoutChannel returns the timeout channel for the CURRENTLY ACTIVE timeout.
 	// Each time the pace maker starts a new timeout, this channel is replaced.
 	TimeoutChannel() <-chan time.Time
@@ -88,6 +88,37 @@ func (p *NitroPaceMaker) UpdateCurViewWithQC(qc *flow.QuorumCertificate) (*model
 	return p.gotoView(newView), true
 }
 
+// UpdateCurViewWithTC notifies the pacemaker with a new TC, which might allow pacemaker to
+// fast forward its view.
+func (p *NitroPaceMaker) UpdateCurViewWithTC(tc *flow.TimeoutCertificate) (*model.NewViewEvent, bool) {
+	if tc.View < p.currentView {
+		return nil, false
+	}
+	// tc.view = p.currentView + k for k ≥ 0
+	// 2/3 of replicas have already timed out of round p.currentView + k, hence proceeded past currentView
+	// => 2/3 of replicas are at least in view tc.view + 1.
+	// => replica can skip ahead to view tc.view + 1
+	// As the committee did not make progress, the timeout is not decreased.
+	newView := tc.View + 1
+	p.notifier.OnTcTriggeredViewChange(tc, newView)
+	return p.gotoView(newView), true
+}
+
+// UpdateCurViewWithTimeouts notifies the pacemaker of the highest view that replicas with more
+// than a third of the stake have timed out in, which might allow pacemaker to fast forward its view.
+func (p *NitroPaceMaker) UpdateCurViewWithTimeouts(timedOutView uint64) (*model.NewViewEvent, bool) {
+	if timedOutView < p.currentView {
+		return nil, false
+	}
+	// timedOutView = p.currentView + k for k ≥ 0
+	// 1/3 of replicas have already timed out of round p.currentView + k, hence proceeded past currentView
+	// => at least one honest replica is in view timedOutView + 1 or higher.
+	// => replica can skip ahead to view timedOutView + 1 without risking to outrun the honest replicas
+	newView := timedOutView + 1
+	p.notifier.OnViewSyncTriggeredViewChange(timedOutView, newView)
+	return p.gotoView(newView), true
+}
+
 // UpdateCurViewWithBlock indicates the pacermaker that the block for the current view has received.
 // and isLeaderForNextView indicates whether or not this replica is the primary for the NEXT view.
 func (p *NitroPaceMaker) UpdateCurViewWithBlock(block *model.Block, isLeaderForNextView bool) (*model.NewViewEvent, bool) {
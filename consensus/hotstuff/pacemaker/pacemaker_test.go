@@ -100,6 +100,57 @@ func Test_IgnoreOldQC(t *testing.T) {
 	assert.Equal(t, uint64(3), pm.CurView())
 }
 
+// Test_SkipIncreaseViewThroughTC tests that PaceMaker increases View when receiving TC,
+// if applicable, by skipping views
+func Test_SkipIncreaseViewThroughTC(t *testing.T) {
+	pm, notifier := initPaceMaker(t, 3)
+
+	tc := &flow.TimeoutCertificate{View: 3, HighestQC: QC(2)}
+	notifier.On("OnStartingTimeout", expectedTimerInfo(4, model.ReplicaTimeout)).Return().Once()
+	notifier.On("OnTcTriggeredViewChange", tc, uint64(4)).Return().Once()
+	nve, nveOccurred := pm.UpdateCurViewWithTC(tc)
+	notifier.AssertExpectations(t)
+	assert.Equal(t, uint64(4), pm.CurView())
+	assert.True(t, nveOccurred && nve.View == 4)
+
+	tc = &flow.TimeoutCertificate{View: 12, HighestQC: QC(2)}
+	notifier.On("OnStartingTimeout", expectedTimerInfo(13, model.ReplicaTimeout)).Return().Once()
+	notifier.On("OnTcTriggeredViewChange", tc, uint64(13)).Return().Once()
+	nve, nveOccurred = pm.UpdateCurViewWithTC(tc)
+	assert.True(t, nveOccurred && nve.View == 13)
+
+	notifier.AssertExpectations(t)
+	assert.Equal(t, uint64(13), pm.CurView())
+}
+
+// Test_IgnoreOldTC tests that PaceMaker ignores old TCs
+func Test_IgnoreOldTC(t *testing.T) {
+	pm, notifier := initPaceMaker(t, 3)
+	nve, nveOccurred := pm.UpdateCurViewWithTC(&flow.TimeoutCertificate{View: 2, HighestQC: QC(1)})
+	assert.True(t, !nveOccurred && nve == nil)
+	notifier.AssertExpectations(t)
+	assert.Equal(t, uint64(3), pm.CurView())
+}
+
+// Test_SkipIncreaseViewThroughTimeouts tests that PaceMaker moves beyond the highest view
+// replicas with more than a third of the stake have timed out in, and ignores views it
+// has already left.
+func Test_SkipIncreaseViewThroughTimeouts(t *testing.T) {
+	pm, notifier := initPaceMaker(t, 3)
+
+	nve, nveOccurred := pm.UpdateCurViewWithTimeouts(2)
+	assert.True(t, !nveOccurred && nve == nil)
+	assert.Equal(t, uint64(3), pm.CurView())
+
+	notifier.On("OnStartingTimeout", expectedTimerInfo(21, model.ReplicaTimeout)).Return().Once()
+	notifier.On("OnViewSyncTriggeredViewChange", uint64(20), uint64(21)).Return().Once()
+	nve, nveOccurred = pm.UpdateCurViewWithTimeouts(20)
+	assert.True(t, nveOccurred && nve.View == 21)
+
+	notifier.AssertExpectations(t)
+	assert.Equal(t, uint64(21), pm.CurView())
+}
+
 // Test_SkipViewThroughBlock tests that PaceMaker skips View when receiving Block containing QC with larger View Number
 func Test_SkipViewThroughBlock(t *testing.T) {
 	pm, notifier := initPaceMaker(t, 3)
@@ -0,0 +1,79 @@
+package timeout
+
+import (
+	"time"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+)
+
+// BlockRateConfig contains the configuration parameters for the BlockRateController,
+// which adjusts the delay for broadcasting proposals at runtime:
+// - on each view change: the smoothed view duration is compared to the target view
+//   duration, and the delay is moved by a fraction `Gain` of the difference
+// - the delay always stays within [MinDelay, MaxDelay]
+type BlockRateConfig struct {
+	// TargetViewDuration is the view duration the controller aims for [MILLISECONDS]
+	TargetViewDuration float64
+	// MinDelay is the lower bound of the delay for broadcasting proposals [MILLISECONDS]
+	MinDelay float64
+	// MaxDelay is the upper bound of the delay for broadcasting proposals [MILLISECONDS]
+	MaxDelay float64
+	// Gain is the fraction of the difference between the target and the observed
+	// view duration by which the delay is adjusted on each view change
+	Gain float64
+	// Smoothing is the weight of the latest observed view duration in the
+	// exponential moving average of the view duration
+	Smoothing float64
+}
+
+var DefaultBlockRateConfig = NewDefaultBlockRateConfig()
+
+// NewDefaultBlockRateConfig returns a default block rate configuration, which
+// aims for one block per second.
+func NewDefaultBlockRateConfig() BlockRateConfig {
+	conf, err := NewBlockRateConfig(
+		time.Second,
+		0,
+		2*time.Second,
+		0.1,
+	)
+	if err != nil {
+		// we check in a unit test that this does not happen
+		panic("Default block rate config is not compliant with block rate Config requirements")
+	}
+	return conf
+}
+
+// NewBlockRateConfig creates a new BlockRateConfig.
+// targetViewDuration: view duration the controller aims for;
+// minDelay: lower bound of the delay for broadcasting proposals;
+// maxDelay: upper bound of the delay for broadcasting proposals;
+// gain: fraction of the deviation from the target by which the delay is adjusted per view.
+func NewBlockRateConfig(
+	targetViewDuration time.Duration,
+	minDelay time.Duration,
+	maxDelay time.Duration,
+	gain float64,
+) (BlockRateConfig, error) {
+	if targetViewDuration <= 0 {
+		return BlockRateConfig{}, model.ConfigurationError{Msg: "targetViewDuration must be positive"}
+	}
+	if minDelay < 0 {
+		return BlockRateConfig{}, model.ConfigurationError{Msg: "minDelay must be non-negative"}
+	}
+	if maxDelay < minDelay {
+		return BlockRateConfig{}, model.ConfigurationError{Msg: "maxDelay cannot be smaller than minDelay"}
+	}
+	if gain <= 0 || 1 < gain {
+		return BlockRateConfig{}, model.ConfigurationError{Msg: "gain must be in range (0,1]"}
+	}
+
+	bc := BlockRateConfig{
+		TargetViewDuration: float64(targetViewDuration.Milliseconds()),
+		MinDelay:           float64(minDelay.Milliseconds()),
+		MaxDelay:           float64(maxDelay.Milliseconds()),
+		Gain:               gain,
+		Smoothing:          0.2,
+	}
+	return bc, nil
+}
@@ -0,0 +1,114 @@
+package timeout
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go/module"
+)
+
+// BlockRateController adjusts the delay for broadcasting proposals at runtime, so
+// that views take the target view duration on average under varying load:
+// - the duration of each view is observed when the next view starts, and
+//   smoothed with an exponential moving average
+// - the delay is moved by a fraction of the difference between the target and
+//   the smoothed view duration, and kept within the configured bounds
+// Optionally, the controller aims for the final view of the epoch to end at a
+// given time; the target view duration then is the remaining time of the epoch
+// spread over its remaining views.
+type BlockRateController struct {
+	sync.Mutex
+	cfg     BlockRateConfig
+	metrics module.HotstuffMetrics
+	// delay is the current delay for broadcasting proposals [MILLISECONDS]
+	delay float64
+	// viewDuration is the smoothed duration of the recent views [MILLISECONDS]
+	viewDuration   float64
+	lastView       uint64
+	lastViewStart  time.Time
+	epochFinalView uint64
+	epochEndTime   time.Time
+}
+
+// NewBlockRateController creates a new BlockRateController, which starts with the
+// given delay for broadcasting proposals (limited to the configured bounds).
+func NewBlockRateController(cfg BlockRateConfig, initialDelay time.Duration, metrics module.HotstuffMetrics) *BlockRateController {
+	bc := BlockRateController{
+		cfg:     cfg,
+		metrics: metrics,
+		delay:   math.Min(math.Max(float64(initialDelay.Milliseconds()), cfg.MinDelay), cfg.MaxDelay),
+	}
+	return &bc
+}
+
+// SetEpochTarget makes the controller aim for the given final view of the epoch to
+// end at the given time. Once the final view is passed, the controller falls back
+// to the configured target view duration, until a target is set for the next epoch.
+func (b *BlockRateController) SetEpochTarget(finalView uint64, endTime time.Time) {
+	b.Lock()
+	defer b.Unlock()
+	b.epochFinalView = finalView
+	b.epochEndTime = endTime
+}
+
+// OnViewStarted indicates to the controller that the given view was started at the
+// given time, which completes the observation of the previous view.
+func (b *BlockRateController) OnViewStarted(view uint64, now time.Time) {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.lastViewStart.IsZero() {
+		b.lastView = view
+		b.lastViewStart = now
+		return
+	}
+	if view <= b.lastView {
+		return
+	}
+
+	// when skipping views, spread the elapsed time over all views we went through
+	elapsed := float64(now.Sub(b.lastViewStart)) / float64(time.Millisecond)
+	observed := math.Max(elapsed, 0) / float64(view-b.lastView)
+	if b.viewDuration == 0 {
+		b.viewDuration = observed
+	} else {
+		b.viewDuration = b.cfg.Smoothing*observed + (1-b.cfg.Smoothing)*b.viewDuration
+	}
+	b.lastView = view
+	b.lastViewStart = now
+
+	// views shorter than the target increase the delay, longer views decrease it
+	target := b.targetViewDuration(view, now)
+	b.delay = b.delay + b.cfg.Gain*(target-b.viewDuration)
+	b.delay = math.Min(math.Max(b.delay, b.cfg.MinDelay), b.cfg.MaxDelay)
+
+	b.metrics.SetViewDuration(millis(b.viewDuration))
+	b.metrics.SetTargetViewDuration(millis(target))
+	b.metrics.SetBlockRateDelay(millis(b.delay))
+}
+
+// BlockRateDelay returns the current delay for broadcasting proposals.
+func (b *BlockRateController) BlockRateDelay() time.Duration {
+	b.Lock()
+	defer b.Unlock()
+	return millis(b.delay)
+}
+
+// targetViewDuration returns the view duration to aim for when starting the given view.
+func (b *BlockRateController) targetViewDuration(view uint64, now time.Time) float64 {
+	if b.epochEndTime.IsZero() || view > b.epochFinalView {
+		return b.cfg.TargetViewDuration
+	}
+	remaining := float64(b.epochEndTime.Sub(now)) / float64(time.Millisecond)
+	if remaining <= 0 {
+		// we are late for the end of the epoch, produce blocks as fast as possible
+		return 0
+	}
+	return remaining / float64(b.epochFinalView-view+1)
+}
+
+// millis converts a float64 value in milliseconds to a duration.
+func millis(ms float64) time.Duration {
+	return time.Duration(ms * float64(time.Millisecond))
+}
@@ -0,0 +1,161 @@
+package timeout
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/module/metrics"
+	module "github.com/onflow/flow-go/module/mock"
+)
+
+// runViews lets the controller observe the given number of views, each taking the
+// given processing time plus the current delay, and returns the time after the last view.
+func runViews(bc *BlockRateController, view uint64, now time.Time, views int, processing time.Duration) (uint64, time.Time) {
+	for i := 0; i < views; i++ {
+		bc.OnViewStarted(view, now)
+		now = now.Add(processing + bc.BlockRateDelay())
+		view++
+	}
+	return view, now
+}
+
+func initBlockRateController(t *testing.T, target time.Duration) *BlockRateController {
+	cfg, err := NewBlockRateConfig(target, 0, 5*time.Second, 0.1)
+	require.NoError(t, err)
+	return NewBlockRateController(cfg, 0, metrics.NewNoopCollector())
+}
+
+// assertDurationNear checks that the duration is within one percent of the expected value.
+func assertDurationNear(t *testing.T, expected time.Duration, actual time.Duration) {
+	assert.True(t, math.Abs(float64(expected-actual)) <= float64(expected)/100, "expected %s, got %s", expected, actual)
+}
+
+// Test_BlockRateInitialDelay verifies that the initial delay is limited to the bounds.
+func Test_BlockRateInitialDelay(t *testing.T) {
+	cfg, err := NewBlockRateConfig(time.Second, 100*time.Millisecond, 500*time.Millisecond, 0.1)
+	require.NoError(t, err)
+
+	bc := NewBlockRateController(cfg, 300*time.Millisecond, metrics.NewNoopCollector())
+	assert.Equal(t, 300*time.Millisecond, bc.BlockRateDelay())
+	bc = NewBlockRateController(cfg, 0, metrics.NewNoopCollector())
+	assert.Equal(t, 100*time.Millisecond, bc.BlockRateDelay())
+	bc = NewBlockRateController(cfg, time.Second, metrics.NewNoopCollector())
+	assert.Equal(t, 500*time.Millisecond, bc.BlockRateDelay())
+}
+
+// Test_BlockRateConvergence verifies that the delay converges such that views take
+// the target view duration, and follows changes of the processing time.
+func Test_BlockRateConvergence(t *testing.T) {
+	bc := initBlockRateController(t, time.Second)
+
+	view, now := runViews(bc, 1, time.Now(), 300, 300*time.Millisecond)
+	assertDurationNear(t, 700*time.Millisecond, bc.BlockRateDelay())
+
+	// under more load, the delay is reduced to keep the block time
+	_, _ = runViews(bc, view, now, 300, 800*time.Millisecond)
+	assertDurationNear(t, 200*time.Millisecond, bc.BlockRateDelay())
+}
+
+// Test_BlockRateBounds verifies that the delay stays within the configured bounds.
+func Test_BlockRateBounds(t *testing.T) {
+	cfg, err := NewBlockRateConfig(time.Second, 100*time.Millisecond, 500*time.Millisecond, 0.1)
+	require.NoError(t, err)
+	bc := NewBlockRateController(cfg, 300*time.Millisecond, metrics.NewNoopCollector())
+
+	// views are longer than the target even without delay
+	view, now := runViews(bc, 1, time.Now(), 100, 2*time.Second)
+	assert.Equal(t, 100*time.Millisecond, bc.BlockRateDelay())
+
+	// views are much shorter than the target even with the maximum delay
+	_, _ = runViews(bc, view, now, 100, 0)
+	assert.Equal(t, 500*time.Millisecond, bc.BlockRateDelay())
+}
+
+// Test_BlockRateSkippedViews verifies that the time elapsed while skipping views is
+// spread over all skipped views, and that stale views are ignored.
+func Test_BlockRateSkippedViews(t *testing.T) {
+	bc := initBlockRateController(t, time.Second)
+	now := time.Now()
+
+	// ten views in ten seconds are right on target
+	bc.OnViewStarted(10, now)
+	bc.OnViewStarted(20, now.Add(10*time.Second))
+	assert.Equal(t, time.Duration(0), bc.BlockRateDelay())
+
+	// views we have already passed are not observed
+	bc.OnViewStarted(15, now.Add(11*time.Second))
+	assert.Equal(t, time.Duration(0), bc.BlockRateDelay())
+}
+
+// Test_BlockRateEpochTarget verifies that the controller aims for the final view of
+// the epoch to end at the target time, and falls back to the target view duration
+// after the epoch.
+func Test_BlockRateEpochTarget(t *testing.T) {
+	bc := initBlockRateController(t, time.Second)
+	start := time.Now()
+
+	// 1000 views in 2000 seconds require views of two seconds
+	bc.SetEpochTarget(1000, start.Add(2000*time.Second))
+	view, now := runViews(bc, 1, start, 990, 300*time.Millisecond)
+	assertDurationNear(t, 1700*time.Millisecond, bc.BlockRateDelay())
+
+	// ending the epoch on time, the average view took two seconds
+	view, now = runViews(bc, view, now, 10, 300*time.Millisecond)
+	assertDurationNear(t, 2000*time.Second, now.Sub(start))
+
+	// after the epoch, the target view duration applies again
+	_, _ = runViews(bc, view, now, 300, 300*time.Millisecond)
+	assertDurationNear(t, 700*time.Millisecond, bc.BlockRateDelay())
+}
+
+// Test_BlockRateNextEpochTarget verifies that a target set for the next epoch after
+// the final view of the current one has passed is applied.
+func Test_BlockRateNextEpochTarget(t *testing.T) {
+	bc := initBlockRateController(t, time.Second)
+	start := time.Now()
+
+	bc.SetEpochTarget(100, start.Add(200*time.Second))
+	view, now := runViews(bc, 1, start, 300, 300*time.Millisecond)
+	assertDurationNear(t, 700*time.Millisecond, bc.BlockRateDelay())
+
+	// 1000 views in 2000 seconds require views of two seconds
+	bc.SetEpochTarget(view+999, now.Add(2000*time.Second))
+	_, _ = runViews(bc, view, now, 500, 300*time.Millisecond)
+	assertDurationNear(t, 1700*time.Millisecond, bc.BlockRateDelay())
+}
+
+// Test_BlockRateEpochLate verifies that the delay goes down to the lower bound when
+// the end of the epoch is already overdue.
+func Test_BlockRateEpochLate(t *testing.T) {
+	bc := initBlockRateController(t, time.Second)
+	start := time.Now()
+
+	view, now := runViews(bc, 1, start, 300, 300*time.Millisecond)
+	bc.SetEpochTarget(1000, now.Add(-time.Second))
+	_, _ = runViews(bc, view, now, 100, 300*time.Millisecond)
+	assert.Equal(t, time.Duration(0), bc.BlockRateDelay())
+}
+
+// Test_BlockRateMetrics verifies that the controller reports the delay, the view
+// duration and the target view duration on each observed view.
+func Test_BlockRateMetrics(t *testing.T) {
+	collector := &module.HotstuffMetrics{}
+	cfg, err := NewBlockRateConfig(time.Second, 0, 5*time.Second, 0.5)
+	require.NoError(t, err)
+	bc := NewBlockRateController(cfg, 0, collector)
+	now := time.Now()
+
+	// the first view only starts the observation
+	bc.OnViewStarted(1, now)
+	collector.AssertExpectations(t)
+
+	collector.On("SetViewDuration", 600*time.Millisecond).Once()
+	collector.On("SetTargetViewDuration", time.Second).Once()
+	collector.On("SetBlockRateDelay", 200*time.Millisecond).Once()
+	bc.OnViewStarted(2, now.Add(600*time.Millisecond))
+	collector.AssertExpectations(t)
+}
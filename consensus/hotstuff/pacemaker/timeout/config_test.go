@@ -91,3 +91,39 @@ func TestStandardTimeoutDecreaseFactor(t *testing.T) {
 	numericalError = math.Abs(expected - 1.0)
 	require.True(t, numericalError < 1e-15)
 }
+
+func TestBlockRateConstructor(t *testing.T) {
+	c, err := NewBlockRateConfig(1500*time.Millisecond, 100*time.Millisecond, 2*time.Second, 0.3)
+	require.NoError(t, err)
+	require.Equal(t, float64(1500), c.TargetViewDuration)
+	require.Equal(t, float64(100), c.MinDelay)
+	require.Equal(t, float64(2000), c.MaxDelay)
+	require.Equal(t, float64(0.3), c.Gain)
+
+	// should not allow a targetViewDuration of zero
+	_, err = NewBlockRateConfig(0, 100*time.Millisecond, 2*time.Second, 0.3)
+	require.Error(t, err)
+
+	// should not allow negative minDelay
+	_, err = NewBlockRateConfig(1500*time.Millisecond, -100*time.Millisecond, 2*time.Second, 0.3)
+	require.Error(t, err)
+
+	// should not allow maxDelay < minDelay
+	_, err = NewBlockRateConfig(1500*time.Millisecond, 2*time.Second, 100*time.Millisecond, 0.3)
+	require.Error(t, err)
+
+	// should not allow gain to be 0 or larger than 1
+	_, err = NewBlockRateConfig(1500*time.Millisecond, 100*time.Millisecond, 2*time.Second, 0)
+	require.Error(t, err)
+	_, err = NewBlockRateConfig(1500*time.Millisecond, 100*time.Millisecond, 2*time.Second, 1.00001)
+	require.Error(t, err)
+}
+
+func TestDefaultBlockRateConfig(t *testing.T) {
+	c := NewDefaultBlockRateConfig()
+
+	require.Equal(t, float64(1000), c.TargetViewDuration)
+	require.Equal(t, float64(0), c.MinDelay)
+	require.Equal(t, float64(2000), c.MaxDelay)
+	require.Equal(t, float64(0.1), c.Gain)
+}
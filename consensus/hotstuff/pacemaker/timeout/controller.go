@@ -16,6 +16,7 @@ type Controller struct {
 	timer          *time.Timer
 	timerInfo      *model.TimerInfo
 	timeoutChannel <-chan time.Time
+	blockRate      *BlockRateController
 }
 
 // timeoutCap this is an internal cap on the timeout to avoid numerical overflows.
@@ -37,6 +38,15 @@ func NewController(timeoutConfig Config) *Controller {
 	return &tc
 }
 
+// NewAdaptiveController creates a new Controller, which uses the given block rate
+// controller to adjust the delay for broadcasting proposals to the observed views,
+// instead of the fixed delay from the timeout config.
+func NewAdaptiveController(timeoutConfig Config, blockRate *BlockRateController) *Controller {
+	tc := NewController(timeoutConfig)
+	tc.blockRate = blockRate
+	return tc
+}
+
 func DefaultController() *Controller {
 	return NewController(DefaultConfig)
 }
@@ -66,6 +76,11 @@ func (t *Controller) StartTimeout(mode model.TimeoutMode, view uint64) *model.Ti
 	t.timeoutChannel = t.timer.C
 	t.timerInfo = &timerInfo
 
+	// the replica timeout is started whenever we enter a new view
+	if t.blockRate != nil && mode == model.ReplicaTimeout {
+		t.blockRate.OnViewStarted(view, startTime)
+	}
+
 	return &timerInfo
 }
 
@@ -109,5 +124,8 @@ func (t *Controller) OnProgressBeforeTimeout() {
 
 // BlockRateDelay is a delay to broadcast the proposal in order to control block production rate
 func (t *Controller) BlockRateDelay() time.Duration {
+	if t.blockRate != nil {
+		return t.blockRate.BlockRateDelay()
+	}
 	return time.Duration(t.cfg.BlockRateDelayMS * float64(time.Millisecond))
 }
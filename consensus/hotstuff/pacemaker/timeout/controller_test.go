@@ -7,6 +7,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/module/metrics"
 )
 
 const (
@@ -167,3 +170,30 @@ func Test_BlockRateDelay(t *testing.T) {
 	tc := NewController(c)
 	assert.Equal(t, time.Second, tc.BlockRateDelay())
 }
+
+// Test_AdaptiveBlockRateDelay verifies that the adaptive controller reports the
+// delay of the block rate controller, which observes the views as the replica
+// timeouts are started.
+func Test_AdaptiveBlockRateDelay(t *testing.T) {
+	c, err := NewConfig(
+		time.Duration(startRepTimeout*1e6),
+		time.Duration(minRepTimeout*1e6),
+		voteTimeoutFraction,
+		multiplicativeIncrease,
+		multiplicativeDecrease,
+		100*time.Millisecond)
+	require.NoError(t, err)
+	bc, err := NewBlockRateConfig(time.Hour, 0, time.Second, 1)
+	require.NoError(t, err)
+	tc := NewAdaptiveController(c, NewBlockRateController(bc, 100*time.Millisecond, metrics.NewNoopCollector()))
+	assert.Equal(t, 100*time.Millisecond, tc.BlockRateDelay())
+
+	// the vote collection timeout doesn't start a new view
+	tc.StartTimeout(model.ReplicaTimeout, 1)
+	tc.StartTimeout(model.VoteCollectionTimeout, 1)
+	assert.Equal(t, 100*time.Millisecond, tc.BlockRateDelay())
+
+	// the view was much shorter than the target, so the delay goes up to the bound
+	tc.StartTimeout(model.ReplicaTimeout, 2)
+	assert.Equal(t, time.Second, tc.BlockRateDelay())
+}
@@ -1,5 +1,9 @@
 package hotstuff
 
+import (
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+)
+
 // Persister is responsible for persisting state we need to bootstrap after a
 // restart or crash.
 type Persister interface {
@@ -7,12 +11,13 @@ type Persister interface {
 	// GetStarted will retrieve the last started view.
 	GetStarted() (uint64, error)
 
-	// GetVoted will retrieve the last voted view.
-	GetVoted() (uint64, error)
+	// GetSafetyData will retrieve the data persisted with the last vote.
+	GetSafetyData() (*model.SafetyData, error)
 
 	// PutStarted persists the last started view.
 	PutStarted(view uint64) error
 
-	// PutVoted persists the last voted view.
-	PutVoted(view uint64) error
+	// PutSafetyData persists the last voted view and block, as well as the
+	// locked and highest QCs at the time of the vote, in one transaction.
+	PutSafetyData(data *model.SafetyData) error
 }
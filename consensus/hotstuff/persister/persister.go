@@ -1,9 +1,14 @@
 package persister
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/dgraph-io/badger/v2"
 
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/storage/badger/operation"
 )
 
@@ -30,11 +35,40 @@ func (p *Persister) GetStarted() (uint64, error) {
 	return view, err
 }
 
-// GetVoted returns the last persisted started view.
-func (p *Persister) GetVoted() (uint64, error) {
-	var view uint64
-	err := p.db.View(operation.RetrieveVotedView(p.chainID, &view))
-	return view, err
+// GetSafetyData returns the safety data persisted with the last vote. Only the
+// voted view is initialized when bootstrapping the database, so the voted block
+// and the QCs are left empty until the first vote.
+func (p *Persister) GetSafetyData() (*model.SafetyData, error) {
+	var data model.SafetyData
+	err := p.db.View(func(tx *badger.Txn) error {
+		err := operation.RetrieveVotedView(p.chainID, &data.LastVotedView)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve voted view: %w", err)
+		}
+		err = operation.RetrieveVotedBlock(p.chainID, &data.LastVotedBlockID)(tx)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("could not retrieve voted block: %w", err)
+		}
+		var lockedQC flow.QuorumCertificate
+		err = operation.RetrieveLockedQC(p.chainID, &lockedQC)(tx)
+		if err == nil {
+			data.LockedQC = &lockedQC
+		} else if !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("could not retrieve locked QC: %w", err)
+		}
+		var highestQC flow.QuorumCertificate
+		err = operation.RetrieveHighestQC(p.chainID, &highestQC)(tx)
+		if err == nil {
+			data.HighestQC = &highestQC
+		} else if !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("could not retrieve highest QC: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
 }
 
 // PutStarted persists the view when we start it in hotstuff.
@@ -42,7 +76,49 @@ func (p *Persister) PutStarted(view uint64) error {
 	return operation.RetryOnConflict(p.db.Update, operation.UpdateStartedView(p.chainID, view))
 }
 
-// PutVoted persist the view when we voted in hotstuff.
-func (p *Persister) PutVoted(view uint64) error {
-	return operation.RetryOnConflict(p.db.Update, operation.UpdateVotedView(p.chainID, view))
+// PutSafetyData persists the safety data atomically when we vote or time out in hotstuff.
+func (p *Persister) PutSafetyData(data *model.SafetyData) error {
+	return operation.RetryOnConflict(p.db.Update, func(tx *badger.Txn) error {
+		err := operation.UpdateVotedView(p.chainID, data.LastVotedView)(tx)
+		if err != nil {
+			return fmt.Errorf("could not update voted view: %w", err)
+		}
+		err = upsert(
+			operation.InsertVotedBlock(p.chainID, data.LastVotedBlockID),
+			operation.UpdateVotedBlock(p.chainID, data.LastVotedBlockID),
+		)(tx)
+		if err != nil {
+			return fmt.Errorf("could not update voted block: %w", err)
+		}
+		if data.LockedQC != nil {
+			err = upsert(
+				operation.InsertLockedQC(p.chainID, data.LockedQC),
+				operation.UpdateLockedQC(p.chainID, data.LockedQC),
+			)(tx)
+			if err != nil {
+				return fmt.Errorf("could not update locked QC: %w", err)
+			}
+		}
+		if data.HighestQC != nil {
+			err = upsert(
+				operation.InsertHighestQC(p.chainID, data.HighestQC),
+				operation.UpdateHighestQC(p.chainID, data.HighestQC),
+			)(tx)
+			if err != nil {
+				return fmt.Errorf("could not update highest QC: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// upsert updates a value, or inserts it if it wasn't persisted before.
+func upsert(insert func(*badger.Txn) error, update func(*badger.Txn) error) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		err := update(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return insert(tx)
+		}
+		return err
+	}
 }
@@ -0,0 +1,70 @@
+package persister
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestSafetyDataBootstrapped tests that the safety data of a freshly bootstrapped
+// database only contains the voted view.
+func TestSafetyDataBootstrapped(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		chainID := flow.ChainID("chain")
+		err := db.Update(operation.InsertVotedView(chainID, 10))
+		require.NoError(t, err)
+
+		data, err := New(db, chainID).GetSafetyData()
+		require.NoError(t, err)
+		assert.Equal(t, &model.SafetyData{LastVotedView: 10}, data)
+	})
+}
+
+// TestSafetyDataRestart tests that the safety data survives a restart of the
+// database, and that later safety data replaces it.
+func TestSafetyDataRestart(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		chainID := flow.ChainID("chain")
+		first := &model.SafetyData{
+			LastVotedView:    12,
+			LastVotedBlockID: unittest.IdentifierFixture(),
+			LockedQC:         unittest.QuorumCertificateFixture(),
+			HighestQC:        unittest.QuorumCertificateFixture(),
+		}
+		second := &model.SafetyData{
+			LastVotedView:    13,
+			LastVotedBlockID: unittest.IdentifierFixture(),
+			LockedQC:         unittest.QuorumCertificateFixture(),
+			HighestQC:        unittest.QuorumCertificateFixture(),
+		}
+
+		// bootstrap the database and persist safety data twice
+		db := unittest.BadgerDB(t, dir)
+		err := db.Update(operation.InsertVotedView(chainID, 10))
+		require.NoError(t, err)
+		persist := New(db, chainID)
+		err = persist.PutSafetyData(first)
+		require.NoError(t, err)
+		err = persist.PutSafetyData(second)
+		require.NoError(t, err)
+		require.NoError(t, db.Close())
+
+		// restart and check we get the latest safety data back
+		db = unittest.BadgerDB(t, dir)
+		defer db.Close()
+		data, err := New(db, chainID).GetSafetyData()
+		require.NoError(t, err)
+		assert.Equal(t, second, data)
+
+		// safety data is kept separately for each chain
+		_, err = New(db, flow.ChainID("other")).GetSafetyData()
+		require.Error(t, err)
+	})
+}
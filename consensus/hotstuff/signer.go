@@ -21,4 +21,11 @@ type Signer interface {
 
 	// CreateQC creates a QC for the given block.
 	CreateQC(votes []*model.Vote) (*flow.QuorumCertificate, error)
+
+	// CreateTimeout creates a timeout for the given view, carrying the given
+	// highest QC known to the replica.
+	CreateTimeout(view uint64, highestQC *flow.QuorumCertificate) (*model.Timeout, error)
+
+	// CreateTC creates a TC for the view of the given timeouts.
+	CreateTC(timeouts []*model.Timeout) (*flow.TimeoutCertificate, error)
 }
@@ -16,4 +16,8 @@ type Validator interface {
 
 	// ValidateVote checks the validity of a vote for a given block.
 	ValidateVote(vote *model.Vote, block *model.Block) (*flow.Identity, error)
+
+	// ValidateTimeout checks the validity of a timeout, with the participants
+	// determined at the given reference block.
+	ValidateTimeout(timeout *model.Timeout, refBlock *model.Block) (*flow.Identity, error)
 }
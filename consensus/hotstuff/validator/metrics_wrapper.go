@@ -46,3 +46,10 @@ func (w ValidatorMetricsWrapper) ValidateVote(vote *model.Vote, block *model.Blo
 	w.metrics.ValidatorProcessingDuration(time.Since(processStart))
 	return identity, err
 }
+
+func (w ValidatorMetricsWrapper) ValidateTimeout(timeout *model.Timeout, refBlock *model.Block) (*flow.Identity, error) {
+	processStart := time.Now()
+	identity, err := w.validator.ValidateTimeout(timeout, refBlock)
+	w.metrics.ValidatorProcessingDuration(time.Since(processStart))
+	return identity, err
+}
@@ -159,6 +159,63 @@ func (v *Validator) ValidateVote(vote *model.Vote, block *model.Block) (*flow.Id
 	return voter, nil
 }
 
+// ValidateTimeout validates the timeout and returns the identity of the replica who signed it
+// timeout - the timeout to be validated
+// refBlock - the block at which the signers are determined, usually the latest finalized block
+func (v *Validator) ValidateTimeout(timeout *model.Timeout, refBlock *model.Block) (*flow.Identity, error) {
+	signer, err := v.committee.Identity(refBlock.BlockID, timeout.SignerID)
+	if errors.Is(err, model.ErrInvalidSigner) {
+		return nil, newInvalidTimeoutError(timeout, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving signer Identity at block %x: %w", refBlock.BlockID, err)
+	}
+
+	// the highest QC must be for a view before the one we time out in
+	qc := timeout.HighestQC
+	if qc == nil {
+		return nil, newInvalidTimeoutError(timeout, fmt.Errorf("timeout is missing its highest QC"))
+	}
+	if qc.View >= timeout.View {
+		return nil, newInvalidTimeoutError(timeout, fmt.Errorf("timeout's highest QC view %d is not lower than timeout view %d", qc.View, timeout.View))
+	}
+
+	// QCs at or below the reference block certify blocks which were already
+	// finalized or have been orphaned; these don't influence our view, so we
+	// only verify QCs for newer blocks
+	if qc.View > refBlock.View {
+		block, found := v.forks.GetBlock(qc.BlockID)
+		if !found {
+			return nil, model.ErrUnverifiableTimeout
+		}
+		err = v.ValidateQC(qc, block)
+		if model.IsInvalidBlockError(err) {
+			return nil, newInvalidTimeoutError(timeout, fmt.Errorf("invalid highest QC: %w", err))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not validate highest QC of timeout (%x): %w", timeout.ID(), err)
+		}
+	}
+
+	// check whether the signature data is valid for the timeout in the hotstuff context
+	valid, err := v.verifier.VerifyTimeout(timeout.SignerID, timeout.SigData, timeout.View, qc.View, qc.BlockID, refBlock.BlockID)
+	if err != nil {
+		switch {
+		case errors.Is(err, verification.ErrInvalidFormat):
+			return nil, newInvalidTimeoutError(timeout, err)
+		case errors.Is(err, model.ErrInvalidSigner):
+			return nil, newInvalidTimeoutError(timeout, err)
+		default:
+			return nil, fmt.Errorf("cannot verify signature for timeout (%x): %w", timeout.ID(), err)
+		}
+	}
+	if !valid {
+		return nil, newInvalidTimeoutError(timeout, model.ErrInvalidSignature)
+	}
+
+	return signer, nil
+}
+
 func newInvalidBlockError(block *model.Block, err error) error {
 	return model.InvalidBlockError{
 		BlockID: block.BlockID,
@@ -174,3 +231,11 @@ func newInvalidVoteError(vote *model.Vote, err error) error {
 		Err:    err,
 	}
 }
+
+func newInvalidTimeoutError(timeout *model.Timeout, err error) error {
+	return model.InvalidTimeoutError{
+		TimeoutID: timeout.ID(),
+		View:      timeout.View,
+		Err:       err,
+	}
+}
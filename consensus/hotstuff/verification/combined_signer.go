@@ -175,6 +175,70 @@ func (c *CombinedSigner) CreateQC(votes []*model.Vote) (*flow.QuorumCertificate,
 	return qc, nil
 }
 
+// CreateTimeout will create a timeout for the given view. Timeouts only carry
+// the staking signature, as they don't contribute to the random beacon, so that
+// they can be aggregated into a timeout certificate.
+func (c *CombinedSigner) CreateTimeout(view uint64, highestQC *flow.QuorumCertificate) (*model.Timeout, error) {
+
+	// create the message to be signed and generate signature
+	msg := makeTimeoutMessage(view, highestQC.View, highestQC.BlockID)
+	stakingSig, err := c.staking.Sign(msg)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate staking signature: %w", err)
+	}
+
+	// create the timeout
+	timeout := &model.Timeout{
+		View:      view,
+		HighestQC: highestQC,
+		SignerID:  c.signerID,
+		SigData:   stakingSig,
+	}
+
+	return timeout, nil
+}
+
+// CreateTC will create a timeout certificate with an aggregated staking
+// signature for the given timeouts.
+func (c *CombinedSigner) CreateTC(timeouts []*model.Timeout) (*flow.TimeoutCertificate, error) {
+
+	// check the consistency of the timeouts
+	highestQC, err := checkTimeoutsValidity(timeouts)
+	if err != nil {
+		return nil, fmt.Errorf("timeouts are not valid: %w", err)
+	}
+
+	// collect signers and staking signatures
+	signerIDs := make([]flow.Identifier, 0, len(timeouts))
+	highestQCViews := make([]uint64, 0, len(timeouts))
+	highestQCIDs := make([]flow.Identifier, 0, len(timeouts))
+	stakingSigs := make([]crypto.Signature, 0, len(timeouts))
+	for _, timeout := range timeouts {
+		signerIDs = append(signerIDs, timeout.SignerID)
+		highestQCViews = append(highestQCViews, timeout.HighestQC.View)
+		highestQCIDs = append(highestQCIDs, timeout.HighestQC.BlockID)
+		stakingSigs = append(stakingSigs, timeout.SigData)
+	}
+
+	// aggregate all staking signatures into one aggregated signature
+	stakingAggSig, err := c.staking.Aggregate(stakingSigs)
+	if err != nil {
+		return nil, fmt.Errorf("could not aggregate staking signatures: %w", err)
+	}
+
+	// create the TC
+	tc := &flow.TimeoutCertificate{
+		View:           timeouts[0].View,
+		HighestQC:      highestQC,
+		SignerIDs:      signerIDs,
+		HighestQCViews: highestQCViews,
+		HighestQCIDs:   highestQCIDs,
+		SigData:        stakingAggSig,
+	}
+
+	return tc, nil
+}
+
 // genSigData generates the signature data for our local node for the given block.
 func (c *CombinedSigner) genSigData(block *model.Block) ([]byte, error) {
 
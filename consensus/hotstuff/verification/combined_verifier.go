@@ -142,3 +142,52 @@ func (c *CombinedVerifier) VerifyQC(voterIDs []flow.Identifier, sigData []byte,
 
 	return stakingValid && beaconValid, nil
 }
+
+// VerifyTimeout verifies the validity of the staking signature on a timeout.
+func (c *CombinedVerifier) VerifyTimeout(signerID flow.Identifier, sigData []byte, view uint64, highestQCView uint64, highestQCID flow.Identifier, refBlockID flow.Identifier) (bool, error) {
+
+	// get the identity of the signer
+	signer, err := c.committee.Identity(refBlockID, signerID)
+	if err != nil {
+		return false, fmt.Errorf("could not get signer identity at block %x: %w", refBlockID, err)
+	}
+
+	// verify the staking signature
+	msg := makeTimeoutMessage(view, highestQCView, highestQCID)
+	stakingValid, err := c.staking.Verify(msg, sigData, signer.StakingPubKey)
+	if err != nil {
+		return false, fmt.Errorf("could not verify staking signature: %w", err)
+	}
+
+	return stakingValid, nil
+}
+
+// VerifyTC verifies the validity of the aggregated staking signature on a
+// timeout certificate.
+func (c *CombinedVerifier) VerifyTC(signerIDs []flow.Identifier, sigData []byte, view uint64, highestQCViews []uint64, highestQCIDs []flow.Identifier, refBlockID flow.Identifier) (bool, error) {
+
+	// get the full Identities of the signers
+	signers, err := c.committee.Identities(refBlockID, filter.HasNodeID(signerIDs...))
+	if err != nil {
+		return false, fmt.Errorf("could not get signer identities: %w", err)
+	}
+	if len(signers) < len(signerIDs) { // check we have valid consensus member Identities for all signers
+		return false, fmt.Errorf("some signers are not valid consensus participants at block %x: %w", refBlockID, model.ErrInvalidSigner)
+	}
+	signers = signers.Order(order.ByReferenceOrder(signerIDs)) // re-arrange Identities into the same order as in signerIDs
+
+	// create the messages we verify against, one per signer
+	if len(highestQCViews) != len(signerIDs) || len(highestQCIDs) != len(signerIDs) {
+		return false, fmt.Errorf("expected highest QC of each of the %d signers: %w", len(signerIDs), ErrInvalidFormat)
+	}
+	msgs := make([][]byte, 0, len(signerIDs))
+	for i := range signerIDs {
+		msgs = append(msgs, makeTimeoutMessage(view, highestQCViews[i], highestQCIDs[i]))
+	}
+	stakingValid, err := c.staking.VerifyManyMessages(msgs, sigData, signers.StakingKeys())
+	if err != nil {
+		return false, fmt.Errorf("could not verify staking signature: %w", err)
+	}
+
+	return stakingValid, nil
+}
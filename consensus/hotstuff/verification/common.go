@@ -23,6 +23,23 @@ func makeVoteMessage(view uint64, blockID flow.Identifier) []byte {
 	return msg[:]
 }
 
+// makeTimeoutMessage generates the message we have to sign in order to time
+// out in a view. Besides the view, it contains the view and block ID of the
+// highest QC of the replica, so that the highest QC carried by a timeout can
+// not be replaced without invalidating its signature.
+func makeTimeoutMessage(view uint64, highestQCView uint64, highestQCID flow.Identifier) []byte {
+	msg := flow.MakeID(struct {
+		TimeoutView   uint64
+		HighestQCView uint64
+		HighestQCID   flow.Identifier
+	}{
+		TimeoutView:   view,
+		HighestQCView: highestQCView,
+		HighestQCID:   highestQCID,
+	})
+	return msg[:]
+}
+
 // checkVotesValidity checks the validity of each vote by checking that they are
 // all for the same view number, the same block ID and that each vote is from a
 // different signer.
@@ -65,3 +82,46 @@ func checkVotesValidity(votes []*model.Vote) error {
 
 	return nil
 }
+
+// checkTimeoutsValidity checks the validity of each timeout by checking that
+// they are all for the same view and that each timeout is from a different
+// signer. It returns the highest QC contained in the timeouts.
+func checkTimeoutsValidity(timeouts []*model.Timeout) (*flow.QuorumCertificate, error) {
+
+	// first, we should be sure to have timeouts at all
+	if len(timeouts) == 0 {
+		return nil, fmt.Errorf("need at least one timeout")
+	}
+
+	// we use this map to check each timeout has a different signer
+	signerIDs := make(map[flow.Identifier]struct{}, len(timeouts))
+
+	// we use the view from the first timeout to check that all timeouts have
+	// the same view, and keep track of the newest QC
+	view := timeouts[0].View
+	highestQC := timeouts[0].HighestQC
+
+	// go through all timeouts to check their validity
+	for _, timeout := range timeouts {
+
+		// if we have a view mismatch, bail
+		if timeout.View != view {
+			return nil, fmt.Errorf("view mismatch between timeouts (%d != %d)", timeout.View, view)
+		}
+
+		// keep the newest QC
+		if timeout.HighestQC.View > highestQC.View {
+			highestQC = timeout.HighestQC
+		}
+
+		// register the signer in our map
+		signerIDs[timeout.SignerID] = struct{}{}
+	}
+
+	// check that we have as many signers as timeouts
+	if len(signerIDs) != len(timeouts) {
+		return nil, fmt.Errorf("less signers than timeouts (signers: %d, timeouts: %d)", len(signerIDs), len(timeouts))
+	}
+
+	return highestQC, nil
+}
@@ -60,3 +60,31 @@ func (w SignerMetricsWrapper) CreateQC(votes []*model.Vote) (*flow.QuorumCertifi
 	w.metrics.SignerProcessingDuration(time.Since(processStart))
 	return qc, err
 }
+
+func (w SignerMetricsWrapper) VerifyTimeout(signerID flow.Identifier, sigData []byte, view uint64, highestQCView uint64, highestQCID flow.Identifier, refBlockID flow.Identifier) (bool, error) {
+	processStart := time.Now()
+	valid, err := w.signer.VerifyTimeout(signerID, sigData, view, highestQCView, highestQCID, refBlockID)
+	w.metrics.SignerProcessingDuration(time.Since(processStart))
+	return valid, err
+}
+
+func (w SignerMetricsWrapper) VerifyTC(signerIDs []flow.Identifier, sigData []byte, view uint64, highestQCViews []uint64, highestQCIDs []flow.Identifier, refBlockID flow.Identifier) (bool, error) {
+	processStart := time.Now()
+	valid, err := w.signer.VerifyTC(signerIDs, sigData, view, highestQCViews, highestQCIDs, refBlockID)
+	w.metrics.SignerProcessingDuration(time.Since(processStart))
+	return valid, err
+}
+
+func (w SignerMetricsWrapper) CreateTimeout(view uint64, highestQC *flow.QuorumCertificate) (*model.Timeout, error) {
+	processStart := time.Now()
+	timeout, err := w.signer.CreateTimeout(view, highestQC)
+	w.metrics.SignerProcessingDuration(time.Since(processStart))
+	return timeout, err
+}
+
+func (w SignerMetricsWrapper) CreateTC(timeouts []*model.Timeout) (*flow.TimeoutCertificate, error) {
+	processStart := time.Now()
+	tc, err := w.signer.CreateTC(timeouts)
+	w.metrics.SignerProcessingDuration(time.Since(processStart))
+	return tc, err
+}
@@ -121,3 +121,65 @@ func (s *SingleSigner) CreateQC(votes []*model.Vote) (*flow.QuorumCertificate, e
 
 	return qc, nil
 }
+
+// CreateTimeout creates a timeout with a single signature for the given view.
+func (s *SingleSigner) CreateTimeout(view uint64, highestQC *flow.QuorumCertificate) (*model.Timeout, error) {
+
+	// create the message to be signed and generate signature
+	msg := makeTimeoutMessage(view, highestQC.View, highestQC.BlockID)
+	sig, err := s.signer.Sign(msg)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate staking signature: %w", err)
+	}
+
+	// create the timeout
+	timeout := &model.Timeout{
+		View:      view,
+		HighestQC: highestQC,
+		SignerID:  s.signerID,
+		SigData:   sig,
+	}
+
+	return timeout, nil
+}
+
+// CreateTC generates a timeout certificate with a single aggregated signature for
+// the given timeouts.
+func (s *SingleSigner) CreateTC(timeouts []*model.Timeout) (*flow.TimeoutCertificate, error) {
+
+	// check the consistency of the timeouts
+	highestQC, err := checkTimeoutsValidity(timeouts)
+	if err != nil {
+		return nil, fmt.Errorf("timeouts are not valid: %w", err)
+	}
+
+	// collect all the timeout signatures
+	signerIDs := make([]flow.Identifier, 0, len(timeouts))
+	highestQCViews := make([]uint64, 0, len(timeouts))
+	highestQCIDs := make([]flow.Identifier, 0, len(timeouts))
+	sigs := make([]crypto.Signature, 0, len(timeouts))
+	for _, timeout := range timeouts {
+		signerIDs = append(signerIDs, timeout.SignerID)
+		highestQCViews = append(highestQCViews, timeout.HighestQC.View)
+		highestQCIDs = append(highestQCIDs, timeout.HighestQC.BlockID)
+		sigs = append(sigs, timeout.SigData)
+	}
+
+	// aggregate the signatures
+	aggSig, err := s.signer.Aggregate(sigs)
+	if err != nil {
+		return nil, fmt.Errorf("could not aggregate signatures: %w", err)
+	}
+
+	// create the TC
+	tc := &flow.TimeoutCertificate{
+		View:           timeouts[0].View,
+		HighestQC:      highestQC,
+		SignerIDs:      signerIDs,
+		HighestQCViews: highestQCViews,
+		HighestQCIDs:   highestQCIDs,
+		SigData:        aggSig,
+	}
+
+	return tc, nil
+}
@@ -140,3 +140,55 @@ func TestSingleQC(t *testing.T) {
 	assert.False(t, valid, "QC with changed block view data should be invalid")
 	block.View--
 }
+
+func TestSingleTC(t *testing.T) {
+
+	identities := unittest.IdentityListFixture(4, unittest.WithRole(flow.RoleConsensus))
+	signerIDs := identities.NodeIDs()
+	committeeState, stakingKeys, _ := MakeHotstuffCommitteeState(t, identities, false)
+	signers := MakeSigners(t, committeeState, identities.NodeIDs(), stakingKeys, nil)
+	refBlockID := unittest.IdentifierFixture()
+
+	// each signer times out with its own highest QC
+	view := uint64(20)
+	var timeouts []*model.Timeout
+	for i, signer := range signers {
+		highestQC := &flow.QuorumCertificate{View: uint64(10 + i), BlockID: unittest.IdentifierFixture()}
+		timeout, err := signer.CreateTimeout(view, highestQC)
+		require.NoError(t, err)
+		timeouts = append(timeouts, timeout)
+	}
+
+	// the timeouts are signed for their highest QC
+	valid, err := signers[0].VerifyTimeout(signerIDs[1], timeouts[1].SigData, view, timeouts[1].HighestQC.View, timeouts[1].HighestQC.BlockID, refBlockID)
+	require.NoError(t, err)
+	assert.True(t, valid, "timeout should be valid")
+	valid, err = signers[0].VerifyTimeout(signerIDs[1], timeouts[1].SigData, view, timeouts[0].HighestQC.View, timeouts[0].HighestQC.BlockID, refBlockID)
+	require.NoError(t, err)
+	assert.False(t, valid, "timeout with replaced highest QC should be invalid")
+
+	// should be able to create TC with the newest QC and verify it
+	tc, err := signers[0].CreateTC(timeouts)
+	require.NoError(t, err)
+	assert.Equal(t, timeouts[3].HighestQC, tc.HighestQC)
+	valid, err = signers[0].VerifyTC(tc.SignerIDs, tc.SigData, tc.View, tc.HighestQCViews, tc.HighestQCIDs, refBlockID)
+	require.NoError(t, err)
+	assert.True(t, valid, "original TC should be valid")
+
+	// verification with a changed highest QC of a signer should fail
+	tc.HighestQCViews[0]++
+	valid, err = signers[0].VerifyTC(tc.SignerIDs, tc.SigData, tc.View, tc.HighestQCViews, tc.HighestQCIDs, refBlockID)
+	require.NoError(t, err)
+	assert.False(t, valid, "TC with changed highest QC view should be invalid")
+	tc.HighestQCViews[0]--
+
+	tc.HighestQCIDs[0][0]++
+	valid, err = signers[0].VerifyTC(tc.SignerIDs, tc.SigData, tc.View, tc.HighestQCViews, tc.HighestQCIDs, refBlockID)
+	require.NoError(t, err)
+	assert.False(t, valid, "TC with changed highest QC ID should be invalid")
+	tc.HighestQCIDs[0][0]--
+
+	// verification without the highest QC of each signer should fail
+	_, err = signers[0].VerifyTC(tc.SignerIDs, tc.SigData, tc.View, tc.HighestQCViews[1:], tc.HighestQCIDs[1:], refBlockID)
+	assert.Error(t, err, "verification with missing highest QCs should not work")
+}
@@ -54,6 +54,25 @@ func (s *SingleVerifier) VerifyVote(voterID flow.Identifier, sigData []byte, blo
 	return valid, nil
 }
 
+// VerifyTimeout verifies a timeout with a single signature as signature data.
+func (s *SingleVerifier) VerifyTimeout(signerID flow.Identifier, sigData []byte, view uint64, highestQCView uint64, highestQCID flow.Identifier, refBlockID flow.Identifier) (bool, error) {
+
+	// get the identity of the signer
+	signer, err := s.committee.Identity(refBlockID, signerID)
+	if err != nil {
+		return false, fmt.Errorf("could not get signer identity at block %x: %w", refBlockID, err)
+	}
+
+	// create the message we verify against and check signature
+	msg := makeTimeoutMessage(view, highestQCView, highestQCID)
+	valid, err := s.verifier.Verify(msg, sigData, signer.StakingPubKey)
+	if err != nil {
+		return false, fmt.Errorf("could not verify signature: %w", err)
+	}
+
+	return valid, nil
+}
+
 // VerifyQC verifies a QC with a single aggregated signature as signature data.
 func (s *SingleVerifier) VerifyQC(voterIDs []flow.Identifier, sigData []byte, block *model.Block) (bool, error) {
 
@@ -76,3 +95,32 @@ func (s *SingleVerifier) VerifyQC(voterIDs []flow.Identifier, sigData []byte, bl
 
 	return valid, nil
 }
+
+// VerifyTC verifies a TC with a single aggregated signature as signature data.
+func (s *SingleVerifier) VerifyTC(signerIDs []flow.Identifier, sigData []byte, view uint64, highestQCViews []uint64, highestQCIDs []flow.Identifier, refBlockID flow.Identifier) (bool, error) {
+
+	// get the full Identities of the signers
+	signers, err := s.committee.Identities(refBlockID, filter.HasNodeID(signerIDs...))
+	if err != nil {
+		return false, fmt.Errorf("could not get signer identities: %w", err)
+	}
+	if len(signers) < len(signerIDs) { // check we have valid consensus member Identities for all signers
+		return false, fmt.Errorf("some signers are not valid consensus participants at block %x: %w", refBlockID, model.ErrInvalidSigner)
+	}
+	signers = signers.Order(order.ByReferenceOrder(signerIDs)) // re-arrange Identities into the same order as in signerIDs
+
+	// create the messages we verify against, one per signer
+	if len(highestQCViews) != len(signerIDs) || len(highestQCIDs) != len(signerIDs) {
+		return false, fmt.Errorf("expected highest QC of each of the %d signers: %w", len(signerIDs), ErrInvalidFormat)
+	}
+	msgs := make([][]byte, 0, len(signerIDs))
+	for i := range signerIDs {
+		msgs = append(msgs, makeTimeoutMessage(view, highestQCViews[i], highestQCIDs[i]))
+	}
+	valid, err := s.verifier.VerifyManyMessages(msgs, sigData, signers.StakingKeys())
+	if err != nil {
+		return false, fmt.Errorf("could not verify signature: %w", err)
+	}
+
+	return valid, nil
+}
@@ -14,4 +14,14 @@ type Verifier interface {
 
 	// VerifyQC checks the validity of a QC for the given block.
 	VerifyQC(voterIDs []flow.Identifier, sigData []byte, block *model.Block) (bool, error)
+
+	// VerifyTimeout checks the validity of a timeout for the given view and
+	// highest QC of the signer. The signer is looked up among the participants
+	// at the reference block.
+	VerifyTimeout(signerID flow.Identifier, sigData []byte, view uint64, highestQCView uint64, highestQCID flow.Identifier, refBlockID flow.Identifier) (bool, error)
+
+	// VerifyTC checks the validity of a TC for the given view and highest QCs
+	// of the signers, in the order of the signers. The signers are looked up
+	// among the participants at the reference block.
+	VerifyTC(signerIDs []flow.Identifier, sigData []byte, view uint64, highestQCViews []uint64, highestQCIDs []flow.Identifier, refBlockID flow.Identifier) (bool, error)
 }
@@ -24,6 +24,16 @@ type VoteAggregator interface {
 	// case enough votes can be accumulated for it.
 	BuildQCOnReceivedBlock(block *model.Block) (*flow.QuorumCertificate, bool, error)
 
+	// StoreTimeoutAndBuildTC will store a timeout and build the TC for the
+	// timed out view if enough timeouts can be accumulated. The participants
+	// are determined at the given reference block.
+	StoreTimeoutAndBuildTC(timeout *model.Timeout, refBlock *model.Block) (*flow.TimeoutCertificate, bool, error)
+
+	// HighestTimedOutView returns the highest view such that replicas with
+	// more than a third of the stake have timed out in this view or a later
+	// one. At least one honest replica has thus moved beyond this view.
+	HighestTimedOutView() uint64
+
 	// PruneByView will remove any data held for the provided view.
 	PruneByView(view uint64)
 }
@@ -0,0 +1,89 @@
+package voteaggregator
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// TimeoutStatus keeps track of the timeouts for the same view
+type TimeoutStatus struct {
+	signer           hotstuff.SignerVerifier
+	view             uint64
+	stakeThreshold   uint64
+	accumulatedStake uint64
+	// assume timeouts are all valid to build TC
+	timeouts map[flow.Identifier]*model.Timeout
+	stakes   map[flow.Identifier]uint64
+}
+
+// NewTimeoutStatus creates a new Timeout Status instance
+func NewTimeoutStatus(view uint64, stakeThreshold uint64, signer hotstuff.SignerVerifier) *TimeoutStatus {
+	return &TimeoutStatus{
+		signer:           signer,
+		view:             view,
+		stakeThreshold:   stakeThreshold,
+		accumulatedStake: 0,
+		timeouts:         make(map[flow.Identifier]*model.Timeout),
+		stakes:           make(map[flow.Identifier]uint64),
+	}
+}
+
+// AddTimeout adds the timeout to the list, and accumulates the stake.
+// Assumes the timeout is valid. Only the first timeout of each signer is
+// accumulated, so a replica that sends timeouts with different highest QCs
+// for the same view is only counted once.
+func (ts *TimeoutStatus) AddTimeout(timeout *model.Timeout, signer *flow.Identity) {
+	_, exists := ts.timeouts[timeout.SignerID]
+	if exists {
+		return
+	}
+	ts.timeouts[timeout.SignerID] = timeout
+	ts.stakes[timeout.SignerID] = signer.Stake
+	ts.accumulatedStake += signer.Stake
+}
+
+// RemoveTimeout removes the timeout of the signer from the list, and deducts
+// its stake.
+func (ts *TimeoutStatus) RemoveTimeout(signerID flow.Identifier) {
+	_, exists := ts.timeouts[signerID]
+	if !exists {
+		return
+	}
+	ts.accumulatedStake -= ts.stakes[signerID]
+	delete(ts.timeouts, signerID)
+	delete(ts.stakes, signerID)
+}
+
+// IsEmpty returns whether there are no timeouts in the list.
+func (ts *TimeoutStatus) IsEmpty() bool {
+	return len(ts.timeouts) == 0
+}
+
+// CanBuildTC checks whether the accumulated stake is sufficient for a TC.
+func (ts *TimeoutStatus) CanBuildTC() bool {
+	return ts.accumulatedStake >= ts.stakeThreshold
+}
+
+// TryBuildTC returns a TC if the existing timeouts are enough to build a TC.
+func (ts *TimeoutStatus) TryBuildTC() (*flow.TimeoutCertificate, bool, error) {
+
+	// check if there are enough timeouts to build TC
+	if !ts.CanBuildTC() {
+		return nil, false, nil
+	}
+
+	// build the aggregated signature
+	timeouts := make([]*model.Timeout, 0, len(ts.timeouts))
+	for _, timeout := range ts.timeouts {
+		timeouts = append(timeouts, timeout)
+	}
+	tc, err := ts.signer.CreateTC(timeouts)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not create TC from timeouts: %w", err)
+	}
+
+	return tc, true, nil
+}
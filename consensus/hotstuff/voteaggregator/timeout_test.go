@@ -0,0 +1,273 @@
+package voteaggregator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/mocks"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestTimeouts(t *testing.T) {
+	suite.Run(t, new(TimeoutSuite))
+}
+
+// TimeoutSuite tests the aggregation of timeouts into timeout certificates and
+// the view synchronization based on timeouts.
+// There are 7 participants with equal stake, meaning the threshold for a TC is 5
+// and the threshold for view synchronization is 3.
+type TimeoutSuite struct {
+	suite.Suite
+	participants flow.IdentityList
+	refBlock     *model.Block
+	committee    *mocks.Committee
+	validator    *mocks.Validator
+	signer       *mocks.SignerVerifier
+	notifier     *mocks.Consumer
+
+	aggregator *VoteAggregator
+}
+
+func (ts *TimeoutSuite) SetupTest() {
+	ts.participants = unittest.IdentityListFixture(7, unittest.WithRole(flow.RoleConsensus))
+	ts.refBlock = &model.Block{
+		BlockID: unittest.IdentifierFixture(),
+		View:    1,
+	}
+
+	ts.committee = &mocks.Committee{}
+	ts.committee.On("Identities", ts.refBlock.BlockID, mock.Anything).Return(ts.participants, nil)
+
+	// the validator accepts timeouts from all participants
+	ts.validator = &mocks.Validator{}
+	for _, participant := range ts.participants {
+		ts.validator.On("ValidateTimeout", mock.MatchedBy(signedBy(participant.NodeID)), ts.refBlock).Return(participant, nil)
+	}
+
+	ts.signer = &mocks.SignerVerifier{}
+	ts.signer.On("CreateTC", mock.AnythingOfType("[]*model.Timeout")).Return(
+		func(timeouts []*model.Timeout) *flow.TimeoutCertificate {
+			tc := &flow.TimeoutCertificate{
+				View:      timeouts[0].View,
+				HighestQC: timeouts[0].HighestQC,
+				SigData:   []byte{},
+			}
+			for _, timeout := range timeouts {
+				tc.SignerIDs = append(tc.SignerIDs, timeout.SignerID)
+			}
+			return tc
+		},
+		nil,
+	)
+
+	ts.notifier = &mocks.Consumer{}
+	ts.aggregator = New(ts.notifier, 0, ts.committee, ts.validator, ts.signer)
+}
+
+// TestBuildTC tests that a TC is built as soon as timeouts with enough stake are stored,
+// and that the same TC is returned afterwards.
+func (ts *TimeoutSuite) TestBuildTC() {
+	view := uint64(10)
+	for i := 0; i < 4; i++ {
+		tc, built, err := ts.aggregator.StoreTimeoutAndBuildTC(ts.newTimeout(view, i), ts.refBlock)
+		require.NoError(ts.T(), err)
+		require.False(ts.T(), built)
+		require.Nil(ts.T(), tc)
+	}
+
+	tc, built, err := ts.aggregator.StoreTimeoutAndBuildTC(ts.newTimeout(view, 4), ts.refBlock)
+	require.NoError(ts.T(), err)
+	require.True(ts.T(), built)
+	require.Equal(ts.T(), view, tc.View)
+	require.Len(ts.T(), tc.SignerIDs, 5)
+
+	// subsequent timeouts return the same TC
+	again, built, err := ts.aggregator.StoreTimeoutAndBuildTC(ts.newTimeout(view, 5), ts.refBlock)
+	require.NoError(ts.T(), err)
+	require.True(ts.T(), built)
+	require.Equal(ts.T(), tc, again)
+	ts.signer.AssertNumberOfCalls(ts.T(), "CreateTC", 1)
+}
+
+// TestDuplicateTimeouts tests that the stake of a replica is only accumulated once.
+func (ts *TimeoutSuite) TestDuplicateTimeouts() {
+	view := uint64(10)
+	for i := 0; i < 5; i++ {
+		tc, built, err := ts.aggregator.StoreTimeoutAndBuildTC(ts.newTimeout(view, 0), ts.refBlock)
+		require.NoError(ts.T(), err)
+		require.False(ts.T(), built)
+		require.Nil(ts.T(), tc)
+	}
+}
+
+// TestTimeoutsForDifferentViews tests that timeouts for different views are not
+// aggregated together.
+func (ts *TimeoutSuite) TestTimeoutsForDifferentViews() {
+	for i := 0; i < 7; i++ {
+		tc, built, err := ts.aggregator.StoreTimeoutAndBuildTC(ts.newTimeout(uint64(10+i%2), i), ts.refBlock)
+		require.NoError(ts.T(), err)
+		require.False(ts.T(), built)
+		require.Nil(ts.T(), tc)
+	}
+}
+
+// TestInvalidTimeouts tests that invalid and unverifiable timeouts are dropped
+// without an error.
+func (ts *TimeoutSuite) TestInvalidTimeouts() {
+	invalid := ts.newTimeout(10, 0)
+	invalid.SignerID = unittest.IdentifierFixture()
+	ts.validator.On("ValidateTimeout", invalid, ts.refBlock).Return(nil, model.InvalidTimeoutError{TimeoutID: invalid.ID(), View: invalid.View, Err: model.ErrInvalidSigner})
+	tc, built, err := ts.aggregator.StoreTimeoutAndBuildTC(invalid, ts.refBlock)
+	require.NoError(ts.T(), err)
+	require.False(ts.T(), built)
+	require.Nil(ts.T(), tc)
+
+	unverifiable := ts.newTimeout(10, 0)
+	unverifiable.SignerID = unittest.IdentifierFixture()
+	ts.validator.On("ValidateTimeout", unverifiable, ts.refBlock).Return(nil, model.ErrUnverifiableTimeout)
+	tc, built, err = ts.aggregator.StoreTimeoutAndBuildTC(unverifiable, ts.refBlock)
+	require.NoError(ts.T(), err)
+	require.False(ts.T(), built)
+	require.Nil(ts.T(), tc)
+
+	require.Equal(ts.T(), uint64(0), ts.aggregator.HighestTimedOutView())
+
+	// other errors are returned
+	broken := ts.newTimeout(10, 0)
+	broken.SignerID = unittest.IdentifierFixture()
+	ts.validator.On("ValidateTimeout", broken, ts.refBlock).Return(nil, fmt.Errorf("unexpected"))
+	_, _, err = ts.aggregator.StoreTimeoutAndBuildTC(broken, ts.refBlock)
+	require.Error(ts.T(), err)
+}
+
+// TestStaleTimeouts tests that timeouts for pruned views are ignored.
+func (ts *TimeoutSuite) TestStaleTimeouts() {
+	ts.aggregator.PruneByView(10)
+	for i := 0; i < 7; i++ {
+		tc, built, err := ts.aggregator.StoreTimeoutAndBuildTC(ts.newTimeout(10, i), ts.refBlock)
+		require.NoError(ts.T(), err)
+		require.False(ts.T(), built)
+		require.Nil(ts.T(), tc)
+	}
+	require.Equal(ts.T(), uint64(0), ts.aggregator.HighestTimedOutView())
+	ts.validator.AssertNotCalled(ts.T(), "ValidateTimeout", mock.Anything, mock.Anything)
+}
+
+// TestTimeoutViewsBounded tests that the timeouts of a replica are only collected
+// for its latest views, so that timeouts for arbitrary future views can't make the
+// aggregator allocate unbounded state.
+func (ts *TimeoutSuite) TestTimeoutViewsBounded() {
+	store := func(view uint64, index int) {
+		_, _, err := ts.aggregator.StoreTimeoutAndBuildTC(ts.newTimeout(view, index), ts.refBlock)
+		require.NoError(ts.T(), err)
+	}
+
+	// a replica times out in many future views
+	for view := uint64(1000); view < 1100; view++ {
+		store(view, 0)
+	}
+	require.Len(ts.T(), ts.aggregator.viewToTimeoutStatus, maxTimeoutViewsPerSigner)
+	_, exists := ts.aggregator.viewToTimeoutStatus[1100-maxTimeoutViewsPerSigner]
+	require.True(ts.T(), exists)
+
+	// timeouts below the collected views of the replica are dropped
+	store(10, 0)
+	require.Len(ts.T(), ts.aggregator.viewToTimeoutStatus, maxTimeoutViewsPerSigner)
+	_, exists = ts.aggregator.viewToTimeoutStatus[10]
+	require.False(ts.T(), exists)
+
+	// the other replicas can still build a TC for their view
+	for i := 1; i < 5; i++ {
+		store(10, i)
+	}
+	tc, built, err := ts.aggregator.StoreTimeoutAndBuildTC(ts.newTimeout(10, 5), ts.refBlock)
+	require.NoError(ts.T(), err)
+	require.True(ts.T(), built)
+	require.Equal(ts.T(), uint64(10), tc.View)
+
+	// pruning releases the collected views of the replica
+	ts.aggregator.PruneByView(1099)
+	require.Empty(ts.T(), ts.aggregator.signerTimeoutViews)
+	require.Empty(ts.T(), ts.aggregator.viewToTimeoutStatus)
+}
+
+// TestDroppedTimeoutStake tests that the stake of a replica's dropped timeout is no
+// longer counted towards the TC of its view.
+func (ts *TimeoutSuite) TestDroppedTimeoutStake() {
+	store := func(view uint64, index int) {
+		_, _, err := ts.aggregator.StoreTimeoutAndBuildTC(ts.newTimeout(view, index), ts.refBlock)
+		require.NoError(ts.T(), err)
+	}
+
+	// four replicas time out in view 10, the first then moves far ahead
+	for i := 0; i < 4; i++ {
+		store(10, i)
+	}
+	for view := uint64(11); view <= 10+maxTimeoutViewsPerSigner; view++ {
+		store(view, 0)
+	}
+
+	// a fifth timeout is not enough anymore, as the first one was dropped
+	tc, built, err := ts.aggregator.StoreTimeoutAndBuildTC(ts.newTimeout(10, 4), ts.refBlock)
+	require.NoError(ts.T(), err)
+	require.False(ts.T(), built)
+	require.Nil(ts.T(), tc)
+
+	tc, built, err = ts.aggregator.StoreTimeoutAndBuildTC(ts.newTimeout(10, 5), ts.refBlock)
+	require.NoError(ts.T(), err)
+	require.True(ts.T(), built)
+	require.NotContains(ts.T(), tc.SignerIDs, ts.participants[0].NodeID)
+}
+
+// TestHighestTimedOutView tests that the highest timed out view is the highest view
+// reached by replicas with more than a third of the stake.
+func (ts *TimeoutSuite) TestHighestTimedOutView() {
+	require.Equal(ts.T(), uint64(0), ts.aggregator.HighestTimedOutView())
+
+	store := func(view uint64, index int) {
+		_, _, err := ts.aggregator.StoreTimeoutAndBuildTC(ts.newTimeout(view, index), ts.refBlock)
+		require.NoError(ts.T(), err)
+	}
+
+	// two replicas are not enough
+	store(20, 0)
+	store(15, 1)
+	require.Equal(ts.T(), uint64(0), ts.aggregator.HighestTimedOutView())
+
+	// the third replica determines the view
+	store(12, 2)
+	require.Equal(ts.T(), uint64(12), ts.aggregator.HighestTimedOutView())
+
+	// the replica at view 12 moves ahead
+	store(17, 2)
+	require.Equal(ts.T(), uint64(15), ts.aggregator.HighestTimedOutView())
+
+	// older timeouts of a replica don't move it back
+	store(11, 1)
+	require.Equal(ts.T(), uint64(15), ts.aggregator.HighestTimedOutView())
+
+	// pruning removes replicas whose latest timeout is pruned
+	ts.aggregator.PruneByView(16)
+	require.Equal(ts.T(), uint64(0), ts.aggregator.HighestTimedOutView())
+}
+
+func (ts *TimeoutSuite) newTimeout(view uint64, signerIndex int) *model.Timeout {
+	return &model.Timeout{
+		View:      view,
+		HighestQC: &flow.QuorumCertificate{View: view - 1, BlockID: unittest.IdentifierFixture()},
+		SignerID:  ts.participants[signerIndex].NodeID,
+		SigData:   []byte{},
+	}
+}
+
+func signedBy(signerID flow.Identifier) func(*model.Timeout) bool {
+	return func(timeout *model.Timeout) bool {
+		return timeout.SignerID == signerID
+	}
+}
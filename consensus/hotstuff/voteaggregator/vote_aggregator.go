@@ -1,7 +1,9 @@
 package voteaggregator
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
@@ -9,6 +11,12 @@ import (
 	"github.com/onflow/flow-go/model/flow/filter"
 )
 
+// maxTimeoutViewsPerSigner is the maximum number of views for which the timeouts
+// of a single replica are collected. It bounds the state a replica can make us
+// allocate by timing out in arbitrary future views. As honest replicas time out
+// in increasing views, only their latest timeouts are relevant for building TCs.
+const maxTimeoutViewsPerSigner = 8
+
 // VoteAggregator stores the votes and aggregates them into a QC when enough votes have been collected
 type VoteAggregator struct {
 	notifier              hotstuff.Consumer
@@ -22,6 +30,18 @@ type VoteAggregator struct {
 	createdQC             map[flow.Identifier]*flow.QuorumCertificate // keeps track of QCs that have been made for blocks
 	blockIDToVotingStatus map[flow.Identifier]*VotingStatus           // keeps track of accumulated votes and stakes for blocks
 	proposerVotes         map[flow.Identifier]*model.Vote             // holds the votes of block proposers, so we can avoid passing around proposals everywhere
+	viewToTimeoutStatus   map[uint64]*TimeoutStatus                   // keeps track of accumulated timeouts and stakes for views
+	createdTC             map[uint64]*flow.TimeoutCertificate         // keeps track of TCs that have been made for views
+	timedOutViews         map[flow.Identifier]*timedOutView           // keeps track of the latest view each replica has timed out in
+	signerTimeoutViews    map[flow.Identifier][]uint64                // keeps track of the views each replica's timeouts are collected for, in ascending order
+	totalStake            uint64                                      // total stake of the participants the timeouts were validated against
+}
+
+// timedOutView is the latest view a replica has timed out in, with the
+// replica's stake.
+type timedOutView struct {
+	view  uint64
+	stake uint64
 }
 
 // New creates an instance of vote aggregator
@@ -38,6 +58,10 @@ func New(notifier hotstuff.Consumer, highestPrunedView uint64, committee hotstuf
 		createdQC:             make(map[flow.Identifier]*flow.QuorumCertificate),
 		blockIDToVotingStatus: make(map[flow.Identifier]*VotingStatus),
 		proposerVotes:         make(map[flow.Identifier]*model.Vote),
+		viewToTimeoutStatus:   make(map[uint64]*TimeoutStatus),
+		createdTC:             make(map[uint64]*flow.TimeoutCertificate),
+		timedOutViews:         make(map[flow.Identifier]*timedOutView),
+		signerTimeoutViews:    make(map[flow.Identifier][]uint64),
 	}
 }
 
@@ -216,6 +240,142 @@ func (va *VoteAggregator) BuildQCOnReceivedBlock(block *model.Block) (*flow.Quor
 	return qc, built, nil
 }
 
+// StoreTimeoutAndBuildTC stores the timeout and returns a TC if there are timeouts with
+// enough stake for its view. The signers are determined at the given reference block,
+// which is usually the latest finalized block.
+// It's idempotent. Meaning, calling it again with the same timeout returns the same result.
+// Once a TC has been built for a view, VoteAggregator ALWAYS returns the same TC for that view.
+// It returns (tc, true, nil) if a TC is built
+// It returns (nil, false, nil) if not enough timeouts to build a TC, or if the timeout is stale or invalid
+// It returns (nil, false, err) if there is an unknown error
+func (va *VoteAggregator) StoreTimeoutAndBuildTC(timeout *model.Timeout, refBlock *model.Block) (*flow.TimeoutCertificate, bool, error) {
+	// if the TC for the view has been created before, return the TC
+	oldTC, built := va.createdTC[timeout.View]
+	if built {
+		return oldTC, true, nil
+	}
+
+	// ignore stale timeouts
+	if timeout.View <= va.highestPrunedView {
+		return nil, false, nil
+	}
+
+	// validate the timeout
+	signer, err := va.voteValidator.ValidateTimeout(timeout, refBlock)
+	if model.IsInvalidTimeoutError(err) || errors.Is(err, model.ErrUnverifiableTimeout) {
+		// does not report invalid timeouts as an error, they are simply dropped
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("could not validate timeout: %w", err)
+	}
+
+	// remember the latest view each replica has timed out in, for view synchronization
+	latest, exists := va.timedOutViews[timeout.SignerID]
+	if !exists || latest.view < timeout.View {
+		va.timedOutViews[timeout.SignerID] = &timedOutView{view: timeout.View, stake: signer.Stake}
+	}
+
+	// only collect the timeouts of the replica for its latest views
+	if !va.trackTimeoutView(timeout.SignerID, timeout.View) {
+		return nil, false, nil
+	}
+
+	// update existing timeout status or create a new one
+	timeoutStatus, exists := va.viewToTimeoutStatus[timeout.View]
+	if !exists {
+		// get all identities
+		identities, err := va.committee.Identities(refBlock.BlockID, filter.Any)
+		if err != nil {
+			return nil, false, fmt.Errorf("error retrieving consensus participants: %w", err)
+		}
+
+		// create TimeoutStatus for view
+		va.totalStake = identities.TotalStake()
+		stakeThreshold := hotstuff.ComputeStakeThresholdForBuildingQC(va.totalStake) // stake threshold for building valid tc
+		timeoutStatus = NewTimeoutStatus(timeout.View, stakeThreshold, va.signer)
+		va.viewToTimeoutStatus[timeout.View] = timeoutStatus
+	}
+	timeoutStatus.AddTimeout(timeout, signer)
+
+	// try to build the TC with existing timeouts
+	tc, built, err := timeoutStatus.TryBuildTC()
+	if err != nil {
+		return nil, false, fmt.Errorf("could not build TC: %w", err)
+	}
+	if !built {
+		return nil, false, nil
+	}
+
+	va.createdTC[timeout.View] = tc
+	return tc, true, nil
+}
+
+// trackTimeoutView records that a timeout of the signer is collected for the given
+// view. If the timeouts of the signer are already collected for the maximum number
+// of views, its timeout for the lowest of them is dropped to make room, unless the
+// given view is lower than all of them. It returns false if the timeout should not
+// be collected.
+func (va *VoteAggregator) trackTimeoutView(signerID flow.Identifier, view uint64) bool {
+	views := va.signerTimeoutViews[signerID]
+	index := sort.Search(len(views), func(i int) bool { return views[i] >= view })
+	if index < len(views) && views[index] == view {
+		return true
+	}
+	if len(views) >= maxTimeoutViewsPerSigner {
+		if index == 0 {
+			return false
+		}
+		va.dropTimeout(signerID, views[0])
+		views = views[1:]
+		index--
+	}
+
+	views = append(views, 0)
+	copy(views[index+1:], views[index:])
+	views[index] = view
+	va.signerTimeoutViews[signerID] = views
+	return true
+}
+
+// dropTimeout removes the timeout of the signer for the given view, and the timeout
+// status of the view if no timeouts are left.
+func (va *VoteAggregator) dropTimeout(signerID flow.Identifier, view uint64) {
+	timeoutStatus, exists := va.viewToTimeoutStatus[view]
+	if !exists {
+		return
+	}
+	timeoutStatus.RemoveTimeout(signerID)
+	if timeoutStatus.IsEmpty() {
+		delete(va.viewToTimeoutStatus, view)
+	}
+}
+
+// HighestTimedOutView returns the highest view such that replicas with more than a
+// third of the total stake have timed out in this view or a later one. As at least
+// one of them is honest, an honest replica has moved beyond this view, and we can
+// safely follow it. It returns 0 if there is no such view.
+func (va *VoteAggregator) HighestTimedOutView() uint64 {
+	latest := make([]*timedOutView, 0, len(va.timedOutViews))
+	for _, timedOut := range va.timedOutViews {
+		latest = append(latest, timedOut)
+	}
+	sort.Slice(latest, func(i int, j int) bool {
+		return latest[i].view > latest[j].view
+	})
+
+	// accumulate the stake from the highest view down, until we pass the threshold
+	threshold := hotstuff.ComputeStakeThresholdForViewSync(va.totalStake)
+	accumulatedStake := uint64(0)
+	for _, timedOut := range latest {
+		accumulatedStake += timedOut.stake
+		if accumulatedStake >= threshold {
+			return timedOut.view
+		}
+	}
+	return 0
+}
+
 // PruneByView will delete all votes equal or below to the given view, as well as related indexes.
 func (va *VoteAggregator) PruneByView(view uint64) {
 	if view <= va.highestPrunedView {
@@ -231,6 +391,21 @@ func (va *VoteAggregator) PruneByView(view uint64) {
 		}
 		delete(va.viewToBlockIDSet, i)
 		delete(va.viewToVoteID, i)
+		delete(va.viewToTimeoutStatus, i)
+		delete(va.createdTC, i)
+	}
+	for signerID, timedOut := range va.timedOutViews {
+		if timedOut.view <= view {
+			delete(va.timedOutViews, signerID)
+		}
+	}
+	for signerID, views := range va.signerTimeoutViews {
+		index := sort.Search(len(views), func(i int) bool { return views[i] > view })
+		if index == len(views) {
+			delete(va.signerTimeoutViews, signerID)
+			continue
+		}
+		va.signerTimeoutViews[signerID] = views[index:]
 	}
 	va.highestPrunedView = view
 }
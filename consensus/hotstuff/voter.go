@@ -2,6 +2,7 @@ package hotstuff
 
 import (
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
 )
 
 // Voter produces votes for the given block
@@ -10,4 +11,12 @@ type Voter interface {
 	// ProduceVoteIfVotable will produce a vote for the given block if voting on
 	// the given block is a valid action.
 	ProduceVoteIfVotable(block *model.Block, curView uint64) (*model.Vote, error)
+
+	// ProduceProposal will sign the given block as our proposal for the current
+	// view, if we haven't voted in the view yet.
+	ProduceProposal(block *model.Block, curView uint64) (*model.Proposal, error)
+
+	// ProduceTimeout will produce a timeout for the given view, carrying the
+	// highest QC known to the replica.
+	ProduceTimeout(curView uint64, highestQC *flow.QuorumCertificate) (*model.Timeout, error)
 }
@@ -5,58 +5,161 @@ import (
 
 	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
 )
 
 // Voter produces votes for the given block
 type Voter struct {
-	signer        hotstuff.SignerVerifier
-	forks         hotstuff.ForksReader
-	persist       hotstuff.Persister
-	lastVotedView uint64 // need to keep track of the last view we voted for so we don't double vote accidentally
+	signer  hotstuff.SignerVerifier
+	forks   hotstuff.Forks
+	persist hotstuff.Persister
+	// need to keep track of the last view we voted for so we don't double vote accidentally,
+	// and of the locked QC at that time, so we don't vote against it after a restart
+	safetyData *model.SafetyData
 }
 
 // New creates a new Voter instance
-func New(signer hotstuff.SignerVerifier, forks hotstuff.ForksReader, persist hotstuff.Persister, lastVotedView uint64) *Voter {
+func New(signer hotstuff.SignerVerifier, forks hotstuff.Forks, persist hotstuff.Persister, safetyData *model.SafetyData) *Voter {
 	return &Voter{
-		signer:        signer,
-		forks:         forks,
-		persist:       persist,
-		lastVotedView: lastVotedView,
+		signer:     signer,
+		forks:      forks,
+		persist:    persist,
+		safetyData: safetyData,
 	}
 }
 
 // ProduceVoteIfVotable will make a decision on whether it will vote for the given proposal, the returned
 // error indicates whether to vote or not.
 // In order to ensure that only a safe node will be voted, Voter will ask Forks whether a vote is a safe node or not.
+// As Forks might not have recovered all blocks after a restart, the block also has to be safe with regard to the
+// locked QC persisted with the last vote.
 // The curView is taken as input to ensure Voter will only vote for proposals at current view and prevent double voting.
 // This method will only ever _once_ return a `non-nil vote, nil` vote: the very first time it encounters a safe block of the
 //  current view to vote for. Subsequently, voter does _not_ vote for any other block with the same (or lower) view.
 // (including repeated calls with the initial block we voted for also return `nil, error`).
+// The safety data is persisted _before_ the vote is signed, so a vote is never released without it.
 func (v *Voter) ProduceVoteIfVotable(block *model.Block, curView uint64) (*model.Vote, error) {
 	if !v.forks.IsSafeBlock(block) {
 		return nil, model.NoVoteError{Msg: "not safe block"}
 	}
 
+	if !v.isSafeForPersistedLock(block) {
+		return nil, model.NoVoteError{Msg: "not safe block for the persisted locked QC"}
+	}
+
 	if curView != block.View {
 		return nil, model.NoVoteError{Msg: "not for current view"}
 	}
 
-	if curView <= v.lastVotedView {
+	if curView <= v.safetyData.LastVotedView {
 		return nil, model.NoVoteError{Msg: "not above the last voted view"}
 	}
 
+	// persist that we vote for the current view before signing the vote,
+	// to prevent from voting for the same view again, even after a restart
+	safetyData := &model.SafetyData{
+		LastVotedView:    curView,
+		LastVotedBlockID: block.BlockID,
+		LockedQC:         newerQC(v.safetyData.LockedQC, v.forks.LockedQC()),
+		HighestQC:        newerQC(v.safetyData.HighestQC, v.forks.HighestQC()),
+	}
+	err := v.persist.PutSafetyData(safetyData)
+	if err != nil {
+		return nil, fmt.Errorf("could not persist safety data: %w", err)
+	}
+	v.safetyData = safetyData
+
 	vote, err := v.signer.CreateVote(block)
 	if err != nil {
 		return nil, fmt.Errorf("could not vote for block: %w", err)
 	}
 
-	// vote for the current view has been produced, update lastVotedView
-	// to prevent from voting for the same view again
-	v.lastVotedView = curView
-	err = v.persist.PutVoted(curView)
+	return vote, nil
+}
+
+// ProduceProposal signs the given block as our proposal for the current view. As the
+// proposal includes the proposer's vote for the block, the same safety rules as for
+// voting apply: a proposal is only produced for the current view if we haven't voted
+// in it yet, and the safety data is persisted _before_ the proposal is signed, so that
+// we neither vote for a conflicting block nor propose again after a restart.
+func (v *Voter) ProduceProposal(block *model.Block, curView uint64) (*model.Proposal, error) {
+	if curView != block.View {
+		return nil, model.NoVoteError{Msg: "not for current view"}
+	}
+
+	if curView <= v.safetyData.LastVotedView {
+		return nil, model.NoVoteError{Msg: "not above the last voted view"}
+	}
+
+	// persist that we vote for the current view before signing the proposal
+	safetyData := &model.SafetyData{
+		LastVotedView:    curView,
+		LastVotedBlockID: block.BlockID,
+		LockedQC:         newerQC(v.safetyData.LockedQC, v.forks.LockedQC()),
+		HighestQC:        newerQC(v.safetyData.HighestQC, v.forks.HighestQC()),
+	}
+	err := v.persist.PutSafetyData(safetyData)
 	if err != nil {
-		return nil, fmt.Errorf("could not persist last voted: %w", err)
+		return nil, fmt.Errorf("could not persist safety data: %w", err)
 	}
+	v.safetyData = safetyData
 
-	return vote, nil
+	proposal, err := v.signer.CreateProposal(block)
+	if err != nil {
+		return nil, fmt.Errorf("could not sign proposal: %w", err)
+	}
+
+	return proposal, nil
+}
+
+// ProduceTimeout produces a timeout for the given view, which the replica is
+// leaving because of a local timeout. The highest QC known to the replica is
+// included, so that replicas which receive the resulting timeout certificate
+// can catch up with the highest QC.
+// If the highest QC persisted before a restart is higher than the given one, it is
+// included instead, and a higher given QC is persisted before the timeout is signed.
+func (v *Voter) ProduceTimeout(curView uint64, highestQC *flow.QuorumCertificate) (*model.Timeout, error) {
+	highestQC = newerQC(v.safetyData.HighestQC, highestQC)
+	if highestQC != v.safetyData.HighestQC {
+		safetyData := *v.safetyData
+		safetyData.HighestQC = highestQC
+		err := v.persist.PutSafetyData(&safetyData)
+		if err != nil {
+			return nil, fmt.Errorf("could not persist safety data: %w", err)
+		}
+		v.safetyData = &safetyData
+	}
+
+	timeout, err := v.signer.CreateTimeout(curView, highestQC)
+	if err != nil {
+		return nil, fmt.Errorf("could not create timeout for view %d: %w", curView, err)
+	}
+	return timeout, nil
+}
+
+// isSafeForPersistedLock checks the block against the locked QC persisted with the
+// last vote: the block either has to extend the locked block, or its QC has to be
+// newer than the locked QC. As a block's QC points to its parent, extending the
+// locked block with a QC that isn't newer means the QC is the locked QC itself.
+func (v *Voter) isSafeForPersistedLock(block *model.Block) bool {
+	lockedQC := v.safetyData.LockedQC
+	if lockedQC == nil {
+		return true
+	}
+	if block.QC.View > lockedQC.View {
+		return true
+	}
+	return block.QC.View == lockedQC.View && block.QC.BlockID == lockedQC.BlockID
+}
+
+// newerQC returns the QC with the higher view, preferring the first one on equal
+// views; either QC may be nil.
+func newerQC(first *flow.QuorumCertificate, second *flow.QuorumCertificate) *flow.QuorumCertificate {
+	if first == nil {
+		return second
+	}
+	if second == nil || first.View >= second.View {
+		return first
+	}
+	return second
 }
@@ -1,6 +1,7 @@
 package voter
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -19,22 +20,40 @@ func TestProduceVote(t *testing.T) {
 	t.Run("should not vote for block with the same view as the last voted view", testEqualLastVotedView)
 	t.Run("should not vote for block with its view below the last voted view", testBelowLastVotedView)
 	t.Run("should not vote for the same view again", testVotingAgain)
+	t.Run("should not vote if safety data can't be persisted", testPersistFailure)
+	t.Run("should not vote against the persisted locked QC", testPersistedLock)
+	t.Run("should not vote for the same view again after a restart", testVotingAgainAfterRestart)
+}
+
+func TestProduceProposal(t *testing.T) {
+	t.Run("should sign proposal after persisting the voted view", testProposalOK)
+	t.Run("should not sign proposal for a view other than the current view", testProposalNotCurView)
+	t.Run("should not sign proposal in a view already voted in", testProposalVotedView)
+	t.Run("should not sign proposal if safety data can't be persisted", testProposalPersistFailure)
+	t.Run("should not propose or vote again in the view after a restart", testProposingAgainAfterRestart)
+}
+
+func TestProduceTimeout(t *testing.T) {
+	t.Run("should persist a newer highest QC", testTimeoutNewerQC)
+	t.Run("should include the persisted highest QC if it is newer", testTimeoutPersistedQC)
 }
 
 func createVoter(t *testing.T, blockView uint64, lastVotedView uint64, isBlockSafe bool) (*model.Block, *model.Vote, *Voter) {
 	block := helper.MakeBlock(t, helper.WithBlockView(blockView))
 	expectVote := makeVote(block)
 
-	forks := &mocks.ForksReader{}
+	forks := &mocks.Forks{}
 	forks.On("IsSafeBlock", block).Return(isBlockSafe)
+	forks.On("LockedQC").Return(block.QC)
+	forks.On("HighestQC").Return(block.QC)
 
 	persist := &mocks.Persister{}
-	persist.On("PutVoted", mock.Anything).Return(nil)
+	persist.On("PutSafetyData", mock.Anything).Return(nil)
 
 	signer := &mocks.SignerVerifier{}
 	signer.On("CreateVote", mock.Anything).Return(expectVote, nil)
 
-	voter := New(signer, forks, persist, lastVotedView)
+	voter := New(signer, forks, persist, &model.SafetyData{LastVotedView: lastVotedView})
 	return block, expectVote, voter
 }
 
@@ -128,6 +147,226 @@ func testVotingAgain(t *testing.T) {
 	require.Contains(t, err.Error(), "not above the last voted view")
 }
 
+func testPersistFailure(t *testing.T) {
+	blockView, curView, lastVotedView, isBlockSafe := uint64(3), uint64(3), uint64(2), true
+
+	// create voter
+	block, _, voter := createVoter(t, blockView, lastVotedView, isBlockSafe)
+	persist := &mocks.Persister{}
+	persist.On("PutSafetyData", mock.Anything).Return(fmt.Errorf("database failure"))
+	signer := &mocks.SignerVerifier{}
+	voter.persist = persist
+	voter.signer = signer
+
+	// the vote must not be signed without the safety data being persisted
+	_, err := voter.ProduceVoteIfVotable(block, curView)
+	require.Error(t, err)
+	signer.AssertNotCalled(t, "CreateVote", mock.Anything)
+}
+
+func testPersistedLock(t *testing.T) {
+	lockedBlock := helper.MakeBlock(t, helper.WithBlockView(10))
+	lockedQC := helper.MakeQC(t, helper.WithQCBlock(lockedBlock))
+
+	// after a restart, forks might consider blocks safe which conflict with the lock from before
+	forks := &mocks.Forks{}
+	forks.On("IsSafeBlock", mock.Anything).Return(true)
+	forks.On("LockedQC").Return(helper.MakeQC(t, helper.WithQCView(5)))
+	forks.On("HighestQC").Return(lockedQC)
+	persist := &mocks.Persister{}
+	persist.On("PutSafetyData", mock.Anything).Return(nil)
+	signer := &mocks.SignerVerifier{}
+	signer.On("CreateVote", mock.Anything).Return(func(block *model.Block) *model.Vote { return makeVote(block) }, nil)
+	voter := New(signer, forks, persist, &model.SafetyData{LastVotedView: 11, LockedQC: lockedQC})
+
+	// a block on a fork from below the locked block is not safe
+	conflicting := helper.MakeBlock(t, helper.WithBlockView(12), helper.WithParentBlock(helper.MakeBlock(t, helper.WithBlockView(9))))
+	_, err := voter.ProduceVoteIfVotable(conflicting, 12)
+	require.True(t, model.IsNoVoteError(err))
+	require.Contains(t, err.Error(), "persisted locked QC")
+
+	// a block with a different parent at the view of the locked block is not safe either
+	conflicting = helper.MakeBlock(t, helper.WithBlockView(12), helper.WithParentBlock(helper.MakeBlock(t, helper.WithBlockView(10))))
+	_, err = voter.ProduceVoteIfVotable(conflicting, 12)
+	require.True(t, model.IsNoVoteError(err))
+
+	// a block extending the locked block is safe
+	extending := helper.MakeBlock(t, helper.WithBlockView(12), helper.WithParentBlock(lockedBlock))
+	_, err = voter.ProduceVoteIfVotable(extending, 12)
+	require.NoError(t, err)
+
+	// a block with a newer QC than the locked one is safe, and the persisted lock doesn't go back
+	newer := helper.MakeBlock(t, helper.WithBlockView(13), helper.WithParentBlock(helper.MakeBlock(t, helper.WithBlockView(11))))
+	_, err = voter.ProduceVoteIfVotable(newer, 13)
+	require.NoError(t, err)
+	persist.AssertCalled(t, "PutSafetyData", &model.SafetyData{
+		LastVotedView:    13,
+		LastVotedBlockID: newer.BlockID,
+		LockedQC:         lockedQC,
+		HighestQC:        lockedQC,
+	})
+}
+
+func testVotingAgainAfterRestart(t *testing.T) {
+	blockView, curView, lastVotedView, isBlockSafe := uint64(3), uint64(3), uint64(2), true
+
+	// create voter and keep the persisted safety data
+	block, _, voter := createVoter(t, blockView, lastVotedView, isBlockSafe)
+	var persisted *model.SafetyData
+	persist := &mocks.Persister{}
+	persist.On("PutSafetyData", mock.Anything).Run(func(args mock.Arguments) {
+		persisted = args.Get(0).(*model.SafetyData)
+	}).Return(nil)
+	voter.persist = persist
+
+	// produce vote
+	_, err := voter.ProduceVoteIfVotable(block, curView)
+	require.NoError(t, err)
+	require.Equal(t, curView, persisted.LastVotedView)
+	require.Equal(t, block.BlockID, persisted.LastVotedBlockID)
+
+	// restart the voter with the persisted safety data
+	voter = New(voter.signer, voter.forks, persist, persisted)
+
+	// produce vote for a conflicting block of the same view, which is safe otherwise
+	conflicting := helper.MakeBlock(t, helper.WithBlockView(blockView))
+	conflicting.QC = block.QC
+	voter.forks.(*mocks.Forks).On("IsSafeBlock", conflicting).Return(true)
+	_, err = voter.ProduceVoteIfVotable(conflicting, curView)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not above the last voted view")
+}
+
+func testProposalOK(t *testing.T) {
+	block, _, voter := createVoter(t, 3, 2, true)
+	persisted := false
+	persist := &mocks.Persister{}
+	persist.On("PutSafetyData", mock.Anything).Run(func(args mock.Arguments) {
+		persisted = true
+	}).Return(nil)
+	voter.persist = persist
+	expectProposal := &model.Proposal{Block: block}
+	signer := &mocks.SignerVerifier{}
+	signer.On("CreateProposal", block).Run(func(args mock.Arguments) {
+		require.True(t, persisted, "proposal signed before safety data was persisted")
+	}).Return(expectProposal, nil)
+	voter.signer = signer
+
+	proposal, err := voter.ProduceProposal(block, 3)
+	require.NoError(t, err)
+	require.Equal(t, expectProposal, proposal)
+	persist.AssertCalled(t, "PutSafetyData", &model.SafetyData{
+		LastVotedView:    3,
+		LastVotedBlockID: block.BlockID,
+		LockedQC:         block.QC,
+		HighestQC:        block.QC,
+	})
+}
+
+func testProposalNotCurView(t *testing.T) {
+	block, _, voter := createVoter(t, 3, 2, true)
+	signer := &mocks.SignerVerifier{}
+	voter.signer = signer
+
+	_, err := voter.ProduceProposal(block, 4)
+	require.True(t, model.IsNoVoteError(err))
+	signer.AssertNotCalled(t, "CreateProposal", mock.Anything)
+}
+
+func testProposalVotedView(t *testing.T) {
+	block, _, voter := createVoter(t, 3, 3, true)
+	signer := &mocks.SignerVerifier{}
+	voter.signer = signer
+
+	_, err := voter.ProduceProposal(block, 3)
+	require.True(t, model.IsNoVoteError(err))
+	signer.AssertNotCalled(t, "CreateProposal", mock.Anything)
+	voter.persist.(*mocks.Persister).AssertNotCalled(t, "PutSafetyData", mock.Anything)
+}
+
+func testProposalPersistFailure(t *testing.T) {
+	block, _, voter := createVoter(t, 3, 2, true)
+	persist := &mocks.Persister{}
+	persist.On("PutSafetyData", mock.Anything).Return(fmt.Errorf("database failure"))
+	signer := &mocks.SignerVerifier{}
+	voter.persist = persist
+	voter.signer = signer
+
+	// the proposal must not be signed without the safety data being persisted
+	_, err := voter.ProduceProposal(block, 3)
+	require.Error(t, err)
+	require.False(t, model.IsNoVoteError(err))
+	signer.AssertNotCalled(t, "CreateProposal", mock.Anything)
+}
+
+func testProposingAgainAfterRestart(t *testing.T) {
+	block, _, voter := createVoter(t, 3, 2, true)
+	var persisted *model.SafetyData
+	persist := &mocks.Persister{}
+	persist.On("PutSafetyData", mock.Anything).Run(func(args mock.Arguments) {
+		persisted = args.Get(0).(*model.SafetyData)
+	}).Return(nil)
+	voter.persist = persist
+	voter.signer.(*mocks.SignerVerifier).On("CreateProposal", mock.Anything).Return(&model.Proposal{Block: block}, nil)
+
+	// propose, then crash before the proposal is broadcast
+	_, err := voter.ProduceProposal(block, 3)
+	require.NoError(t, err)
+
+	// restart the voter with the persisted safety data
+	voter = New(voter.signer, voter.forks, persist, persisted)
+
+	// a different proposal for the same view is not signed
+	conflicting := helper.MakeBlock(t, helper.WithBlockView(3))
+	conflicting.QC = block.QC
+	_, err = voter.ProduceProposal(conflicting, 3)
+	require.True(t, model.IsNoVoteError(err))
+
+	// neither is a vote for a conflicting block of the view
+	voter.forks.(*mocks.Forks).On("IsSafeBlock", conflicting).Return(true)
+	_, err = voter.ProduceVoteIfVotable(conflicting, 3)
+	require.True(t, model.IsNoVoteError(err))
+	voter.signer.(*mocks.SignerVerifier).AssertNumberOfCalls(t, "CreateProposal", 1)
+}
+
+func testTimeoutNewerQC(t *testing.T) {
+	_, _, voter := createVoter(t, 3, 2, true)
+	persist := &mocks.Persister{}
+	persist.On("PutSafetyData", mock.Anything).Return(nil)
+	voter.persist = persist
+	voter.safetyData.HighestQC = helper.MakeQC(t, helper.WithQCView(1))
+	highestQC := helper.MakeQC(t, helper.WithQCView(2))
+	signer := &mocks.SignerVerifier{}
+	signer.On("CreateTimeout", mock.Anything, highestQC).Return(&model.Timeout{View: 3, HighestQC: highestQC}, nil)
+	voter.signer = signer
+
+	timeout, err := voter.ProduceTimeout(3, highestQC)
+	require.NoError(t, err)
+	require.Equal(t, highestQC, timeout.HighestQC)
+	persist.AssertCalled(t, "PutSafetyData", &model.SafetyData{LastVotedView: 2, HighestQC: highestQC})
+
+	// the same QC is not persisted again
+	_, err = voter.ProduceTimeout(4, highestQC)
+	require.NoError(t, err)
+	persist.AssertNumberOfCalls(t, "PutSafetyData", 1)
+}
+
+func testTimeoutPersistedQC(t *testing.T) {
+	_, _, voter := createVoter(t, 3, 2, true)
+	persist := &mocks.Persister{}
+	voter.persist = persist
+	persistedQC := helper.MakeQC(t, helper.WithQCView(2))
+	voter.safetyData.HighestQC = persistedQC
+	signer := &mocks.SignerVerifier{}
+	signer.On("CreateTimeout", uint64(3), persistedQC).Return(&model.Timeout{View: 3, HighestQC: persistedQC}, nil)
+	voter.signer = signer
+
+	timeout, err := voter.ProduceTimeout(3, helper.MakeQC(t, helper.WithQCView(1)))
+	require.NoError(t, err)
+	require.Equal(t, persistedQC, timeout.HighestQC)
+	persist.AssertNotCalled(t, "PutSafetyData", mock.Anything)
+}
+
 func makeVote(block *model.Block) *model.Vote {
 	return &model.Vote{
 		BlockID: block.BlockID,
@@ -45,6 +45,7 @@ func NewParticipant(
 
 	// initialize the default configuration
 	defTimeout := timeout.DefaultConfig
+	defBlockRate := timeout.DefaultBlockRateConfig
 	cfg := ParticipantConfig{
 		TimeoutInitial:             time.Duration(defTimeout.ReplicaTimeout) * time.Millisecond,
 		TimeoutMinimum:             time.Duration(defTimeout.MinReplicaTimeout) * time.Millisecond,
@@ -52,6 +53,9 @@ func NewParticipant(
 		TimeoutIncreaseFactor:      defTimeout.TimeoutIncrease,
 		TimeoutDecreaseFactor:      defTimeout.TimeoutDecrease,
 		BlockRateDelay:             time.Duration(defTimeout.BlockRateDelayMS) * time.Millisecond,
+		BlockRateMinDelay:          time.Duration(defBlockRate.MinDelay) * time.Millisecond,
+		BlockRateMaxDelay:          time.Duration(defBlockRate.MaxDelay) * time.Millisecond,
+		BlockRateGain:              defBlockRate.Gain,
 	}
 
 	// apply the configuration options
@@ -77,10 +81,10 @@ func NewParticipant(
 		return nil, fmt.Errorf("could not recover last started: %w", err)
 	}
 
-	// get the last view we voted
-	voted, err := persist.GetVoted()
+	// get the last view we voted, with the block we voted for and the QCs at the time
+	safetyData, err := persist.GetSafetyData()
 	if err != nil {
-		return nil, fmt.Errorf("could not recover last voted: %w", err)
+		return nil, fmt.Errorf("could not recover safety data: %w", err)
 	}
 
 	// initialize the vote aggregator
@@ -106,22 +110,44 @@ func NewParticipant(
 		return nil, fmt.Errorf("could not initialize timeout config: %w", err)
 	}
 
-	// initialize the pacemaker
+	// initialize the timeout controller; if a target view duration is configured,
+	// the block rate delay is adjusted at runtime within the configured bounds
 	controller := timeout.NewController(timeoutConfig)
+	if cfg.BlockRateTarget > 0 {
+		blockRateConfig, err := timeout.NewBlockRateConfig(
+			cfg.BlockRateTarget,
+			cfg.BlockRateMinDelay,
+			cfg.BlockRateMaxDelay,
+			cfg.BlockRateGain,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("could not initialize block rate config: %w", err)
+		}
+		blockRate := timeout.NewBlockRateController(blockRateConfig, cfg.BlockRateDelay, metrics)
+		if !cfg.EpochEndTime.IsZero() {
+			blockRate.SetEpochTarget(cfg.EpochFinalView, cfg.EpochEndTime)
+		}
+		if cfg.EpochTargets != nil {
+			cfg.EpochTargets.attach(blockRate)
+		}
+		controller = timeout.NewAdaptiveController(timeoutConfig, blockRate)
+	}
+
+	// initialize the pacemaker
 	pacemaker, err := pacemaker.New(started+1, controller, notifier)
 	if err != nil {
 		return nil, fmt.Errorf("could not initialize flow pacemaker: %w", err)
 	}
 
+	// initialize the voter
+	voter := voter.New(signer, forks, persist, safetyData)
+
 	// initialize block producer
-	producer, err := blockproducer.New(signer, committee, builder)
+	producer, err := blockproducer.New(voter, committee, builder)
 	if err != nil {
 		return nil, fmt.Errorf("could not initialize block producer: %w", err)
 	}
 
-	// initialize the voter
-	voter := voter.New(signer, forks, persist, voted)
-
 	// initialize the event handler
 	handler, err := eventhandler.New(log, pacemaker, producer, forks, persist, communicator, committee, aggregator, voter, validator, notifier)
 	if err != nil {
@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/onflow/flow-go/access"
+	accessext "github.com/onflow/flow-go/access/protobuf"
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/engine"
@@ -26,6 +27,7 @@ import (
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
 	factorymock "github.com/onflow/flow-go/engine/access/rpc/backend/mock"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	executionext "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
@@ -48,6 +50,7 @@ type Suite struct {
 	request    *module.Requester
 	collClient *accessmock.AccessAPIClient
 	execClient *accessmock.ExecutionAPIClient
+	extClient  *accessmock.ExecutionExtensionAPIClient
 	me         *module.Local
 	chainID    flow.ChainID
 	metrics    *metrics.NoopCollector
@@ -73,6 +76,7 @@ func (suite *Suite) SetupTest() {
 
 	suite.collClient = new(accessmock.AccessAPIClient)
 	suite.execClient = new(accessmock.ExecutionAPIClient)
+	suite.extClient = new(accessmock.ExecutionExtensionAPIClient)
 
 	suite.request = new(module.Requester)
 	suite.request.On("EntityByID", mock.Anything, mock.Anything)
@@ -99,16 +103,22 @@ func (suite *Suite) RunTest(
 		suite.backend = backend.New(
 			suite.state,
 			suite.execClient,
+			suite.extClient,
 			suite.collClient,
 			blocks,
 			headers,
 			collections,
 			transactions,
+			nil,
 			suite.chainID,
 			suite.metrics,
 			uint(9000),
+			0,
 			nil,
 			false,
+			0,
+			suite.metrics,
+			suite.log,
 		)
 
 		handler := access.NewHandler(suite.backend, suite.chainID.Chain())
@@ -277,16 +287,22 @@ func (suite *Suite) TestSendTransactionToRandomCollectionNode() {
 		backend := backend.New(
 			suite.state,
 			nil,
+			nil,
 			nil, // setting collectionRPC to nil to choose a random collection node for each send tx request
 			nil,
 			nil,
 			collections,
 			transactions,
+			nil,
 			suite.chainID,
 			metrics,
 			collectionGrpcPort,
+			0,
 			connFactory, // passing in the connection factory
 			false,
+			0,
+			metrics,
+			suite.log,
 		)
 
 		handler := access.NewHandler(backend, suite.chainID.Chain())
@@ -428,9 +444,11 @@ func (suite *Suite) TestGetSealedTransaction() {
 		suite.snapshot.On("Identities", mock.Anything).Return(colIdentities, nil).Once()
 
 		exeEventResp := execproto.GetTransactionResultResponse{
-			Events: nil,
+			Events:       nil,
+			StatusCode:   flow.TransactionErrCodeInvalidProposalKeySequenceNumber,
+			ErrorMessage: "invalid proposal key sequence number",
 		}
-		// assume execution node returns an empty list of events
+		// assume execution node returns an empty list of events and a failure
 		suite.execClient.On("GetTransactionResult", mock.Anything, mock.Anything).Return(&exeEventResp, nil)
 
 		// initialize storage
@@ -444,8 +462,8 @@ func (suite *Suite) TestGetSealedTransaction() {
 		blocksToMarkExecuted, err := stdmap.NewTimes(100)
 		require.NoError(suite.T(), err)
 
-		rpcEng := rpc.New(suite.log, suite.state, rpc.Config{}, nil, nil, blocks, headers, collections, transactions,
-			suite.chainID, metrics, 0, false)
+		rpcEng := rpc.New(suite.log, suite.state, rpc.Config{}, nil, nil, nil, blocks, headers, collections, transactions, nil,
+			suite.chainID, metrics, metrics, 0, 0, false)
 
 		// create the ingest engine
 		ingestEng, err := ingestion.New(suite.log, suite.net, suite.state, suite.me, suite.request, blocks, headers, collections,
@@ -481,6 +499,13 @@ func (suite *Suite) TestGetSealedTransaction() {
 		require.NoError(suite.T(), err)
 		// assert that the transaction is reported as Sealed
 		require.Equal(suite.T(), entitiesproto.TransactionStatus_SEALED, gResp.GetStatus())
+
+		// 6. client requests the transaction result with the error category
+		suite.snapshot.On("Head").Return(block.Header, nil).Once()
+		cResp, err := handler.GetTransactionResultWithCategory(context.Background(), getReq)
+		require.NoError(suite.T(), err)
+		require.Equal(suite.T(), gResp, cResp.GetResult())
+		require.Equal(suite.T(), "sequence_number", cResp.GetErrorCategory())
 	})
 }
 
@@ -569,6 +594,51 @@ func (suite *Suite) TestExecuteScript() {
 	})
 }
 
+// TestEstimateTransaction tests that transaction estimation requests are forwarded to the extension
+// service of execution nodes, and their results returned
+func (suite *Suite) TestEstimateTransaction() {
+	suite.RunTest(func(handler *access.Handler, db *badger.DB, blocks *storage.Blocks, headers *storage.Headers) {
+
+		block := unittest.BlockFixture()
+		err := blocks.Store(&block)
+		require.NoError(suite.T(), err)
+		blockID := block.ID()
+
+		ctx := context.Background()
+		tx := unittest.TransactionBodyFixture()
+		txMsg := convert.TransactionToMessage(tx)
+		event := unittest.EventFixture(flow.EventAccountCreated, 0, 0, tx.ID())
+
+		executionReq := &executionext.EstimateTransactionRequest{
+			Transaction:             txMsg,
+			BlockId:                 blockID[:],
+			SkipSequenceNumberCheck: true,
+		}
+		executionResp := &executionext.EstimateTransactionResponse{
+			ComputationUsed: 42,
+			Events:          convert.EventsToMessages([]flow.Event{event}),
+			Logs:            []string{"estimated"},
+		}
+		suite.extClient.On("EstimateTransaction", ctx, executionReq).Return(executionResp, nil).Once()
+
+		req := &accessext.EstimateTransactionRequest{
+			Transaction:             txMsg,
+			BlockId:                 blockID[:],
+			SkipSequenceNumberCheck: true,
+		}
+		resp, err := handler.EstimateTransaction(ctx, req)
+		require.NoError(suite.T(), err)
+
+		expected := &accessext.EstimateTransactionResponse{
+			ComputationUsed: executionResp.ComputationUsed,
+			Events:          executionResp.Events,
+			Logs:            executionResp.Logs,
+		}
+		suite.Require().Equal(expected, resp)
+		suite.extClient.AssertExpectations(suite.T())
+	})
+}
+
 func (suite *Suite) createChain() (flow.Block, flow.Collection) {
 	collection := unittest.CollectionFixture(10)
 	guarantee := &flow.CollectionGuarantee{
@@ -251,6 +251,9 @@ func (e *Engine) trackFinalizedMetricForBlock(hb *model.Block) {
 }
 
 func (e *Engine) handleExecutionReceipt(originID flow.Identifier, r *flow.ExecutionReceipt) error {
+	// Notify rpc handler of the new execution receipt
+	e.rpcEngine.SubmitLocal(r)
+
 	e.trackExecutedMetricForReceipt(r)
 	return nil
 }
@@ -7,10 +7,12 @@ import (
 
 	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/rs/zerolog"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-go/access"
+	executionext "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/state/protocol"
@@ -41,35 +43,59 @@ type Backend struct {
 	state        protocol.State
 	chainID      flow.ChainID
 	collections  storage.Collections
+	updates      *broadcaster
+	executors    *executors // execution nodes which produced receipts for recent blocks
 }
 
 func New(
 	state protocol.State,
 	executionRPC execproto.ExecutionAPIClient,
+	executionExtRPC executionext.ExecutionExtensionAPIClient,
 	collectionRPC accessproto.AccessAPIClient,
 	blocks storage.Blocks,
 	headers storage.Headers,
 	collections storage.Collections,
 	transactions storage.Transactions,
+	seals storage.Seals,
 	chainID flow.ChainID,
 	transactionMetrics module.TransactionMetrics,
 	collectionGRPCPort uint,
+	executionGRPCPort uint,
 	connFactory ConnectionFactory,
 	retryEnabled bool,
+	scriptCrossCheck uint,
+	scriptMetrics module.ScriptMetrics,
+	log zerolog.Logger,
 ) *Backend {
 	retry := newRetry()
 	if retryEnabled {
 		retry.Activate()
 	}
 
+	// shared by all subscriptions, notified on every new finalized block and execution receipt
+	updates := newBroadcaster()
+
+	// shared by the sub-backends forwarding scripts and account queries to execution nodes
+	executionNodes := &executionNodes{
+		staticExecutionRPC:    executionRPC,
+		staticExecutionExtRPC: executionExtRPC,
+		state:                 state,
+		executionGRPCPort:     executionGRPCPort,
+		connFactory:           connFactory,
+		executors:             newExecutors(),
+	}
+
 	b := &Backend{
 		executionRPC: executionRPC,
 		state:        state,
 		// create the sub-backends
 		backendScripts: backendScripts{
-			headers:      headers,
-			executionRPC: executionRPC,
-			state:        state,
+			headers:        headers,
+			executionNodes: executionNodes,
+			state:          state,
+			crossCheck:     scriptCrossCheck,
+			metrics:        scriptMetrics,
+			log:            log,
 		},
 		backendTransactions: backendTransactions{
 			staticCollectionRPC:  collectionRPC,
@@ -84,27 +110,34 @@ func New(
 			retry:                retry,
 			collectionGRPCPort:   collectionGRPCPort,
 			connFactory:          connFactory,
+			updates:              updates,
+			executionNodes:       executionNodes,
 		},
 		backendEvents: backendEvents{
 			executionRPC: executionRPC,
 			state:        state,
 			blocks:       blocks,
+			updates:      updates,
 		},
 		backendBlockHeaders: backendBlockHeaders{
 			headers: headers,
 			state:   state,
 		},
 		backendBlockDetails: backendBlockDetails{
-			blocks: blocks,
-			state:  state,
+			blocks:  blocks,
+			state:   state,
+			updates: updates,
 		},
 		backendAccounts: backendAccounts{
-			executionRPC: executionRPC,
-			state:        state,
-			headers:      headers,
+			executionNodes: executionNodes,
+			state:          state,
+			headers:        headers,
+			seals:          seals,
 		},
+		executors:   executionNodes.executors,
 		collections: collections,
 		chainID:     chainID,
+		updates:     updates,
 	}
 
 	retry.SetBackend(b)
@@ -157,6 +190,18 @@ func (b *Backend) GetCollectionByID(_ context.Context, colID flow.Identifier) (*
 	return col, nil
 }
 
+// NotifyFinalizedBlockHeight is called when a new block has been finalized.
+func (b *Backend) NotifyFinalizedBlockHeight(height uint64) {
+	b.backendTransactions.NotifyFinalizedBlockHeight(height)
+	b.updates.Publish()
+}
+
+// NotifyExecutionReceipt is called when an execution receipt has been received.
+func (b *Backend) NotifyExecutionReceipt(receipt *flow.ExecutionReceipt) {
+	b.executors.Add(receipt.ExecutionResult.BlockID, receipt.ExecutorID)
+	b.updates.Publish()
+}
+
 func (b *Backend) GetNetworkParameters(_ context.Context) access.NetworkParameters {
 	return access.NetworkParameters{
 		ChainID: b.chainID,
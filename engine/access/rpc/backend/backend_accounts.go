@@ -7,16 +7,22 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/verifier"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	executionext "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 )
 
 type backendAccounts struct {
-	state        protocol.State
-	executionRPC execproto.ExecutionAPIClient
-	headers      storage.Headers
+	state          protocol.State
+	executionNodes *executionNodes
+	headers        storage.Headers
+	seals          storage.Seals
 }
 
 func (b *backendAccounts) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
@@ -76,7 +82,12 @@ func (b *backendAccounts) getAccountAtBlockID(
 		BlockId: blockID[:],
 	}
 
-	exeRes, err := b.executionRPC.GetAccountAtBlockID(ctx, &exeReq)
+	var exeRes *execproto.GetAccountAtBlockIDResponse
+	err := b.executionNodes.execute(ctx, func(client execproto.ExecutionAPIClient) error {
+		var err error
+		exeRes, err = client.GetAccountAtBlockID(ctx, &exeReq)
+		return err
+	})
 	if err != nil {
 		errStatus, _ := status.FromError(err)
 		if errStatus.Code() == codes.NotFound {
@@ -93,3 +104,143 @@ func (b *backendAccounts) getAccountAtBlockID(
 
 	return account, nil
 }
+
+// GetAccountWithProof returns the account decoded from the execution state sealed as of the given
+// block, together with a proof of the registers it is decoded from against the sealed state commitment.
+func (b *backendAccounts) GetAccountWithProof(
+	ctx context.Context,
+	address flow.Address,
+	blockID flow.Identifier,
+) (*access.AccountWithProof, error) {
+
+	seal, err := b.seals.ByBlockID(blockID)
+	if err != nil {
+		return nil, convertStorageError(err)
+	}
+
+	req := &executionext.GetAccountWithProofRequest{
+		Address: address.Bytes(),
+		BlockId: seal.BlockID[:],
+	}
+
+	var result *access.AccountWithProof
+	err = b.executionNodes.executeExtension(ctx, func(client executionext.ExecutionExtensionAPIClient) error {
+		res, err := client.GetAccountWithProof(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		account, err := convert.MessageToAccount(res.GetAccount())
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "execution node returned invalid account: %v", err)
+		}
+		proof, err := encoding.DecodeTrieBatchProof(res.GetProof())
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "execution node returned invalid proof: %v", err)
+		}
+		registerIDs := convert.MessagesToRegisterIDs(res.GetRegisterIds())
+
+		candidate := &access.AccountWithProof{
+			Account:            account,
+			RegistersWithProof: *registersWithProof(blockID, seal, registerIDs, proof),
+		}
+
+		// do not pass on invalid proofs, but try the next execution node instead
+		err = verifier.VerifyAccount(seal.FinalState, address, candidate)
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "execution node returned invalid proof: %v", err)
+		}
+
+		result = candidate
+		return nil
+	})
+	if err != nil {
+		return nil, convertProofError(err)
+	}
+
+	return result, nil
+}
+
+// GetRegistersWithProof returns the values of the given registers in the execution state sealed as of
+// the given block, together with a proof of the values against the sealed state commitment.
+func (b *backendAccounts) GetRegistersWithProof(
+	ctx context.Context,
+	blockID flow.Identifier,
+	registerIDs []flow.RegisterID,
+) (*access.RegistersWithProof, error) {
+
+	seal, err := b.seals.ByBlockID(blockID)
+	if err != nil {
+		return nil, convertStorageError(err)
+	}
+
+	req := &executionext.GetRegistersWithProofRequest{
+		BlockId:     seal.BlockID[:],
+		RegisterIds: convert.RegisterIDsToMessages(registerIDs),
+	}
+
+	var result *access.RegistersWithProof
+	err = b.executionNodes.executeExtension(ctx, func(client executionext.ExecutionExtensionAPIClient) error {
+		res, err := client.GetRegistersWithProof(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		proof, err := encoding.DecodeTrieBatchProof(res.GetProof())
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "execution node returned invalid proof: %v", err)
+		}
+
+		candidate := registersWithProof(blockID, seal, registerIDs, proof)
+
+		// do not pass on invalid proofs, but try the next execution node instead
+		err = verifier.VerifyRegisters(seal.FinalState, candidate)
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "execution node returned invalid proof: %v", err)
+		}
+
+		result = candidate
+		return nil
+	})
+	if err != nil {
+		return nil, convertProofError(err)
+	}
+
+	return result, nil
+}
+
+// registersWithProof assembles the registers proven against the given seal, taking the register values
+// from the proven payloads.
+func registersWithProof(
+	blockID flow.Identifier,
+	seal *flow.Seal,
+	registerIDs []flow.RegisterID,
+	proof *ledger.TrieBatchProof,
+) *access.RegistersWithProof {
+
+	values := make([]flow.RegisterValue, 0, len(registerIDs))
+	if proof != nil {
+		for _, p := range proof.Proofs {
+			values = append(values, p.Payload.Value)
+		}
+	}
+
+	return &access.RegistersWithProof{
+		BlockID:       blockID,
+		SealedBlockID: seal.BlockID,
+		Commitment:    seal.FinalState,
+		RegisterIDs:   registerIDs,
+		Values:        values,
+		Proof:         proof,
+	}
+}
+
+// convertProofError passes on errors about the request itself and reports all other errors of
+// proof requests as internal errors.
+func convertProofError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound, codes.InvalidArgument, codes.Unimplemented:
+		return err
+	}
+	return status.Errorf(codes.Internal, "failed to get proof from the execution node: %v", err)
+}
@@ -3,14 +3,18 @@ package backend
 import (
 	"context"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 )
 
 type backendBlockDetails struct {
-	blocks storage.Blocks
-	state  protocol.State
+	blocks  storage.Blocks
+	state   protocol.State
+	updates *broadcaster
 }
 
 func (b *backendBlockDetails) GetLatestBlock(_ context.Context, isSealed bool) (*flow.Block, error) {
@@ -58,3 +62,66 @@ func (b *backendBlockDetails) GetBlockByHeight(_ context.Context, height uint64)
 
 	return block, nil
 }
+
+// SubscribeBlocks streams every finalized (or sealed, if isSealed is set) block, in order of height,
+// starting at the given height. Since every block is delivered exactly once, a client that gets
+// disconnected can resume the stream at the height following the last block it received.
+// The returned channel is closed when the context is cancelled.
+func (b *backendBlockDetails) SubscribeBlocks(
+	ctx context.Context,
+	startHeight uint64,
+	isSealed bool,
+) (<-chan *flow.Block, error) {
+	root, err := b.state.Params().Root()
+	if err != nil {
+		return nil, convertStorageError(err)
+	}
+	if startHeight < root.Height {
+		return nil, status.Errorf(codes.InvalidArgument, "start height %d is below the root height %d", startHeight, root.Height)
+	}
+
+	// subscribe before the first lookup, so that no update can be missed in between
+	notify, unsubscribe := b.updates.Subscribe()
+
+	blocks := make(chan *flow.Block)
+
+	go func() {
+		defer close(blocks)
+		defer unsubscribe()
+
+		next := startHeight
+		for {
+			var head *flow.Header
+			var err error
+			if isSealed {
+				head, err = b.state.Sealed().Head()
+			} else {
+				head, err = b.state.Final().Head()
+			}
+
+			// errors are treated as transient, the lookup is retried on the next update
+			if err == nil {
+				for ; next <= head.Height; next++ {
+					block, err := b.blocks.ByHeight(next)
+					if err != nil {
+						break
+					}
+
+					select {
+					case blocks <- block:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			select {
+			case <-notify:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return blocks, nil
+}
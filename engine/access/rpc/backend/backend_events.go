@@ -5,11 +5,13 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
 
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/state/protocol"
@@ -20,6 +22,7 @@ type backendEvents struct {
 	executionRPC execproto.ExecutionAPIClient
 	blocks       storage.Blocks
 	state        protocol.State
+	updates      *broadcaster
 }
 
 // GetEventsForHeightRange retrieves events for all sealed blocks between the start block height and
@@ -82,6 +85,125 @@ func (b *backendEvents) GetEventsForBlockIDs(
 	return b.getBlockEventsFromExecutionNode(ctx, blockHeaders, eventType)
 }
 
+// SubscribeEvents streams the events matching the given filter for every sealed block, in order of
+// height, starting at the given height. A result is delivered for every block, even if it contains
+// no matching events, so that a client that gets disconnected can resume the stream at the height
+// following the last result it received. The returned channel is closed when the context is cancelled.
+func (b *backendEvents) SubscribeEvents(
+	ctx context.Context,
+	filter access.EventFilter,
+	startHeight uint64,
+) (<-chan flow.BlockEvents, error) {
+	if len(filter.EventTypes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one event type is required")
+	}
+
+	// query each event type only once, even if it is listed repeatedly
+	eventTypes := make([]flow.EventType, 0, len(filter.EventTypes))
+	requested := make(map[flow.EventType]struct{}, len(filter.EventTypes))
+	for _, eventType := range filter.EventTypes {
+		_, err := convert.EventType(string(eventType))
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := requested[eventType]; ok {
+			continue
+		}
+		requested[eventType] = struct{}{}
+		eventTypes = append(eventTypes, eventType)
+	}
+	filter.EventTypes = eventTypes
+
+	root, err := b.state.Params().Root()
+	if err != nil {
+		return nil, convertStorageError(err)
+	}
+	if startHeight < root.Height {
+		return nil, status.Errorf(codes.InvalidArgument, "start height %d is below the root height %d", startHeight, root.Height)
+	}
+
+	// subscribe before the first lookup, so that no update can be missed in between
+	notify, unsubscribe := b.updates.Subscribe()
+
+	results := make(chan flow.BlockEvents)
+
+	go func() {
+		defer close(results)
+		defer unsubscribe()
+
+		next := startHeight
+		for {
+			// errors are treated as transient, the lookup is retried on the next update
+			head, err := b.state.Sealed().Head()
+			if err == nil {
+				for ; next <= head.Height; next++ {
+					result, err := b.getFilteredBlockEvents(ctx, next, filter)
+					if err != nil {
+						break
+					}
+
+					select {
+					case results <- result:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			select {
+			case <-notify:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return results, nil
+}
+
+// getFilteredBlockEvents retrieves the events of the block at the given height that match the filter,
+// in the order in which they were emitted.
+func (b *backendEvents) getFilteredBlockEvents(
+	ctx context.Context,
+	height uint64,
+	filter access.EventFilter,
+) (flow.BlockEvents, error) {
+
+	block, err := b.blocks.ByHeight(height)
+	if err != nil {
+		return flow.BlockEvents{}, err
+	}
+
+	result := flow.BlockEvents{
+		BlockID:        block.ID(),
+		BlockHeight:    block.Header.Height,
+		BlockTimestamp: block.Header.Timestamp,
+	}
+
+	// the execution node only supports querying one event type at a time
+	for _, eventType := range filter.EventTypes {
+		typeResults, err := b.getBlockEventsFromExecutionNode(ctx, []*flow.Header{block.Header}, string(eventType))
+		if err != nil {
+			return flow.BlockEvents{}, err
+		}
+
+		for _, event := range typeResults[0].Events {
+			if filter.Match(event) {
+				result.Events = append(result.Events, event)
+			}
+		}
+	}
+
+	sort.Slice(result.Events, func(i, j int) bool {
+		if result.Events[i].TransactionIndex != result.Events[j].TransactionIndex {
+			return result.Events[i].TransactionIndex < result.Events[j].TransactionIndex
+		}
+		return result.Events[i].EventIndex < result.Events[j].EventIndex
+	})
+
+	return result, nil
+}
+
 func (b *backendEvents) getBlockEventsFromExecutionNode(
 	ctx context.Context,
 	blockHeaders []*flow.Header,
@@ -1,21 +1,30 @@
 package backend
 
 import (
+	"bytes"
 	"context"
+	"sync"
 
+	"github.com/hashicorp/go-multierror"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/rs/zerolog"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/logging"
 )
 
 type backendScripts struct {
-	headers      storage.Headers
-	state        protocol.State
-	executionRPC execproto.ExecutionAPIClient
+	headers        storage.Headers
+	state          protocol.State
+	executionNodes *executionNodes
+	crossCheck     uint // number of execution nodes scripts are executed on, cross-checking is disabled below 2
+	metrics        module.ScriptMetrics
+	log            zerolog.Logger
 }
 
 func (b *backendScripts) ExecuteScriptAtLatestBlock(
@@ -66,7 +75,7 @@ func (b *backendScripts) ExecuteScriptAtBlockHeight(
 	return b.executeScriptOnExecutionNode(ctx, blockID, script, arguments)
 }
 
-// executeScriptOnExecutionNode forwards the request to the execution node using the execution node
+// executeScriptOnExecutionNode forwards the request to an execution node using the execution node
 // grpc client and converts the response back to the access node api response format
 func (b *backendScripts) executeScriptOnExecutionNode(
 	ctx context.Context,
@@ -81,10 +90,131 @@ func (b *backendScripts) executeScriptOnExecutionNode(
 		Arguments: arguments,
 	}
 
-	execResp, err := b.executionRPC.ExecuteScriptAtBlockID(ctx, &execReq)
+	// cross-checking requires choosing execution nodes, instead of a fixed one
+	if b.crossCheck > 1 && b.executionNodes.executionGRPCPort != 0 {
+		return b.executeScriptCrossChecked(ctx, blockID, &execReq)
+	}
+
+	var execResp *execproto.ExecuteScriptAtBlockIDResponse
+	err := b.executionNodes.execute(ctx, func(client execproto.ExecutionAPIClient) error {
+		var err error
+		execResp, err = client.ExecuteScriptAtBlockID(ctx, &execReq)
+		return err
+	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to execute the script on the execution node: %v", err)
 	}
 
 	return execResp.GetValue(), nil
 }
+
+// scriptResult is a script result and the execution nodes which returned it.
+type scriptResult struct {
+	value       []byte
+	executorIDs []flow.Identifier
+}
+
+// executeScriptCrossChecked executes the script on multiple execution nodes,
+// preferably those which produced receipts for the block, and returns the
+// result of the majority of the nodes which responded. Nodes which returned a
+// different result are reported as divergent. If there is no majority, or the
+// majority consists of a single node while more nodes were queried, no result
+// is returned, as it can not be told apart from the result of a faulty node.
+func (b *backendScripts) executeScriptCrossChecked(
+	ctx context.Context,
+	blockID flow.Identifier,
+	execReq *execproto.ExecuteScriptAtBlockIDRequest,
+) ([]byte, error) {
+
+	var mu sync.Mutex
+	values := make(map[flow.Identifier][]byte)
+	nodes, errs, err := b.executionNodes.executeOnEach(blockID, b.crossCheck, func(nodeID flow.Identifier, client execproto.ExecutionAPIClient) error {
+		execResp, err := client.ExecuteScriptAtBlockID(ctx, execReq)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		values[nodeID] = execResp.GetValue()
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// group the nodes by result, in the order the nodes were chosen
+	var results []*scriptResult
+	var failures error
+	responded := 0
+	for i, node := range nodes {
+		if errs[i] != nil {
+			failures = multierror.Append(failures, errs[i])
+			continue
+		}
+		responded++
+
+		value := values[node.nodeID]
+		var result *scriptResult
+		for _, r := range results {
+			if bytes.Equal(r.value, value) {
+				result = r
+				break
+			}
+		}
+		if result == nil {
+			result = &scriptResult{value: value}
+			results = append(results, result)
+		}
+		result.executorIDs = append(result.executorIDs, node.nodeID)
+	}
+
+	if responded == 0 {
+		return nil, status.Errorf(codes.Internal, "failed to execute the script on the execution nodes: %v", failures)
+	}
+
+	majority := results[0]
+	for _, r := range results[1:] {
+		if len(r.executorIDs) > len(majority.executorIDs) {
+			majority = r
+		}
+	}
+	if 2*len(majority.executorIDs) <= responded {
+		b.metrics.ScriptResultUndecided()
+		b.log.Warn().
+			Hex("block_id", logging.ID(blockID)).
+			Int("responded", responded).
+			Int("results", len(results)).
+			Msg("execution nodes have no majority script result")
+		return nil, status.Errorf(codes.Internal, "execution nodes returned conflicting script results")
+	}
+
+	// the result must be confirmed by a second node, unless a single node was queried
+	required := 2
+	if len(nodes) < required {
+		required = len(nodes)
+	}
+	if len(majority.executorIDs) < required {
+		b.metrics.ScriptResultUndecided()
+		b.log.Warn().
+			Hex("block_id", logging.ID(blockID)).
+			Int("queried", len(nodes)).
+			Int("responded", responded).
+			Msg("script result is not confirmed by enough execution nodes")
+		return nil, status.Errorf(codes.Unavailable, "script result is not confirmed by enough execution nodes: %v", failures)
+	}
+
+	for _, r := range results {
+		if r == majority {
+			continue
+		}
+		for _, executorID := range r.executorIDs {
+			b.metrics.ScriptResultDivergent(executorID)
+			b.log.Warn().
+				Hex("block_id", logging.ID(blockID)).
+				Hex("executor_id", logging.ID(executorID)).
+				Msg("execution node returned divergent script result")
+		}
+	}
+
+	return majority.value, nil
+}
@@ -2,6 +2,7 @@ package backend
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"testing"
 	"time"
@@ -15,10 +16,17 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
-	access "github.com/onflow/flow-go/engine/access/mock"
+	"github.com/onflow/flow-go/access"
+	accessmock "github.com/onflow/flow-go/engine/access/mock"
+	backendmock "github.com/onflow/flow-go/engine/access/rpc/backend/mock"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	executionext "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+	executionState "github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
+	modulemock "github.com/onflow/flow-go/module/mock"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
 	"github.com/onflow/flow-go/storage"
 	storagemock "github.com/onflow/flow-go/storage/mock"
@@ -36,8 +44,8 @@ type Suite struct {
 	headers      *storagemock.Headers
 	collections  *storagemock.Collections
 	transactions *storagemock.Transactions
-	colClient    *access.AccessAPIClient
-	execClient   *access.ExecutionAPIClient
+	colClient    *accessmock.AccessAPIClient
+	execClient   *accessmock.ExecutionAPIClient
 	chainID      flow.ChainID
 }
 
@@ -56,8 +64,8 @@ func (suite *Suite) SetupTest() {
 	suite.headers = new(storagemock.Headers)
 	suite.transactions = new(storagemock.Transactions)
 	suite.collections = new(storagemock.Collections)
-	suite.colClient = new(access.AccessAPIClient)
-	suite.execClient = new(access.ExecutionAPIClient)
+	suite.colClient = new(accessmock.AccessAPIClient)
+	suite.execClient = new(accessmock.ExecutionAPIClient)
 	suite.chainID = flow.Testnet
 }
 
@@ -73,13 +81,18 @@ func (suite *Suite) TestPing() {
 	backend := New(
 		suite.state,
 		suite.execClient,
+		nil,
 		suite.colClient,
-		nil, nil, nil, nil,
+		nil, nil, nil, nil, nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		0,
+		0,
 		nil,
 		false,
+		0,
+		metrics.NewNoopCollector(),
+		suite.log,
 	)
 
 	err := backend.Ping(context.Background())
@@ -96,12 +109,17 @@ func (suite *Suite) TestGetLatestFinalizedBlockHeader() {
 	backend := New(
 		suite.state,
 		suite.execClient,
-		nil, nil, nil, nil, nil,
+		nil,
+		nil, nil, nil, nil, nil, nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		0,
+		0,
 		nil,
 		false,
+		0,
+		metrics.NewNoopCollector(),
+		suite.log,
 	)
 
 	// query the handler for the latest finalized block
@@ -123,13 +141,17 @@ func (suite *Suite) TestGetLatestSealedBlockHeader() {
 
 	backend := New(
 		suite.state,
-		nil, nil, nil,
-		suite.headers, nil, nil,
+		nil, nil, nil, nil,
+		suite.headers, nil, nil, nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		0,
+		0,
 		nil,
 		false,
+		0,
+		metrics.NewNoopCollector(),
+		suite.log,
 	)
 
 	// query the handler for the latest sealed block
@@ -155,13 +177,18 @@ func (suite *Suite) TestGetTransaction() {
 
 	backend := New(
 		suite.state,
-		nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil,
 		suite.transactions,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		0,
+		0,
 		nil,
 		false,
+		0,
+		metrics.NewNoopCollector(),
+		suite.log,
 	)
 
 	actual, err := backend.GetTransaction(context.Background(), transaction.ID())
@@ -182,14 +209,19 @@ func (suite *Suite) TestGetCollection() {
 
 	backend := New(
 		suite.state,
-		nil, nil, nil, nil,
+		nil, nil, nil, nil, nil,
 		suite.collections,
 		suite.transactions,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		0,
+		0,
 		nil,
 		false,
+		0,
+		metrics.NewNoopCollector(),
+		suite.log,
 	)
 
 	actual, err := backend.GetCollectionByID(context.Background(), expected.ID())
@@ -249,15 +281,21 @@ func (suite *Suite) TestTransactionStatusTransition() {
 		suite.state,
 		suite.execClient,
 		nil,
+		nil,
 		suite.blocks,
 		suite.headers,
 		suite.collections,
 		suite.transactions,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		0,
+		0,
 		nil,
 		false,
+		0,
+		metrics.NewNoopCollector(),
+		suite.log,
 	)
 
 	// Successfully return empty event list
@@ -309,6 +347,185 @@ func (suite *Suite) TestTransactionStatusTransition() {
 	suite.assertAllExpectations()
 }
 
+// TestSubscribeTransactionStatus tests that a subscriber is notified of every status transition of a
+// transaction, and that the subscription ends once the transaction is sealed
+func (suite *Suite) TestSubscribeTransactionStatus() {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	collection := unittest.CollectionFixture(1)
+	transactionBody := collection.Transactions[0]
+	block := unittest.BlockFixture()
+	block.Header.Height = 2
+	headBlock := unittest.BlockFixture()
+	headBlock.Header.Height = block.Header.Height - 1 // head is behind the current block
+
+	suite.snapshot.
+		On("Head").
+		Return(headBlock.Header, nil)
+
+	light := collection.Light()
+
+	suite.transactions.
+		On("ByID", transactionBody.ID()).
+		Return(transactionBody, nil)
+
+	suite.collections.
+		On("LightByTransactionID", transactionBody.ID()).
+		Return(&light, nil)
+
+	suite.blocks.
+		On("ByCollectionID", collection.ID()).
+		Return(&block, nil)
+
+	txID := transactionBody.ID()
+	blockID := block.ID()
+
+	exeEventReq := execproto.GetTransactionResultRequest{
+		BlockId:       blockID[:],
+		TransactionId: txID[:],
+	}
+
+	backend := New(
+		suite.state,
+		suite.execClient,
+		nil,
+		nil,
+		suite.blocks,
+		suite.headers,
+		suite.collections,
+		suite.transactions,
+		nil,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		0,
+		0,
+		nil,
+		false,
+		0,
+		metrics.NewNoopCollector(),
+		suite.log,
+	)
+
+	// the execution node does not know about the transaction yet
+	suite.execClient.
+		On("GetTransactionResult", mock.Anything, &exeEventReq).
+		Return(nil, status.Errorf(codes.NotFound, "not found")).
+		Once()
+
+	results, err := backend.SubscribeTransactionStatus(ctx, txID)
+	suite.Require().NoError(err)
+
+	result := <-results
+	suite.Assert().Equal(flow.TransactionStatusFinalized, result.Status)
+
+	// updates which don't change the status of the transaction are not forwarded to the execution node
+	backend.NotifyFinalizedBlockHeight(headBlock.Header.Height)
+	backend.NotifyFinalizedBlockHeight(headBlock.Header.Height)
+
+	// the block containing the transaction gets executed
+	suite.execClient.
+		On("GetTransactionResult", mock.Anything, &exeEventReq).
+		Return(&execproto.GetTransactionResultResponse{}, nil)
+	receipt := unittest.ExecutionReceiptFixture()
+	receipt.ExecutionResult.BlockID = blockID
+	backend.NotifyExecutionReceipt(receipt)
+
+	result = <-results
+	suite.Assert().Equal(flow.TransactionStatusExecuted, result.Status)
+
+	// the block containing the transaction gets sealed
+	headBlock.Header.Height = block.Header.Height + 1
+	backend.NotifyFinalizedBlockHeight(headBlock.Header.Height)
+
+	result = <-results
+	suite.Assert().Equal(flow.TransactionStatusSealed, result.Status)
+
+	// no more updates are sent once the transaction is sealed
+	_, ok := <-results
+	suite.Assert().False(ok)
+
+	// the execution node was only asked once per status
+	suite.execClient.AssertNumberOfCalls(suite.T(), "GetTransactionResult", 3)
+	suite.assertAllExpectations()
+}
+
+// estimatingExecutionClient is an execution API client that also supports transaction estimation
+// TestEstimateTransaction tests that transaction estimation is forwarded to the extension service of
+// execution nodes
+func (suite *Suite) TestEstimateTransaction() {
+
+	ctx := context.Background()
+	tx := unittest.TransactionBodyFixture()
+	block := unittest.BlockFixture()
+	blockID := block.ID()
+
+	suite.blocks.
+		On("ByID", blockID).
+		Return(&block, nil)
+
+	extClient := new(accessmock.ExecutionExtensionAPIClient)
+
+	backend := New(
+		suite.state,
+		suite.execClient,
+		extClient,
+		nil,
+		suite.blocks,
+		nil, nil, nil, nil,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		0,
+		0,
+		nil,
+		false,
+		0,
+		metrics.NewNoopCollector(),
+		suite.log,
+	)
+
+	suite.Run("happy path", func() {
+		req := &executionext.EstimateTransactionRequest{
+			Transaction:               convert.TransactionToMessage(tx),
+			BlockId:                   blockID[:],
+			SkipSignatureVerification: true,
+		}
+		res := &executionext.EstimateTransactionResponse{
+			ComputationUsed: 42,
+			Logs:            []string{"estimated"},
+			StatusCode:      1007,
+			ErrorMessage:    "invalid proposal key",
+		}
+		extClient.
+			On("EstimateTransaction", ctx, req).
+			Return(res, nil).
+			Once()
+
+		estimate, err := backend.EstimateTransaction(ctx, &tx, blockID, access.EstimateOptions{
+			SkipSignatureVerification: true,
+		})
+		suite.checkResponse(estimate, err)
+		suite.Assert().Equal(uint64(42), estimate.ComputationUsed)
+		suite.Assert().Equal(res.Logs, estimate.Logs)
+		suite.Assert().Equal(uint(1007), estimate.StatusCode)
+		suite.Assert().Equal(res.ErrorMessage, estimate.ErrorMessage)
+		extClient.AssertExpectations(suite.T())
+	})
+
+	suite.Run("execution node failure", func() {
+		extClient.
+			On("EstimateTransaction", ctx, mock.Anything).
+			Return(nil, status.Error(codes.Unavailable, "unavailable")).
+			Once()
+
+		_, err := backend.EstimateTransaction(ctx, &tx, blockID, access.EstimateOptions{})
+		suite.Require().Error(err)
+		suite.Assert().Equal(codes.Internal, status.Code(err))
+		extClient.AssertExpectations(suite.T())
+	})
+}
+
 // TestTransactionExpiredStatusTransition tests that the status of transaction changes from Unknown to Expired
 // when enough blocks pass
 func (suite *Suite) TestTransactionExpiredStatusTransition() {
@@ -350,15 +567,21 @@ func (suite *Suite) TestTransactionExpiredStatusTransition() {
 		suite.state,
 		suite.execClient,
 		nil,
+		nil,
 		suite.blocks,
 		suite.headers,
 		suite.collections,
 		suite.transactions,
+		nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		0,
+		0,
 		nil,
 		false,
+		0,
+		metrics.NewNoopCollector(),
+		suite.log,
 	)
 
 	// first call - referenced block isn't known yet, so should return pending status
@@ -396,14 +619,18 @@ func (suite *Suite) TestGetLatestFinalizedBlock() {
 
 	backend := New(
 		suite.state,
-		nil, nil,
-		suite.blocks,
 		nil, nil, nil,
+		suite.blocks,
+		nil, nil, nil, nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		0,
+		0,
 		nil,
 		false,
+		0,
+		metrics.NewNoopCollector(),
+		suite.log,
 	)
 
 	// query the handler for the latest finalized header
@@ -416,6 +643,145 @@ func (suite *Suite) TestGetLatestFinalizedBlock() {
 	suite.assertAllExpectations()
 }
 
+// TestSubscribeBlocks tests that a block subscription delivers every finalized block in order of height,
+// starting at the requested height and following new finalized blocks
+func (suite *Suite) TestSubscribeBlocks() {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blocks := make([]*flow.Block, 4)
+	for i := range blocks {
+		block := unittest.BlockFixture()
+		block.Header.Height = uint64(i)
+		blocks[i] = &block
+		suite.blocks.
+			On("ByHeight", block.Header.Height).
+			Return(blocks[i], nil).
+			Maybe()
+	}
+
+	params := new(protocol.Params)
+	params.On("Root").Return(blocks[0].Header, nil)
+	suite.state.On("Params").Return(params)
+
+	// the finalized head advances by one block between the first and the second lookup
+	suite.snapshot.On("Head").Return(blocks[2].Header, nil).Once()
+	suite.snapshot.On("Head").Return(blocks[3].Header, nil).Once()
+
+	backend := New(
+		suite.state,
+		nil, nil, nil,
+		suite.blocks,
+		nil, nil, nil, nil,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		0,
+		0,
+		nil,
+		false,
+		0,
+		metrics.NewNoopCollector(),
+		suite.log,
+	)
+
+	received, err := backend.SubscribeBlocks(ctx, 1, false)
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal(blocks[1], <-received)
+	suite.Assert().Equal(blocks[2], <-received)
+
+	backend.NotifyFinalizedBlockHeight(blocks[3].Header.Height)
+	suite.Assert().Equal(blocks[3], <-received)
+
+	// the stream is closed once the subscription is cancelled
+	cancel()
+	_, ok := <-received
+	suite.Assert().False(ok)
+
+	suite.assertAllExpectations()
+}
+
+// TestSubscribeEvents tests that an event subscription merges the events of all requested types in the
+// order in which they were emitted, drops events emitted by contracts at other addresses, and
+// delivers events of a type listed repeatedly only once
+func (suite *Suite) TestSubscribeEvents() {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	root := unittest.BlockHeaderFixture()
+	root.Height = 0
+	block := unittest.BlockFixture()
+	block.Header.Height = 1
+
+	params := new(protocol.Params)
+	params.On("Root").Return(&root, nil)
+	suite.state.On("Params").Return(params)
+	suite.snapshot.On("Head").Return(block.Header, nil)
+	suite.blocks.On("ByHeight", block.Header.Height).Return(&block, nil)
+
+	address := flow.HexToAddress("01")
+	deposited := flow.EventType(fmt.Sprintf("A.%s.Token.Deposited", address))
+	withdrawn := flow.EventType(fmt.Sprintf("A.%s.Token.Withdrawn", address))
+	other := flow.EventType(fmt.Sprintf("A.%s.Token.Deposited", flow.HexToAddress("02")))
+
+	events := map[flow.EventType]flow.Event{
+		deposited: unittest.EventFixture(deposited, 1, 0, unittest.IdentifierFixture()),
+		withdrawn: unittest.EventFixture(withdrawn, 0, 0, unittest.IdentifierFixture()),
+		other:     unittest.EventFixture(other, 0, 1, unittest.IdentifierFixture()),
+	}
+
+	for eventType, event := range events {
+		suite.execClient.
+			On("GetEventsForBlockIDs", mock.Anything, &execproto.GetEventsForBlockIDsRequest{
+				BlockIds: convert.IdentifiersToMessages([]flow.Identifier{block.ID()}),
+				Type:     string(eventType),
+			}).
+			Return(&execproto.GetEventsForBlockIDsResponse{
+				Results: []*execproto.GetEventsForBlockIDsResponse_Result{{
+					BlockId:     convert.IdentifierToMessage(block.ID()),
+					BlockHeight: block.Header.Height,
+					Events:      convert.EventsToMessages([]flow.Event{event}),
+				}},
+			}, nil)
+	}
+
+	backend := New(
+		suite.state,
+		suite.execClient,
+		nil,
+		nil,
+		suite.blocks,
+		nil, nil, nil, nil,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		0,
+		0,
+		nil,
+		false,
+		0,
+		metrics.NewNoopCollector(),
+		suite.log,
+	)
+
+	filter := access.EventFilter{
+		EventTypes: []flow.EventType{deposited, withdrawn, other, deposited},
+		Addresses:  []flow.Address{address},
+	}
+
+	received, err := backend.SubscribeEvents(ctx, filter, block.Header.Height)
+	suite.Require().NoError(err)
+
+	result := <-received
+	suite.Assert().Equal(block.ID(), result.BlockID)
+	suite.Assert().Equal(block.Header.Height, result.BlockHeight)
+	suite.Assert().Equal([]flow.Event{events[withdrawn], events[deposited]}, result.Events)
+	suite.execClient.AssertNumberOfCalls(suite.T(), "GetEventsForBlockIDs", len(events))
+
+	suite.assertAllExpectations()
+}
+
 func (suite *Suite) TestGetEventsForBlockIDs() {
 	events := getEvents(10)
 
@@ -481,13 +847,18 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 		suite.state,
 		suite.execClient,
 		nil,
+		nil,
 		suite.blocks,
-		nil, nil, nil,
+		nil, nil, nil, nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		0,
+		0,
 		nil,
 		false,
+		0,
+		metrics.NewNoopCollector(),
+		suite.log,
 	)
 
 	// execute request
@@ -573,12 +944,16 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 	suite.Run("invalid request max height < min height", func() {
 		backend := New(
 			suite.state,
-			nil, nil, nil, nil, nil, nil,
+			nil, nil, nil, nil, nil, nil, nil, nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			0,
+			0,
 			nil,
 			false,
+			0,
+			metrics.NewNoopCollector(),
+			suite.log,
 		)
 
 		_, err := backend.GetEventsForHeightRange(ctx, string(flow.EventAccountCreated), maxHeight, minHeight)
@@ -601,14 +976,19 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			suite.state,
 			suite.execClient,
 			nil,
+			nil,
 			suite.blocks,
 			suite.headers,
-			nil, nil,
+			nil, nil, nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			0,
+			0,
 			nil,
 			false,
+			0,
+			metrics.NewNoopCollector(),
+			suite.log,
 		)
 
 		// execute request
@@ -630,14 +1010,19 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			suite.state,
 			suite.execClient,
 			nil,
+			nil,
 			suite.blocks,
 			suite.headers,
-			nil, nil,
+			nil, nil, nil,
 			suite.chainID,
 			metrics.NewNoopCollector(),
 			0,
+			0,
 			nil,
 			false,
+			0,
+			metrics.NewNoopCollector(),
+			suite.log,
 		)
 
 		actualResp, err := backend.GetEventsForHeightRange(ctx, string(flow.EventAccountCreated), minHeight, maxHeight)
@@ -691,14 +1076,19 @@ func (suite *Suite) TestGetAccount() {
 	backend := New(
 		suite.state,
 		suite.execClient,
+		nil,
 		nil, nil,
 		suite.headers,
-		nil, nil,
+		nil, nil, nil,
 		suite.chainID,
 		metrics.NewNoopCollector(),
 		0,
+		0,
 		nil,
 		false,
+		0,
+		metrics.NewNoopCollector(),
+		suite.log,
 	)
 
 	suite.Run("happy path - valid request and valid response", func() {
@@ -750,14 +1140,19 @@ func (suite *Suite) TestGetAccountAtBlockHeight() {
 	backend := New(
 		suite.state,
 		suite.execClient,
+		nil,
 		nil, nil,
 		suite.headers,
-		nil, nil,
+		nil, nil, nil,
 		flow.Testnet,
 		metrics.NewNoopCollector(),
 		0,
+		0,
 		nil,
 		false,
+		0,
+		metrics.NewNoopCollector(),
+		suite.log,
 	)
 
 	suite.Run("happy path - valid request and valid response", func() {
@@ -770,16 +1165,335 @@ func (suite *Suite) TestGetAccountAtBlockHeight() {
 	})
 }
 
+// TestExecuteScriptOnExecutionNodes tests that scripts are forwarded to the
+// preferred execution node and fail over to the next one if it is unavailable.
+func (suite *Suite) TestExecuteScriptOnExecutionNodes() {
+
+	en1 := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution), unittest.WithAddress("en1:3569"))
+	en2 := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution), unittest.WithAddress("en2:3569"))
+	suite.snapshot.On("Identities", mock.Anything).Return(flow.IdentityList{en1, en2}, nil)
+
+	blockID := unittest.IdentifierFixture()
+	script := []byte("pub fun main() {}")
+	execReq := &execproto.ExecuteScriptAtBlockIDRequest{
+		BlockId: blockID[:],
+		Script:  script,
+	}
+	ctx := context.Background()
+
+	// the first execution node is preferred over the second one
+	setup := func() (*Backend, *accessmock.ExecutionAPIClient, *accessmock.ExecutionAPIClient) {
+		client1 := new(accessmock.ExecutionAPIClient)
+		client2 := new(accessmock.ExecutionAPIClient)
+		connFactory := new(backendmock.ConnectionFactory)
+		connFactory.On("GetExecutionAPIClient", "en1:9000").Return(client1, nopCloser{}, nil).Maybe()
+		connFactory.On("GetExecutionAPIClient", "en2:9000").Return(client2, nopCloser{}, nil).Maybe()
+
+		backend := New(
+			suite.state,
+			nil, nil, nil, nil, nil, nil, nil, nil,
+			suite.chainID,
+			metrics.NewNoopCollector(),
+			0,
+			9000,
+			rankedConnectionFactory{connFactory, []string{"en1:9000", "en2:9000"}},
+			false,
+			0,
+			metrics.NewNoopCollector(),
+			suite.log,
+		)
+		return backend, client1, client2
+	}
+
+	suite.Run("preferred node", func() {
+		backend, client1, client2 := setup()
+		client1.On("ExecuteScriptAtBlockID", ctx, execReq).
+			Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: []byte("1")}, nil).
+			Once()
+
+		value, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, nil)
+		suite.Require().NoError(err)
+		suite.Assert().Equal([]byte("1"), value)
+		client1.AssertExpectations(suite.T())
+		client2.AssertExpectations(suite.T())
+	})
+
+	suite.Run("failover to next node", func() {
+		backend, client1, client2 := setup()
+		client1.On("ExecuteScriptAtBlockID", ctx, execReq).
+			Return(nil, status.Error(codes.Unavailable, "unavailable")).
+			Once()
+		client2.On("ExecuteScriptAtBlockID", ctx, execReq).
+			Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: []byte("2")}, nil).
+			Once()
+
+		value, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, nil)
+		suite.Require().NoError(err)
+		suite.Assert().Equal([]byte("2"), value)
+		client1.AssertExpectations(suite.T())
+		client2.AssertExpectations(suite.T())
+	})
+
+	suite.Run("no failover for invalid request", func() {
+		backend, client1, client2 := setup()
+		client1.On("ExecuteScriptAtBlockID", ctx, execReq).
+			Return(nil, status.Error(codes.InvalidArgument, "invalid script")).
+			Once()
+
+		_, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, nil)
+		suite.Require().Error(err)
+		client1.AssertExpectations(suite.T())
+		client2.AssertNotCalled(suite.T(), "ExecuteScriptAtBlockID", mock.Anything, mock.Anything)
+	})
+
+	suite.Run("no failover for internal error", func() {
+		backend, client1, client2 := setup()
+		client1.On("ExecuteScriptAtBlockID", ctx, execReq).
+			Return(nil, status.Error(codes.Internal, "script panicked")).
+			Once()
+
+		_, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, nil)
+		suite.Require().Error(err)
+		client1.AssertExpectations(suite.T())
+		client2.AssertNotCalled(suite.T(), "ExecuteScriptAtBlockID", mock.Anything, mock.Anything)
+	})
+
+	suite.Run("failover for deadline exceeded", func() {
+		backend, client1, client2 := setup()
+		client1.On("ExecuteScriptAtBlockID", ctx, execReq).
+			Return(nil, status.Error(codes.DeadlineExceeded, "timeout")).
+			Once()
+		client2.On("ExecuteScriptAtBlockID", ctx, execReq).
+			Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: []byte("2")}, nil).
+			Once()
+
+		value, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, nil)
+		suite.Require().NoError(err)
+		suite.Assert().Equal([]byte("2"), value)
+		client1.AssertExpectations(suite.T())
+		client2.AssertExpectations(suite.T())
+	})
+}
+
+// TestExecuteScriptCrossChecked tests that scripts are executed on the execution
+// nodes which produced receipts for the block, and that the majority result is returned.
+func (suite *Suite) TestExecuteScriptCrossChecked() {
+
+	ens := unittest.IdentityListFixture(4, unittest.WithRole(flow.RoleExecution))
+	for i, en := range ens {
+		en.Address = fmt.Sprintf("en%d:3569", i)
+	}
+	suite.snapshot.On("Identities", mock.Anything).Return(
+		func(selector flow.IdentityFilter) flow.IdentityList {
+			return ens.Filter(selector)
+		},
+		nil,
+	)
+
+	blockID := unittest.IdentifierFixture()
+	script := []byte("pub fun main() {}")
+	execReq := &execproto.ExecuteScriptAtBlockIDRequest{
+		BlockId: blockID[:],
+		Script:  script,
+	}
+	ctx := context.Background()
+
+	// the first three execution nodes produced receipts for the block, the last one is never queried
+	setup := func(values ...interface{}) (*Backend, *modulemock.ScriptMetrics) {
+		connFactory := new(backendmock.ConnectionFactory)
+		for i, value := range values {
+			client := new(accessmock.ExecutionAPIClient)
+			call := client.On("ExecuteScriptAtBlockID", ctx, execReq)
+			switch v := value.(type) {
+			case error:
+				call.Return(nil, v)
+			case string:
+				call.Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: []byte(v)}, nil)
+			}
+			connFactory.On("GetExecutionAPIClient", fmt.Sprintf("en%d:9000", i)).Return(client, nopCloser{}, nil)
+		}
+
+		scriptMetrics := new(modulemock.ScriptMetrics)
+		backend := New(
+			suite.state,
+			nil, nil, nil, nil, nil, nil, nil, nil,
+			suite.chainID,
+			metrics.NewNoopCollector(),
+			0,
+			9000,
+			connFactory,
+			false,
+			3,
+			scriptMetrics,
+			suite.log,
+		)
+		for _, en := range ens[:3] {
+			receipt := unittest.ExecutionReceiptFixture()
+			receipt.ExecutorID = en.NodeID
+			receipt.ExecutionResult.BlockID = blockID
+			backend.NotifyExecutionReceipt(receipt)
+		}
+		return backend, scriptMetrics
+	}
+
+	suite.Run("majority result", func() {
+		backend, scriptMetrics := setup("a", "a", "b")
+		scriptMetrics.On("ScriptResultDivergent", ens[2].NodeID).Once()
+
+		value, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, nil)
+		suite.Require().NoError(err)
+		suite.Assert().Equal([]byte("a"), value)
+		scriptMetrics.AssertExpectations(suite.T())
+	})
+
+	suite.Run("majority of responding nodes", func() {
+		backend, scriptMetrics := setup("a", status.Error(codes.Unavailable, "unavailable"), "a")
+
+		value, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, nil)
+		suite.Require().NoError(err)
+		suite.Assert().Equal([]byte("a"), value)
+		scriptMetrics.AssertExpectations(suite.T())
+	})
+
+	suite.Run("no majority", func() {
+		backend, scriptMetrics := setup("a", "b", status.Error(codes.Unavailable, "unavailable"))
+		scriptMetrics.On("ScriptResultUndecided").Once()
+
+		_, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, nil)
+		suite.Require().Error(err)
+		scriptMetrics.AssertExpectations(suite.T())
+	})
+
+	suite.Run("single responding node", func() {
+		unavailable := status.Error(codes.Unavailable, "unavailable")
+		backend, scriptMetrics := setup("a", unavailable, unavailable)
+		scriptMetrics.On("ScriptResultUndecided").Once()
+
+		_, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, nil)
+		suite.Assert().Equal(codes.Unavailable, status.Code(err))
+		scriptMetrics.AssertExpectations(suite.T())
+	})
+
+	suite.Run("all nodes failing", func() {
+		unavailable := status.Error(codes.Unavailable, "unavailable")
+		backend, _ := setup(unavailable, unavailable, unavailable)
+
+		_, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, nil)
+		suite.Require().Error(err)
+	})
+}
+
+func (suite *Suite) TestGetRegistersWithProof() {
+	unittest.RunWithTempDir(suite.T(), func(dir string) {
+		led, err := complete.NewLedger(dir, 100, &metrics.NoopCollector{}, zerolog.Nop(), nil, complete.DefaultPathFinderVersion)
+		suite.Require().NoError(err)
+
+		registerIDs := []flow.RegisterID{flow.NewRegisterID("owner", "", "key")}
+		keys := executionState.RegisterIDSToKeys(registerIDs)
+		update, err := ledger.NewUpdate(led.InitialState(), keys, []ledger.Value{[]byte("value")})
+		suite.Require().NoError(err)
+		commitment, err := led.Set(update)
+		suite.Require().NoError(err)
+
+		prove := func(commitment ledger.State) []byte {
+			query, err := ledger.NewQuery(commitment, keys)
+			suite.Require().NoError(err)
+			proof, err := led.Prove(query)
+			suite.Require().NoError(err)
+			return proof
+		}
+
+		blockID := unittest.IdentifierFixture()
+		seal := unittest.Seal.Fixture()
+		seal.FinalState = flow.StateCommitment(commitment)
+		seals := new(storagemock.Seals)
+		seals.On("ByBlockID", blockID).Return(seal, nil)
+		seals.On("ByBlockID", mock.Anything).Return(nil, storage.ErrNotFound)
+
+		newBackend := func(proof []byte, err error) *Backend {
+			client := new(accessmock.ExecutionExtensionAPIClient)
+			client.
+				On("GetRegistersWithProof", mock.Anything, mock.Anything).
+				Return(&executionext.GetRegistersWithProofResponse{Proof: proof}, err)
+
+			return New(
+				suite.state,
+				suite.execClient,
+				client,
+				nil, nil, nil, nil, nil,
+				seals,
+				suite.chainID,
+				metrics.NewNoopCollector(),
+				0,
+				0,
+				nil,
+				false,
+				0,
+				metrics.NewNoopCollector(),
+				suite.log,
+			)
+		}
+		ctx := context.Background()
+
+		suite.Run("valid proof", func() {
+			backend := newBackend(prove(commitment), nil)
+
+			registers, err := backend.GetRegistersWithProof(ctx, blockID, registerIDs)
+			suite.Require().NoError(err)
+			suite.Assert().Equal(blockID, registers.BlockID)
+			suite.Assert().Equal(seal.BlockID, registers.SealedBlockID)
+			suite.Assert().Equal(seal.FinalState, registers.Commitment)
+			suite.Assert().Equal([]flow.RegisterValue{[]byte("value")}, registers.Values)
+		})
+
+		suite.Run("proof against another commitment", func() {
+			backend := newBackend(prove(led.InitialState()), nil)
+
+			_, err := backend.GetRegistersWithProof(ctx, blockID, registerIDs)
+			suite.Require().Error(err)
+			suite.Assert().Equal(codes.Internal, status.Code(err))
+		})
+
+		suite.Run("undecodable proof", func() {
+			backend := newBackend([]byte("invalid"), nil)
+
+			_, err := backend.GetRegistersWithProof(ctx, blockID, registerIDs)
+			suite.Require().Error(err)
+			suite.Assert().Equal(codes.Internal, status.Code(err))
+		})
+
+		suite.Run("execution node without proofs", func() {
+			backend := newBackend(nil, status.Error(codes.Unimplemented, "unknown method"))
+
+			_, err := backend.GetRegistersWithProof(ctx, blockID, registerIDs)
+			suite.Require().Error(err)
+			suite.Assert().Equal(codes.Unimplemented, status.Code(err))
+		})
+
+		suite.Run("unknown block", func() {
+			backend := newBackend(prove(commitment), nil)
+
+			_, err := backend.GetRegistersWithProof(ctx, unittest.IdentifierFixture(), registerIDs)
+			suite.Require().Error(err)
+			suite.Assert().Equal(codes.NotFound, status.Code(err))
+		})
+	})
+}
+
 func (suite *Suite) TestGetNetworkParameters() {
 	expectedChainID := flow.Mainnet
 
 	backend := New(
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		flow.Mainnet,
 		metrics.NewNoopCollector(),
 		0,
+		0,
 		nil,
 		false,
+		0,
+		metrics.NewNoopCollector(),
+		suite.log,
 	)
 
 	params := backend.GetNetworkParameters(context.Background())
@@ -802,6 +1516,29 @@ func (suite *Suite) checkResponse(resp interface{}, err error) {
 	suite.Require().NotNil(resp)
 }
 
+// nopCloser is a closer of mocked clients, which have nothing to close.
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
+// rankedConnectionFactory is a connection factory ranking nodes in a fixed order.
+type rankedConnectionFactory struct {
+	ConnectionFactory
+	order []string
+}
+
+func (r rankedConnectionFactory) Rank(addresses []string) []string {
+	ranked := make([]string, 0, len(addresses))
+	for _, address := range r.order {
+		for _, candidate := range addresses {
+			if candidate == address {
+				ranked = append(ranked, address)
+			}
+		}
+	}
+	return ranked
+}
+
 func getEvents(n int) []flow.Event {
 	events := make([]flow.Event, n)
 	for i := range events {
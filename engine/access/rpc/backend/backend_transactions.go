@@ -15,6 +15,7 @@ import (
 
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	executionext "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/state/protocol"
@@ -36,6 +37,8 @@ type backendTransactions struct {
 	retry                *Retry
 	collectionGRPCPort   uint
 	connFactory          ConnectionFactory
+	updates              *broadcaster // notified on every new finalized block and execution receipt
+	executionNodes       *executionNodes
 }
 
 // SendTransaction forwards the transaction to the collection node
@@ -141,7 +144,6 @@ func (b *backendTransactions) sendTransactionToCollector(ctx context.Context,
 	tx *flow.TransactionBody,
 	collectionNodeAddr string) error {
 
-	// TODO: Use a connection pool to cache connections
 	collectionRPC, conn, err := b.connFactory.GetAccessAPIClient(collectionNodeAddr)
 	if err != nil {
 		return fmt.Errorf("failed to connect to collection node at %s: %w", collectionNodeAddr, err)
@@ -205,13 +207,12 @@ func (b *backendTransactions) GetTransactionResult(
 		return nil, convertStorageError(err)
 	}
 
-	// TODO: Set correct values for StatusCode and ErrorMessage
-
 	return &access.TransactionResult{
-		Status:       status,
-		StatusCode:   uint(statusCode),
-		Events:       events,
-		ErrorMessage: txError,
+		Status:        status,
+		StatusCode:    uint(statusCode),
+		ErrorCategory: access.TransactionErrorCategoryForCode(uint(statusCode)),
+		Events:        events,
+		ErrorMessage:  txError,
 	}, nil
 }
 
@@ -353,6 +354,128 @@ func (b *backendTransactions) getTransactionResultFromExecutionNode(
 	return events, resp.GetStatusCode(), resp.GetErrorMessage(), nil
 }
 
+// EstimateTransaction forwards the transaction to the execution node, which executes it against the
+// state at the given block and reports the computation used, the emitted events and logs, and the
+// error the transaction would fail with, if any.
+func (b *backendTransactions) EstimateTransaction(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	blockID flow.Identifier,
+	opts access.EstimateOptions,
+) (*access.TransactionEstimate, error) {
+	_, err := b.blocks.ByID(blockID)
+	if err != nil {
+		return nil, convertStorageError(err)
+	}
+
+	req := &executionext.EstimateTransactionRequest{
+		Transaction:               convert.TransactionToMessage(*tx),
+		BlockId:                   blockID[:],
+		SkipSignatureVerification: opts.SkipSignatureVerification,
+		SkipSequenceNumberCheck:   opts.SkipSequenceNumberCheck,
+	}
+
+	var res *executionext.EstimateTransactionResponse
+	err = b.executionNodes.executeExtension(ctx, func(client executionext.ExecutionExtensionAPIClient) error {
+		var err error
+		res, err = client.EstimateTransaction(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to estimate transaction on execution node: %v", err)
+	}
+
+	estimate := &access.TransactionEstimate{
+		ComputationUsed: res.GetComputationUsed(),
+		Events:          convert.MessagesToEvents(res.GetEvents()),
+		Logs:            res.GetLogs(),
+		StatusCode:      uint(res.GetStatusCode()),
+		ErrorMessage:    res.GetErrorMessage(),
+	}
+
+	return estimate, nil
+}
+
+// SubscribeTransactionStatus streams the result of the given transaction every time its status
+// changes. The returned channel is closed once the transaction is sealed or expired, or when the
+// context is cancelled.
+func (b *backendTransactions) SubscribeTransactionStatus(
+	ctx context.Context,
+	txID flow.Identifier,
+) (<-chan *access.TransactionResult, error) {
+	// only accept subscriptions for transactions we know about
+	tx, err := b.transactions.ByID(txID)
+	if err != nil {
+		return nil, convertStorageError(err)
+	}
+
+	// subscribe before the first lookup, so that no update can be missed in between
+	notify, unsubscribe := b.updates.Subscribe()
+
+	results := make(chan *access.TransactionResult)
+
+	go func() {
+		defer close(results)
+		defer unsubscribe()
+
+		lastStatus := flow.TransactionStatusUnknown
+		for {
+			// the status is derived from local state on every update, the full result is only
+			// requested from the execution node once the status has changed; errors are treated
+			// as transient, the lookup is retried on the next update
+			status, err := b.subscriptionStatus(tx)
+			if err == nil && status != lastStatus {
+				result, err := b.GetTransactionResult(ctx, txID)
+				if err == nil && result.Status != lastStatus {
+					select {
+					case results <- result:
+					case <-ctx.Done():
+						return
+					}
+
+					lastStatus = result.Status
+					if lastStatus == flow.TransactionStatusSealed || lastStatus == flow.TransactionStatusExpired {
+						return
+					}
+				}
+			}
+
+			select {
+			case <-notify:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return results, nil
+}
+
+// subscriptionStatus derives the status of a transaction from local state only. A transaction in
+// a finalized block is considered executed once an execution receipt for the block was received,
+// or the block is sealed.
+func (b *backendTransactions) subscriptionStatus(tx *flow.TransactionBody) (flow.TransactionStatus, error) {
+	block, err := b.lookupBlock(tx.ID())
+	if errors.Is(err, storage.ErrNotFound) {
+		return b.DeriveTransactionStatus(tx, false)
+	}
+	if err != nil {
+		return flow.TransactionStatusUnknown, err
+	}
+
+	sealed, err := b.state.Sealed().Head()
+	if err != nil {
+		return flow.TransactionStatusUnknown, err
+	}
+	if block.Header.Height <= sealed.Height {
+		return flow.TransactionStatusSealed, nil
+	}
+	if len(b.executionNodes.executors.ByBlockID(block.ID())) > 0 {
+		return flow.TransactionStatusExecuted, nil
+	}
+	return flow.TransactionStatusFinalized, nil
+}
+
 func (b *backendTransactions) NotifyFinalizedBlockHeight(height uint64) {
 	b.retry.Retry(height)
 }
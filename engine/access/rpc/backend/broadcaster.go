@@ -0,0 +1,57 @@
+package backend
+
+import (
+	"sync"
+)
+
+// broadcaster notifies any number of subscribers that something has changed.
+//
+// Notifications carry no payload and are coalesced: a subscriber that has not
+// yet consumed a pending notification will not receive a second one. This makes
+// publishing non-blocking, at the cost of subscribers having to re-read whatever
+// state they are interested in after each notification.
+type broadcaster struct {
+	mu          sync.Mutex
+	nextID      uint64
+	subscribers map[uint64]chan struct{}
+}
+
+func newBroadcaster() *broadcaster {
+	return &broadcaster{
+		subscribers: make(map[uint64]chan struct{}),
+	}
+}
+
+// Subscribe registers a new subscriber. It returns the channel on which the
+// subscriber is notified and a function that must be called to unsubscribe.
+func (b *broadcaster) Subscribe() (<-chan struct{}, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+
+	notify := make(chan struct{}, 1)
+	b.subscribers[id] = notify
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers, id)
+	}
+
+	return notify, unsubscribe
+}
+
+// Publish notifies all current subscribers without blocking.
+func (b *broadcaster) Publish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, notify := range b.subscribers {
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -5,29 +5,32 @@ import (
 	"io"
 
 	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/execution"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
+	executionext "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	grpcutils "github.com/onflow/flow-go/utils/grpc"
 )
 
 // ConnectionFactory is used to create an access api client
 type ConnectionFactory interface {
 	GetAccessAPIClient(address string) (access.AccessAPIClient, io.Closer, error)
+	GetExecutionAPIClient(address string) (execution.ExecutionAPIClient, io.Closer, error)
+	GetExecutionExtensionAPIClient(address string) (executionext.ExecutionExtensionAPIClient, io.Closer, error)
 }
 
+// CredentialsFunc returns the transport credentials for connections to the
+// node at the given address.
+type CredentialsFunc func(address string) (credentials.TransportCredentials, error)
+
 type ConnectionFactoryImpl struct {
+	Credentials CredentialsFunc // if nil, connections are insecure
 }
 
 // createConnection creates new gRPC connections to remote node
 func (cf *ConnectionFactoryImpl) createConnection(address string) (*grpc.ClientConn, error) {
-	conn, err := grpc.Dial(
-		address,
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutils.DefaultMaxMsgSize)),
-		grpc.WithInsecure())
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to address %s: %w", address, err)
-	}
-	return conn, nil
+	return dial(address, cf.Credentials)
 }
 
 func (cf *ConnectionFactoryImpl) GetAccessAPIClient(address string) (access.AccessAPIClient, io.Closer, error) {
@@ -39,3 +42,45 @@ func (cf *ConnectionFactoryImpl) GetAccessAPIClient(address string) (access.Acce
 	closer := io.Closer(conn)
 	return accessAPIClient, closer, nil
 }
+
+func (cf *ConnectionFactoryImpl) GetExecutionAPIClient(address string) (execution.ExecutionAPIClient, io.Closer, error) {
+	conn, err := cf.createConnection(address)
+	if err != nil {
+		return nil, nil, err
+	}
+	executionAPIClient := execution.NewExecutionAPIClient(conn)
+	closer := io.Closer(conn)
+	return executionAPIClient, closer, nil
+}
+
+func (cf *ConnectionFactoryImpl) GetExecutionExtensionAPIClient(address string) (executionext.ExecutionExtensionAPIClient, io.Closer, error) {
+	conn, err := cf.createConnection(address)
+	if err != nil {
+		return nil, nil, err
+	}
+	executionExtensionAPIClient := executionext.NewExecutionExtensionAPIClient(conn)
+	closer := io.Closer(conn)
+	return executionExtensionAPIClient, closer, nil
+}
+
+// dial creates a new gRPC connection to the node at the given address, which
+// is insecure if no credentials are provided
+func dial(address string, creds CredentialsFunc) (*grpc.ClientConn, error) {
+	security := grpc.WithInsecure()
+	if creds != nil {
+		transport, err := creds(address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get credentials for address %s: %w", address, err)
+		}
+		security = grpc.WithTransportCredentials(transport)
+	}
+
+	conn, err := grpc.Dial(
+		address,
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutils.DefaultMaxMsgSize)),
+		security)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to address %s: %w", address, err)
+	}
+	return conn, nil
+}
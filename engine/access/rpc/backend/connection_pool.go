@@ -0,0 +1,300 @@
+package backend
+
+import (
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go/engine"
+	executionext "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+)
+
+// ConnectionPoolConfig configures the caching and health checking of pooled
+// connections. Zero values are replaced by the defaults.
+type ConnectionPoolConfig struct {
+	IdleTimeout         time.Duration   // connections unused for this long are closed
+	HealthCheckInterval time.Duration   // interval between health probes of pooled connections
+	HealthCheckTimeout  time.Duration   // timeout of a single health probe
+	Credentials         CredentialsFunc // if nil, connections are insecure
+}
+
+// DefaultConnectionPoolConfig returns the default connection pool configuration.
+func DefaultConnectionPoolConfig() ConnectionPoolConfig {
+	return ConnectionPoolConfig{
+		IdleTimeout:         5 * time.Minute,
+		HealthCheckInterval: 10 * time.Second,
+		HealthCheckTimeout:  2 * time.Second,
+	}
+}
+
+// ConnectionPool is a ConnectionFactory which caches a connection per node
+// address, instead of dialing a new connection for every request. Connections
+// which have not been used for a while are closed, and the remaining ones are
+// periodically probed by pinging the node, so that requests can be routed to
+// healthy nodes with a low latency first.
+//
+// Clients are returned with a closer releasing the lease of the connection
+// rather than closing it, as the pool owns the connections. Connections are
+// only closed for being idle once all their leases are released.
+type ConnectionPool struct {
+	unit   *engine.Unit
+	log    zerolog.Logger
+	config ConnectionPoolConfig
+	dial   func(address string, creds CredentialsFunc) (*grpc.ClientConn, error)
+	now    func() time.Time
+
+	mu    sync.Mutex
+	conns map[string]*pooledConn
+}
+
+// pooledConn is a cached connection with the result of its latest health probe.
+type pooledConn struct {
+	conn     *grpc.ClientConn
+	ping     func(ctx context.Context) error
+	leases   uint // number of clients which have not been closed yet
+	lastUsed time.Time
+	probed   bool // whether the connection was probed at least once
+	healthy  bool
+	latency  time.Duration
+}
+
+// NewConnectionPool creates a new connection pool. Health probes and eviction
+// of idle connections run between the pool being started with Ready and
+// stopped with Done.
+func NewConnectionPool(log zerolog.Logger, config ConnectionPoolConfig) *ConnectionPool {
+
+	defaults := DefaultConnectionPoolConfig()
+	if config.IdleTimeout == 0 {
+		config.IdleTimeout = defaults.IdleTimeout
+	}
+	if config.HealthCheckInterval == 0 {
+		config.HealthCheckInterval = defaults.HealthCheckInterval
+	}
+	if config.HealthCheckTimeout == 0 {
+		config.HealthCheckTimeout = defaults.HealthCheckTimeout
+	}
+
+	return &ConnectionPool{
+		unit:   engine.NewUnit(),
+		log:    log.With().Str("component", "connection_pool").Logger(),
+		config: config,
+		dial:   dial,
+		now:    time.Now,
+		conns:  make(map[string]*pooledConn),
+	}
+}
+
+// Ready starts the periodic health probes of pooled connections.
+func (p *ConnectionPool) Ready() <-chan struct{} {
+	p.unit.LaunchPeriodically(p.check, p.config.HealthCheckInterval, 0)
+	return p.unit.Ready()
+}
+
+// Done stops the health probes and closes all pooled connections.
+func (p *ConnectionPool) Done() <-chan struct{} {
+	return p.unit.Done(func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		for address, pc := range p.conns {
+			p.close(address, pc)
+		}
+	})
+}
+
+func (p *ConnectionPool) GetAccessAPIClient(address string) (access.AccessAPIClient, io.Closer, error) {
+	conn, err := p.get(address, func(conn *grpc.ClientConn) func(ctx context.Context) error {
+		client := access.NewAccessAPIClient(conn)
+		return func(ctx context.Context) error {
+			_, err := client.Ping(ctx, &access.PingRequest{})
+			return err
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return access.NewAccessAPIClient(conn.conn), p.lease(conn), nil
+}
+
+func (p *ConnectionPool) GetExecutionAPIClient(address string) (execution.ExecutionAPIClient, io.Closer, error) {
+	conn, err := p.get(address, pingExecutionNode)
+	if err != nil {
+		return nil, nil, err
+	}
+	return execution.NewExecutionAPIClient(conn.conn), p.lease(conn), nil
+}
+
+func (p *ConnectionPool) GetExecutionExtensionAPIClient(address string) (executionext.ExecutionExtensionAPIClient, io.Closer, error) {
+	conn, err := p.get(address, pingExecutionNode)
+	if err != nil {
+		return nil, nil, err
+	}
+	return executionext.NewExecutionExtensionAPIClient(conn.conn), p.lease(conn), nil
+}
+
+// pingExecutionNode returns the health probe of a connection to an execution node.
+func pingExecutionNode(conn *grpc.ClientConn) func(ctx context.Context) error {
+	client := execution.NewExecutionAPIClient(conn)
+	return func(ctx context.Context) error {
+		_, err := client.Ping(ctx, &execution.PingRequest{})
+		return err
+	}
+}
+
+// get returns the pooled connection to the given address, dialing a new one
+// if there is none, and leases it. The health of a new connection is probed
+// with the ping function created for it.
+func (p *ConnectionPool) get(address string, pinger func(*grpc.ClientConn) func(ctx context.Context) error) (*pooledConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pc, ok := p.conns[address]
+	if !ok {
+		conn, err := p.dial(address, p.config.Credentials)
+		if err != nil {
+			return nil, err
+		}
+		pc = &pooledConn{
+			conn: conn,
+			ping: pinger(conn),
+		}
+		p.conns[address] = pc
+	}
+	pc.leases++
+	pc.lastUsed = p.now()
+
+	return pc, nil
+}
+
+// lease returns the closer releasing a lease of the given connection.
+func (p *ConnectionPool) lease(pc *pooledConn) io.Closer {
+	return &connLease{pool: p, pc: pc}
+}
+
+// release releases a lease of the given connection, which counts as a use.
+func (p *ConnectionPool) release(pc *pooledConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pc.leases--
+	pc.lastUsed = p.now()
+}
+
+// Rank orders the given node addresses by preference: healthy nodes come
+// first, ordered by ascending latency, followed by nodes which have not been
+// probed yet and finally by unhealthy nodes. Nodes of equal preference keep
+// their relative order, so that callers can randomize it.
+func (p *ConnectionPool) Rank(addresses []string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// ranks of the health states, lower is better
+	const (
+		healthy = iota
+		unknown
+		unhealthy
+	)
+	rank := func(address string) (int, time.Duration) {
+		pc, ok := p.conns[address]
+		switch {
+		case !ok || !pc.probed:
+			return unknown, 0
+		case pc.healthy:
+			return healthy, pc.latency
+		default:
+			return unhealthy, 0
+		}
+	}
+
+	ranked := make([]string, len(addresses))
+	copy(ranked, addresses)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		rankI, latencyI := rank(ranked[i])
+		rankJ, latencyJ := rank(ranked[j])
+		if rankI != rankJ {
+			return rankI < rankJ
+		}
+		return latencyI < latencyJ
+	})
+
+	return ranked
+}
+
+// check closes idle connections which are not leased, and probes the health
+// of the remaining ones.
+func (p *ConnectionPool) check() {
+
+	p.mu.Lock()
+	now := p.now()
+	probes := make(map[string]*pooledConn, len(p.conns))
+	for address, pc := range p.conns {
+		if pc.leases == 0 && now.Sub(pc.lastUsed) >= p.config.IdleTimeout {
+			p.close(address, pc)
+			continue
+		}
+		probes[address] = pc
+	}
+	p.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for address, pc := range probes {
+		wg.Add(1)
+		go func(address string, pc *pooledConn) {
+			defer wg.Done()
+			p.probe(address, pc)
+		}(address, pc)
+	}
+	wg.Wait()
+}
+
+// probe pings the node of the given connection and records its health.
+func (p *ConnectionPool) probe(address string, pc *pooledConn) {
+	ctx, cancel := context.WithTimeout(p.unit.Ctx(), p.config.HealthCheckTimeout)
+	defer cancel()
+
+	start := p.now()
+	err := pc.ping(ctx)
+	latency := p.now().Sub(start)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pc.probed = true
+	pc.healthy = err == nil
+	pc.latency = latency
+
+	if err != nil {
+		p.log.Debug().Err(err).Str("address", address).Msg("health probe failed")
+	}
+}
+
+// close closes and forgets the given connection. It must be called with the
+// lock held.
+func (p *ConnectionPool) close(address string, pc *pooledConn) {
+	delete(p.conns, address)
+	err := pc.conn.Close()
+	if err != nil {
+		p.log.Warn().Err(err).Str("address", address).Msg("could not close connection")
+	}
+}
+
+// connLease is returned for pooled connections, which are closed by the pool.
+// Closing it releases the lease of the connection once.
+type connLease struct {
+	pool *ConnectionPool
+	pc   *pooledConn
+	once sync.Once
+}
+
+func (l *connLease) Close() error {
+	l.once.Do(func() {
+		l.pool.release(l.pc)
+	})
+	return nil
+}
@@ -0,0 +1,110 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestConnectionPool_Caching(t *testing.T) {
+	pool := NewConnectionPool(unittest.Logger(), ConnectionPoolConfig{})
+
+	dials := 0
+	pool.dial = func(address string, creds CredentialsFunc) (*grpc.ClientConn, error) {
+		dials++
+		return dial(address, creds)
+	}
+
+	// connections are reused across clients of both APIs
+	_, closer, err := pool.GetAccessAPIClient("localhost:1")
+	require.NoError(t, err)
+	require.NoError(t, closer.Close())
+	_, _, err = pool.GetExecutionAPIClient("localhost:1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, dials)
+
+	_, _, err = pool.GetExecutionAPIClient("localhost:2")
+	require.NoError(t, err)
+	assert.Equal(t, 2, dials)
+
+	unittest.AssertClosesBefore(t, pool.Done(), time.Second)
+	assert.Empty(t, pool.conns)
+}
+
+func TestConnectionPool_HealthCheck(t *testing.T) {
+	idleTimeout := time.Minute
+	pool := NewConnectionPool(unittest.Logger(), ConnectionPoolConfig{IdleTimeout: idleTimeout})
+
+	// the health of nodes is simulated by replacing the probes
+	healthy := map[string]bool{
+		"healthy-slow:9000": true,
+		"healthy-fast:9000": true,
+		"unhealthy:9000":    false,
+		"idle:9000":         true,
+	}
+	for address, ok := range healthy {
+		_, closer, err := pool.GetExecutionAPIClient(address)
+		require.NoError(t, err)
+		require.NoError(t, closer.Close())
+		ok := ok
+		pool.conns[address].ping = func(ctx context.Context) error {
+			if !ok {
+				return fmt.Errorf("unavailable")
+			}
+			return nil
+		}
+	}
+	pool.conns["healthy-slow:9000"].ping = func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}
+
+	// nodes are ranked in the given order until they are probed
+	addresses := []string{"unhealthy:9000", "unknown:9000", "healthy-slow:9000", "healthy-fast:9000"}
+	assert.Equal(t, addresses, pool.Rank(addresses))
+
+	// the idle connection is closed, the remaining connections are probed
+	pool.conns["idle:9000"].lastUsed = time.Now().Add(-idleTimeout)
+	pool.check()
+	assert.NotContains(t, pool.conns, "idle:9000")
+	assert.Len(t, pool.conns, 3)
+
+	// healthy nodes with a low latency are preferred, unhealthy nodes are tried last
+	expected := []string{"healthy-fast:9000", "healthy-slow:9000", "unknown:9000", "unhealthy:9000"}
+	assert.Equal(t, expected, pool.Rank(addresses))
+
+	unittest.AssertClosesBefore(t, pool.Done(), time.Second)
+}
+
+func TestConnectionPool_Leases(t *testing.T) {
+	idleTimeout := time.Minute
+	pool := NewConnectionPool(unittest.Logger(), ConnectionPoolConfig{IdleTimeout: idleTimeout})
+
+	_, first, err := pool.GetExecutionAPIClient("localhost:1")
+	require.NoError(t, err)
+	_, second, err := pool.GetExecutionAPIClient("localhost:1")
+	require.NoError(t, err)
+	pool.conns["localhost:1"].ping = func(ctx context.Context) error { return nil }
+
+	// a connection in use is not closed, however long the request takes
+	require.NoError(t, first.Close())
+	require.NoError(t, first.Close())
+	pool.conns["localhost:1"].lastUsed = time.Now().Add(-idleTimeout)
+	pool.check()
+	assert.Contains(t, pool.conns, "localhost:1")
+
+	// once released, the connection is closed after being idle
+	require.NoError(t, second.Close())
+	pool.conns["localhost:1"].lastUsed = time.Now().Add(-idleTimeout)
+	pool.check()
+	assert.NotContains(t, pool.conns, "localhost:1")
+
+	unittest.AssertClosesBefore(t, pool.Done(), time.Second)
+}
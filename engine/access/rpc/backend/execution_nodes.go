@@ -0,0 +1,253 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	lru "github.com/hashicorp/golang-lru"
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	executionext "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+const executionNodesToTry = 3
+
+// executorsCacheSize is the number of recent blocks for which the executors
+// which produced receipts are remembered.
+const executorsCacheSize = 1000
+
+// NodeRanker is implemented by connection factories which track the health of
+// nodes, such as the ConnectionPool, to order nodes by preference.
+type NodeRanker interface {
+	Rank(addresses []string) []string
+}
+
+// executionNode is an execution node with the address of its gRPC API.
+type executionNode struct {
+	nodeID  flow.Identifier
+	address string
+}
+
+// executionNodes chooses the execution nodes which scripts and account queries
+// are forwarded to.
+type executionNodes struct {
+	staticExecutionRPC    execproto.ExecutionAPIClient             // rpc client tied to a fixed execution node
+	staticExecutionExtRPC executionext.ExecutionExtensionAPIClient // extension rpc client tied to the same node
+	state                 protocol.State
+	executionGRPCPort     uint // if zero, the fixed execution node is used
+	connFactory           ConnectionFactory
+	executors             *executors
+}
+
+// execute calls the given function with the client of an execution node. If
+// a gRPC port for execution nodes is configured, execution nodes are chosen
+// from the staked execution nodes, preferring healthy nodes with low latency if
+// the connection factory is a NodeRanker, and the next node is tried if the
+// node is unavailable or does not respond in time.
+func (e *executionNodes) execute(ctx context.Context, f func(client execproto.ExecutionAPIClient) error) error {
+
+	if e.executionGRPCPort == 0 {
+		return f(e.staticExecutionRPC)
+	}
+
+	return e.tryEach(ctx, func(node executionNode) error {
+		return e.executeOn(node, f)
+	})
+}
+
+// executeExtension calls the given function with the client of the extension
+// service of an execution node, which is chosen in the same way as for execute.
+func (e *executionNodes) executeExtension(ctx context.Context, f func(client executionext.ExecutionExtensionAPIClient) error) error {
+
+	if e.executionGRPCPort == 0 {
+		return f(e.staticExecutionExtRPC)
+	}
+
+	return e.tryEach(ctx, func(node executionNode) error {
+		client, closer, err := e.connFactory.GetExecutionExtensionAPIClient(node.address)
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "failed to connect to execution node at %s: %v", node.address, err)
+		}
+		defer closer.Close()
+
+		return f(client)
+	})
+}
+
+// tryEach calls the given function with the chosen execution nodes in turn,
+// until the call succeeds or fails for a reason other than the node being
+// unavailable or not responding in time. Other errors, including internal
+// errors, are returned right away, as they are most likely caused by the
+// request and retrying would multiply the work of failing requests.
+func (e *executionNodes) tryEach(ctx context.Context, f func(node executionNode) error) error {
+
+	nodes, err := e.chooseExecutionNodes(filter.Any, executionNodesToTry)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to choose execution nodes: %v", err)
+	}
+
+	var errs error
+	for _, node := range nodes {
+		err = f(node)
+		if err == nil {
+			return nil
+		}
+		switch status.Code(err) {
+		case codes.Unavailable, codes.DeadlineExceeded:
+		default:
+			return err
+		}
+		errs = multierror.Append(errs, err)
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return errs
+}
+
+// executeOnEach calls the given function concurrently with the clients of up
+// to n execution nodes which produced receipts for the given block, or of any
+// execution nodes if no receipts for the block were received. It returns the
+// execution nodes together with the errors of their calls.
+func (e *executionNodes) executeOnEach(blockID flow.Identifier, n uint, f func(nodeID flow.Identifier, client execproto.ExecutionAPIClient) error) ([]executionNode, []error, error) {
+
+	selector := filter.Any
+	executorIDs := e.executors.ByBlockID(blockID)
+	if len(executorIDs) > 0 {
+		selector = filter.HasNodeID(executorIDs...)
+	}
+
+	nodes, err := e.chooseExecutionNodes(selector, n)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to choose execution nodes: %v", err)
+	}
+
+	errs := make([]error, len(nodes))
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node executionNode) {
+			defer wg.Done()
+			errs[i] = e.executeOn(node, func(client execproto.ExecutionAPIClient) error {
+				return f(node.nodeID, client)
+			})
+		}(i, node)
+	}
+	wg.Wait()
+
+	return nodes, errs, nil
+}
+
+// executeOn calls the given function with the client of the given execution node.
+func (e *executionNodes) executeOn(node executionNode, f func(client execproto.ExecutionAPIClient) error) error {
+	client, closer, err := e.connFactory.GetExecutionAPIClient(node.address)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "failed to connect to execution node at %s: %v", node.address, err)
+	}
+	defer closer.Close()
+
+	return f(client)
+}
+
+// chooseExecutionNodes returns up to n of the staked execution nodes matching
+// the given selector, in the order they should be tried.
+func (e *executionNodes) chooseExecutionNodes(selector flow.IdentityFilter, n uint) ([]executionNode, error) {
+
+	identities, err := e.state.Final().Identities(filter.And(filter.HasRole(flow.RoleExecution), selector))
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution nodes: %w", err)
+	}
+	if len(identities) == 0 {
+		return nil, fmt.Errorf("no execution nodes found")
+	}
+
+	// shuffle the execution nodes, so that load is spread across nodes of equal preference
+	identities = identities.Sample(uint(len(identities)))
+
+	// convert the node addresses of the execution nodes to the GRPC address
+	// (identity list does not directly provide execution nodes gRPC address)
+	nodes := make(map[string]executionNode, len(identities))
+	addrs := make([]string, 0, len(identities))
+	for _, id := range identities {
+		hostnameOrIP, _, err := net.SplitHostPort(id.Address)
+		if err != nil {
+			return nil, err
+		}
+		addr := fmt.Sprintf("%s:%d", hostnameOrIP, e.executionGRPCPort)
+		nodes[addr] = executionNode{nodeID: id.NodeID, address: addr}
+		addrs = append(addrs, addr)
+	}
+
+	if ranker, ok := e.connFactory.(NodeRanker); ok {
+		addrs = ranker.Rank(addrs)
+	}
+	if uint(len(addrs)) > n {
+		addrs = addrs[:n]
+	}
+
+	chosen := make([]executionNode, 0, len(addrs))
+	for _, addr := range addrs {
+		chosen = append(chosen, nodes[addr])
+	}
+
+	return chosen, nil
+}
+
+// executors tracks the execution nodes which produced receipts for recent blocks.
+type executors struct {
+	mu    sync.Mutex
+	cache *lru.Cache
+}
+
+func newExecutors() *executors {
+	// the cache size is a positive constant, so creating the cache can not fail
+	cache, _ := lru.New(executorsCacheSize)
+	return &executors{
+		cache: cache,
+	}
+}
+
+// Add records that the given execution node produced a receipt for the given block.
+func (e *executors) Add(blockID flow.Identifier, executorID flow.Identifier) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var executorIDs []flow.Identifier
+	cached, ok := e.cache.Get(blockID)
+	if ok {
+		executorIDs = cached.([]flow.Identifier)
+	}
+	for _, id := range executorIDs {
+		if id == executorID {
+			return
+		}
+	}
+
+	// copy on write, as the previous list may be in use by readers
+	updated := make([]flow.Identifier, 0, len(executorIDs)+1)
+	updated = append(updated, executorIDs...)
+	updated = append(updated, executorID)
+	e.cache.Add(blockID, updated)
+}
+
+// ByBlockID returns the execution nodes which produced receipts for the given block.
+func (e *executors) ByBlockID(blockID flow.Identifier) []flow.Identifier {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	cached, ok := e.cache.Get(blockID)
+	if !ok {
+		return nil
+	}
+	return cached.([]flow.Identifier)
+}
@@ -40,8 +40,8 @@ func (suite *Suite) TestTransactionRetry() {
 	// txID := transactionBody.ID()
 	// blockID := block.ID()
 	// Setup Handler + Retry
-	backend := New(suite.state, suite.execClient, suite.colClient, suite.blocks, suite.headers,
-		suite.collections, suite.transactions, suite.chainID, metrics.NewNoopCollector(), 0, nil, false)
+	backend := New(suite.state, suite.execClient, nil, suite.colClient, suite.blocks, suite.headers,
+		suite.collections, suite.transactions, nil, suite.chainID, metrics.NewNoopCollector(), 0, 0, nil, false, 0, metrics.NewNoopCollector(), suite.log)
 	retry := newRetry().SetBackend(backend).Activate()
 	backend.retry = retry
 
@@ -98,8 +98,8 @@ func (suite *Suite) TestSuccessfulTransactionsDontRetry() {
 	}
 
 	// Setup Handler + Retry
-	backend := New(suite.state, suite.execClient, suite.colClient, suite.blocks, suite.headers,
-		suite.collections, suite.transactions, suite.chainID, metrics.NewNoopCollector(), 0, nil, false)
+	backend := New(suite.state, suite.execClient, nil, suite.colClient, suite.blocks, suite.headers,
+		suite.collections, suite.transactions, nil, suite.chainID, metrics.NewNoopCollector(), 0, 0, nil, false, 0, metrics.NewNoopCollector(), suite.log)
 	retry := newRetry().SetBackend(backend).Activate()
 	backend.retry = retry
 
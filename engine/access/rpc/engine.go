@@ -16,8 +16,10 @@ import (
 
 	"github.com/onflow/flow-go/access"
 	legacyaccess "github.com/onflow/flow-go/access/legacy"
+	accessext "github.com/onflow/flow-go/access/protobuf"
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
+	executionext "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/state/protocol"
@@ -27,11 +29,13 @@ import (
 
 // Config defines the configurable options for the access node server
 type Config struct {
-	GRPCListenAddr string
-	HTTPListenAddr string
-	ExecutionAddr  string
-	CollectionAddr string
-	MaxMsgSize     int // In bytes
+	GRPCListenAddr   string
+	HTTPListenAddr   string
+	ExecutionAddr    string
+	CollectionAddr   string
+	MaxMsgSize       int                          // In bytes
+	ConnectionPool   backend.ConnectionPoolConfig // connections to collection and execution nodes
+	ScriptCrossCheck uint                         // number of execution nodes script results are cross-checked with
 }
 
 // Engine implements a gRPC server with a simplified version of the Observation API.
@@ -41,6 +45,7 @@ type Engine struct {
 	backend    *backend.Backend // the gRPC service implementation
 	grpcServer *grpc.Server     // the gRPC server
 	httpServer *http.Server
+	connPool   *backend.ConnectionPool // connections to collection and execution nodes
 	config     Config
 }
 
@@ -49,14 +54,18 @@ func New(log zerolog.Logger,
 	state protocol.State,
 	config Config,
 	executionRPC execproto.ExecutionAPIClient,
+	executionExtRPC executionext.ExecutionExtensionAPIClient,
 	collectionRPC accessproto.AccessAPIClient,
 	blocks storage.Blocks,
 	headers storage.Headers,
 	collections storage.Collections,
 	transactions storage.Transactions,
+	seals storage.Seals,
 	chainID flow.ChainID,
 	transactionMetrics module.TransactionMetrics,
+	scriptMetrics module.ScriptMetrics,
 	collectionGRPCPort uint,
+	executionGRPCPort uint,
 	retryEnabled bool,
 ) *Engine {
 
@@ -75,19 +84,28 @@ func New(log zerolog.Logger,
 	// wrap the GRPC server with an HTTP proxy server to serve HTTP clients
 	httpServer := NewHTTPServer(grpcServer, config.HTTPListenAddr)
 
+	// share connections to collection and execution nodes across requests
+	connPool := backend.NewConnectionPool(log, config.ConnectionPool)
+
 	backend := backend.New(
 		state,
 		executionRPC,
+		executionExtRPC,
 		collectionRPC,
 		blocks,
 		headers,
 		collections,
 		transactions,
+		seals,
 		chainID,
 		transactionMetrics,
 		collectionGRPCPort,
-		&backend.ConnectionFactoryImpl{},
+		executionGRPCPort,
+		connPool,
 		retryEnabled,
+		config.ScriptCrossCheck,
+		scriptMetrics,
+		log,
 	)
 
 	eng := &Engine{
@@ -96,13 +114,16 @@ func New(log zerolog.Logger,
 		backend:    backend,
 		grpcServer: grpcServer,
 		httpServer: httpServer,
+		connPool:   connPool,
 		config:     config,
 	}
 
-	accessproto.RegisterAccessAPIServer(
-		eng.grpcServer,
-		access.NewHandler(backend, chainID.Chain()),
-	)
+	handler := access.NewHandler(backend, chainID.Chain())
+	accessproto.RegisterAccessAPIServer(eng.grpcServer, handler)
+
+	// the calls which are not part of the AccessAPI service definition yet are served
+	// by the extension service on the same server
+	accessext.RegisterAccessExtensionAPIServer(eng.grpcServer, handler)
 
 	// Register legacy gRPC handlers for backwards compatibility, to be removed at a later date
 	legacyaccessproto.RegisterAccessAPIServer(
@@ -119,7 +140,9 @@ func New(log zerolog.Logger,
 func (e *Engine) Ready() <-chan struct{} {
 	e.unit.Launch(e.serveGRPC)
 	e.unit.Launch(e.serveGRPCWebProxy)
-	return e.unit.Ready()
+	return e.unit.Ready(func() {
+		<-e.connPool.Ready()
+	})
 }
 
 // Done returns a done channel that is closed once the engine has fully stopped.
@@ -132,6 +155,9 @@ func (e *Engine) Done() <-chan struct{} {
 			if err != nil {
 				e.log.Error().Err(err).Msg("error stopping http server")
 			}
+		},
+		func() {
+			<-e.connPool.Done()
 		})
 }
 
@@ -153,6 +179,9 @@ func (e *Engine) process(event interface{}) error {
 	case *flow.Block:
 		e.backend.NotifyFinalizedBlockHeight(entity.Header.Height)
 		return nil
+	case *flow.ExecutionReceipt:
+		e.backend.NotifyExecutionReceipt(entity)
+		return nil
 	default:
 		return fmt.Errorf("invalid event type (%T)", event)
 	}
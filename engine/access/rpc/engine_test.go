@@ -0,0 +1,131 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
+	entitiesproto "github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	accessext "github.com/onflow/flow-go/access/protobuf"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// serve serves the gRPC server of the engine on an in-memory listener and returns a
+// connection to it.
+func serve(t *testing.T, eng *Engine) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = eng.grpcServer.Serve(listener)
+	}()
+	t.Cleanup(eng.grpcServer.Stop)
+
+	dialer := func(context.Context, string) (net.Conn, error) {
+		return listener.Dial()
+	}
+	conn, err := grpc.Dial("bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// TestSubscribeTransactionStatus tests that the transaction status subscription is
+// reachable through the gRPC server of the engine.
+func TestSubscribeTransactionStatus(t *testing.T) {
+	tx := unittest.TransactionBodyFixture()
+	txID := tx.ID()
+	reference := unittest.BlockHeaderFixture()
+	final := unittest.BlockHeaderWithParentFixture(&reference)
+
+	transactions := new(storagemock.Transactions)
+	transactions.On("ByID", txID).Return(&tx, nil)
+	collections := new(storagemock.Collections)
+	collections.On("LightByTransactionID", txID).Return(nil, storage.ErrNotFound)
+
+	snapshot := new(protocol.Snapshot)
+	snapshot.On("Head").Return(&final, nil)
+	referenceSnapshot := new(protocol.Snapshot)
+	referenceSnapshot.On("Head").Return(&reference, nil)
+	state := new(protocol.State)
+	state.On("Final").Return(snapshot)
+	state.On("AtBlockID", tx.ReferenceBlockID).Return(referenceSnapshot)
+
+	collector := metrics.NewNoopCollector()
+	eng := New(zerolog.Nop(), state, Config{}, nil, nil, nil, nil, nil, collections, transactions, nil,
+		flow.Testnet, collector, collector, 0, 0, false)
+	client := accessext.NewAccessExtensionAPIClient(serve(t, eng))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.SubscribeTransactionStatus(ctx, &accessproto.GetTransactionRequest{Id: txID[:]})
+	require.NoError(t, err)
+
+	// the transaction is not in a block yet
+	result, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, entitiesproto.TransactionStatus_PENDING, result.GetResult().GetStatus())
+	assert.Equal(t, "none", result.GetErrorCategory())
+}
+
+// TestSubscribeBlocks tests that the block subscription is reachable through the gRPC
+// server of the engine.
+func TestSubscribeBlocks(t *testing.T) {
+	root := unittest.BlockFixture()
+	block := unittest.BlockWithParentFixture(root.Header)
+
+	blocks := new(storagemock.Blocks)
+	blocks.On("ByHeight", root.Header.Height).Return(&root, nil)
+	blocks.On("ByHeight", block.Header.Height).Return(&block, nil)
+
+	params := new(protocol.Params)
+	params.On("Root").Return(root.Header, nil)
+	snapshot := new(protocol.Snapshot)
+	snapshot.On("Head").Return(block.Header, nil)
+	state := new(protocol.State)
+	state.On("Params").Return(params)
+	state.On("Final").Return(snapshot)
+
+	collector := metrics.NewNoopCollector()
+	eng := New(zerolog.Nop(), state, Config{}, nil, nil, nil, blocks, nil, nil, nil, nil,
+		flow.Testnet, collector, collector, 0, 0, false)
+	client := accessext.NewAccessExtensionAPIClient(serve(t, eng))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.SubscribeBlocks(ctx, &accessext.SubscribeBlocksRequest{StartHeight: root.Header.Height})
+	require.NoError(t, err)
+
+	for _, expected := range []flow.Block{root, block} {
+		res, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, expected.ID(), flow.HashToID(res.GetBlock().GetId()))
+	}
+}
+
+// TestSubscribeEventsInvalidFilter tests that the event subscription is reachable through
+// the gRPC server of the engine and rejects a filter without event types.
+func TestSubscribeEventsInvalidFilter(t *testing.T) {
+	collector := metrics.NewNoopCollector()
+	eng := New(zerolog.Nop(), new(protocol.State), Config{}, nil, nil, nil, nil, nil, nil, nil, nil,
+		flow.Testnet, collector, collector, 0, 0, false)
+	client := accessext.NewAccessExtensionAPIClient(serve(t, eng))
+
+	stream, err := client.SubscribeEvents(context.Background(), &accessext.SubscribeEventsRequest{})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,11 @@
+package wrapper
+
+import (
+	executionext "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+)
+
+// ExecutionExtensionAPIClient allows for generation of a mock (via mockery) for the ExecutionExtensionAPIClient
+// generated from the execution extension service definition
+type ExecutionExtensionAPIClient interface {
+	executionext.ExecutionExtensionAPIClient
+}
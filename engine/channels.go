@@ -59,6 +59,7 @@ const (
 
 	// Channels for consensus protocols
 	ConsensusCommittee     = "consensus-committee"
+	ConsensusSlashing      = "consensus-slashing"
 	consensusClusterPrefix = "consensus-cluster" // dynamic channel, use ChannelConsensusCluster function
 
 	// Channels for protocols actively synchronizing state across nodes
@@ -104,6 +105,7 @@ func initializeChannelIdMap() {
 
 	// Channels for consensus protocols
 	channelIdMap[ConsensusCommittee] = flow.RoleList{flow.RoleConsensus}
+	channelIdMap[ConsensusSlashing] = flow.RoleList{flow.RoleConsensus}
 
 	// Channels for protocols actively synchronizing state across nodes
 	channelIdMap[SyncCommittee] = flow.RoleList{flow.RoleConsensus}
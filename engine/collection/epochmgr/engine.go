@@ -280,7 +280,12 @@ func (e *Engine) stopEpochComponents(counter uint64) error {
 	select {
 	case <-components.Done():
 		delete(e.epochs, counter)
-		e.pools.ForEpoch(counter).Clear()
+		pool, err := e.pools.ForEpoch(counter)
+		if err != nil {
+			return fmt.Errorf("could not get transaction pool of epoch %d: %w", counter, err)
+		}
+		pool.Clear()
+		e.pools.Remove(counter)
 		return nil
 	case <-time.After(e.startupTimeout):
 		return fmt.Errorf("could not stop epoch %d components after %s", counter, e.startupTimeout)
@@ -113,7 +113,7 @@ func (suite *Suite) SetupTest() {
 	suite.AddEpoch(suite.counter)
 	suite.AddEpoch(suite.counter + 1)
 
-	pools := epochs.NewTransactionPools(func() mempool.Transactions { return stdmap.NewTransactions(1000) })
+	pools := epochs.NewTransactionPools(func(_ uint64) (mempool.Transactions, error) { return stdmap.NewTransactions(1000), nil }, nil)
 
 	var err error
 	suite.engine, err = New(log, suite.me, suite.state, pools, suite.voter, suite.factory, suite.heights)
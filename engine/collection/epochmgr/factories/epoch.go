@@ -103,7 +103,11 @@ func (factory *EpochComponentsFactory) Create(
 		err = fmt.Errorf("could not get epoch counter: %w", err)
 		return
 	}
-	pool := factory.pools.ForEpoch(counter)
+	pool, err := factory.pools.ForEpoch(counter)
+	if err != nil {
+		err = fmt.Errorf("could not get transaction pool: %w", err)
+		return
+	}
 
 	builder, finalizer, err := factory.builder.Create(headers, payloads, pool)
 	if err != nil {
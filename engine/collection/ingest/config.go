@@ -1,6 +1,7 @@
 package ingest
 
 import (
+	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -18,6 +19,16 @@ type Config struct {
 	// how many extra nodes in the responsible cluster we propagate transactions to
 	// (we always send to at least one)
 	PropagationRedundancy uint
+	// the quota of transactions per payer address
+	PayerQuota Quota
+	// the quota of transactions per proposal key
+	ProposerQuota Quota
+	// the quota of transactions per origin, ie. per client address for
+	// transactions received through the ingress server
+	OriginQuota Quota
+	// the provider of account keys to verify transaction signatures against
+	// (nil means only the format of signatures is checked)
+	AccountKeys access.AccountKeyProvider
 }
 
 func DefaultConfig() Config {
@@ -27,5 +38,9 @@ func DefaultConfig() Config {
 		CheckScriptsParse:     true,
 		MaxAddressIndex:       1_000_000,
 		PropagationRedundancy: 2,
+		PayerQuota:            Quota{}, // no payer quota
+		ProposerQuota:         Quota{}, // no proposer quota
+		OriginQuota:           Quota{}, // no origin quota
+		AccountKeys:           nil,     // no signature verification
 	}
 }
@@ -3,6 +3,7 @@
 package ingest
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/rs/zerolog"
@@ -31,6 +32,7 @@ type Engine struct {
 	state                protocol.State
 	pools                *epochs.TransactionPools
 	transactionValidator *access.TransactionValidator
+	admission            *admission
 
 	config Config
 }
@@ -60,6 +62,7 @@ func New(
 			MaxAddressIndex:   config.MaxAddressIndex,
 			CheckScriptsParse: config.CheckScriptsParse,
 			MaxTxSizeLimit:    flow.DefaultMaxTxSizeLimit,
+			AccountKeys:       config.AccountKeys,
 		},
 	)
 
@@ -73,6 +76,7 @@ func New(
 		pools:                pools,
 		config:               config,
 		transactionValidator: transactionValidator,
+		admission:            newAdmission(config),
 	}
 
 	conduit, err := net.Register(engine.PushTransactions, e)
@@ -126,6 +130,27 @@ func (e *Engine) Process(originID flow.Identifier, event interface{}) error {
 	})
 }
 
+// ProcessClientTransaction processes a transaction submitted by an external
+// client through the ingress server in a blocking manner. The address of the
+// client is the origin of the transaction for admission control, which only
+// applies to clients, as transactions forwarded by other collection nodes have
+// been admitted by them already.
+func (e *Engine) ProcessClientTransaction(clientAddr string, tx *flow.TransactionBody) error {
+	return e.unit.Do(func() error {
+		e.engMetrics.MessageReceived(metrics.EngineCollectionIngest, metrics.MessageTransaction)
+		defer e.engMetrics.MessageHandled(metrics.EngineCollectionIngest, metrics.MessageTransaction)
+
+		// enforce the quota of the client before spending any resources on the transaction
+		err := e.admission.admitOrigin(clientAddr)
+		if err != nil {
+			e.dropped(err)
+			return fmt.Errorf("could not admit transaction: %w", err)
+		}
+
+		return e.onTransaction(e.me.NodeID(), tx)
+	})
+}
+
 // process processes engine events.
 //
 // Transactions are validated and routed to the correct cluster, then added
@@ -177,7 +202,10 @@ func (e *Engine) onTransaction(originID flow.Identifier, tx *flow.TransactionBod
 	}
 
 	// use the transaction pool for the epoch the reference block is part of
-	pool := e.pools.ForEpoch(counter)
+	pool, err := e.pools.ForEpoch(counter)
+	if err != nil {
+		return fmt.Errorf("could not get transaction pool for reference epoch: %w", err)
+	}
 
 	// short-circuit if we have already stored the transaction
 	if pool.Has(txID) {
@@ -191,6 +219,14 @@ func (e *Engine) onTransaction(originID flow.Identifier, tx *flow.TransactionBod
 		return engine.NewInvalidInputErrorf("invalid transaction: %w", err)
 	}
 
+	// enforce the quotas of the payer and proposer key, which are only charged
+	// for valid transactions
+	err = e.admission.admitAccounts(tx)
+	if err != nil {
+		e.dropped(err)
+		return fmt.Errorf("could not admit transaction: %w", err)
+	}
+
 	// get the locally assigned cluster and the cluster responsible for the transaction
 	txCluster, ok := clusters.ByTxID(txID)
 	if !ok {
@@ -238,3 +274,11 @@ func (e *Engine) onTransaction(originID flow.Identifier, tx *flow.TransactionBod
 
 	return nil
 }
+
+// dropped reports a transaction dropped because of the given admission error.
+func (e *Engine) dropped(err error) {
+	var quotaErr QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		e.colMetrics.TransactionDropped(quotaErr.Quota)
+	}
+}
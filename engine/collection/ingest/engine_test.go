@@ -4,12 +4,14 @@ import (
 	"errors"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/crypto/hash"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module/mempool"
@@ -77,9 +79,9 @@ func (suite *Suite) SetupTest() {
 	suite.me = new(module.Local)
 	suite.me.On("NodeID").Return(me.NodeID)
 
-	suite.pools = epochs.NewTransactionPools(func() mempool.Transactions {
-		return stdmap.NewTransactions(1000)
-	})
+	suite.pools = epochs.NewTransactionPools(func(_ uint64) (mempool.Transactions, error) {
+		return stdmap.NewTransactions(1000), nil
+	}, nil)
 
 	assignments := unittest.ClusterAssignment(suite.N_CLUSTERS, collectors)
 	suite.clusters, err = flow.NewClusterList(assignments, collectors)
@@ -122,6 +124,13 @@ func (suite *Suite) SetupTest() {
 	suite.Require().NoError(err)
 }
 
+// pool returns the transaction pool of the given epoch.
+func (suite *Suite) pool(epoch uint64) mempool.Transactions {
+	pool, err := suite.pools.ForEpoch(epoch)
+	suite.Require().NoError(err)
+	return pool
+}
+
 func (suite *Suite) TestInvalidTransaction() {
 
 	suite.Run("missing field", func() {
@@ -254,7 +263,7 @@ func (suite *Suite) TestRoutingLocalCluster() {
 	// should be added to local mempool for the current epoch
 	counter, err := suite.epochQuery.Current().Counter()
 	suite.Assert().NoError(err)
-	suite.Assert().True(suite.pools.ForEpoch(counter).Has(tx.ID()))
+	suite.Assert().True(suite.pool(counter).Has(tx.ID()))
 	suite.conduit.AssertExpectations(suite.T())
 }
 
@@ -284,7 +293,7 @@ func (suite *Suite) TestRoutingRemoteCluster() {
 	// should not be added to local mempool
 	counter, err := suite.epochQuery.Current().Counter()
 	suite.Assert().NoError(err)
-	suite.Assert().False(suite.pools.ForEpoch(counter).Has(tx.ID()))
+	suite.Assert().False(suite.pool(counter).Has(tx.ID()))
 	suite.conduit.AssertExpectations(suite.T())
 }
 
@@ -312,7 +321,7 @@ func (suite *Suite) TestRoutingLocalClusterFromOtherNode() {
 	// should be added to local mempool for current epoch
 	counter, err := suite.epochQuery.Current().Counter()
 	suite.Assert().NoError(err)
-	suite.Assert().True(suite.pools.ForEpoch(counter).Has(tx.ID()))
+	suite.Assert().True(suite.pool(counter).Has(tx.ID()))
 	suite.conduit.AssertExpectations(suite.T())
 }
 
@@ -340,7 +349,7 @@ func (suite *Suite) TestRoutingInvalidTransaction() {
 	// should not be added to local mempool
 	counter, err := suite.epochQuery.Current().Counter()
 	suite.Assert().NoError(err)
-	suite.Assert().False(suite.pools.ForEpoch(counter).Has(tx.ID()))
+	suite.Assert().False(suite.pool(counter).Has(tx.ID()))
 	suite.conduit.AssertExpectations(suite.T())
 }
 
@@ -377,8 +386,8 @@ func (suite *Suite) TestRouting_ClusterAssignmentChanged() {
 	suite.Assert().NoError(err)
 
 	// should add to local mempool for epoch 2 only
-	suite.Assert().True(suite.pools.ForEpoch(2).Has(tx.ID()))
-	suite.Assert().False(suite.pools.ForEpoch(1).Has(tx.ID()))
+	suite.Assert().True(suite.pool(2).Has(tx.ID()))
+	suite.Assert().False(suite.pool(1).Has(tx.ID()))
 	suite.conduit.AssertExpectations(suite.T())
 }
 
@@ -408,8 +417,8 @@ func (suite *Suite) TestRouting_ClusterAssignmentRemoved() {
 	suite.Assert().Error(err)
 
 	// should not add to mempool
-	suite.Assert().False(suite.pools.ForEpoch(2).Has(tx.ID()))
-	suite.Assert().False(suite.pools.ForEpoch(1).Has(tx.ID()))
+	suite.Assert().False(suite.pool(2).Has(tx.ID()))
+	suite.Assert().False(suite.pool(1).Has(tx.ID()))
 	// should not propagate
 	suite.conduit.AssertNumberOfCalls(suite.T(), "Multicast", 0)
 }
@@ -446,8 +455,8 @@ func (suite *Suite) TestRouting_ClusterAssignmentAdded() {
 	suite.Assert().Error(err)
 
 	// should not add to mempool
-	suite.Assert().False(suite.pools.ForEpoch(2).Has(tx.ID()))
-	suite.Assert().False(suite.pools.ForEpoch(1).Has(tx.ID()))
+	suite.Assert().False(suite.pool(2).Has(tx.ID()))
+	suite.Assert().False(suite.pool(1).Has(tx.ID()))
 	// should not propagate
 	suite.conduit.AssertNumberOfCalls(suite.T(), "Multicast", 0)
 
@@ -481,3 +490,269 @@ func (suite *Suite) TestRouting_ClusterAssignmentAdded() {
 	err = suite.engine.ProcessLocal(&tx)
 	suite.Assert().NoError(err)
 }
+
+// should reject transactions exceeding the quota of their payer, proposal key or origin
+func (suite *Suite) TestQuotas() {
+
+	now := time.Now()
+	colMetrics := new(module.CollectionMetrics)
+	colMetrics.On("TransactionIngested", mock.Anything)
+
+	// quota allowing bursts of 2 transactions, then one transaction per second
+	quota := Quota{Rate: 1, Burst: 2}
+
+	// transactions are received from another collection node, so they are not propagated
+	origin := suite.identities.Filter(filter.HasRole(flow.RoleCollection))[1].NodeID
+
+	// creates an engine with the quota set by the given function
+	setup := func(set func(conf *Config)) {
+		conf := suite.conf
+		set(&conf)
+		net := new(module.Network)
+		net.On("Register", mock.Anything, mock.Anything).Return(suite.conduit, nil).Once()
+		var err error
+		suite.engine, err = New(zerolog.New(ioutil.Discard), net, suite.state, metrics.NewNoopCollector(), colMetrics, suite.me, flow.Testnet.Chain(), suite.pools, conf)
+		suite.Require().NoError(err)
+		suite.engine.admission.now = func() time.Time { return now }
+	}
+
+	address := func(index uint64) flow.Address {
+		addr, err := flow.Testnet.Chain().AddressAtIndex(index)
+		suite.Require().NoError(err)
+		return addr
+	}
+
+	// creates a transaction with the given payer and proposal key address
+	create := func(payer uint64, proposer uint64) *flow.TransactionBody {
+		tx := unittest.TransactionBodyFixture()
+		tx.ReferenceBlockID = suite.root.ID()
+		tx.Payer = address(payer)
+		tx.ProposalKey.Address = address(proposer)
+		return &tx
+	}
+
+	suite.Run("payer quota", func() {
+		setup(func(conf *Config) { conf.PayerQuota = quota })
+		colMetrics.On("TransactionDropped", QuotaPayer).Once()
+
+		suite.Assert().NoError(suite.engine.Process(origin, create(1, 2)))
+		suite.Assert().NoError(suite.engine.Process(origin, create(1, 3)))
+
+		err := suite.engine.Process(origin, create(1, 4))
+		var quotaErr QuotaExceededError
+		suite.Require().True(errors.As(err, &quotaErr))
+		suite.Assert().Equal(QuotaPayer, quotaErr.Quota)
+
+		// other payers are not affected
+		suite.Assert().NoError(suite.engine.Process(origin, create(5, 4)))
+
+		// the quota is replenished over time
+		now = now.Add(time.Second)
+		suite.Assert().NoError(suite.engine.Process(origin, create(1, 6)))
+	})
+
+	suite.Run("payer quota is not charged for invalid transactions", func() {
+		setup(func(conf *Config) { conf.PayerQuota = quota })
+
+		for i := 0; i < 3; i++ {
+			tx := create(1, 2)
+			tx.GasLimit = flow.DefaultMaxGasLimit + 1
+			err := suite.engine.Process(origin, tx)
+			suite.Assert().True(errors.As(err, &access.InvalidGasLimitError{}))
+		}
+
+		suite.Assert().NoError(suite.engine.Process(origin, create(1, 2)))
+		suite.Assert().NoError(suite.engine.Process(origin, create(1, 3)))
+	})
+
+	suite.Run("proposer quota", func() {
+		setup(func(conf *Config) { conf.ProposerQuota = quota })
+		colMetrics.On("TransactionDropped", QuotaProposer).Once()
+
+		suite.Assert().NoError(suite.engine.Process(origin, create(2, 1)))
+		suite.Assert().NoError(suite.engine.Process(origin, create(3, 1)))
+
+		err := suite.engine.Process(origin, create(4, 1))
+		var quotaErr QuotaExceededError
+		suite.Require().True(errors.As(err, &quotaErr))
+		suite.Assert().Equal(QuotaProposer, quotaErr.Quota)
+
+		// another key of the same account is not affected
+		tx := create(4, 1)
+		tx.ProposalKey.KeyIndex++
+		suite.Assert().NoError(suite.engine.Process(origin, tx))
+	})
+
+	suite.Run("origin quota", func() {
+		setup(func(conf *Config) { conf.OriginQuota = quota })
+		colMetrics.On("TransactionDropped", QuotaOrigin).Once()
+
+		suite.conduit.On("Multicast", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		suite.Assert().NoError(suite.engine.ProcessClientTransaction("10.0.0.1", create(1, 1)))
+		suite.Assert().NoError(suite.engine.ProcessClientTransaction("10.0.0.1", create(2, 2)))
+
+		err := suite.engine.ProcessClientTransaction("10.0.0.1", create(3, 3))
+		var quotaErr QuotaExceededError
+		suite.Require().True(errors.As(err, &quotaErr))
+		suite.Assert().Equal(QuotaOrigin, quotaErr.Quota)
+
+		// other clients are not affected
+		suite.Assert().NoError(suite.engine.ProcessClientTransaction("10.0.0.2", create(3, 3)))
+
+		// transactions forwarded by other collection nodes are not subject to the quota
+		for i := uint64(0); i < 3; i++ {
+			suite.Assert().NoError(suite.engine.Process(origin, create(10+i, 10+i)))
+		}
+	})
+
+	colMetrics.AssertExpectations(suite.T())
+}
+
+// should verify transaction signatures against account keys, if configured
+func (suite *Suite) TestSignatureValidation() {
+
+	address, err := flow.Testnet.Chain().AddressAtIndex(1)
+	suite.Require().NoError(err)
+
+	privateKey, err := unittest.AccountKeyFixture()
+	suite.Require().NoError(err)
+	otherKey, err := unittest.AccountKeyFixture()
+	suite.Require().NoError(err)
+
+	// the account has a full weight key 0 with sequence number 5
+	accountKey := func() flow.AccountPublicKey {
+		key := privateKey.PublicKey(access.AccountKeyWeightThreshold)
+		key.Index = 0
+		key.SeqNumber = 5
+		return key
+	}
+	keys := accountKeys{address: []flow.AccountPublicKey{accountKey()}}
+
+	// a second key of the account without any weight
+	second := otherKey.PublicKey(0)
+	second.Index = 1
+
+	conf := suite.conf
+	conf.AccountKeys = keys
+	net := new(module.Network)
+	net.On("Register", mock.Anything, mock.Anything).Return(suite.conduit, nil).Once()
+	metrics := metrics.NewNoopCollector()
+	suite.engine, err = New(zerolog.New(ioutil.Discard), net, suite.state, metrics, metrics, suite.me, flow.Testnet.Chain(), suite.pools, conf)
+	suite.Require().NoError(err)
+
+	// transactions are received from another collection node, so they are not propagated
+	origin := suite.identities.Filter(filter.HasRole(flow.RoleCollection))[1].NodeID
+
+	// creates a transaction of the account, signed with the given key
+	create := func(signer *flow.AccountPrivateKey, seqNumber uint64) *flow.TransactionBody {
+		tx := unittest.TransactionBodyFixture()
+		tx.ReferenceBlockID = suite.root.ID()
+		tx.SetProposalKey(address, 0, seqNumber)
+		tx.Payer = address
+		tx.Authorizers = []flow.Address{address}
+		tx.PayloadSignatures = nil
+		tx.EnvelopeSignatures = nil
+		err := tx.SignEnvelope(address, 0, signer.PrivateKey, hash.NewSHA3_256())
+		suite.Require().NoError(err)
+		return &tx
+	}
+
+	suite.Run("valid signature", func() {
+		err := suite.engine.Process(origin, create(privateKey, 5))
+		suite.Assert().NoError(err)
+
+		// a higher sequence number may become valid
+		err = suite.engine.Process(origin, create(privateKey, 7))
+		suite.Assert().NoError(err)
+	})
+
+	suite.Run("invalid signature", func() {
+		err := suite.engine.Process(origin, create(otherKey, 5))
+		suite.Assert().True(errors.As(err, &access.SignatureVerificationError{}))
+	})
+
+	suite.Run("stale sequence number", func() {
+		err := suite.engine.Process(origin, create(privateKey, 4))
+		suite.Assert().True(errors.As(err, &access.StaleSequenceNumberError{}))
+	})
+
+	suite.Run("insufficient key weight", func() {
+		key := accountKey()
+		key.Weight = access.AccountKeyWeightThreshold / 2
+		keys[address] = []flow.AccountPublicKey{key}
+		defer func() { keys[address] = []flow.AccountPublicKey{accountKey()} }()
+
+		err := suite.engine.Process(origin, create(privateKey, 5))
+		suite.Assert().True(errors.As(err, &access.MissingSignatureError{}))
+	})
+
+	suite.Run("revoked key", func() {
+		key := accountKey()
+		key.Revoked = true
+		keys[address] = []flow.AccountPublicKey{key}
+		defer func() { keys[address] = []flow.AccountPublicKey{accountKey()} }()
+
+		err := suite.engine.Process(origin, create(privateKey, 5))
+		suite.Assert().True(errors.As(err, &access.RevokedAccountKeyError{}))
+	})
+
+	suite.Run("unknown key", func() {
+		// the key may have been added to the account after the keys were retrieved
+		keys[address] = []flow.AccountPublicKey{second}
+		defer func() { keys[address] = []flow.AccountPublicKey{accountKey()} }()
+
+		err := suite.engine.Process(origin, create(otherKey, 5))
+		suite.Assert().NoError(err)
+	})
+
+	suite.Run("missing proposal key signature", func() {
+		// the second key is the proposal key but does not sign
+		keys[address] = []flow.AccountPublicKey{accountKey(), second}
+		defer func() { keys[address] = []flow.AccountPublicKey{accountKey()} }()
+
+		tx := create(privateKey, 5)
+		tx.ProposalKey.KeyIndex = 1
+		tx.EnvelopeSignatures = nil
+		err := tx.SignEnvelope(address, 0, privateKey.PrivateKey, hash.NewSHA3_256())
+		suite.Require().NoError(err)
+
+		err = suite.engine.Process(origin, tx)
+		suite.Assert().True(errors.As(err, &access.MissingProposalKeySignatureError{}))
+	})
+}
+
+// should accept transactions if the account keys can not be retrieved
+func (suite *Suite) TestSignatureValidationUnavailable() {
+
+	conf := suite.conf
+	conf.AccountKeys = failingAccountKeys{}
+	net := new(module.Network)
+	net.On("Register", mock.Anything, mock.Anything).Return(suite.conduit, nil).Once()
+	metrics := metrics.NewNoopCollector()
+	var err error
+	suite.engine, err = New(zerolog.New(ioutil.Discard), net, suite.state, metrics, metrics, suite.me, flow.Testnet.Chain(), suite.pools, conf)
+	suite.Require().NoError(err)
+
+	// transactions are received from another collection node, so they are not propagated
+	origin := suite.identities.Filter(filter.HasRole(flow.RoleCollection))[1].NodeID
+
+	tx := unittest.TransactionBodyFixture()
+	tx.ReferenceBlockID = suite.root.ID()
+	err = suite.engine.Process(origin, &tx)
+	suite.Assert().NoError(err)
+}
+
+// accountKeys is an account key provider backed by a map.
+type accountKeys map[flow.Address][]flow.AccountPublicKey
+
+func (a accountKeys) AccountKeys(address flow.Address) ([]flow.AccountPublicKey, error) {
+	return a[address], nil
+}
+
+// failingAccountKeys is an account key provider which is unavailable.
+type failingAccountKeys struct{}
+
+func (failingAccountKeys) AccountKeys(flow.Address) ([]flow.AccountPublicKey, error) {
+	return nil, errors.New("execution node unavailable")
+}
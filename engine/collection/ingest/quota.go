@@ -0,0 +1,179 @@
+package ingest
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// names of the quotas transactions are subject to, also used as reasons for
+// dropped transactions in metrics
+const (
+	QuotaPayer    = "payer_quota"
+	QuotaProposer = "proposer_quota"
+	QuotaOrigin   = "origin_quota"
+)
+
+// Quota configures a token bucket admitting transactions at a sustained rate
+// with bursts. A zero rate disables the quota.
+type Quota struct {
+	// Rate is the number of transactions per second added to the bucket.
+	Rate float64
+	// Burst is the capacity of the bucket, ie. the number of transactions
+	// admitted at once after a period of inactivity. It is at least 1.
+	Burst uint
+}
+
+// QuotaExceededError indicates that a transaction was rejected because the
+// quota of its payer, proposer key or origin was exhausted.
+type QuotaExceededError struct {
+	Quota string
+	Key   string
+}
+
+func (e QuotaExceededError) Error() string {
+	return fmt.Sprintf("transaction quota exceeded: %s %s", e.Quota, e.Key)
+}
+
+// admission implements admission control of transactions, with a token bucket
+// per payer address, per proposer key and per origin. The origin of a client
+// transaction is charged before the transaction is validated, as it is the
+// only thing known about the sender. The payer and proposer key are charged
+// once the transaction is known to be valid, so that they can not be exhausted
+// by transactions forged on their behalf.
+type admission struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	payers    *buckets
+	proposers *buckets
+	origins   *buckets
+}
+
+func newAdmission(config Config) *admission {
+	return &admission{
+		now:       time.Now,
+		payers:    newBuckets(QuotaPayer, config.PayerQuota),
+		proposers: newBuckets(QuotaProposer, config.ProposerQuota),
+		origins:   newBuckets(QuotaOrigin, config.OriginQuota),
+	}
+}
+
+// quotaKey identifies the bucket of a key within the buckets of a quota.
+type quotaKey struct {
+	buckets *buckets
+	key     string
+}
+
+// admitOrigin takes a token from the given origin's bucket, or returns a
+// QuotaExceededError if it is exhausted.
+func (a *admission) admitOrigin(origin string) error {
+	return a.admit(quotaKey{a.origins, origin})
+}
+
+// admitAccounts takes a token for the given transaction from the payer's and
+// proposer key's buckets, or returns a QuotaExceededError for the first
+// exhausted bucket without taking any token.
+func (a *admission) admitAccounts(tx *flow.TransactionBody) error {
+	return a.admit(
+		quotaKey{a.payers, tx.Payer.Hex()},
+		quotaKey{a.proposers, fmt.Sprintf("%s/%d", tx.ProposalKey.Address.Hex(), tx.ProposalKey.KeyIndex)},
+	)
+}
+
+// admit takes a token from each of the given buckets if all of them have a
+// token left, or returns a QuotaExceededError for the first exhausted bucket.
+func (a *admission) admit(keys ...quotaKey) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	for _, k := range keys {
+		if !k.buckets.available(k.key, now) {
+			return QuotaExceededError{Quota: k.buckets.name, Key: k.key}
+		}
+	}
+	for _, k := range keys {
+		k.buckets.take(k.key)
+	}
+
+	return nil
+}
+
+// buckets holds the token buckets of one quota, keyed by the entity subject
+// to the quota.
+type buckets struct {
+	name       string
+	quota      Quota
+	tokens     map[string]*bucket
+	lastPruned time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func newBuckets(name string, quota Quota) *buckets {
+	// a bucket must hold at least one token to admit anything
+	if quota.Burst == 0 {
+		quota.Burst = 1
+	}
+	return &buckets{
+		name:   name,
+		quota:  quota,
+		tokens: make(map[string]*bucket),
+	}
+}
+
+// available refills the bucket of the given key and returns whether it has a
+// token left.
+func (b *buckets) available(key string, now time.Time) bool {
+	if b.quota.Rate <= 0 {
+		return true
+	}
+
+	b.prune(now)
+
+	bkt, ok := b.tokens[key]
+	if !ok {
+		bkt = &bucket{tokens: float64(b.quota.Burst), updated: now}
+		b.tokens[key] = bkt
+	}
+
+	elapsed := now.Sub(bkt.updated).Seconds()
+	if elapsed > 0 {
+		bkt.tokens += elapsed * b.quota.Rate
+		if bkt.tokens > float64(b.quota.Burst) {
+			bkt.tokens = float64(b.quota.Burst)
+		}
+		bkt.updated = now
+	}
+
+	return bkt.tokens >= 1
+}
+
+// take takes a token from the bucket of the given key, which must be available.
+func (b *buckets) take(key string) {
+	if b.quota.Rate <= 0 {
+		return
+	}
+	b.tokens[key].tokens--
+}
+
+// prune forgets the buckets which have been refilled completely, as they are
+// equivalent to new buckets. This bounds the memory used by the quota to the
+// number of keys which were active within the time needed to refill a bucket.
+func (b *buckets) prune(now time.Time) {
+	refill := time.Duration(float64(b.quota.Burst) / b.quota.Rate * float64(time.Second))
+	if now.Sub(b.lastPruned) < refill {
+		return
+	}
+	for key, bkt := range b.tokens {
+		if now.Sub(bkt.updated) >= refill {
+			delete(b.tokens, key)
+		}
+	}
+	b.lastPruned = now
+}
@@ -205,6 +205,45 @@ func (e *Engine) SendVote(blockID flow.Identifier, view uint64, sigData []byte,
 	return nil
 }
 
+// BroadcastTimeout submits a timeout of cluster consensus to all the collection
+// nodes in our cluster.
+func (e *Engine) BroadcastTimeout(view uint64, highestQC *flow.QuorumCertificate, sigData []byte) error {
+
+	log := e.log.With().
+		Uint64("timeout_view", view).
+		Uint64("highest_qc_view", highestQC.View).
+		Logger()
+	log.Debug().Msg("preparing to broadcast timeout from hotstuff")
+
+	// retrieve all collection nodes in our cluster
+	recipients, err := e.protoState.Final().Identities(filter.And(
+		filter.In(e.cluster),
+		filter.Not(filter.HasNodeID(e.me.NodeID())),
+	))
+	if err != nil {
+		return fmt.Errorf("could not get cluster members: %w", err)
+	}
+
+	// build the timeout message
+	timeout := &messages.ClusterBlockTimeout{
+		View:      view,
+		HighestQC: highestQC,
+		SigData:   sigData,
+	}
+
+	e.unit.Launch(func() {
+		err := e.conduit.Publish(timeout, recipients.NodeIDs()...)
+		if err != nil {
+			log.Warn().Err(err).Msg("could not broadcast timeout")
+			return
+		}
+		e.engMetrics.MessageSent(metrics.EngineProposal, metrics.MessageClusterBlockTimeout)
+		log.Debug().Msg("broadcast timeout from hotstuff")
+	})
+
+	return nil
+}
+
 // BroadcastProposal submits a cluster block proposal (effectively a proposal
 // for the next collection) to all the collection nodes in our cluster.
 func (e *Engine) BroadcastProposal(header *flow.Header) error {
@@ -314,6 +353,11 @@ func (e *Engine) process(originID flow.Identifier, event interface{}) error {
 		e.engMetrics.MessageReceived(metrics.EngineProposal, metrics.MessageClusterBlockVote)
 		defer e.engMetrics.MessageHandled(metrics.EngineProposal, metrics.MessageClusterBlockVote)
 		return e.onBlockVote(originID, ev)
+	case *messages.ClusterBlockTimeout:
+		// like votes, timeouts are passed directly to HotStuff
+		e.engMetrics.MessageReceived(metrics.EngineProposal, metrics.MessageClusterBlockTimeout)
+		defer e.engMetrics.MessageHandled(metrics.EngineProposal, metrics.MessageClusterBlockTimeout)
+		return e.onBlockTimeout(originID, ev)
 	default:
 		return fmt.Errorf("invalid event type (%T)", event)
 	}
@@ -564,6 +608,25 @@ func (e *Engine) onBlockVote(originID flow.Identifier, vote *messages.ClusterBlo
 	return nil
 }
 
+// onBlockTimeout handles timeouts of cluster consensus by passing them to the
+// core consensus algorithm
+func (e *Engine) onBlockTimeout(originID flow.Identifier, timeout *messages.ClusterBlockTimeout) error {
+
+	// the highest QC is dereferenced by HotStuff, a timeout without it is invalid
+	if timeout.HighestQC == nil {
+		return fmt.Errorf("timeout from %x for view %d has no highest QC", originID, timeout.View)
+	}
+
+	e.log.Debug().
+		Hex("origin_id", originID[:]).
+		Uint64("view", timeout.View).
+		Uint64("highest_qc_view", timeout.HighestQC.View).
+		Msg("received timeout")
+
+	e.hotstuff.SubmitTimeout(originID, timeout.View, timeout.HighestQC, timeout.SigData)
+	return nil
+}
+
 // prunePendingCache prunes the pending block cache by removing any blocks that
 // are below the finalized height.
 func (e *Engine) prunePendingCache() {
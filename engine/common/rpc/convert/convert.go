@@ -9,6 +9,7 @@ import (
 
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/crypto/hash"
+	executionext "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -359,3 +360,23 @@ func MessagesToIdentifiers(l [][]byte) []flow.Identifier {
 	}
 	return results
 }
+
+func RegisterIDsToMessages(l []flow.RegisterID) []*executionext.RegisterID {
+	results := make([]*executionext.RegisterID, len(l))
+	for i, item := range l {
+		results[i] = &executionext.RegisterID{
+			Owner:      []byte(item.Owner),
+			Controller: []byte(item.Controller),
+			Key:        []byte(item.Key),
+		}
+	}
+	return results
+}
+
+func MessagesToRegisterIDs(l []*executionext.RegisterID) []flow.RegisterID {
+	results := make([]flow.RegisterID, len(l))
+	for i, item := range l {
+		results[i] = flow.NewRegisterID(string(item.GetOwner()), string(item.GetController()), string(item.GetKey()))
+	}
+	return results
+}
@@ -186,6 +186,46 @@ func (e *Engine) SendVote(blockID flow.Identifier, view uint64, sigData []byte,
 	return nil
 }
 
+// BroadcastTimeout will propagate a timeout to all non-local consensus nodes.
+func (e *Engine) BroadcastTimeout(view uint64, highestQC *flow.QuorumCertificate, sigData []byte) error {
+
+	log := e.log.With().
+		Uint64("timeout_view", view).
+		Uint64("highest_qc_view", highestQC.View).
+		Hex("highest_qc_block_id", highestQC.BlockID[:]).
+		Logger()
+	log.Info().Msg("processing timeout broadcast request from hotstuff")
+
+	// retrieve all consensus nodes without our ID
+	recipients, err := e.state.Final().Identities(filter.And(
+		filter.HasRole(flow.RoleConsensus),
+		filter.Not(filter.HasNodeID(e.me.NodeID())),
+	))
+	if err != nil {
+		return fmt.Errorf("could not get consensus recipients: %w", err)
+	}
+
+	// build the timeout message
+	timeout := &messages.BlockTimeout{
+		View:      view,
+		HighestQC: highestQC,
+		SigData:   sigData,
+	}
+
+	e.unit.Launch(func() {
+		// broadcast the timeout to consensus nodes
+		err := e.con.Publish(timeout, recipients.NodeIDs()...)
+		if err != nil {
+			log.Warn().Err(err).Msg("could not send timeout message")
+			return
+		}
+		e.metrics.MessageSent(metrics.EngineCompliance, metrics.MessageBlockTimeout)
+		log.Info().Msg("block timeout broadcasted")
+	})
+
+	return nil
+}
+
 // BroadcastProposalWithDelay will propagate a block proposal to all non-local consensus nodes.
 // Note the header has incomplete fields, because it was converted from a hotstuff.
 func (e *Engine) BroadcastProposalWithDelay(header *flow.Header, delay time.Duration) error {
@@ -305,6 +345,11 @@ func (e *Engine) process(originID flow.Identifier, event interface{}) error {
 		e.metrics.MessageReceived(metrics.EngineCompliance, metrics.MessageBlockVote)
 		defer e.metrics.MessageHandled(metrics.EngineCompliance, metrics.MessageBlockVote)
 		return e.onBlockVote(originID, ev)
+	case *messages.BlockTimeout:
+		// like votes, timeouts are passed directly to HotStuff
+		e.metrics.MessageReceived(metrics.EngineCompliance, metrics.MessageBlockTimeout)
+		defer e.metrics.MessageHandled(metrics.EngineCompliance, metrics.MessageBlockTimeout)
+		return e.onBlockTimeout(originID, ev)
 	default:
 		return fmt.Errorf("invalid event type (%T)", event)
 	}
@@ -546,6 +591,28 @@ func (e *Engine) onBlockVote(originID flow.Identifier, vote *messages.BlockVote)
 	return nil
 }
 
+// onBlockTimeout handles incoming block timeouts.
+func (e *Engine) onBlockTimeout(originID flow.Identifier, timeout *messages.BlockTimeout) error {
+
+	// the highest QC is dereferenced by HotStuff, a timeout without it is invalid
+	if timeout.HighestQC == nil {
+		return fmt.Errorf("timeout from %x for view %d has no highest QC", originID, timeout.View)
+	}
+
+	log := e.log.With().
+		Uint64("timeout_view", timeout.View).
+		Uint64("highest_qc_view", timeout.HighestQC.View).
+		Hex("signer", originID[:]).
+		Logger()
+
+	log.Info().Msg("forwarding block timeout to hotstuff")
+
+	// forward the timeout to hotstuff for processing
+	e.hotstuff.SubmitTimeout(originID, timeout.View, timeout.HighestQC, timeout.SigData)
+
+	return nil
+}
+
 // processPendingChildren checks if there are proposals connected to the given
 // parent block that was just processed; if this is the case, they should now
 // all be validly connected to the finalized state and we should process them.
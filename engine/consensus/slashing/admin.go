@@ -0,0 +1,122 @@
+package slashing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// EvidencePath is the path of the admin API serving evidence of slashable
+// offences.
+const EvidencePath = "/slashing/evidence"
+
+// NewAdminHandler returns the handler of the admin API serving the evidence of
+// slashable offences collected by the node. GET /slashing/evidence lists all
+// evidence, optionally filtered by the offending node with the `offender` query
+// parameter, and GET /slashing/evidence/<id> returns the evidence with the
+// given ID.
+func NewAdminHandler(log zerolog.Logger, evidence storage.SlashingEvidence) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(EvidencePath, func(w http.ResponseWriter, r *http.Request) {
+		all, err := evidence.All()
+		if err != nil {
+			log.Error().Err(err).Msg("could not get slashing evidence")
+			http.Error(w, "could not get evidence", http.StatusInternalServerError)
+			return
+		}
+
+		offender := r.URL.Query().Get("offender")
+		if offender != "" {
+			offenderID, err := flow.HexStringToIdentifier(offender)
+			if err != nil {
+				http.Error(w, "invalid offender ID", http.StatusBadRequest)
+				return
+			}
+			filtered := make([]*flow.SlashingEvidence, 0, len(all))
+			for _, ev := range all {
+				if ev.Body.OffenderID == offenderID {
+					filtered = append(filtered, ev)
+				}
+			}
+			all = filtered
+		}
+
+		writeJSON(log, w, all)
+	})
+	mux.HandleFunc(EvidencePath+"/", func(w http.ResponseWriter, r *http.Request) {
+		evidenceID, err := flow.HexStringToIdentifier(strings.TrimPrefix(r.URL.Path, EvidencePath+"/"))
+		if err != nil {
+			http.Error(w, "invalid evidence ID", http.StatusBadRequest)
+			return
+		}
+		ev, err := evidence.ByID(evidenceID)
+		if errors.Is(err, storage.ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("could not get slashing evidence")
+			http.Error(w, "could not get evidence", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(log, w, ev)
+	})
+
+	return mux
+}
+
+func writeJSON(log zerolog.Logger, w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		log.Error().Err(err).Msg("could not write admin response")
+	}
+}
+
+// AdminServer is the http server of the admin API.
+type AdminServer struct {
+	server *http.Server
+	log    zerolog.Logger
+}
+
+// NewAdminServer creates a new admin API server listening on the given address.
+func NewAdminServer(log zerolog.Logger, address string, evidence storage.SlashingEvidence) *AdminServer {
+	log = log.With().Str("component", "admin_server").Logger()
+	return &AdminServer{
+		server: &http.Server{Addr: address, Handler: NewAdminHandler(log, evidence)},
+		log:    log,
+	}
+}
+
+// Ready returns a channel that will close when the server is started.
+func (s *AdminServer) Ready() <-chan struct{} {
+	ready := make(chan struct{})
+	go func() {
+		err := s.server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.log.Err(err).Msg("error running admin server")
+		}
+	}()
+	close(ready)
+	return ready
+}
+
+// Done returns a channel that will close when shutdown is complete.
+func (s *AdminServer) Done() <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_ = s.server.Shutdown(ctx)
+		cancel()
+		close(done)
+	}()
+	return done
+}
@@ -0,0 +1,62 @@
+package slashing
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestAdminHandler(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		evidence := bstorage.NewSlashingEvidence(db)
+		ev1 := unittest.SlashingEvidenceFixture()
+		ev2 := unittest.SlashingEvidenceFixture()
+		require.NoError(t, evidence.Store(ev1))
+		require.NoError(t, evidence.Store(ev2))
+
+		server := httptest.NewServer(NewAdminHandler(unittest.Logger(), evidence))
+		defer server.Close()
+
+		get := func(path string, v interface{}) int {
+			res, err := http.Get(server.URL + path)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			if res.StatusCode == http.StatusOK {
+				require.NoError(t, json.NewDecoder(res.Body).Decode(v))
+			}
+			return res.StatusCode
+		}
+
+		t.Run("list all evidence", func(t *testing.T) {
+			var all []*flow.SlashingEvidence
+			require.Equal(t, http.StatusOK, get(EvidencePath, &all))
+			assert.ElementsMatch(t, []*flow.SlashingEvidence{ev1, ev2}, all)
+		})
+
+		t.Run("list evidence of offender", func(t *testing.T) {
+			var all []*flow.SlashingEvidence
+			require.Equal(t, http.StatusOK, get(EvidencePath+"?offender="+ev2.Body.OffenderID.String(), &all))
+			assert.Equal(t, []*flow.SlashingEvidence{ev2}, all)
+
+			require.Equal(t, http.StatusBadRequest, get(EvidencePath+"?offender=invalid", nil))
+		})
+
+		t.Run("get evidence by ID", func(t *testing.T) {
+			var ev flow.SlashingEvidence
+			require.Equal(t, http.StatusOK, get(EvidencePath+"/"+ev1.ID().String(), &ev))
+			assert.Equal(t, ev1, &ev)
+
+			require.Equal(t, http.StatusNotFound, get(EvidencePath+"/"+unittest.IdentifierFixture().String(), nil))
+			require.Equal(t, http.StatusBadRequest, get(EvidencePath+"/invalid", nil))
+		})
+	})
+}
@@ -0,0 +1,90 @@
+package slashing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// VerifierFactory creates the verifier of signatures by the given committee.
+type VerifierFactory func(committee hotstuff.Committee) hotstuff.Verifier
+
+// epochCommittee is the consensus committee of a single epoch. It ignores the
+// block IDs it is queried with, so that signatures can be verified for blocks
+// which are unknown to this node, such as the conflicting block of a double
+// vote.
+type epochCommittee struct {
+	participants flow.IdentityList
+	dkg          protocol.DKG
+	me           flow.Identifier
+}
+
+// committeeForView returns the consensus committee of the epoch containing the
+// given view, as of the finalized state. Only the previous, current and next
+// epoch are known.
+func committeeForView(state protocol.State, me flow.Identifier, view uint64) (*epochCommittee, error) {
+
+	epochs := state.Final().Epochs()
+	for _, epoch := range []protocol.Epoch{epochs.Previous(), epochs.Current(), epochs.Next()} {
+		firstView, err := epoch.FirstView()
+		if errors.Is(err, protocol.ErrNoPreviousEpoch) || errors.Is(err, protocol.ErrNextEpochNotSetup) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not get first view of epoch: %w", err)
+		}
+		finalView, err := epoch.FinalView()
+		if err != nil {
+			return nil, fmt.Errorf("could not get final view of epoch: %w", err)
+		}
+		if view < firstView || view > finalView {
+			continue
+		}
+
+		identities, err := epoch.InitialIdentities()
+		if err != nil {
+			return nil, fmt.Errorf("could not get identities of epoch: %w", err)
+		}
+		dkg, err := epoch.DKG()
+		if err != nil {
+			return nil, fmt.Errorf("could not get DKG of epoch: %w", err)
+		}
+
+		c := &epochCommittee{
+			participants: identities.Filter(filter.IsVotingConsensusCommitteeMember),
+			dkg:          dkg,
+			me:           me,
+		}
+		return c, nil
+	}
+
+	return nil, fmt.Errorf("no known epoch contains view %d", view)
+}
+
+func (c *epochCommittee) Identities(_ flow.Identifier, selector flow.IdentityFilter) (flow.IdentityList, error) {
+	return c.participants.Filter(selector), nil
+}
+
+func (c *epochCommittee) Identity(_ flow.Identifier, participantID flow.Identifier) (*flow.Identity, error) {
+	identity, ok := c.participants.ByNodeID(participantID)
+	if !ok {
+		return nil, protocol.IdentityNotFoundError{NodeID: participantID}
+	}
+	return identity, nil
+}
+
+func (c *epochCommittee) LeaderForView(_ uint64) (flow.Identifier, error) {
+	return flow.ZeroID, fmt.Errorf("leader selection is not supported by epoch committee")
+}
+
+func (c *epochCommittee) Self() flow.Identifier {
+	return c.me
+}
+
+func (c *epochCommittee) DKG(_ flow.Identifier) (hotstuff.DKG, error) {
+	return c.dkg, nil
+}
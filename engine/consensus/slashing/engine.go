@@ -0,0 +1,390 @@
+package slashing
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/consensus/hotstuff/notifications"
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/encoding"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// Engine collects evidence of slashable offences by consensus nodes. It
+// consumes the slashing violations detected by HotStuff, packages them into
+// evidence signed by this node, persists the evidence and gossips it to the
+// other consensus nodes, which verify and persist it in turn.
+//
+// Invalid votes are only reported if their signature is valid, as votes with
+// an invalid signature can not be attributed to the voter.
+type Engine struct {
+	notifications.NoopConsumer // only slashing violations are consumed
+
+	unit        *engine.Unit             // used for concurrency & shutdown
+	log         zerolog.Logger           // used to log relevant actions with context
+	metrics     module.EngineMetrics     // used to track sent & received messages
+	con         network.Conduit          // used to gossip evidence to other consensus nodes
+	me          module.Local             // used to sign evidence reported by this node
+	state       protocol.State           // used to look up reporters, consensus nodes and committees by view
+	headers     storage.Headers          // used to look up the blocks of offences detected by this node
+	newVerifier VerifierFactory          // used to verify the signatures of offenders by the committee of the offence
+	evidence    storage.SlashingEvidence // used to persist and deduplicate evidence
+}
+
+// New creates a new slashing engine.
+func New(
+	log zerolog.Logger,
+	metrics module.EngineMetrics,
+	net module.Network,
+	me module.Local,
+	state protocol.State,
+	headers storage.Headers,
+	newVerifier VerifierFactory,
+	evidence storage.SlashingEvidence,
+) (*Engine, error) {
+
+	e := &Engine{
+		unit:        engine.NewUnit(),
+		log:         log.With().Str("engine", "slashing").Logger(),
+		metrics:     metrics,
+		me:          me,
+		state:       state,
+		headers:     headers,
+		newVerifier: newVerifier,
+		evidence:    evidence,
+	}
+
+	con, err := net.Register(engine.ConsensusSlashing, e)
+	if err != nil {
+		return nil, fmt.Errorf("could not register engine: %w", err)
+	}
+	e.con = con
+
+	return e, nil
+}
+
+// Ready returns a ready channel that is closed once the engine has fully
+// started.
+func (e *Engine) Ready() <-chan struct{} {
+	return e.unit.Ready()
+}
+
+// Done returns a done channel that is closed once the engine has fully stopped,
+// after pending evidence has been processed.
+func (e *Engine) Done() <-chan struct{} {
+	return e.unit.Done()
+}
+
+// SubmitLocal submits an event originating on the local node.
+func (e *Engine) SubmitLocal(event interface{}) {
+	e.Submit(e.me.NodeID(), event)
+}
+
+// Submit submits the given event from the node with the given origin ID
+// for processing in a non-blocking manner. It returns instantly and logs
+// a potential processing error internally when done.
+func (e *Engine) Submit(originID flow.Identifier, event interface{}) {
+	e.unit.Launch(func() {
+		err := e.Process(originID, event)
+		if err != nil {
+			engine.LogError(e.log, err)
+		}
+	})
+}
+
+// ProcessLocal processes an event originating on the local node.
+func (e *Engine) ProcessLocal(event interface{}) error {
+	return e.Process(e.me.NodeID(), event)
+}
+
+// Process processes the given event from the node with the given origin ID in
+// a blocking manner. It returns the potential processing error when done.
+func (e *Engine) Process(originID flow.Identifier, event interface{}) error {
+	return e.unit.Do(func() error {
+		return e.process(originID, event)
+	})
+}
+
+func (e *Engine) process(originID flow.Identifier, event interface{}) error {
+	switch ev := event.(type) {
+	case *flow.SlashingEvidence:
+		e.metrics.MessageReceived(metrics.EngineSlashing, metrics.MessageSlashingEvidence)
+		return e.onEvidence(originID, ev)
+	default:
+		return fmt.Errorf("invalid event type (%T)", event)
+	}
+}
+
+// OnDoubleVotingDetected reports the conflicting votes of a voter.
+func (e *Engine) OnDoubleVotingDetected(vote1 *model.Vote, vote2 *model.Vote) {
+	e.unit.Launch(func() {
+		votes := []flow.SignedVote{
+			{BlockID: vote1.BlockID, SigData: vote1.SigData},
+			{BlockID: vote2.BlockID, SigData: vote2.SigData},
+		}
+		err := e.report(flow.SlashingDoubleVote, vote1.SignerID, vote1.View, votes, nil)
+		if err != nil {
+			e.log.Error().Err(err).Hex("voter_id", vote1.SignerID[:]).Msg("could not report double voting")
+		}
+	})
+}
+
+// OnInvalidVoteDetected reports an invalid vote, if it can be attributed to the
+// voter. The view of the voted block is proven by its stored header.
+func (e *Engine) OnInvalidVoteDetected(vote *model.Vote) {
+	e.unit.Launch(func() {
+		header, err := e.headers.ByBlockID(vote.BlockID)
+		if err != nil {
+			e.log.Warn().Err(err).Hex("block_id", vote.BlockID[:]).Msg("could not get header of invalid vote")
+			return
+		}
+
+		votes := []flow.SignedVote{{BlockID: vote.BlockID, SigData: vote.SigData}}
+		err = e.report(flow.SlashingInvalidVote, vote.SignerID, vote.View, votes, []flow.Header{*header})
+		if err != nil {
+			e.log.Warn().Err(err).Hex("voter_id", vote.SignerID[:]).Msg("could not report invalid vote")
+		}
+	})
+}
+
+// OnDoubleProposeDetected reports the conflicting proposals of a proposer. The
+// signatures of the proposer are taken from the stored block headers, which are
+// included in the evidence.
+func (e *Engine) OnDoubleProposeDetected(block1 *model.Block, block2 *model.Block) {
+	e.unit.Launch(func() {
+		votes := make([]flow.SignedVote, 0, 2)
+		headers := make([]flow.Header, 0, 2)
+		for _, block := range []*model.Block{block1, block2} {
+			header, err := e.headers.ByBlockID(block.BlockID)
+			if err != nil {
+				e.log.Error().Err(err).Hex("block_id", block.BlockID[:]).Msg("could not get header of double proposal")
+				return
+			}
+			votes = append(votes, flow.SignedVote{BlockID: block.BlockID, SigData: header.ProposerSig})
+			headers = append(headers, *header)
+		}
+
+		err := e.report(flow.SlashingDoublePropose, block1.ProposerID, block1.View, votes, headers)
+		if err != nil {
+			e.log.Error().Err(err).Hex("proposer_id", block1.ProposerID[:]).Msg("could not report double proposal")
+		}
+	})
+}
+
+// report packages the given votes of the offender, along with the headers of
+// the voted blocks if the offence depends on them, into evidence signed by this
+// node, persists it and gossips it to the other consensus nodes.
+func (e *Engine) report(violation flow.SlashingViolation, offenderID flow.Identifier, view uint64, votes []flow.SignedVote, headers []flow.Header) error {
+
+	// order conflicting votes by block ID, so that reports of the same offence
+	// are deduplicated regardless of the order the votes were received in
+	if len(votes) == 2 && bytes.Compare(votes[0].BlockID[:], votes[1].BlockID[:]) > 0 {
+		votes[0], votes[1] = votes[1], votes[0]
+		if len(headers) == 2 {
+			headers[0], headers[1] = headers[1], headers[0]
+		}
+	}
+	body := flow.SlashingEvidenceBody{
+		Violation:  violation,
+		OffenderID: offenderID,
+		View:       view,
+		Votes:      votes,
+		Headers:    headers,
+	}
+
+	err := e.verifyBody(&body)
+	if err != nil {
+		return fmt.Errorf("could not verify evidence: %w", err)
+	}
+
+	bodyID := body.ID()
+	sig, err := e.me.Sign(bodyID[:], crypto.NewBLSKMAC(encoding.SlashingEvidenceTag))
+	if err != nil {
+		return fmt.Errorf("could not sign evidence: %w", err)
+	}
+	evidence := &flow.SlashingEvidence{
+		Body:        body,
+		ReporterID:  e.me.NodeID(),
+		ReporterSig: sig,
+	}
+
+	log := e.log.With().
+		Str("violation", string(violation)).
+		Hex("offender_id", offenderID[:]).
+		Uint64("view", view).
+		Hex("evidence_id", bodyID[:]).
+		Logger()
+
+	err = e.evidence.Store(evidence)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		log.Debug().Msg("skipping evidence of already reported offence")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not store evidence: %w", err)
+	}
+
+	log.Warn().Msg("slashable offence detected")
+
+	recipients, err := e.state.Final().Identities(filter.And(
+		filter.HasRole(flow.RoleConsensus),
+		filter.Not(filter.HasNodeID(e.me.NodeID())),
+	))
+	if err != nil {
+		return fmt.Errorf("could not get consensus nodes: %w", err)
+	}
+	err = e.con.Publish(evidence, recipients.NodeIDs()...)
+	if err != nil {
+		return fmt.Errorf("could not gossip evidence: %w", err)
+	}
+
+	e.metrics.MessageSent(metrics.EngineSlashing, metrics.MessageSlashingEvidence)
+
+	return nil
+}
+
+// onEvidence verifies and persists evidence gossiped by another consensus node.
+func (e *Engine) onEvidence(originID flow.Identifier, evidence *flow.SlashingEvidence) error {
+
+	// evidence is only gossiped by the reporter
+	if originID != evidence.ReporterID {
+		return engine.NewInvalidInputErrorf("evidence reported by %x was sent by %x", evidence.ReporterID, originID)
+	}
+
+	reporter, err := e.state.Final().Identity(evidence.ReporterID)
+	if protocol.IsIdentityNotFound(err) {
+		return engine.NewInvalidInputErrorf("evidence reported by unknown node %x", evidence.ReporterID)
+	}
+	if err != nil {
+		return fmt.Errorf("could not get reporter identity: %w", err)
+	}
+	if reporter.Role != flow.RoleConsensus {
+		return engine.NewInvalidInputErrorf("evidence reported by non-consensus node %x", evidence.ReporterID)
+	}
+
+	bodyID := evidence.Body.ID()
+	valid, err := reporter.StakingPubKey.Verify(evidence.ReporterSig, bodyID[:], crypto.NewBLSKMAC(encoding.SlashingEvidenceTag))
+	if err != nil {
+		return fmt.Errorf("could not verify reporter signature: %w", err)
+	}
+	if !valid {
+		return engine.NewInvalidInputErrorf("invalid reporter signature on evidence %x", bodyID)
+	}
+
+	err = e.verifyBody(&evidence.Body)
+	if err != nil {
+		return engine.NewInvalidInputErrorf("invalid evidence %x: %v", bodyID, err)
+	}
+
+	err = e.evidence.Store(evidence)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not store evidence: %w", err)
+	}
+
+	e.log.Warn().
+		Str("violation", string(evidence.Body.Violation)).
+		Hex("offender_id", evidence.Body.OffenderID[:]).
+		Uint64("view", evidence.Body.View).
+		Hex("reporter_id", evidence.ReporterID[:]).
+		Hex("evidence_id", bodyID[:]).
+		Msg("slashable offence reported")
+
+	return nil
+}
+
+// verifyBody checks that the votes of the evidence are signed by the offender
+// and prove the offence, using the headers included in the evidence rather than
+// the blocks known to this node, which may not include the voted blocks. The
+// signatures are verified against the committee of the epoch containing the
+// view of the offence.
+func (e *Engine) verifyBody(body *flow.SlashingEvidenceBody) error {
+
+	switch body.Violation {
+	case flow.SlashingDoubleVote, flow.SlashingDoublePropose:
+		if len(body.Votes) != 2 {
+			return fmt.Errorf("expected two conflicting votes, got %d", len(body.Votes))
+		}
+		if bytes.Compare(body.Votes[0].BlockID[:], body.Votes[1].BlockID[:]) >= 0 {
+			return fmt.Errorf("conflicting votes are not for distinct blocks ordered by ID")
+		}
+		if body.Violation == flow.SlashingDoubleVote {
+			if len(body.Headers) != 0 {
+				return fmt.Errorf("unexpected headers for double vote")
+			}
+			break
+		}
+		err := verifyHeaders(body)
+		if err != nil {
+			return err
+		}
+		for i, header := range body.Headers {
+			if header.ProposerID != body.OffenderID || header.View != body.View {
+				return fmt.Errorf("block %x is not proposed by the offender at view %d", header.ID(), body.View)
+			}
+			if !bytes.Equal(header.ProposerSig, body.Votes[i].SigData) {
+				return fmt.Errorf("vote for block %x is not the signature of its proposal", body.Votes[i].BlockID)
+			}
+		}
+
+	case flow.SlashingInvalidVote:
+		if len(body.Votes) != 1 {
+			return fmt.Errorf("expected a single invalid vote, got %d", len(body.Votes))
+		}
+		err := verifyHeaders(body)
+		if err != nil {
+			return err
+		}
+		if body.Headers[0].View == body.View {
+			return fmt.Errorf("vote view matches the view of the voted block")
+		}
+
+	default:
+		return fmt.Errorf("unknown violation %q", body.Violation)
+	}
+
+	committee, err := committeeForView(e.state, e.me.NodeID(), body.View)
+	if err != nil {
+		return fmt.Errorf("could not get committee for view %d: %w", body.View, err)
+	}
+	verifier := e.newVerifier(committee)
+
+	for _, vote := range body.Votes {
+		valid, err := verifier.VerifyVote(body.OffenderID, vote.SigData, &model.Block{BlockID: vote.BlockID, View: body.View})
+		if err != nil {
+			return fmt.Errorf("could not verify offender signature for block %x: %w", vote.BlockID, err)
+		}
+		if !valid {
+			return fmt.Errorf("invalid offender signature for block %x", vote.BlockID)
+		}
+	}
+
+	return nil
+}
+
+// verifyHeaders checks that the evidence includes the header of the block of
+// every vote.
+func verifyHeaders(body *flow.SlashingEvidenceBody) error {
+	if len(body.Headers) != len(body.Votes) {
+		return fmt.Errorf("expected %d headers of voted blocks, got %d", len(body.Votes), len(body.Headers))
+	}
+	for i, header := range body.Headers {
+		if header.ID() != body.Votes[i].BlockID {
+			return fmt.Errorf("header does not match voted block %x", body.Votes[i].BlockID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,466 @@
+package slashing
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	hotstuffmocks "github.com/onflow/flow-go/consensus/hotstuff/mocks"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/encoding"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/module/local"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/network/mocknetwork"
+	realprotocol "github.com/onflow/flow-go/state/protocol"
+	bprotocol "github.com/onflow/flow-go/state/protocol/badger"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	storerr "github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	storage "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+	"github.com/onflow/flow-go/utils/unittest/mocks"
+)
+
+type Suite struct {
+	suite.Suite
+
+	db       *badger.DB
+	dbDir    string
+	keys     map[flow.Identifier]crypto.PrivateKey
+	me       *flow.Identity
+	other    *flow.Identity // another consensus node
+	offender *flow.Identity
+	verifier *hotstuffmocks.Verifier
+	headers  map[flow.Identifier]*flow.Header
+	sigs     map[flow.Identifier]crypto.Signature // valid signatures of the offender by block ID
+	conduit  *mocknetwork.Conduit
+	state    *protocol.State
+	final    *protocol.Snapshot
+
+	identities flow.IdentityList
+	committees []hotstuff.Committee // committees the signatures of offenders were verified against
+
+	engine *Engine
+}
+
+func TestSlashingEngine(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (suite *Suite) SetupTest() {
+
+	suite.dbDir = unittest.TempDir(suite.T())
+	suite.db = unittest.BadgerDB(suite.T(), suite.dbDir)
+
+	// staking keys of the nodes are ECDSA keys, to not depend on BLS in tests
+	suite.keys = make(map[flow.Identifier]crypto.PrivateKey)
+	suite.identities = unittest.CompleteIdentitySet(unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleConsensus))...)
+	for _, identity := range suite.identities {
+		key, err := unittest.NetworkingKey()
+		suite.Require().NoError(err)
+		identity.StakingPubKey = key.PublicKey()
+		suite.keys[identity.NodeID] = key
+	}
+	consensus := suite.identities.Filter(func(identity *flow.Identity) bool { return identity.Role == flow.RoleConsensus })
+	suite.me, suite.other, suite.offender = consensus[0], consensus[1], consensus[2]
+
+	me, err := local.New(suite.me, suite.keys[suite.me.NodeID])
+	suite.Require().NoError(err)
+
+	suite.headers = make(map[flow.Identifier]*flow.Header)
+	headers := new(storage.Headers)
+	headers.On("ByBlockID", mock.Anything).Return(
+		func(blockID flow.Identifier) *flow.Header { return suite.headers[blockID] },
+		func(blockID flow.Identifier) error {
+			if _, ok := suite.headers[blockID]; !ok {
+				return storerr.ErrNotFound
+			}
+			return nil
+		},
+	)
+
+	suite.sigs = make(map[flow.Identifier]crypto.Signature)
+	suite.verifier = new(hotstuffmocks.Verifier)
+	suite.verifier.On("VerifyVote", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(voterID flow.Identifier, sigData []byte, block *model.Block) bool {
+			return voterID == suite.offender.NodeID && bytes.Equal(sigData, suite.sigs[block.BlockID])
+		},
+		nil,
+	)
+
+	suite.conduit = new(mocknetwork.Conduit)
+	suite.state = new(protocol.State)
+	suite.final = new(protocol.Snapshot)
+	suite.state.On("Final").Return(suite.final)
+	suite.final.On("Identities", mock.Anything).Return(
+		func(f flow.IdentityFilter) flow.IdentityList { return suite.identities.Filter(f) },
+		nil,
+	)
+	suite.final.On("Identity", mock.Anything).Return(
+		func(nodeID flow.Identifier) *flow.Identity {
+			identity, _ := suite.identities.ByNodeID(nodeID)
+			return identity
+		},
+		func(nodeID flow.Identifier) error {
+			_, ok := suite.identities.ByNodeID(nodeID)
+			if !ok {
+				return realprotocol.IdentityNotFoundError{NodeID: nodeID}
+			}
+			return nil
+		},
+	)
+
+	// the offences are committed within the current epoch, which ends at view 100
+	setup := unittest.EpochSetupFixture(
+		unittest.SetupWithCounter(1),
+		unittest.WithParticipants(suite.identities),
+		unittest.WithFinalView(100),
+	)
+	setup.FirstView = 0
+	commit := &flow.EpochCommit{
+		Counter:         1,
+		DKGParticipants: make(map[flow.Identifier]flow.DKGParticipant),
+	}
+	for i, participant := range consensus {
+		commit.DKGParticipants[participant.NodeID] = flow.DKGParticipant{Index: uint(i)}
+	}
+	suite.committees = nil
+	suite.final.On("Epochs").Return(mocks.NewEpochQuery(suite.T(), 1, bprotocol.NewCommittedEpoch(setup, commit)))
+
+	suite.engine = &Engine{
+		unit:    engine.NewUnit(),
+		log:     unittest.Logger(),
+		metrics: metrics.NewNoopCollector(),
+		con:     suite.conduit,
+		me:      me,
+		state:   suite.state,
+		headers: headers,
+		newVerifier: func(committee hotstuff.Committee) hotstuff.Verifier {
+			suite.committees = append(suite.committees, committee)
+			return suite.verifier
+		},
+		evidence: bstorage.NewSlashingEvidence(suite.db),
+	}
+}
+
+func (suite *Suite) TearDownTest() {
+	suite.Require().NoError(suite.db.Close())
+	suite.Require().NoError(os.RemoveAll(suite.dbDir))
+}
+
+// vote returns a vote of the offender for a new block at the given view. The
+// block is proposed by the offender, and its header is known.
+func (suite *Suite) vote(view uint64) *model.Vote {
+	header := unittest.BlockHeaderFixture()
+	header.View = view
+	header.ProposerID = suite.offender.NodeID
+	header.ProposerSig = unittest.SignatureFixture()
+	blockID := header.ID()
+	suite.headers[blockID] = &header
+	suite.sigs[blockID] = header.ProposerSig
+
+	return &model.Vote{
+		View:     view,
+		BlockID:  blockID,
+		SignerID: suite.offender.NodeID,
+		SigData:  header.ProposerSig,
+	}
+}
+
+// wait waits for the notifications launched by the engine to be processed.
+func (suite *Suite) wait() {
+	<-suite.engine.Done()
+	suite.engine.unit = engine.NewUnit()
+}
+
+// expectGossip expects the evidence to be published to the other consensus nodes.
+func (suite *Suite) expectGossip() {
+	suite.conduit.On("Publish", mock.Anything, suite.other.NodeID, suite.offender.NodeID).Return(nil).Once()
+}
+
+// reported returns all evidence stored by the engine.
+func (suite *Suite) reported() []*flow.SlashingEvidence {
+	all, err := suite.engine.evidence.All()
+	suite.Require().NoError(err)
+	return all
+}
+
+// sign returns evidence of the given body signed by the given reporter.
+func (suite *Suite) sign(body flow.SlashingEvidenceBody, reporterID flow.Identifier) *flow.SlashingEvidence {
+	bodyID := body.ID()
+	sig, err := suite.keys[reporterID].Sign(bodyID[:], crypto.NewBLSKMAC(encoding.SlashingEvidenceTag))
+	suite.Require().NoError(err)
+	return &flow.SlashingEvidence{
+		Body:        body,
+		ReporterID:  reporterID,
+		ReporterSig: sig,
+	}
+}
+
+func (suite *Suite) TestDoubleVote() {
+	vote1, vote2 := suite.vote(10), suite.vote(10)
+
+	suite.expectGossip()
+	suite.engine.OnDoubleVotingDetected(vote1, vote2)
+	suite.wait()
+	// the same offence detected with the votes in the other order is deduplicated
+	suite.engine.OnDoubleVotingDetected(vote2, vote1)
+	suite.wait()
+
+	reported := suite.reported()
+	suite.Require().Len(reported, 1)
+	evidence := reported[0]
+	suite.Assert().Equal(flow.SlashingDoubleVote, evidence.Body.Violation)
+	suite.Assert().Equal(suite.offender.NodeID, evidence.Body.OffenderID)
+	suite.Assert().Equal(uint64(10), evidence.Body.View)
+	suite.Assert().Len(evidence.Body.Votes, 2)
+	suite.Assert().Equal(suite.me.NodeID, evidence.ReporterID)
+
+	// the evidence is signed by this node
+	bodyID := evidence.Body.ID()
+	valid, err := suite.me.StakingPubKey.Verify(evidence.ReporterSig, bodyID[:], crypto.NewBLSKMAC(encoding.SlashingEvidenceTag))
+	suite.Require().NoError(err)
+	suite.Assert().True(valid)
+
+	suite.conduit.AssertExpectations(suite.T())
+}
+
+func (suite *Suite) TestDoubleVoteInvalidSignature() {
+	vote1, vote2 := suite.vote(10), suite.vote(10)
+	vote2.SigData = unittest.SignatureFixture()
+
+	suite.engine.OnDoubleVotingDetected(vote1, vote2)
+	suite.wait()
+
+	suite.Assert().Empty(suite.reported())
+	suite.conduit.AssertNotCalled(suite.T(), "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *Suite) TestDoubleVoteForUnknownBlock() {
+	vote1, vote2 := suite.vote(10), suite.vote(10)
+	delete(suite.headers, vote2.BlockID)
+
+	suite.expectGossip()
+	suite.engine.OnDoubleVotingDetected(vote1, vote2)
+	suite.wait()
+
+	suite.Assert().Len(suite.reported(), 1)
+	suite.conduit.AssertExpectations(suite.T())
+
+	// the signatures are verified against the committee of the epoch of the view
+	suite.Require().NotEmpty(suite.committees)
+	for _, committee := range suite.committees {
+		participants, err := committee.Identities(vote2.BlockID, filter.Any)
+		suite.Require().NoError(err)
+		suite.Assert().ElementsMatch(suite.identities.Filter(filter.IsVotingConsensusCommitteeMember).NodeIDs(), participants.NodeIDs())
+	}
+}
+
+func (suite *Suite) TestDoubleVoteOutsideKnownEpochs() {
+	vote1, vote2 := suite.vote(101), suite.vote(101)
+
+	suite.engine.OnDoubleVotingDetected(vote1, vote2)
+	suite.wait()
+
+	suite.Assert().Empty(suite.reported())
+	suite.Assert().Empty(suite.committees)
+	suite.conduit.AssertNotCalled(suite.T(), "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *Suite) TestDoublePropose() {
+	vote1, vote2 := suite.vote(10), suite.vote(10)
+
+	suite.expectGossip()
+	suite.engine.OnDoubleProposeDetected(
+		&model.Block{BlockID: vote1.BlockID, View: 10, ProposerID: suite.offender.NodeID},
+		&model.Block{BlockID: vote2.BlockID, View: 10, ProposerID: suite.offender.NodeID},
+	)
+	suite.wait()
+
+	reported := suite.reported()
+	suite.Require().Len(reported, 1)
+	suite.Assert().Equal(flow.SlashingDoublePropose, reported[0].Body.Violation)
+	suite.Assert().ElementsMatch([]flow.SignedVote{
+		{BlockID: vote1.BlockID, SigData: suite.headers[vote1.BlockID].ProposerSig},
+		{BlockID: vote2.BlockID, SigData: suite.headers[vote2.BlockID].ProposerSig},
+	}, reported[0].Body.Votes)
+	suite.Assert().ElementsMatch([]flow.Identifier{vote1.BlockID, vote2.BlockID}, flow.GetIDs([]flow.Entity{&reported[0].Body.Headers[0], &reported[0].Body.Headers[1]}))
+	suite.conduit.AssertExpectations(suite.T())
+}
+
+func (suite *Suite) TestInvalidVote() {
+
+	suite.Run("vote for block at another view", func() {
+		vote := suite.vote(10)
+		vote.View = 11
+		suite.sigs[vote.BlockID] = vote.SigData
+
+		suite.expectGossip()
+		suite.engine.OnInvalidVoteDetected(vote)
+		suite.wait()
+
+		reported := suite.reported()
+		suite.Require().Len(reported, 1)
+		suite.Assert().Equal(flow.SlashingInvalidVote, reported[0].Body.Violation)
+		suite.conduit.AssertExpectations(suite.T())
+	})
+
+	suite.Run("unattributable vote", func() {
+		vote := suite.vote(10)
+		vote.View = 11
+		vote.SigData = unittest.SignatureFixture()
+
+		suite.engine.OnInvalidVoteDetected(vote)
+		suite.wait()
+
+		suite.Assert().Len(suite.reported(), 1)
+	})
+}
+
+func (suite *Suite) TestOnEvidence() {
+	vote1, vote2 := suite.vote(10), suite.vote(10)
+	if bytes.Compare(vote1.BlockID[:], vote2.BlockID[:]) > 0 {
+		vote1, vote2 = vote2, vote1
+	}
+	body := flow.SlashingEvidenceBody{
+		Violation:  flow.SlashingDoubleVote,
+		OffenderID: suite.offender.NodeID,
+		View:       10,
+		Votes: []flow.SignedVote{
+			{BlockID: vote1.BlockID, SigData: vote1.SigData},
+			{BlockID: vote2.BlockID, SigData: vote2.SigData},
+		},
+	}
+
+	suite.Run("invalid evidence", func() {
+		collector := suite.identities.Filter(func(identity *flow.Identity) bool { return identity.Role == flow.RoleCollection })[0]
+
+		unordered := body
+		unordered.Votes = []flow.SignedVote{body.Votes[1], body.Votes[0]}
+		forged := body
+		forged.Votes = []flow.SignedVote{body.Votes[0], {BlockID: vote2.BlockID, SigData: unittest.SignatureFixture()}}
+		badSig := suite.sign(body, suite.other.NodeID)
+		badSig.ReporterSig = unittest.SignatureFixture()
+
+		cases := map[string]struct {
+			originID flow.Identifier
+			evidence *flow.SlashingEvidence
+		}{
+			"sent by other node":      {suite.offender.NodeID, suite.sign(body, suite.other.NodeID)},
+			"non-consensus reporter":  {collector.NodeID, suite.sign(body, collector.NodeID)},
+			"invalid reporter sig":    {suite.other.NodeID, badSig},
+			"unordered votes":         {suite.other.NodeID, suite.sign(unordered, suite.other.NodeID)},
+			"forged offender sig":     {suite.other.NodeID, suite.sign(forged, suite.other.NodeID)},
+			"single conflicting vote": {suite.other.NodeID, suite.sign(flow.SlashingEvidenceBody{Violation: flow.SlashingDoubleVote, OffenderID: suite.offender.NodeID, View: 10, Votes: body.Votes[:1]}, suite.other.NodeID)},
+		}
+		for name, c := range cases {
+			err := suite.engine.Process(c.originID, c.evidence)
+			suite.Assert().True(engine.IsInvalidInputError(err), name)
+		}
+		suite.Assert().Empty(suite.reported())
+	})
+
+	suite.Run("valid evidence", func() {
+		evidence := suite.sign(body, suite.other.NodeID)
+		err := suite.engine.Process(suite.other.NodeID, evidence)
+		suite.Require().NoError(err)
+		suite.Assert().Equal([]*flow.SlashingEvidence{evidence}, suite.reported())
+
+		// the same offence reported by another node is deduplicated
+		err = suite.engine.Process(suite.me.NodeID, suite.sign(body, suite.me.NodeID))
+		suite.Require().NoError(err)
+		suite.Assert().Equal([]*flow.SlashingEvidence{evidence}, suite.reported())
+
+		// and so is the detection of the offence by this node
+		suite.engine.OnDoubleVotingDetected(vote1, vote2)
+		suite.wait()
+		suite.Assert().Len(suite.reported(), 1)
+		suite.conduit.AssertNotCalled(suite.T(), "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// TestOnEvidenceForUnknownBlocks verifies evidence of offences depending on
+// blocks this node does not know, using the headers included in the evidence.
+func (suite *Suite) TestOnEvidenceForUnknownBlocks() {
+	vote1, vote2 := suite.vote(10), suite.vote(10)
+	if bytes.Compare(vote1.BlockID[:], vote2.BlockID[:]) > 0 {
+		vote1, vote2 = vote2, vote1
+	}
+	header1, header2 := *suite.headers[vote1.BlockID], *suite.headers[vote2.BlockID]
+	invalid := suite.vote(11)
+	invalidHeader := *suite.headers[invalid.BlockID]
+	invalid.View = 12
+	suite.headers = make(map[flow.Identifier]*flow.Header)
+
+	doublePropose := flow.SlashingEvidenceBody{
+		Violation:  flow.SlashingDoublePropose,
+		OffenderID: suite.offender.NodeID,
+		View:       10,
+		Votes: []flow.SignedVote{
+			{BlockID: vote1.BlockID, SigData: vote1.SigData},
+			{BlockID: vote2.BlockID, SigData: vote2.SigData},
+		},
+		Headers: []flow.Header{header1, header2},
+	}
+	invalidVote := flow.SlashingEvidenceBody{
+		Violation:  flow.SlashingInvalidVote,
+		OffenderID: suite.offender.NodeID,
+		View:       12,
+		Votes:      []flow.SignedVote{{BlockID: invalid.BlockID, SigData: invalid.SigData}},
+		Headers:    []flow.Header{invalidHeader},
+	}
+
+	suite.Run("invalid evidence", func() {
+		missing := doublePropose
+		missing.Headers = nil
+		swapped := doublePropose
+		swapped.Headers = []flow.Header{header2, header1}
+		otherProposer := header2
+		otherProposer.ProposerID = suite.other.NodeID
+		notProposed := doublePropose
+		notProposed.Headers = []flow.Header{header1, otherProposer}
+		notProposed.Votes = []flow.SignedVote{doublePropose.Votes[0], {BlockID: otherProposer.ID(), SigData: vote2.SigData}}
+		sameView := invalidVote
+		sameView.View = 11
+
+		cases := map[string]flow.SlashingEvidenceBody{
+			"missing headers":        missing,
+			"headers of other votes": swapped,
+			"not proposed":           notProposed,
+			"vote at block view":     sameView,
+		}
+		for name, body := range cases {
+			err := suite.engine.Process(suite.other.NodeID, suite.sign(body, suite.other.NodeID))
+			suite.Assert().True(engine.IsInvalidInputError(err), name)
+		}
+		suite.Assert().Empty(suite.reported())
+	})
+
+	suite.Run("valid evidence", func() {
+		for _, body := range []flow.SlashingEvidenceBody{doublePropose, invalidVote} {
+			err := suite.engine.Process(suite.other.NodeID, suite.sign(body, suite.other.NodeID))
+			suite.Require().NoError(err)
+		}
+		suite.Assert().Len(suite.reported(), 2)
+	})
+}
+
+func TestEvidenceID(t *testing.T) {
+	// evidence is identified by its body only
+	evidence := unittest.SlashingEvidenceFixture()
+	other := *evidence
+	other.ReporterID = unittest.IdentifierFixture()
+	other.ReporterSig = unittest.SignatureFixture()
+	require.Equal(t, evidence.ID(), other.ID())
+	require.NotEqual(t, evidence.Checksum(), other.Checksum())
+}
@@ -31,12 +31,26 @@ type BlockComputer interface {
 }
 
 type blockComputer struct {
-	vm             VirtualMachine
-	vmCtx          fvm.Context
-	metrics        module.ExecutionMetrics
-	tracer         module.Tracer
-	log            zerolog.Logger
-	systemChunkCtx fvm.Context
+	vm              VirtualMachine
+	vmCtx           fvm.Context
+	metrics         module.ExecutionMetrics
+	tracer          module.Tracer
+	log             zerolog.Logger
+	systemChunkCtx  fvm.Context
+	parallelWorkers int
+}
+
+// BlockComputerOption configures optional behaviour of a block computer.
+type BlockComputerOption func(*blockComputer)
+
+// WithParallelExecution makes the block computer execute the transactions of each collection
+// speculatively in parallel, using the given number of workers. Transactions which read registers
+// written by earlier transactions of the same collection are re-executed, so that results are
+// identical to serial execution. A number of workers lower than 2 keeps serial execution.
+func WithParallelExecution(workers int) BlockComputerOption {
+	return func(e *blockComputer) {
+		e.parallelWorkers = workers
+	}
 }
 
 // NewBlockComputer creates a new block executor.
@@ -46,6 +60,7 @@ func NewBlockComputer(
 	metrics module.ExecutionMetrics,
 	tracer module.Tracer,
 	logger zerolog.Logger,
+	opts ...BlockComputerOption,
 ) (BlockComputer, error) {
 	systemChunkASTCache, err := fvm.NewLRUASTCache(SystemChunkASTCacheSize)
 	if err != nil {
@@ -60,14 +75,19 @@ func NewBlockComputer(
 		fvm.WithTransactionProcessors(fvm.NewTransactionInvocator(logger)),
 	)
 
-	return &blockComputer{
+	e := &blockComputer{
 		vm:             vm,
 		vmCtx:          vmCtx,
 		metrics:        metrics,
 		tracer:         tracer,
 		log:            logger,
 		systemChunkCtx: systemChunkCtx,
-	}, nil
+	}
+	for _, apply := range opts {
+		apply(e)
+	}
+
+	return e, nil
 }
 
 // ExecuteBlock executes a block and returns the resulting chunks.
@@ -180,6 +200,10 @@ func (e *blockComputer) executeCollection(
 	collection *entity.CompleteCollection,
 ) ([]flow.Event, []flow.TransactionResult, uint32, uint64, error) {
 
+	if e.parallelWorkers > 1 {
+		return e.executeCollectionInParallel(ctx, txIndex, blockCtx, collectionView, collection)
+	}
+
 	var colSpan opentracing.Span
 	if e.tracer != nil {
 		colSpan, _ = e.tracer.StartSpanFromContext(ctx, trace.EXEComputeCollection)
@@ -255,12 +279,23 @@ func (e *blockComputer) executeTransaction(
 		return nil, flow.TransactionResult{}, 0, fmt.Errorf("failed to execute transaction: %w", err)
 	}
 
+	txResult := e.transactionResult(txBody, tx)
+
+	if tx.Err == nil {
+		collectionView.MergeView(txView)
+	}
+
+	return tx.Events, txResult, tx.GasUsed, nil
+}
+
+func (e *blockComputer) transactionResult(txBody *flow.TransactionBody, tx *fvm.TransactionProcedure) flow.TransactionResult {
 	txResult := flow.TransactionResult{
 		TransactionID: tx.ID,
 	}
 
 	if tx.Err != nil {
 		txResult.ErrorMessage = tx.Err.Error()
+		txResult.ErrorCode = tx.Err.Code()
 		e.log.Debug().
 			Hex("tx_id", logging.Entity(txBody)).
 			Str("error_message", tx.Err.Error()).
@@ -272,9 +307,5 @@ func (e *blockComputer) executeTransaction(
 			Msg("transaction executed successfully")
 	}
 
-	if tx.Err == nil {
-		collectionView.MergeView(txView)
-	}
-
-	return tx.Events, txResult, tx.GasUsed, nil
+	return txResult
 }
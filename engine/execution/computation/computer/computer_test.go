@@ -134,6 +134,7 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 				txResult := flow.TransactionResult{
 					TransactionID: t.ID(),
 					ErrorMessage:  "no payer address provided",
+					ErrorCode:     (&fvm.MissingPayerError{}).Code(),
 				}
 				expectedResults = append(expectedResults, txResult)
 			}
@@ -0,0 +1,201 @@
+package computer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/entity"
+	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// speculation is the outcome of executing a transaction against the state of the collection view
+// before any transaction of the collection has been applied.
+type speculation struct {
+	tx      *fvm.TransactionProcedure
+	view    *delta.View
+	metrics *fvm.MetricsCollector
+	reads   []flow.RegisterID // registers read from the collection view, in order
+	err     error
+}
+
+// executeCollectionInParallel executes the transactions of a collection with optimistic concurrency.
+//
+// All transactions are first executed speculatively in parallel, each in its own view reading the
+// collection view without modifying it. Transactions are then committed in collection order. A
+// transaction which has not read any register written by an earlier transaction of the collection
+// has read the same values as in serial execution, so its speculative outcome is valid: its reads
+// are replayed on the collection view (which reproduces the register touches, reads count and SPoCK
+// secret of serial execution) and its view is merged. Any other transaction conflicts and is
+// re-executed serially against the collection view.
+func (e *blockComputer) executeCollectionInParallel(
+	ctx context.Context,
+	txIndex uint32,
+	blockCtx fvm.Context,
+	collectionView *delta.View,
+	collection *entity.CompleteCollection,
+) ([]flow.Event, []flow.TransactionResult, uint32, uint64, error) {
+
+	var colSpan opentracing.Span
+	if e.tracer != nil {
+		colSpan, _ = e.tracer.StartSpanFromContext(ctx, trace.EXEComputeCollection)
+		defer colSpan.Finish()
+	}
+
+	var (
+		events    []flow.Event
+		txResults []flow.TransactionResult
+		gasUsed   uint64
+	)
+
+	speculations := e.speculate(blockCtx, colSpan, txIndex, collectionView, collection.Transactions)
+
+	// registers written by the transactions committed so far
+	written := make(map[string]struct{})
+	reExecuted := 0
+
+	for i, txBody := range collection.Transactions {
+		s := speculations[i]
+
+		if conflicts(s.reads, written) {
+			reExecuted++
+			e.log.Debug().
+				Hex("tx_id", logging.Entity(txBody)).
+				Msg("transaction conflicts with an earlier transaction, re-executing")
+
+			// collectionView.Get records the reads as serial execution does, nothing needs to be replayed
+			s = e.executeSpeculatively(blockCtx, colSpan, txBody, txIndex, collectionView.Get)
+		} else {
+			for _, id := range s.reads {
+				_, err := collectionView.Get(id.Owner, id.Controller, id.Key)
+				if err != nil {
+					return nil, nil, txIndex, 0, fmt.Errorf("failed to replay reads of transaction: %w", err)
+				}
+			}
+		}
+
+		if e.metrics != nil {
+			e.metrics.TransactionParsed(s.metrics.Parsed())
+			e.metrics.TransactionChecked(s.metrics.Checked())
+			e.metrics.TransactionInterpreted(s.metrics.Interpreted())
+		}
+
+		txIndex++
+
+		if s.err != nil {
+			return nil, nil, txIndex, 0, fmt.Errorf("failed to execute transaction: %w", s.err)
+		}
+
+		txResult := e.transactionResult(txBody, s.tx)
+
+		if s.tx.Err == nil {
+			for _, id := range s.view.Delta().RegisterIDs() {
+				written[id.String()] = struct{}{}
+			}
+			collectionView.MergeView(s.view)
+		}
+
+		events = append(events, s.tx.Events...)
+		txResults = append(txResults, txResult)
+		gasUsed += s.tx.GasUsed
+	}
+
+	if e.metrics != nil && reExecuted > 0 {
+		e.metrics.ExecutionTransactionsReExecuted(reExecuted)
+	}
+
+	return events, txResults, txIndex, gasUsed, nil
+}
+
+// speculate executes the given transactions in parallel against the current state of the collection view.
+func (e *blockComputer) speculate(
+	blockCtx fvm.Context,
+	colSpan opentracing.Span,
+	txIndex uint32,
+	collectionView *delta.View,
+	transactions []*flow.TransactionBody,
+) []*speculation {
+
+	speculations := make([]*speculation, len(transactions))
+
+	indices := make(chan int, len(transactions))
+	for i := range transactions {
+		indices <- i
+	}
+	close(indices)
+
+	workers := e.parallelWorkers
+	if workers > len(transactions) {
+		workers = len(transactions)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				speculations[i] = e.executeSpeculatively(blockCtx, colSpan, transactions[i], txIndex+uint32(i), collectionView.Peek)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return speculations
+}
+
+// executeSpeculatively executes a transaction in a new view reading registers using the given function,
+// recording the registers read through it.
+func (e *blockComputer) executeSpeculatively(
+	blockCtx fvm.Context,
+	colSpan opentracing.Span,
+	txBody *flow.TransactionBody,
+	txIndex uint32,
+	read delta.GetRegisterFunc,
+) *speculation {
+
+	s := &speculation{
+		tx:      fvm.Transaction(txBody, txIndex),
+		metrics: fvm.NewMetricsCollector(),
+	}
+
+	s.view = delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+		s.reads = append(s.reads, flow.NewRegisterID(owner, controller, key))
+		return read(owner, controller, key)
+	})
+
+	if e.tracer != nil {
+		txSpan := e.tracer.StartSpanFromParent(colSpan, trace.EXEComputeTransaction)
+		defer func() {
+			txSpan.LogFields(
+				log.Int64(trace.EXEParseDurationTag, int64(s.metrics.Parsed())),
+				log.Int64(trace.EXECheckDurationTag, int64(s.metrics.Checked())),
+				log.Int64(trace.EXEInterpretDurationTag, int64(s.metrics.Interpreted())),
+			)
+			txSpan.Finish()
+		}()
+	}
+
+	txCtx := fvm.NewContextFromParent(blockCtx, fvm.WithMetricsCollector(s.metrics))
+
+	s.err = e.vm.Run(txCtx, s.tx, s.view)
+
+	return s
+}
+
+// conflicts returns true if any of the given registers has been written.
+func conflicts(reads []flow.RegisterID, written map[string]struct{}) bool {
+	for _, id := range reads {
+		if _, ok := written[id.String()]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package computer_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/entity"
+)
+
+// registerVM executes transactions whose script is a list of register operations, separated by spaces:
+//   * "r:<key>" reads a register
+//   * "w:<key>" writes a register, with a value depending on all values read so far
+//   * "fail" fails the transaction
+// Every transaction emits an event holding the values it has read.
+type registerVM struct{}
+
+func (registerVM) Run(_ fvm.Context, proc fvm.Procedure, ledger state.Ledger) error {
+	tx, ok := proc.(*fvm.TransactionProcedure)
+	if !ok {
+		return fmt.Errorf("unexpected procedure")
+	}
+
+	var read []string
+	for _, op := range strings.Fields(string(tx.Transaction.Script)) {
+		switch {
+		case strings.HasPrefix(op, "r:"):
+			value, err := ledger.Get("owner", "", op[2:])
+			if err != nil {
+				return err
+			}
+			read = append(read, string(value))
+		case strings.HasPrefix(op, "w:"):
+			ledger.Set("owner", "", op[2:], flow.RegisterValue(fmt.Sprintf("%d(%s)", tx.TxIndex, strings.Join(read, ","))))
+		case op == "fail":
+			tx.Err = &fvm.MissingPayerError{}
+		}
+	}
+
+	tx.Events = []flow.Event{{
+		Type:             "read",
+		TransactionIndex: tx.TxIndex,
+		Payload:          []byte(strings.Join(read, ",")),
+	}}
+	tx.GasUsed = uint64(len(read))
+
+	return nil
+}
+
+func TestBlockExecutor_ParallelExecution(t *testing.T) {
+
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+
+	randomScript := func() string {
+		ops := make([]string, 0)
+		for i := 0; i < 1+rand.Intn(4); i++ {
+			op := "r:"
+			if rand.Intn(3) == 0 {
+				op = "w:"
+			}
+			ops = append(ops, op+keys[rand.Intn(len(keys))])
+		}
+		if rand.Intn(10) == 0 {
+			ops = append(ops, "fail")
+		}
+		return strings.Join(ops, " ")
+	}
+
+	block := generateBlock(3, 20)
+	for _, collection := range block.CompleteCollections {
+		for _, tx := range collection.Transactions {
+			tx.Script = []byte(randomScript())
+		}
+	}
+
+	readFunc := func(owner, controller, key string) (flow.RegisterValue, error) {
+		if key == "a" {
+			return flow.RegisterValue("initial"), nil
+		}
+		return nil, nil
+	}
+
+	execute := func(opts ...computer.BlockComputerOption) (*execution.ComputationResult, *delta.View) {
+		exe, err := computer.NewBlockComputer(registerVM{}, fvm.NewContext(zerolog.Nop()), nil, nil, zerolog.Nop(), opts...)
+		require.NoError(t, err)
+
+		view := delta.NewView(readFunc)
+		result, err := exe.ExecuteBlock(context.Background(), block, view)
+		require.NoError(t, err)
+		return result, view
+	}
+
+	serial, serialView := execute()
+	parallel, parallelView := execute(computer.WithParallelExecution(4))
+
+	assert.Equal(t, serial.Events, parallel.Events)
+	assert.Equal(t, serial.TransactionResult, parallel.TransactionResult)
+	assert.Equal(t, serial.GasUsed, parallel.GasUsed)
+	assert.Equal(t, serial.StateReads, parallel.StateReads)
+
+	require.Len(t, parallel.StateSnapshots, len(serial.StateSnapshots))
+	for i := range serial.StateSnapshots {
+		assert.Equal(t, serial.StateSnapshots[i].Delta, parallel.StateSnapshots[i].Delta)
+		assert.ElementsMatch(t, serial.StateSnapshots[i].Reads, parallel.StateSnapshots[i].Reads)
+		assert.Equal(t, serial.StateSnapshots[i].SpockSecret, parallel.StateSnapshots[i].SpockSecret)
+	}
+
+	assert.Equal(t, serialView.Delta(), parallelView.Delta())
+}
+
+func TestBlockExecutor_ParallelExecutionConflicts(t *testing.T) {
+
+	// each transaction increments the same register, so every transaction but the first one conflicts
+	collection := generateCollection(5)
+	for _, tx := range collection.Transactions {
+		tx.Script = []byte("r:counter w:counter")
+	}
+	block := &entity.ExecutableBlock{
+		Block: &flow.Block{
+			Header:  &flow.Header{View: 42},
+			Payload: &flow.Payload{Guarantees: []*flow.CollectionGuarantee{collection.Guarantee}},
+		},
+		CompleteCollections: map[flow.Identifier]*entity.CompleteCollection{collection.Guarantee.ID(): collection},
+	}
+
+	exe, err := computer.NewBlockComputer(registerVM{}, fvm.NewContext(zerolog.Nop()), nil, nil, zerolog.Nop(), computer.WithParallelExecution(5))
+	require.NoError(t, err)
+
+	view := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+		return nil, nil
+	})
+	result, err := exe.ExecuteBlock(context.Background(), block, view)
+	require.NoError(t, err)
+
+	// every transaction has seen the write of the previous one
+	require.Len(t, result.Events, 5+1) // +1 system chunk
+	assert.Equal(t, "", string(result.Events[0].Payload))
+	for i := 1; i < 5; i++ {
+		assert.Equal(t, fmt.Sprintf("%d(%s)", i-1, string(result.Events[i-1].Payload)), string(result.Events[i].Payload))
+	}
+}
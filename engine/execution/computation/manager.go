@@ -2,9 +2,11 @@ package computation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime"
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/engine/execution"
@@ -32,6 +34,13 @@ type ComputationManager interface {
 		view *delta.View,
 	) (*execution.ComputationResult, error)
 	GetAccount(addr flow.Address, header *flow.Header, view *delta.View) (*flow.Account, error)
+	EstimateTransaction(
+		tx *flow.TransactionBody,
+		header *flow.Header,
+		view *delta.View,
+		skipSignatureVerification bool,
+		skipSequenceNumberCheck bool,
+	) (*fvm.TransactionProcedure, error)
 }
 
 // Manager manages computation and execution
@@ -52,6 +61,7 @@ func New(
 	protoState protocol.State,
 	vm VirtualMachine,
 	vmCtx fvm.Context,
+	computerOpts ...computer.BlockComputerOption,
 ) (*Manager, error) {
 	log := logger.With().Str("engine", "computation").Logger()
 
@@ -61,6 +71,7 @@ func New(
 		metrics,
 		tracer,
 		log.With().Str("component", "block_computer").Logger(),
+		computerOpts...,
 	)
 
 	if err != nil {
@@ -137,3 +148,101 @@ func (e *Manager) GetAccount(address flow.Address, blockHeader *flow.Header, vie
 
 	return account, nil
 }
+
+// EstimateTransaction executes a transaction against a child of the given view, so that none of
+// its effects are persisted, and returns the executed procedure. Signature verification and
+// sequence number checks can optionally be skipped, which allows estimating transactions that
+// have not been signed yet.
+//
+// The runtime only reports the computation used by a transaction once it exceeds its limit, so
+// the gas used is determined by searching for the lowest gas limit under which the transaction
+// does not run out of computation. The gas limit of the given transaction is ignored.
+func (e *Manager) EstimateTransaction(
+	tx *flow.TransactionBody,
+	blockHeader *flow.Header,
+	view *delta.View,
+	skipSignatureVerification bool,
+	skipSequenceNumberCheck bool,
+) (*fvm.TransactionProcedure, error) {
+
+	processors := make([]fvm.TransactionProcessor, 0, len(e.vmCtx.TransactionProcessors))
+	for _, processor := range e.vmCtx.TransactionProcessors {
+		switch processor.(type) {
+		case *fvm.TransactionSignatureVerifier:
+			if skipSignatureVerification {
+				continue
+			}
+		case *fvm.TransactionSequenceNumberChecker:
+			if skipSequenceNumberCheck {
+				continue
+			}
+		}
+		processors = append(processors, processor)
+	}
+
+	blockCtx := fvm.NewContextFromParent(
+		e.vmCtx,
+		fvm.WithBlockHeader(blockHeader),
+		fvm.WithTransactionProcessors(processors...),
+	)
+
+	run := func(gasLimit uint64) (*fvm.TransactionProcedure, error) {
+		body := *tx
+		body.GasLimit = gasLimit
+
+		proc := fvm.Transaction(&body, 0)
+
+		err := e.vm.Run(blockCtx, proc, view.NewChild())
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate transaction (internal error): %w", err)
+		}
+
+		return proc, nil
+	}
+
+	proc, err := run(flow.DefaultMaxGasLimit)
+	if err != nil {
+		return nil, err
+	}
+	if proc.Err != nil {
+		if isComputationLimitExceeded(proc.Err) {
+			proc.GasUsed = flow.DefaultMaxGasLimit
+		}
+		return proc, nil
+	}
+
+	// the transaction succeeds with the maximum gas limit, find the lowest one it succeeds with
+	low, high := uint64(1), uint64(flow.DefaultMaxGasLimit)
+	for low < high {
+		mid := low + (high-low)/2
+
+		attempt, err := run(mid)
+		if err != nil {
+			return nil, err
+		}
+
+		if attempt.Err == nil {
+			proc, high = attempt, mid
+			continue
+		}
+		if !isComputationLimitExceeded(attempt.Err) {
+			// the transaction failed for a reason other than running out of computation,
+			// which can only happen if its execution depends on the gas limit
+			return attempt, nil
+		}
+		low = mid + 1
+	}
+
+	proc.GasUsed = high
+
+	return proc, nil
+}
+
+func isComputationLimitExceeded(err fvm.Error) bool {
+	execErr, ok := err.(*fvm.ExecutionError)
+	if !ok {
+		return false
+	}
+
+	return errors.As(execErr.Err, &runtime.ComputationLimitExceededError{})
+}
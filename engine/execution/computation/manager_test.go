@@ -103,3 +103,53 @@ func TestComputeBlockWithStorage(t *testing.T) {
 	require.Len(t, returnedComputationResult.StateSnapshots, 1+1) // 1 coll + 1 system chunk
 	assert.NotEmpty(t, returnedComputationResult.StateSnapshots[0].Delta)
 }
+
+func TestEstimateTransaction(t *testing.T) {
+	rt := runtime.NewInterpreterRuntime()
+
+	chain := flow.Mainnet.Chain()
+
+	vm := fvm.New(rt)
+	execCtx := fvm.NewContext(zerolog.Nop(), fvm.WithChain(chain), fvm.WithCadenceLogging(true))
+
+	ledger := testutil.RootBootstrappedLedger(vm, execCtx)
+
+	// the transaction is neither signed nor uses the correct sequence number
+	tx := flow.NewTransactionBody().
+		SetScript([]byte(`transaction { prepare(signer: AuthAccount) { log("estimated") } }`)).
+		AddAuthorizer(chain.ServiceAddress()).
+		SetProposalKey(chain.ServiceAddress(), 0, 42).
+		SetPayer(chain.ServiceAddress())
+
+	engine := &Manager{
+		vm:    vm,
+		vmCtx: execCtx,
+	}
+
+	header := &flow.Header{View: 42}
+
+	t.Run("with all checks", func(t *testing.T) {
+		view := delta.NewView(ledger.Get)
+
+		proc, err := engine.EstimateTransaction(tx, header, view, false, false)
+		require.NoError(t, err)
+
+		require.Error(t, proc.Err)
+		assert.Empty(t, proc.Logs)
+		assert.Empty(t, view.Delta().Data)
+	})
+
+	t.Run("skipping signature and sequence number checks", func(t *testing.T) {
+		view := delta.NewView(ledger.Get)
+
+		proc, err := engine.EstimateTransaction(tx, header, view, true, true)
+		require.NoError(t, err)
+
+		require.NoError(t, proc.Err)
+		assert.Equal(t, []string{`"estimated"`}, proc.Logs)
+		assert.Greater(t, proc.GasUsed, uint64(0))
+
+		// the effects of the transaction are not written to the given view
+		assert.Empty(t, view.Delta().Data)
+	})
+}
@@ -12,6 +12,8 @@ import (
 
 	flow "github.com/onflow/flow-go/model/flow"
 
+	fvm "github.com/onflow/flow-go/fvm"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -43,6 +45,29 @@ func (_m *ComputationManager) ComputeBlock(ctx context.Context, block *entity.Ex
 	return r0, r1
 }
 
+// EstimateTransaction provides a mock function with given fields: tx, header, view, skipSignatureVerification, skipSequenceNumberCheck
+func (_m *ComputationManager) EstimateTransaction(tx *flow.TransactionBody, header *flow.Header, view *delta.View, skipSignatureVerification bool, skipSequenceNumberCheck bool) (*fvm.TransactionProcedure, error) {
+	ret := _m.Called(tx, header, view, skipSignatureVerification, skipSequenceNumberCheck)
+
+	var r0 *fvm.TransactionProcedure
+	if rf, ok := ret.Get(0).(func(*flow.TransactionBody, *flow.Header, *delta.View, bool, bool) *fvm.TransactionProcedure); ok {
+		r0 = rf(tx, header, view, skipSignatureVerification, skipSequenceNumberCheck)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*fvm.TransactionProcedure)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*flow.TransactionBody, *flow.Header, *delta.View, bool, bool) error); ok {
+		r1 = rf(tx, header, view, skipSignatureVerification, skipSequenceNumberCheck)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecuteScript provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *ComputationManager) ExecuteScript(_a0 []byte, _a1 [][]byte, _a2 *flow.Header, _a3 *delta.View) ([]byte, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
@@ -50,3 +50,36 @@ func (s *Deltas) All() []*messages.ExecutionStateDelta {
 	}
 	return deltas
 }
+
+// DeltaSize is the stdmap.EntitySizer of state deltas, it accounts for their collections,
+// register updates, events and transaction results.
+func DeltaSize(entity flow.Entity) uint64 {
+	delta := entity.(*messages.ExecutionStateDelta)
+
+	size := uint64(0)
+	for _, collection := range delta.CompleteCollections {
+		for _, tx := range collection.Transactions {
+			size += stdmap.TransactionBodySize(tx)
+		}
+	}
+	for _, snapshot := range delta.StateInteractions {
+		for _, entry := range snapshot.Delta.Data {
+			size += uint64(len(entry.Key.Owner) + len(entry.Key.Controller) + len(entry.Key.Key) + len(entry.Value))
+		}
+		for _, id := range snapshot.Reads {
+			size += uint64(len(id.Owner) + len(id.Controller) + len(id.Key))
+		}
+	}
+	for _, event := range delta.Events {
+		size += uint64(len(event.Type) + len(event.Payload))
+	}
+	for _, result := range delta.TransactionResults {
+		size += uint64(len(result.ErrorMessage))
+	}
+	return size
+}
+
+// DeltaHeight is the stdmap.HeightFunc of state deltas, which is the height of their block.
+func DeltaHeight(entity flow.Entity) uint64 {
+	return entity.(*messages.ExecutionStateDelta).Block.Header.Height
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"sort"
 	"strings"
 
 	"github.com/rs/zerolog"
@@ -23,6 +24,8 @@ import (
 	"github.com/onflow/flow-go/engine/execution/state"
 	"github.com/onflow/flow-go/engine/execution/state/delta"
 	"github.com/onflow/flow-go/engine/execution/utils"
+	"github.com/onflow/flow-go/fvm"
+	fvmState "github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/model/messages"
@@ -981,6 +984,64 @@ func (e *Engine) GetAccount(ctx context.Context, addr flow.Address, blockID flow
 	return e.computationManager.GetAccount(addr, block, blockView)
 }
 
+func (e *Engine) EstimateTransaction(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	blockID flow.Identifier,
+	skipSignatureVerification bool,
+	skipSequenceNumberCheck bool,
+) (*fvm.TransactionProcedure, error) {
+	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state commitment for block (%s): %w", blockID, err)
+	}
+
+	block, err := e.state.AtBlockID(blockID).Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block (%s): %w", blockID, err)
+	}
+
+	blockView := e.execState.NewView(stateCommit)
+
+	return e.computationManager.EstimateTransaction(tx, block, blockView, skipSignatureVerification, skipSequenceNumberCheck)
+}
+
+func (e *Engine) GetRegistersWithProof(ctx context.Context, blockID flow.Identifier, registerIDs []flow.RegisterID) (flow.StorageProof, error) {
+	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state commitment for block (%s): %w", blockID, err)
+	}
+
+	return e.execState.GetProof(ctx, stateCommit, registerIDs)
+}
+
+func (e *Engine) GetAccountWithProof(ctx context.Context, addr flow.Address, blockID flow.Identifier) (*flow.Account, []flow.RegisterID, flow.StorageProof, error) {
+	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get state commitment for block (%s): %w", blockID, err)
+	}
+
+	// decode the account directly from its registers, recording the registers read by the view,
+	// so that the proof covers exactly the registers needed to decode the account again
+	blockView := e.execState.NewView(stateCommit)
+	account, err := fvmState.NewAccounts(blockView).Get(addr)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get account (%s): %w", addr, err)
+	}
+
+	registerIDs := blockView.Interactions().Reads
+	sort.Slice(registerIDs, func(i, j int) bool {
+		return registerIDs[i].String() < registerIDs[j].String()
+	})
+
+	proof, err := e.execState.GetProof(ctx, stateCommit, registerIDs)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get proof for account (%s): %w", addr, err)
+	}
+
+	return account, registerIDs, proof, nil
+}
+
 func (e *Engine) handleComputationResult(
 	ctx context.Context,
 	result *execution.ComputationResult,
@@ -3,6 +3,7 @@ package ingestion
 import (
 	"context"
 
+	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -14,4 +15,20 @@ type IngestRPC interface {
 
 	// GetAccount returns the Account details at the given Block id
 	GetAccount(ctx context.Context, address flow.Address, blockID flow.Identifier) (*flow.Account, error)
+
+	// EstimateTransaction executes a transaction at the given Block id without committing its effects
+	EstimateTransaction(
+		ctx context.Context,
+		tx *flow.TransactionBody,
+		blockID flow.Identifier,
+		skipSignatureVerification bool,
+		skipSequenceNumberCheck bool,
+	) (*fvm.TransactionProcedure, error)
+
+	// GetRegistersWithProof returns a proof of the values of the given registers at the given Block id
+	GetRegistersWithProof(ctx context.Context, blockID flow.Identifier, registerIDs []flow.RegisterID) (flow.StorageProof, error)
+
+	// GetAccountWithProof returns the Account details at the given Block id, together with the registers
+	// the account is decoded from and a proof of their values
+	GetAccountWithProof(ctx context.Context, address flow.Address, blockID flow.Identifier) (*flow.Account, []flow.RegisterID, flow.StorageProof, error)
 }
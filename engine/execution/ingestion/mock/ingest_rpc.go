@@ -7,6 +7,8 @@ import (
 
 	flow "github.com/onflow/flow-go/model/flow"
 
+	fvm "github.com/onflow/flow-go/fvm"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -15,6 +17,29 @@ type IngestRPC struct {
 	mock.Mock
 }
 
+// EstimateTransaction provides a mock function with given fields: ctx, tx, blockID, skipSignatureVerification, skipSequenceNumberCheck
+func (_m *IngestRPC) EstimateTransaction(ctx context.Context, tx *flow.TransactionBody, blockID flow.Identifier, skipSignatureVerification bool, skipSequenceNumberCheck bool) (*fvm.TransactionProcedure, error) {
+	ret := _m.Called(ctx, tx, blockID, skipSignatureVerification, skipSequenceNumberCheck)
+
+	var r0 *fvm.TransactionProcedure
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, flow.Identifier, bool, bool) *fvm.TransactionProcedure); ok {
+		r0 = rf(ctx, tx, blockID, skipSignatureVerification, skipSequenceNumberCheck)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*fvm.TransactionProcedure)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, flow.Identifier, bool, bool) error); ok {
+		r1 = rf(ctx, tx, blockID, skipSignatureVerification, skipSequenceNumberCheck)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecuteScriptAtBlockID provides a mock function with given fields: ctx, script, arguments, blockID
 func (_m *IngestRPC) ExecuteScriptAtBlockID(ctx context.Context, script []byte, arguments [][]byte, blockID flow.Identifier) ([]byte, error) {
 	ret := _m.Called(ctx, script, arguments, blockID)
@@ -60,3 +85,67 @@ func (_m *IngestRPC) GetAccount(ctx context.Context, address flow.Address, block
 
 	return r0, r1
 }
+
+// GetAccountWithProof provides a mock function with given fields: ctx, address, blockID
+func (_m *IngestRPC) GetAccountWithProof(ctx context.Context, address flow.Address, blockID flow.Identifier) (*flow.Account, []flow.RegisterID, []byte, error) {
+	ret := _m.Called(ctx, address, blockID)
+
+	var r0 *flow.Account
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, flow.Identifier) *flow.Account); ok {
+		r0 = rf(ctx, address, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Account)
+		}
+	}
+
+	var r1 []flow.RegisterID
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, flow.Identifier) []flow.RegisterID); ok {
+		r1 = rf(ctx, address, blockID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]flow.RegisterID)
+		}
+	}
+
+	var r2 []byte
+	if rf, ok := ret.Get(2).(func(context.Context, flow.Address, flow.Identifier) []byte); ok {
+		r2 = rf(ctx, address, blockID)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).([]byte)
+		}
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(context.Context, flow.Address, flow.Identifier) error); ok {
+		r3 = rf(ctx, address, blockID)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// GetRegistersWithProof provides a mock function with given fields: ctx, blockID, registerIDs
+func (_m *IngestRPC) GetRegistersWithProof(ctx context.Context, blockID flow.Identifier, registerIDs []flow.RegisterID) ([]byte, error) {
+	ret := _m.Called(ctx, blockID, registerIDs)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, []flow.RegisterID) []byte); ok {
+		r0 = rf(ctx, blockID, registerIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, []flow.RegisterID) error); ok {
+		r1 = rf(ctx, blockID, registerIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
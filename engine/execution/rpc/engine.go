@@ -8,6 +8,7 @@ import (
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow/protobuf/go/flow/execution"
@@ -15,6 +16,9 @@ import (
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
+	executionext "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+	fvmState "github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/ledger/common/encoding"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/storage"
 	grpcutils "github.com/onflow/flow-go/utils/grpc"
@@ -22,8 +26,9 @@ import (
 
 // Config defines the configurable options for the gRPC server.
 type Config struct {
-	ListenAddr string
-	MaxMsgSize int // In bytes
+	ListenAddr  string
+	MaxMsgSize  int                              // In bytes
+	Credentials credentials.TransportCredentials // if set, clients must authenticate with these credentials
 }
 
 // Engine implements a gRPC server with a simplified version of the Observation API.
@@ -51,6 +56,14 @@ func New(
 		config.MaxMsgSize = grpcutils.DefaultMaxMsgSize
 	}
 
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(config.MaxMsgSize),
+		grpc.MaxSendMsgSize(config.MaxMsgSize),
+	}
+	if config.Credentials != nil {
+		opts = append(opts, grpc.Creds(config.Credentials))
+	}
+
 	eng := &Engine{
 		log:  log,
 		unit: engine.NewUnit(),
@@ -62,14 +75,14 @@ func New(
 			exeResults:         exeResults,
 			transactionResults: txResults,
 		},
-		server: grpc.NewServer(
-			grpc.MaxRecvMsgSize(config.MaxMsgSize),
-			grpc.MaxSendMsgSize(config.MaxMsgSize),
-		),
+		server: grpc.NewServer(opts...),
 		config: config,
 	}
 
 	execution.RegisterExecutionAPIServer(eng.server, eng.handler)
+	// the calls which are not part of the ExecutionAPI service definition yet are served
+	// by the extension service on the same server
+	executionext.RegisterExecutionExtensionAPIServer(eng.server, eng.handler)
 
 	return eng
 }
@@ -117,6 +130,7 @@ type handler struct {
 }
 
 var _ execution.ExecutionAPIServer = &handler{}
+var _ executionext.ExecutionExtensionAPIServer = &handler{}
 
 // Ping responds to requests when the server is up.
 func (h *handler) Ping(ctx context.Context, req *execution.PingRequest) (*execution.PingResponse, error) {
@@ -221,7 +235,11 @@ func (h *handler) GetTransactionResult(
 		return nil, status.Errorf(codes.Internal, "failed to get transaction result: %v", err)
 	}
 	if txResult.ErrorMessage != "" {
-		statusCode = 1 // for now a statusCode of 1 indicates an error and 0 indicates no error
+		statusCode = txResult.ErrorCode
+		if statusCode == 0 {
+			// results stored before error codes were persisted only indicate that an error occurred
+			statusCode = flow.TransactionErrCodeUnknown
+		}
 		errMsg = txResult.ErrorMessage
 	}
 
@@ -261,6 +279,121 @@ func (h *handler) eventResult(blockID flow.Identifier,
 	}, nil
 }
 
+// EstimateTransaction executes a transaction against the state at the given block without committing
+// its effects, and reports the computation used, the emitted events and logs, and the error the
+// transaction would fail with, if any.
+func (h *handler) EstimateTransaction(
+	ctx context.Context,
+	req *executionext.EstimateTransactionRequest,
+) (*executionext.EstimateTransactionResponse, error) {
+
+	tx, err := convert.MessageToTransaction(req.GetTransaction(), h.chain.Chain())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction: %v", err)
+	}
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	proc, err := h.engine.EstimateTransaction(
+		ctx,
+		&tx,
+		blockID,
+		req.GetSkipSignatureVerification(),
+		req.GetSkipSequenceNumberCheck(),
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to estimate transaction: %v", err)
+	}
+
+	res := &executionext.EstimateTransactionResponse{
+		ComputationUsed: proc.GasUsed,
+		Events:          convert.EventsToMessages(proc.Events),
+		Logs:            proc.Logs,
+	}
+
+	if proc.Err != nil {
+		res.StatusCode = proc.Err.Code()
+		res.ErrorMessage = proc.Err.Error()
+	}
+
+	return res, nil
+}
+
+// GetRegistersWithProof returns a proof of the values of the given registers at the given block.
+func (h *handler) GetRegistersWithProof(
+	ctx context.Context,
+	req *executionext.GetRegistersWithProofRequest,
+) (*executionext.GetRegistersWithProofResponse, error) {
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	registerIDs := convert.MessagesToRegisterIDs(req.GetRegisterIds())
+
+	proof, err := h.engine.GetRegistersWithProof(ctx, blockID, registerIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get registers with proof: %v", err)
+	}
+
+	// make sure we never hand out a proof the access node cannot decode
+	_, err = encoding.DecodeTrieBatchProof(proof)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode proof: %v", err)
+	}
+
+	return &executionext.GetRegistersWithProofResponse{
+		Proof: proof,
+	}, nil
+}
+
+// GetAccountWithProof returns the account at the given block, together with the registers it is decoded
+// from and a proof of their values.
+func (h *handler) GetAccountWithProof(
+	ctx context.Context,
+	req *executionext.GetAccountWithProofRequest,
+) (*executionext.GetAccountWithProofResponse, error) {
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := convert.Address(req.GetAddress(), h.chain.Chain())
+	if err != nil {
+		return nil, err
+	}
+
+	account, registerIDs, proof, err := h.engine.GetAccountWithProof(ctx, address, blockID)
+	if errors.Is(err, fvmState.ErrAccountNotFound) {
+		return nil, status.Errorf(codes.NotFound, "account with address %s does not exist", address)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get account with proof: %v", err)
+	}
+
+	// make sure we never hand out a proof the access node cannot decode
+	_, err = encoding.DecodeTrieBatchProof(proof)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode proof: %v", err)
+	}
+
+	msg, err := convert.AccountToMessage(account)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert account to message: %v", err)
+	}
+
+	return &executionext.GetAccountWithProofResponse{
+		Account:     msg,
+		RegisterIds: convert.RegisterIDsToMessages(registerIDs),
+		Proof:       proof,
+	}, nil
+}
+
 func (h *handler) GetAccountAtBlockID(
 	ctx context.Context,
 	req *execution.GetAccountAtBlockIDRequest,
@@ -3,6 +3,7 @@ package rpc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/rs/zerolog"
@@ -16,6 +17,11 @@ import (
 
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	ingestion "github.com/onflow/flow-go/engine/execution/ingestion/mock"
+	executionext "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
+	"github.com/onflow/flow-go/fvm"
+	fvmState "github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
 	"github.com/onflow/flow-go/model/flow"
 	realstorage "github.com/onflow/flow-go/storage"
 	storage "github.com/onflow/flow-go/storage/mock"
@@ -232,6 +238,150 @@ func (suite *Suite) TestGetAccountAtBlockID() {
 	})
 }
 
+// TestEstimateTransaction tests the EstimateTransaction call
+func (suite *Suite) TestEstimateTransaction() {
+
+	id := unittest.IdentifierFixture()
+	tx := unittest.TransactionBodyFixture()
+	txMatcher := mock.MatchedBy(func(body *flow.TransactionBody) bool {
+		return body.ID() == tx.ID()
+	})
+
+	mockEngine := new(ingestion.IngestRPC)
+
+	// create the handler
+	handler := &handler{
+		engine: mockEngine,
+		chain:  flow.Testnet,
+	}
+
+	suite.Run("happy path with a transaction error", func() {
+
+		proc := fvm.Transaction(&tx, 0)
+		proc.GasUsed = 42
+		proc.Logs = []string{"estimated"}
+		proc.Err = &fvm.MissingPayerError{}
+
+		// setup mock expectations
+		mockEngine.On("EstimateTransaction", mock.Anything, txMatcher, id, true, false).Return(proc, nil).Once()
+
+		req := &executionext.EstimateTransactionRequest{
+			Transaction:               convert.TransactionToMessage(tx),
+			BlockId:                   id[:],
+			SkipSignatureVerification: true,
+		}
+		res, err := handler.EstimateTransaction(context.Background(), req)
+
+		suite.Require().NoError(err)
+		suite.Assert().Equal(uint64(42), res.ComputationUsed)
+		suite.Assert().Equal(proc.Logs, res.Logs)
+		suite.Assert().Equal(proc.Err.Code(), res.StatusCode)
+		suite.Assert().Equal(proc.Err.Error(), res.ErrorMessage)
+		mockEngine.AssertExpectations(suite.T())
+	})
+
+	suite.Run("execution failure", func() {
+
+		// setup mock expectations
+		mockEngine.On("EstimateTransaction", mock.Anything, txMatcher, id, false, false).Return(nil, errors.New("failure")).Once()
+
+		req := &executionext.EstimateTransactionRequest{
+			Transaction: convert.TransactionToMessage(tx),
+			BlockId:     id[:],
+		}
+		_, err := handler.EstimateTransaction(context.Background(), req)
+
+		suite.Require().Error(err)
+		suite.Assert().Equal(codes.Internal, status.Code(err))
+		mockEngine.AssertExpectations(suite.T())
+	})
+
+	suite.Run("invalid transaction", func() {
+
+		req := &executionext.EstimateTransactionRequest{
+			BlockId: id[:],
+		}
+		_, err := handler.EstimateTransaction(context.Background(), req)
+
+		suite.Require().Error(err)
+		suite.Assert().Equal(codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// TestGetAccountWithProof tests the GetAccountWithProof call
+func (suite *Suite) TestGetAccountWithProof() {
+
+	id := unittest.IdentifierFixture()
+	address := flow.Mainnet.Chain().ServiceAddress()
+	account := &flow.Account{Address: address, Keys: []flow.AccountPublicKey{}}
+	registerIDs := []flow.RegisterID{flow.NewRegisterID(string(address.Bytes()), "", "exists")}
+
+	proof := ledger.NewTrieBatchProofWithEmptyProofs(1)
+	proof.Proofs[0].Path = make([]byte, 32)
+	proof.Proofs[0].Flags = make([]byte, 32)
+
+	mockEngine := new(ingestion.IngestRPC)
+
+	// create the handler
+	handler := &handler{
+		engine: mockEngine,
+		chain:  flow.Mainnet,
+	}
+
+	createReq := func(blockID flow.Identifier) *executionext.GetAccountWithProofRequest {
+		return &executionext.GetAccountWithProofRequest{
+			Address: address.Bytes(),
+			BlockId: blockID[:],
+		}
+	}
+
+	suite.Run("happy path", func() {
+
+		// setup mock expectations
+		mockEngine.On("GetAccountWithProof", mock.Anything, address, id).
+			Return(account, registerIDs, encoding.EncodeTrieBatchProof(proof), nil).
+			Once()
+
+		res, err := handler.GetAccountWithProof(context.Background(), createReq(id))
+		suite.Require().NoError(err)
+
+		actualAccount, err := convert.MessageToAccount(res.GetAccount())
+		suite.Require().NoError(err)
+		suite.Assert().Equal(account, actualAccount)
+		suite.Assert().Equal(registerIDs, convert.MessagesToRegisterIDs(res.GetRegisterIds()))
+		suite.Assert().Equal(encoding.EncodeTrieBatchProof(proof), res.GetProof())
+		mockEngine.AssertExpectations(suite.T())
+	})
+
+	suite.Run("account not found", func() {
+
+		// setup mock expectations
+		mockEngine.On("GetAccountWithProof", mock.Anything, address, id).
+			Return(nil, nil, nil, fmt.Errorf("failed to get account: %w", fvmState.ErrAccountNotFound)).
+			Once()
+
+		_, err := handler.GetAccountWithProof(context.Background(), createReq(id))
+
+		suite.Require().Error(err)
+		suite.Assert().Equal(codes.NotFound, status.Code(err))
+		mockEngine.AssertExpectations(suite.T())
+	})
+
+	suite.Run("invalid proof", func() {
+
+		// setup mock expectations
+		mockEngine.On("GetAccountWithProof", mock.Anything, address, id).
+			Return(account, registerIDs, []byte("invalid"), nil).
+			Once()
+
+		_, err := handler.GetAccountWithProof(context.Background(), createReq(id))
+
+		suite.Require().Error(err)
+		suite.Assert().Equal(codes.Internal, status.Code(err))
+		mockEngine.AssertExpectations(suite.T())
+	})
+}
+
 // TestGetTransactionResult tests the GetTransactionResult API call
 func (suite *Suite) TestGetTransactionResult() {
 
@@ -318,12 +468,50 @@ func (suite *Suite) TestGetTransactionResult() {
 		txResults.AssertExpectations(suite.T())
 	})
 
+	// happy path - valid requests receives all events and the code of the error for the given transaction
+	suite.Run("happy path with valid events and a transaction error code", func() {
+
+		// create the expected result
+		expectedResult := &execution.GetTransactionResultResponse{
+			StatusCode:   100,
+			ErrorMessage: "runtime error",
+			Events:       eventMessages,
+		}
+
+		// setup the storage to return a transaction error with an error code
+		txResults := new(storage.TransactionResults)
+		txResult := flow.TransactionResult{
+			TransactionID: txID,
+			ErrorMessage:  "runtime error",
+			ErrorCode:     100,
+		}
+		txResults.On("ByBlockIDTransactionID", bID, txID).Return(&txResult, nil).Once()
+
+		handler := createHandler(txResults)
+
+		// create a valid API request
+		req := concoctReq(bID[:], txID[:])
+
+		// execute the GetTransactionResult call
+		actualResult, err := handler.GetTransactionResult(context.Background(), req)
+
+		// check that a successful response is received
+		suite.Require().NoError(err)
+
+		// check that all fields in response are as expected
+		assertEqual(expectedResult, actualResult)
+
+		// check that appropriate storage calls were made
+		suite.events.AssertExpectations(suite.T())
+		txResults.AssertExpectations(suite.T())
+	})
+
 	// happy path - valid requests receives all events and an error for the given transaction
 	suite.Run("happy path with valid events and a transaction error", func() {
 
-		// create the expected result
+		// create the expected result, the code of the error is unknown for results stored without one
 		expectedResult := &execution.GetTransactionResultResponse{
-			StatusCode:   1,
+			StatusCode:   flow.TransactionErrCodeUnknown,
 			ErrorMessage: "runtime error",
 			Events:       eventMessages,
 		}
@@ -0,0 +1,888 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        (unknown)
+// source: execution_extension.proto
+
+package executionext
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	entities "github.com/onflow/flow/protobuf/go/flow/entities"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type EstimateTransactionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transaction               *entities.Transaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	BlockId                   []byte                `protobuf:"bytes,2,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	SkipSignatureVerification bool                  `protobuf:"varint,3,opt,name=skip_signature_verification,json=skipSignatureVerification,proto3" json:"skip_signature_verification,omitempty"`
+	SkipSequenceNumberCheck   bool                  `protobuf:"varint,4,opt,name=skip_sequence_number_check,json=skipSequenceNumberCheck,proto3" json:"skip_sequence_number_check,omitempty"`
+}
+
+func (x *EstimateTransactionRequest) Reset() {
+	*x = EstimateTransactionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_execution_extension_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EstimateTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateTransactionRequest) ProtoMessage() {}
+
+func (x *EstimateTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_execution_extension_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateTransactionRequest.ProtoReflect.Descriptor instead.
+func (*EstimateTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_execution_extension_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EstimateTransactionRequest) GetTransaction() *entities.Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *EstimateTransactionRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *EstimateTransactionRequest) GetSkipSignatureVerification() bool {
+	if x != nil {
+		return x.SkipSignatureVerification
+	}
+	return false
+}
+
+func (x *EstimateTransactionRequest) GetSkipSequenceNumberCheck() bool {
+	if x != nil {
+		return x.SkipSequenceNumberCheck
+	}
+	return false
+}
+
+type EstimateTransactionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ComputationUsed uint64            `protobuf:"varint,1,opt,name=computation_used,json=computationUsed,proto3" json:"computation_used,omitempty"`
+	Events          []*entities.Event `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	Logs            []string          `protobuf:"bytes,3,rep,name=logs,proto3" json:"logs,omitempty"`
+	StatusCode      uint32            `protobuf:"varint,4,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	ErrorMessage    string            `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (x *EstimateTransactionResponse) Reset() {
+	*x = EstimateTransactionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_execution_extension_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EstimateTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateTransactionResponse) ProtoMessage() {}
+
+func (x *EstimateTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_execution_extension_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateTransactionResponse.ProtoReflect.Descriptor instead.
+func (*EstimateTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_execution_extension_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EstimateTransactionResponse) GetComputationUsed() uint64 {
+	if x != nil {
+		return x.ComputationUsed
+	}
+	return 0
+}
+
+func (x *EstimateTransactionResponse) GetEvents() []*entities.Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *EstimateTransactionResponse) GetLogs() []string {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *EstimateTransactionResponse) GetStatusCode() uint32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *EstimateTransactionResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type RegisterID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Owner      []byte `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Controller []byte `protobuf:"bytes,2,opt,name=controller,proto3" json:"controller,omitempty"`
+	Key        []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *RegisterID) Reset() {
+	*x = RegisterID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_execution_extension_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegisterID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterID) ProtoMessage() {}
+
+func (x *RegisterID) ProtoReflect() protoreflect.Message {
+	mi := &file_execution_extension_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterID.ProtoReflect.Descriptor instead.
+func (*RegisterID) Descriptor() ([]byte, []int) {
+	return file_execution_extension_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RegisterID) GetOwner() []byte {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *RegisterID) GetController() []byte {
+	if x != nil {
+		return x.Controller
+	}
+	return nil
+}
+
+func (x *RegisterID) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type GetRegistersWithProofRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId     []byte        `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	RegisterIds []*RegisterID `protobuf:"bytes,2,rep,name=register_ids,json=registerIds,proto3" json:"register_ids,omitempty"`
+}
+
+func (x *GetRegistersWithProofRequest) Reset() {
+	*x = GetRegistersWithProofRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_execution_extension_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRegistersWithProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRegistersWithProofRequest) ProtoMessage() {}
+
+func (x *GetRegistersWithProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_execution_extension_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRegistersWithProofRequest.ProtoReflect.Descriptor instead.
+func (*GetRegistersWithProofRequest) Descriptor() ([]byte, []int) {
+	return file_execution_extension_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetRegistersWithProofRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *GetRegistersWithProofRequest) GetRegisterIds() []*RegisterID {
+	if x != nil {
+		return x.RegisterIds
+	}
+	return nil
+}
+
+type GetRegistersWithProofResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// proof is the encoded trie batch proof, with a proof per register in the
+	// order of the request.
+	Proof []byte `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *GetRegistersWithProofResponse) Reset() {
+	*x = GetRegistersWithProofResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_execution_extension_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRegistersWithProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRegistersWithProofResponse) ProtoMessage() {}
+
+func (x *GetRegistersWithProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_execution_extension_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRegistersWithProofResponse.ProtoReflect.Descriptor instead.
+func (*GetRegistersWithProofResponse) Descriptor() ([]byte, []int) {
+	return file_execution_extension_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRegistersWithProofResponse) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+type GetAccountWithProofRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	BlockId []byte `protobuf:"bytes,2,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+}
+
+func (x *GetAccountWithProofRequest) Reset() {
+	*x = GetAccountWithProofRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_execution_extension_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAccountWithProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountWithProofRequest) ProtoMessage() {}
+
+func (x *GetAccountWithProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_execution_extension_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountWithProofRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountWithProofRequest) Descriptor() ([]byte, []int) {
+	return file_execution_extension_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetAccountWithProofRequest) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *GetAccountWithProofRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+type GetAccountWithProofResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Account     *entities.Account `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	RegisterIds []*RegisterID     `protobuf:"bytes,2,rep,name=register_ids,json=registerIds,proto3" json:"register_ids,omitempty"`
+	// proof is the encoded trie batch proof, with a proof per register in the
+	// order of register_ids.
+	Proof []byte `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *GetAccountWithProofResponse) Reset() {
+	*x = GetAccountWithProofResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_execution_extension_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAccountWithProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountWithProofResponse) ProtoMessage() {}
+
+func (x *GetAccountWithProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_execution_extension_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountWithProofResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountWithProofResponse) Descriptor() ([]byte, []int) {
+	return file_execution_extension_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetAccountWithProofResponse) GetAccount() *entities.Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *GetAccountWithProofResponse) GetRegisterIds() []*RegisterID {
+	if x != nil {
+		return x.RegisterIds
+	}
+	return nil
+}
+
+func (x *GetAccountWithProofResponse) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+var File_execution_extension_proto protoreflect.FileDescriptor
+
+var file_execution_extension_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x65, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x74, 0x1a, 0x1b, 0x66, 0x6c, 0x6f, 0x77, 0x2f,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x1f, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xf2, 0x01, 0x0a, 0x1a, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x3c, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x3e, 0x0a, 0x1b, 0x73, 0x6b,
+	0x69, 0x70, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x76, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x19, 0x73, 0x6b, 0x69, 0x70, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x1a, 0x73, 0x6b,
+	0x69, 0x70, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17,
+	0x73, 0x6b, 0x69, 0x70, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x22, 0xd0, 0x01, 0x0a, 0x1b, 0x45, 0x73, 0x74, 0x69,
+	0x6d, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x75,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x73,
+	0x65, 0x64, 0x12, 0x2c, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x6c, 0x6f, 0x67, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x54, 0x0a, 0x0a, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x22, 0x76, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73,
+	0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x3b, 0x0a, 0x0c, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x74,
+	0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x49, 0x44, 0x52, 0x0b, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22, 0x35, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22,
+	0x51, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x57, 0x69, 0x74,
+	0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x49, 0x64, 0x22, 0xa2, 0x01, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x07, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3b, 0x0a, 0x0c, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x65, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x74, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x49, 0x44, 0x52, 0x0b, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x32, 0xe1, 0x02, 0x0a, 0x15, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x50,
+	0x49, 0x12, 0x6a, 0x0a, 0x13, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x2e, 0x65, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x74, 0x2e, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x29, 0x2e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x65, 0x78,
+	0x74, 0x2e, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x70, 0x0a,
+	0x15, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x57, 0x69, 0x74,
+	0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x2a, 0x2e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x65, 0x78, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x73, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x65, 0x78,
+	0x74, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x57, 0x69,
+	0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x6a, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x57, 0x69, 0x74,
+	0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x28, 0x2e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x65, 0x78, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x29, 0x2e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x74, 0x2e,
+	0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x46, 0x5a, 0x44, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x6e, 0x66, 0x6c, 0x6f, 0x77,
+	0x2f, 0x66, 0x6c, 0x6f, 0x77, 0x2d, 0x67, 0x6f, 0x2f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2f,
+	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x3b, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x65, 0x78, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_execution_extension_proto_rawDescOnce sync.Once
+	file_execution_extension_proto_rawDescData = file_execution_extension_proto_rawDesc
+)
+
+func file_execution_extension_proto_rawDescGZIP() []byte {
+	file_execution_extension_proto_rawDescOnce.Do(func() {
+		file_execution_extension_proto_rawDescData = protoimpl.X.CompressGZIP(file_execution_extension_proto_rawDescData)
+	})
+	return file_execution_extension_proto_rawDescData
+}
+
+var file_execution_extension_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_execution_extension_proto_goTypes = []interface{}{
+	(*EstimateTransactionRequest)(nil),    // 0: executionext.EstimateTransactionRequest
+	(*EstimateTransactionResponse)(nil),   // 1: executionext.EstimateTransactionResponse
+	(*RegisterID)(nil),                    // 2: executionext.RegisterID
+	(*GetRegistersWithProofRequest)(nil),  // 3: executionext.GetRegistersWithProofRequest
+	(*GetRegistersWithProofResponse)(nil), // 4: executionext.GetRegistersWithProofResponse
+	(*GetAccountWithProofRequest)(nil),    // 5: executionext.GetAccountWithProofRequest
+	(*GetAccountWithProofResponse)(nil),   // 6: executionext.GetAccountWithProofResponse
+	(*entities.Transaction)(nil),          // 7: flow.entities.Transaction
+	(*entities.Event)(nil),                // 8: flow.entities.Event
+	(*entities.Account)(nil),              // 9: flow.entities.Account
+}
+var file_execution_extension_proto_depIdxs = []int32{
+	7, // 0: executionext.EstimateTransactionRequest.transaction:type_name -> flow.entities.Transaction
+	8, // 1: executionext.EstimateTransactionResponse.events:type_name -> flow.entities.Event
+	2, // 2: executionext.GetRegistersWithProofRequest.register_ids:type_name -> executionext.RegisterID
+	9, // 3: executionext.GetAccountWithProofResponse.account:type_name -> flow.entities.Account
+	2, // 4: executionext.GetAccountWithProofResponse.register_ids:type_name -> executionext.RegisterID
+	0, // 5: executionext.ExecutionExtensionAPI.EstimateTransaction:input_type -> executionext.EstimateTransactionRequest
+	3, // 6: executionext.ExecutionExtensionAPI.GetRegistersWithProof:input_type -> executionext.GetRegistersWithProofRequest
+	5, // 7: executionext.ExecutionExtensionAPI.GetAccountWithProof:input_type -> executionext.GetAccountWithProofRequest
+	1, // 8: executionext.ExecutionExtensionAPI.EstimateTransaction:output_type -> executionext.EstimateTransactionResponse
+	4, // 9: executionext.ExecutionExtensionAPI.GetRegistersWithProof:output_type -> executionext.GetRegistersWithProofResponse
+	6, // 10: executionext.ExecutionExtensionAPI.GetAccountWithProof:output_type -> executionext.GetAccountWithProofResponse
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_execution_extension_proto_init() }
+func file_execution_extension_proto_init() {
+	if File_execution_extension_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_execution_extension_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EstimateTransactionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_execution_extension_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EstimateTransactionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_execution_extension_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_execution_extension_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRegistersWithProofRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_execution_extension_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRegistersWithProofResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_execution_extension_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAccountWithProofRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_execution_extension_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAccountWithProofResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_execution_extension_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_execution_extension_proto_goTypes,
+		DependencyIndexes: file_execution_extension_proto_depIdxs,
+		MessageInfos:      file_execution_extension_proto_msgTypes,
+	}.Build()
+	File_execution_extension_proto = out.File
+	file_execution_extension_proto_rawDesc = nil
+	file_execution_extension_proto_goTypes = nil
+	file_execution_extension_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ExecutionExtensionAPIClient is the client API for ExecutionExtensionAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ExecutionExtensionAPIClient interface {
+	// EstimateTransaction executes a transaction against the state at the given
+	// block without committing its effects.
+	EstimateTransaction(ctx context.Context, in *EstimateTransactionRequest, opts ...grpc.CallOption) (*EstimateTransactionResponse, error)
+	// GetRegistersWithProof returns a proof of the values of the given registers
+	// in the state at the given block.
+	GetRegistersWithProof(ctx context.Context, in *GetRegistersWithProofRequest, opts ...grpc.CallOption) (*GetRegistersWithProofResponse, error)
+	// GetAccountWithProof returns the account at the given block, together with
+	// the registers it is decoded from and a proof of their values.
+	GetAccountWithProof(ctx context.Context, in *GetAccountWithProofRequest, opts ...grpc.CallOption) (*GetAccountWithProofResponse, error)
+}
+
+type executionExtensionAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExecutionExtensionAPIClient(cc grpc.ClientConnInterface) ExecutionExtensionAPIClient {
+	return &executionExtensionAPIClient{cc}
+}
+
+func (c *executionExtensionAPIClient) EstimateTransaction(ctx context.Context, in *EstimateTransactionRequest, opts ...grpc.CallOption) (*EstimateTransactionResponse, error) {
+	out := new(EstimateTransactionResponse)
+	err := c.cc.Invoke(ctx, "/executionext.ExecutionExtensionAPI/EstimateTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionExtensionAPIClient) GetRegistersWithProof(ctx context.Context, in *GetRegistersWithProofRequest, opts ...grpc.CallOption) (*GetRegistersWithProofResponse, error) {
+	out := new(GetRegistersWithProofResponse)
+	err := c.cc.Invoke(ctx, "/executionext.ExecutionExtensionAPI/GetRegistersWithProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionExtensionAPIClient) GetAccountWithProof(ctx context.Context, in *GetAccountWithProofRequest, opts ...grpc.CallOption) (*GetAccountWithProofResponse, error) {
+	out := new(GetAccountWithProofResponse)
+	err := c.cc.Invoke(ctx, "/executionext.ExecutionExtensionAPI/GetAccountWithProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExecutionExtensionAPIServer is the server API for ExecutionExtensionAPI service.
+type ExecutionExtensionAPIServer interface {
+	// EstimateTransaction executes a transaction against the state at the given
+	// block without committing its effects.
+	EstimateTransaction(context.Context, *EstimateTransactionRequest) (*EstimateTransactionResponse, error)
+	// GetRegistersWithProof returns a proof of the values of the given registers
+	// in the state at the given block.
+	GetRegistersWithProof(context.Context, *GetRegistersWithProofRequest) (*GetRegistersWithProofResponse, error)
+	// GetAccountWithProof returns the account at the given block, together with
+	// the registers it is decoded from and a proof of their values.
+	GetAccountWithProof(context.Context, *GetAccountWithProofRequest) (*GetAccountWithProofResponse, error)
+}
+
+// UnimplementedExecutionExtensionAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedExecutionExtensionAPIServer struct {
+}
+
+func (*UnimplementedExecutionExtensionAPIServer) EstimateTransaction(context.Context, *EstimateTransactionRequest) (*EstimateTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateTransaction not implemented")
+}
+func (*UnimplementedExecutionExtensionAPIServer) GetRegistersWithProof(context.Context, *GetRegistersWithProofRequest) (*GetRegistersWithProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRegistersWithProof not implemented")
+}
+func (*UnimplementedExecutionExtensionAPIServer) GetAccountWithProof(context.Context, *GetAccountWithProofRequest) (*GetAccountWithProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountWithProof not implemented")
+}
+
+func RegisterExecutionExtensionAPIServer(s *grpc.Server, srv ExecutionExtensionAPIServer) {
+	s.RegisterService(&_ExecutionExtensionAPI_serviceDesc, srv)
+}
+
+func _ExecutionExtensionAPI_EstimateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionExtensionAPIServer).EstimateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/executionext.ExecutionExtensionAPI/EstimateTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionExtensionAPIServer).EstimateTransaction(ctx, req.(*EstimateTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutionExtensionAPI_GetRegistersWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRegistersWithProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionExtensionAPIServer).GetRegistersWithProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/executionext.ExecutionExtensionAPI/GetRegistersWithProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionExtensionAPIServer).GetRegistersWithProof(ctx, req.(*GetRegistersWithProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutionExtensionAPI_GetAccountWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountWithProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionExtensionAPIServer).GetAccountWithProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/executionext.ExecutionExtensionAPI/GetAccountWithProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionExtensionAPIServer).GetAccountWithProof(ctx, req.(*GetAccountWithProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ExecutionExtensionAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "executionext.ExecutionExtensionAPI",
+	HandlerType: (*ExecutionExtensionAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "EstimateTransaction",
+			Handler:    _ExecutionExtensionAPI_EstimateTransaction_Handler,
+		},
+		{
+			MethodName: "GetRegistersWithProof",
+			Handler:    _ExecutionExtensionAPI_GetRegistersWithProof_Handler,
+		},
+		{
+			MethodName: "GetAccountWithProof",
+			Handler:    _ExecutionExtensionAPI_GetAccountWithProof_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "execution_extension.proto",
+}
@@ -23,6 +23,7 @@ type View struct {
 	// for views other than collection views to improve performance
 	spockSecretHasher hash.Hasher
 	readFunc          GetRegisterFunc
+	parent            *View // view this view has been created from with NewChild, if any
 }
 
 type Snapshot struct {
@@ -94,7 +95,9 @@ func (r *Snapshot) AllRegisters() []flow.RegisterID {
 
 // NewChild generates a new child view, with the current view as the base, sharing the Get function
 func (v *View) NewChild() *View {
-	return NewView(v.Get)
+	child := NewView(v.Get)
+	child.parent = v
+	return child
 }
 
 // Get gets a register value from this view.
@@ -130,6 +133,23 @@ func (v *View) Get(owner, controller, key string) (flow.RegisterValue, error) {
 	return value, err
 }
 
+// Peek returns the value of a register as seen by this view, without recording the read,
+// i.e. without touching the register, counting the read or updating the SPoCK secret of this
+// view or any of its parents. The read function of a view which has not been created with
+// NewChild must be free of side effects for Peek to be.
+//
+// Peek is safe to call concurrently, as long as neither the view nor its parents are modified.
+func (v *View) Peek(owner, controller, key string) (flow.RegisterValue, error) {
+	value, exists := v.delta.Get(owner, controller, key)
+	if exists {
+		return value, nil
+	}
+	if v.parent != nil {
+		return v.parent.Peek(owner, controller, key)
+	}
+	return v.readFunc(owner, controller, key)
+}
+
 // Set sets a register value in this view.
 func (v *View) Set(owner, controller, key string, value flow.RegisterValue) {
 	// every time we write something to delta (order preserving) we update spock
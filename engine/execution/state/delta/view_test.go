@@ -496,6 +496,43 @@ func TestView_Reads(t *testing.T) {
 	})
 }
 
+func TestView_Peek(t *testing.T) {
+	registerID := "fruit"
+
+	parent := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+		if owner == registerID {
+			return flow.RegisterValue("orange"), nil
+		}
+		return nil, nil
+	})
+	child := parent.NewChild()
+
+	t.Run("reads through parents", func(t *testing.T) {
+		b, err := child.Peek(registerID, "", "")
+		require.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("orange"), b)
+
+		parent.Set(registerID, "", "", flow.RegisterValue("apple"))
+		b, err = child.Peek(registerID, "", "")
+		require.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("apple"), b)
+
+		child.Set(registerID, "", "", flow.RegisterValue("pear"))
+		b, err = child.Peek(registerID, "", "")
+		require.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("pear"), b)
+	})
+
+	t.Run("does not record reads", func(t *testing.T) {
+		_, err := child.Peek("vegetable", "", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(0), parent.ReadsCount())
+		assert.Equal(t, uint64(0), child.ReadsCount())
+		assert.NotContains(t, child.Interactions().Reads, flow.NewRegisterID("vegetable", "", ""))
+	})
+}
+
 func hashIt(spock hash.Hasher, value []byte) error {
 	_, err := spock.Write(value)
 	return err
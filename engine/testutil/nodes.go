@@ -139,7 +139,7 @@ func CollectionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identi
 
 	node := GenericNode(t, hub, identity, identities, chainID, options...)
 
-	pools := epochs.NewTransactionPools(func() mempool.Transactions { return stdmap.NewTransactions(1000) })
+	pools := epochs.NewTransactionPools(func(_ uint64) (mempool.Transactions, error) { return stdmap.NewTransactions(1000), nil }, nil)
 	transactions := storage.NewTransactions(node.Metrics, node.DB)
 	collections := storage.NewCollections(node.DB, transactions)
 
@@ -285,6 +285,7 @@ func mintFlowTokenTransaction(
 const (
 	fungibleTokenAccountIndex = 2
 	flowTokenAccountIndex     = 3
+	flowFeesAccountIndex      = 4
 )
 
 func FungibleTokenAddress(chain flow.Chain) flow.Address {
@@ -296,3 +297,8 @@ func FlowTokenAddress(chain flow.Chain) flow.Address {
 	address, _ := chain.AddressAtIndex(flowTokenAccountIndex)
 	return address
 }
+
+func FlowFeesAddress(chain flow.Chain) flow.Address {
+	address, _ := chain.AddressAtIndex(flowFeesAccountIndex)
+	return address
+}
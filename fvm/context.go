@@ -15,6 +15,7 @@ type Context struct {
 	Metrics                          *MetricsCollector
 	GasLimit                         uint64
 	EventCollectionByteSizeLimit     uint64
+	StorageCapacityPerFLOW           uint64
 	BlockHeader                      *flow.Header
 	ServiceAccountEnabled            bool
 	RestrictedAccountCreationEnabled bool
@@ -48,7 +49,7 @@ func newContext(ctx Context, opts ...Option) Context {
 	return ctx
 }
 
-const AccountKeyWeightThreshold = 1000
+const AccountKeyWeightThreshold = flow.AccountKeyWeightThreshold
 
 const defaultGasLimit = 100_000
 
@@ -62,6 +63,7 @@ func defaultContext(logger zerolog.Logger) Context {
 		Metrics:                          nil,
 		GasLimit:                         defaultGasLimit,
 		EventCollectionByteSizeLimit:     defaultEventCollectionByteSizeLimit,
+		StorageCapacityPerFLOW:           0,
 		BlockHeader:                      nil,
 		ServiceAccountEnabled:            true,
 		RestrictedAccountCreationEnabled: true,
@@ -74,6 +76,7 @@ func defaultContext(logger zerolog.Logger) Context {
 			NewTransactionSequenceNumberChecker(),
 			NewTransactionFeeDeductor(),
 			NewTransactionInvocator(logger),
+			NewTransactionStorageLimiter(),
 		},
 		ScriptProcessors: []ScriptProcessor{
 			NewScriptInvocator(),
@@ -117,6 +120,18 @@ func WithEventCollectionSizeLimit(limit uint64) Option {
 	}
 }
 
+// WithStorageCapacityPerFLOW sets the number of storage bytes granted to an account for each FLOW
+// of its balance.
+//
+// The storage capacity of an account is derived from its balance, and transactions leaving an
+// account with more storage used than its capacity fail. A value of zero disables storage limits.
+func WithStorageCapacityPerFLOW(bytes uint64) Option {
+	return func(ctx Context) Context {
+		ctx.StorageCapacityPerFLOW = bytes
+		return ctx
+	}
+}
+
 // WithBlockHeader sets the block header for a virtual machine context.
 //
 // The VM uses the header to provide current block information to the Cadence runtime,
@@ -31,8 +31,8 @@ func (DefaultSignatureVerifier) Verify(
 	publicKey crypto.PublicKey,
 	hashAlgo hash.HashingAlgorithm,
 ) (bool, error) {
-	hasher := newHasher(hashAlgo)
-	if hasher == nil {
+	hasher, err := flow.NewAccountKeyHasher(hashAlgo)
+	if err != nil {
 		return false, ErrInvalidHashAlgorithm
 	}
 
@@ -46,16 +46,6 @@ func (DefaultSignatureVerifier) Verify(
 	return valid, nil
 }
 
-func newHasher(hashAlgo hash.HashingAlgorithm) hash.Hasher {
-	switch hashAlgo {
-	case hash.SHA2_256:
-		return hash.NewSHA2_256()
-	case hash.SHA3_256:
-		return hash.NewSHA3_256()
-	}
-	return nil
-}
-
 // StringToSigningAlgorithm converts a string to a SigningAlgorithm.
 func StringToSigningAlgorithm(s string) crypto.SigningAlgorithm {
 	switch s {
@@ -4,7 +4,6 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
-	"math"
 	"math/rand"
 
 	"github.com/onflow/cadence"
@@ -25,6 +24,7 @@ var _ runtime.HighLevelStorage = &hostEnv{}
 
 type hostEnv struct {
 	ctx              Context
+	vm               *VirtualMachine
 	ledger           state.Ledger
 	accounts         *state.Accounts
 	addressGenerator flow.AddressGenerator
@@ -46,7 +46,7 @@ func (e *hostEnv) Hash(data []byte, hashAlgorithm string) ([]byte, error) {
 	return hasher.ComputeHash(data), nil
 }
 
-func newEnvironment(ctx Context, ledger state.Ledger) (*hostEnv, error) {
+func newEnvironment(ctx Context, vm *VirtualMachine, ledger state.Ledger) (*hostEnv, error) {
 	accounts := state.NewAccounts(ledger)
 	generator, err := state.NewLedgerBoundAddressGenerator(ledger, ctx.Chain)
 	if err != nil {
@@ -58,6 +58,7 @@ func newEnvironment(ctx Context, ledger state.Ledger) (*hostEnv, error) {
 
 	env := &hostEnv{
 		ctx:                ctx,
+		vm:                 vm,
 		ledger:             ledger,
 		Metrics:            &noopMetricsCollector{},
 		accounts:           accounts,
@@ -134,8 +135,8 @@ func (e *hostEnv) GetStorageUsed(address common.Address) (value uint64, err erro
 	return e.accounts.GetStorageUsed(flow.BytesToAddress(address.Bytes()))
 }
 
-func (e *hostEnv) GetStorageCapacity(_ common.Address) (value uint64, err error) {
-	return math.MaxUint64, nil
+func (e *hostEnv) GetStorageCapacity(address common.Address) (value uint64, err error) {
+	return getStorageCapacity(e.vm, e.ctx, e.ledger, flow.BytesToAddress(address.Bytes()))
 }
 
 func (e *hostEnv) ResolveLocation(
@@ -11,25 +11,6 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 )
 
-const (
-	errCodeMissingSignature                      = 1
-	errCodeMissingPayer                          = 2
-	errCodeInvalidSignaturePublicKeyDoesNotExist = 3
-	errCodeInvalidSignaturePublicKeyRevoked      = 4
-	errCodeInvalidSignatureVerification          = 5
-
-	errCodeInvalidProposalKeyPublicKeyDoesNotExist = 6
-	errCodeInvalidProposalKeyPublicKeyRevoked      = 7
-	errCodeInvalidProposalKeySequenceNumber        = 8
-	errCodeInvalidProposalKeyMissingSignature      = 9
-
-	errCodeInvalidHashAlgorithm = 10
-
-	errCodeEventLimitExceededError = 20
-
-	errCodeExecution = 100
-)
-
 var ErrAccountNotFound = errors.New("account not found")
 var ErrInvalidHashAlgorithm = errors.New("invalid hash algorithm")
 
@@ -54,7 +35,7 @@ func (e *MissingSignatureError) Error() string {
 }
 
 func (e *MissingSignatureError) Code() uint32 {
-	return errCodeMissingSignature
+	return flow.TransactionErrCodeMissingSignature
 }
 
 // A MissingPayerError indicates that a transaction is missing a payer.
@@ -65,7 +46,7 @@ func (e *MissingPayerError) Error() string {
 }
 
 func (e *MissingPayerError) Code() uint32 {
-	return errCodeMissingPayer
+	return flow.TransactionErrCodeMissingPayer
 }
 
 // An InvalidSignaturePublicKeyDoesNotExistError indicates that a signature specifies a public key that
@@ -84,7 +65,7 @@ func (e *InvalidSignaturePublicKeyDoesNotExistError) Error() string {
 }
 
 func (e *InvalidSignaturePublicKeyDoesNotExistError) Code() uint32 {
-	return errCodeInvalidSignaturePublicKeyDoesNotExist
+	return flow.TransactionErrCodeInvalidSignaturePublicKeyDoesNotExist
 }
 
 // An InvalidSignaturePublicKeyRevokedError indicates that a signature specifies a public key that has been revoked.
@@ -102,7 +83,7 @@ func (e *InvalidSignaturePublicKeyRevokedError) Error() string {
 }
 
 func (e *InvalidSignaturePublicKeyRevokedError) Code() uint32 {
-	return errCodeInvalidSignaturePublicKeyRevoked
+	return flow.TransactionErrCodeInvalidSignaturePublicKeyRevoked
 }
 
 // An InvalidSignatureVerificationError indicates that a signature could not be verified using its specified
@@ -121,7 +102,7 @@ func (e *InvalidSignatureVerificationError) Error() string {
 }
 
 func (e *InvalidSignatureVerificationError) Code() uint32 {
-	return errCodeInvalidSignatureVerification
+	return flow.TransactionErrCodeInvalidSignatureVerification
 }
 
 // A InvalidProposalKeyPublicKeyDoesNotExistError indicates that proposal key specifies a nonexistent public key.
@@ -139,7 +120,7 @@ func (e *InvalidProposalKeyPublicKeyDoesNotExistError) Error() string {
 }
 
 func (e *InvalidProposalKeyPublicKeyDoesNotExistError) Code() uint32 {
-	return errCodeInvalidProposalKeyPublicKeyDoesNotExist
+	return flow.TransactionErrCodeInvalidProposalKeyPublicKeyDoesNotExist
 }
 
 // An InvalidProposalKeyPublicKeyRevokedError indicates that proposal key sequence number does not match the on-chain value.
@@ -157,7 +138,7 @@ func (e *InvalidProposalKeyPublicKeyRevokedError) Error() string {
 }
 
 func (e *InvalidProposalKeyPublicKeyRevokedError) Code() uint32 {
-	return errCodeInvalidProposalKeyPublicKeyRevoked
+	return flow.TransactionErrCodeInvalidProposalKeyPublicKeyRevoked
 }
 
 // An InvalidProposalKeySequenceNumberError indicates that proposal key sequence number does not match the on-chain value.
@@ -179,7 +160,7 @@ func (e *InvalidProposalKeySequenceNumberError) Error() string {
 }
 
 func (e *InvalidProposalKeySequenceNumberError) Code() uint32 {
-	return errCodeInvalidProposalKeySequenceNumber
+	return flow.TransactionErrCodeInvalidProposalKeySequenceNumber
 }
 
 // A InvalidProposalKeyMissingSignatureError indicates that a proposal key does not have a valid signature.
@@ -197,7 +178,7 @@ func (e *InvalidProposalKeyMissingSignatureError) Error() string {
 }
 
 func (e *InvalidProposalKeyMissingSignatureError) Code() uint32 {
-	return errCodeInvalidProposalKeyMissingSignature
+	return flow.TransactionErrCodeInvalidProposalKeyMissingSignature
 }
 
 // EventLimitExceededError indicates that the transaction has produced events with size more than limit.
@@ -216,7 +197,29 @@ func (e *EventLimitExceededError) Error() string {
 
 // Code returns the error code for this error
 func (e *EventLimitExceededError) Code() uint32 {
-	return errCodeEventLimitExceededError
+	return flow.TransactionErrCodeEventLimitExceeded
+}
+
+// StorageCapacityExceededError indicates that the transaction has left an account using more storage
+// than its capacity, which is derived from the FLOW balance of the account.
+type StorageCapacityExceededError struct {
+	Address         flow.Address
+	StorageUsed     uint64
+	StorageCapacity uint64
+}
+
+func (e *StorageCapacityExceededError) Error() string {
+	return fmt.Sprintf(
+		"account %s storage used (%d) exceeds storage capacity (%d)",
+		e.Address,
+		e.StorageUsed,
+		e.StorageCapacity,
+	)
+}
+
+// Code returns the error code for this error
+func (e *StorageCapacityExceededError) Code() uint32 {
+	return flow.TransactionErrCodeStorageCapacityExceeded
 }
 
 // An InvalidHashAlgorithmError indicates that a given key has an invalid hash algorithm.
@@ -231,7 +234,7 @@ func (e *InvalidHashAlgorithmError) Error() string {
 }
 
 func (e *InvalidHashAlgorithmError) Code() uint32 {
-	return errCodeInvalidHashAlgorithm
+	return flow.TransactionErrCodeInvalidHashAlgorithm
 }
 
 type ExecutionError struct {
@@ -243,7 +246,7 @@ func (e *ExecutionError) Error() string {
 }
 
 func (e *ExecutionError) Code() uint32 {
-	return errCodeExecution
+	return flow.TransactionErrCodeExecution
 }
 
 func handleError(err error) (vmErr Error, fatalErr error) {
@@ -1239,3 +1239,196 @@ func TestEventLimits(t *testing.T) {
 		assert.NoError(t, tx.Err)
 	})
 }
+
+func TestStorageCapacity(t *testing.T) {
+	const storageCapacityPerFLOW = 100000
+
+	createAccount := func(
+		t *testing.T,
+		vm *fvm.VirtualMachine,
+		chain flow.Chain,
+		ctx fvm.Context,
+		ledger state.Ledger,
+		amount string,
+		seqNum uint64,
+	) (flow.Address, flow.AccountPrivateKey, *fvm.TransactionProcedure) {
+		privateKey, err := testutil.GenerateAccountPrivateKey()
+		require.NoError(t, err)
+
+		keyBytes, err := flow.EncodeRuntimeAccountPublicKey(privateKey.PublicKey(fvm.AccountKeyWeightThreshold))
+		require.NoError(t, err)
+
+		txBody := flow.NewTransactionBody().
+			SetScript([]byte(fmt.Sprintf(`
+				import FungibleToken from 0x%s
+				import FlowToken from 0x%s
+
+				transaction {
+				  prepare(signer: AuthAccount) {
+					let account = AuthAccount(payer: signer)
+					account.addPublicKey("%s".decodeHex())
+
+					let vault = signer.borrow<&FlowToken.Vault>(from: /storage/flowTokenVault)!
+					account.getCapability(/public/flowTokenReceiver)!
+						.borrow<&{FungibleToken.Receiver}>()!
+						.deposit(from: <-vault.withdraw(amount: %s))
+				  }
+				}`,
+				fvm.FungibleTokenAddress(chain),
+				fvm.FlowTokenAddress(chain),
+				hex.EncodeToString(keyBytes),
+				amount,
+			))).
+			AddAuthorizer(chain.ServiceAddress())
+
+		err = testutil.SignTransactionAsServiceAccount(txBody, seqNum, chain)
+		require.NoError(t, err)
+
+		tx := fvm.Transaction(txBody, 0)
+
+		err = vm.Run(ctx, tx, ledger)
+		require.NoError(t, err)
+
+		var address flow.Address
+		for _, event := range tx.Events {
+			if event.Type == flow.EventAccountCreated {
+				data, err := jsoncdc.Decode(event.Payload)
+				require.NoError(t, err)
+				address = flow.Address(data.(cadence.Event).Fields[0].(cadence.Address))
+			}
+		}
+
+		return address, privateKey, tx
+	}
+
+	deployContract := func(
+		t *testing.T,
+		vm *fvm.VirtualMachine,
+		chain flow.Chain,
+		ctx fvm.Context,
+		ledger state.Ledger,
+		address flow.Address,
+		privateKey flow.AccountPrivateKey,
+		seqNum uint64,
+	) *fvm.TransactionProcedure {
+		txBody := testutil.DeployCounterContractTransaction(address, chain)
+
+		txBody.SetProposalKey(chain.ServiceAddress(), 0, seqNum)
+		txBody.SetPayer(chain.ServiceAddress())
+
+		err := testutil.SignPayload(txBody, address, privateKey)
+		require.NoError(t, err)
+
+		err = testutil.SignEnvelope(txBody, chain.ServiceAddress(), unittest.ServiceAccountPrivateKey)
+		require.NoError(t, err)
+
+		tx := fvm.Transaction(txBody, 0)
+
+		err = vm.Run(ctx, tx, ledger)
+		require.NoError(t, err)
+
+		return tx
+	}
+
+	t.Run("Account creation fails if the new account cannot afford its storage", vmTest(
+		func(t *testing.T, vm *fvm.VirtualMachine, chain flow.Chain, ctx fvm.Context, ledger state.Ledger) {
+			_, _, tx := createAccount(t, vm, chain, ctx, ledger, "0.0", 0)
+
+			require.Error(t, tx.Err)
+			require.IsType(t, &fvm.StorageCapacityExceededError{}, tx.Err)
+
+			limitErr := tx.Err.(*fvm.StorageCapacityExceededError)
+			assert.NotEqual(t, chain.ServiceAddress(), limitErr.Address)
+			assert.Equal(t, uint64(0), limitErr.StorageCapacity)
+			assert.Greater(t, limitErr.StorageUsed, uint64(0))
+		},
+		fvm.WithStorageCapacityPerFLOW(storageCapacityPerFLOW),
+	))
+
+	t.Run("Account creation succeeds if the new account is funded", vmTest(
+		func(t *testing.T, vm *fvm.VirtualMachine, chain flow.Chain, ctx fvm.Context, ledger state.Ledger) {
+			address, _, tx := createAccount(t, vm, chain, ctx, ledger, "1.0", 0)
+			require.NoError(t, tx.Err)
+
+			// the storage capacity is exposed to Cadence
+			script := fvm.Script([]byte(fmt.Sprintf(`
+				pub fun main(): UInt64 {
+				  return getAccount(0x%s).storageCapacity
+				}`, address)))
+
+			err := vm.Run(ctx, script, ledger)
+			require.NoError(t, err)
+			require.NoError(t, script.Err)
+			assert.Equal(t, cadence.NewUInt64(storageCapacityPerFLOW), script.Value)
+		},
+		fvm.WithStorageCapacityPerFLOW(storageCapacityPerFLOW),
+	))
+
+	t.Run("Account creation succeeds without storage limits", vmTest(
+		func(t *testing.T, vm *fvm.VirtualMachine, chain flow.Chain, ctx fvm.Context, ledger state.Ledger) {
+			_, _, tx := createAccount(t, vm, chain, ctx, ledger, "0.0", 0)
+			require.NoError(t, tx.Err)
+		},
+	))
+
+	t.Run("Contract deployment fails if the account cannot afford its storage", vmTest(
+		func(t *testing.T, vm *fvm.VirtualMachine, chain flow.Chain, ctx fvm.Context, ledger state.Ledger) {
+			// enough for the account itself, not for the contract
+			address, privateKey, tx := createAccount(t, vm, chain, ctx, ledger, "0.01", 0)
+			require.NoError(t, tx.Err)
+
+			tx = deployContract(t, vm, chain, ctx, ledger, address, privateKey, 1)
+			require.Error(t, tx.Err)
+			require.IsType(t, &fvm.StorageCapacityExceededError{}, tx.Err)
+			assert.Equal(t, address, tx.Err.(*fvm.StorageCapacityExceededError).Address)
+		},
+		fvm.WithStorageCapacityPerFLOW(storageCapacityPerFLOW),
+	))
+
+	t.Run("Transaction fails if the balance of the account cannot be read", vmTest(
+		func(t *testing.T, vm *fvm.VirtualMachine, chain flow.Chain, ctx fvm.Context, ledger state.Ledger) {
+			address, privateKey, tx := createAccount(t, vm, chain, ctx, ledger, "1.0", 0)
+			require.NoError(t, tx.Err)
+
+			txBody := flow.NewTransactionBody().
+				SetScript([]byte(fmt.Sprintf(`
+					import FlowToken from 0x%s
+
+					transaction {
+					  prepare(signer: AuthAccount) {
+						destroy signer.load<@FlowToken.Vault>(from: /storage/flowTokenVault)
+					  }
+					}`,
+					fvm.FlowTokenAddress(chain),
+				))).
+				AddAuthorizer(address).
+				SetProposalKey(chain.ServiceAddress(), 0, 1).
+				SetPayer(chain.ServiceAddress())
+
+			err := testutil.SignPayload(txBody, address, privateKey)
+			require.NoError(t, err)
+			err = testutil.SignEnvelope(txBody, chain.ServiceAddress(), unittest.ServiceAccountPrivateKey)
+			require.NoError(t, err)
+
+			tx = fvm.Transaction(txBody, 0)
+			err = vm.Run(ctx, tx, ledger)
+			require.NoError(t, err)
+
+			// the capacity is unknown rather than zero
+			require.Error(t, tx.Err)
+			assert.NotEqual(t, (&fvm.StorageCapacityExceededError{}).Code(), tx.Err.Code())
+		},
+		fvm.WithStorageCapacityPerFLOW(storageCapacityPerFLOW),
+	))
+
+	t.Run("Contract deployment succeeds if the account can afford its storage", vmTest(
+		func(t *testing.T, vm *fvm.VirtualMachine, chain flow.Chain, ctx fvm.Context, ledger state.Ledger) {
+			address, privateKey, tx := createAccount(t, vm, chain, ctx, ledger, "1.0", 0)
+			require.NoError(t, tx.Err)
+
+			tx = deployContract(t, vm, chain, ctx, ledger, address, privateKey, 1)
+			require.NoError(t, tx.Err)
+		},
+		fvm.WithStorageCapacityPerFLOW(storageCapacityPerFLOW),
+	))
+}
@@ -71,7 +71,7 @@ func (i ScriptInvocator) Process(
 	proc *ScriptProcedure,
 	ledger state.Ledger,
 ) error {
-	env, err := newEnvironment(ctx, ledger)
+	env, err := newEnvironment(ctx, vm, ledger)
 	if err != nil {
 		return err
 	}
@@ -325,6 +325,26 @@ func (a *Accounts) GetStorageUsed(address flow.Address) (uint64, error) {
 	return storageUsed, nil
 }
 
+// GetStorageUpdatedAddresses returns the addresses of the accounts whose storage used has been
+// updated in the ledger, in ascending order.
+func (a *Accounts) GetStorageUpdatedAddresses() []flow.Address {
+	ids, _ := a.ledger.RegisterUpdates()
+
+	addresses := make([]flow.Address, 0)
+	for _, id := range ids {
+		if id.Key != keyStorageUsed || id.Controller != "" {
+			continue
+		}
+		addresses = append(addresses, flow.BytesToAddress([]byte(id.Owner)))
+	}
+
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+
+	return addresses
+}
+
 func (a *Accounts) setStorageUsed(address flow.Address, used uint64) error {
 	usedBinary := utils.Uint64ToBinary(used)
 	return a.setValue(address, false, keyStorageUsed, usedBinary)
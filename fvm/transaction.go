@@ -70,7 +70,7 @@ func (i *TransactionInvocator) Process(
 	proc *TransactionProcedure,
 	ledger state.Ledger,
 ) error {
-	env, err := newEnvironment(ctx, ledger)
+	env, err := newEnvironment(ctx, vm, ledger)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,126 @@
+package fvm
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ufix64Factor is the number of fractional units in one FLOW.
+const ufix64Factor = 100000000
+
+// TransactionStorageLimiter fails transactions which leave an account using more storage than its
+// capacity. It must run after the transaction has been invoked.
+type TransactionStorageLimiter struct{}
+
+func NewTransactionStorageLimiter() *TransactionStorageLimiter {
+	return &TransactionStorageLimiter{}
+}
+
+func (d *TransactionStorageLimiter) Process(
+	vm *VirtualMachine,
+	ctx Context,
+	_ *TransactionProcedure,
+	ledger state.Ledger,
+) error {
+	if !storageLimitsEnabled(ctx) {
+		return nil
+	}
+
+	accounts := state.NewAccounts(ledger)
+
+	for _, address := range accounts.GetStorageUpdatedAddresses() {
+		if isSystemAccount(ctx.Chain, address) {
+			continue
+		}
+
+		storageUsed, err := accounts.GetStorageUsed(address)
+		if err != nil {
+			return fmt.Errorf("failed to get storage used of account %s: %w", address, err)
+		}
+
+		storageCapacity, err := getStorageCapacity(vm, ctx, ledger, address)
+		if vmErr, ok := err.(Error); ok {
+			// the balance of the account could not be read, which fails the transaction
+			return vmErr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get storage capacity of account %s: %w", address, err)
+		}
+
+		if storageUsed > storageCapacity {
+			return &StorageCapacityExceededError{
+				Address:         address,
+				StorageUsed:     storageUsed,
+				StorageCapacity: storageCapacity,
+			}
+		}
+	}
+
+	return nil
+}
+
+// storageLimitsEnabled returns true if the storage of accounts is limited in the given context.
+//
+// Storage capacity is derived from FLOW balances, which only exist if the service account is enabled.
+func storageLimitsEnabled(ctx Context) bool {
+	return ctx.ServiceAccountEnabled && ctx.StorageCapacityPerFLOW > 0
+}
+
+// isSystemAccount returns true for the accounts created when bootstrapping the chain, which hold
+// the FLOW token and service contracts and are not subject to storage limits.
+func isSystemAccount(chain flow.Chain, address flow.Address) bool {
+	return address == chain.ServiceAddress() ||
+		address == FungibleTokenAddress(chain) ||
+		address == FlowTokenAddress(chain) ||
+		address == FlowFeesAddress(chain)
+}
+
+// getStorageCapacity returns the number of bytes an account is allowed to store, derived from
+// its FLOW balance.
+func getStorageCapacity(
+	vm *VirtualMachine,
+	ctx Context,
+	ledger state.Ledger,
+	address flow.Address,
+) (uint64, error) {
+	if !storageLimitsEnabled(ctx) || isSystemAccount(ctx.Chain, address) {
+		return math.MaxUint64, nil
+	}
+
+	script := getFlowTokenBalanceScript(address, ctx.Chain.ServiceAddress())
+
+	err := vm.Run(ctx, script, ledger)
+	if err != nil {
+		return 0, err
+	}
+
+	// the capacity of an account whose balance cannot be read (e.g. because its FLOW vault has been
+	// removed) is unknown, the error fails the transaction instead of assuming an empty balance
+	if script.Err != nil {
+		return 0, script.Err
+	}
+
+	balance, ok := script.Value.ToGoValue().(uint64)
+	if !ok {
+		return 0, fmt.Errorf("balance of account %s has unexpected type %T", address, script.Value.ToGoValue())
+	}
+
+	return storageCapacity(balance, ctx.StorageCapacityPerFLOW), nil
+}
+
+// storageCapacity converts a FLOW balance (in fractional units) into a number of bytes, given the
+// number of bytes granted per FLOW. The result saturates at math.MaxUint64.
+func storageCapacity(balance uint64, bytesPerFLOW uint64) uint64 {
+	hi, lo := bits.Mul64(balance, bytesPerFLOW)
+	if hi >= ufix64Factor {
+		// the quotient does not fit in 64 bits
+		return math.MaxUint64
+	}
+
+	capacity, _ := bits.Div64(hi, lo, ufix64Factor)
+	return capacity
+}
@@ -38,89 +38,29 @@ func (v *TransactionSignatureVerifier) verifyTransactionSignatures(
 		return &MissingPayerError{}
 	}
 
-	var payloadWeights map[flow.Address]int
-	var proposalKeyVerifiedInPayload bool
-
-	payloadWeights, proposalKeyVerifiedInPayload, err = v.aggregateAccountSignatures(
-		accounts,
-		tx.PayloadSignatures,
-		tx.PayloadMessage(),
-		tx.ProposalKey,
-	)
-	if err != nil {
-		return err
-	}
-
-	var envelopeWeights map[flow.Address]int
-	var proposalKeyVerifiedInEnvelope bool
-
-	envelopeWeights, proposalKeyVerifiedInEnvelope, err = v.aggregateAccountSignatures(
-		accounts,
-		tx.EnvelopeSignatures,
-		tx.EnvelopeMessage(),
-		tx.ProposalKey,
-	)
+	weights, err := flow.AggregateTransactionSignatures(tx, func(txSig flow.TransactionSignature, message []byte) (*flow.AccountPublicKey, error) {
+		return v.verifyAccountSignature(accounts, txSig, message)
+	})
 	if err != nil {
 		return err
 	}
 
-	proposalKeyVerified := proposalKeyVerifiedInPayload || proposalKeyVerifiedInEnvelope
-
-	if !proposalKeyVerified {
+	if !weights.ProposalKeySigned {
 		return &InvalidProposalKeyMissingSignatureError{
 			Address:  tx.ProposalKey.Address,
 			KeyIndex: tx.ProposalKey.KeyIndex,
 		}
 	}
 
-	for _, addr := range tx.Authorizers {
-		// Skip this authorizer if it is also the payer. In the case where an account is
-		// both a PAYER as well as an AUTHORIZER or PROPOSER, that account is required
-		// to sign only the envelope.
-		if addr == tx.Payer {
-			continue
-		}
-
-		if !v.hasSufficientKeyWeight(payloadWeights, addr) {
-			return &MissingSignatureError{addr}
-		}
-	}
-
-	if !v.hasSufficientKeyWeight(envelopeWeights, tx.Payer) {
-		return &MissingSignatureError{tx.Payer}
+	// In the case where an account is both a PAYER as well as an AUTHORIZER or PROPOSER,
+	// that account is required to sign only the envelope.
+	if addr, unsigned := weights.UnsignedAccount(tx, v.KeyWeightThreshold); unsigned {
+		return &MissingSignatureError{addr}
 	}
 
 	return nil
 }
 
-func (v *TransactionSignatureVerifier) aggregateAccountSignatures(
-	accounts *state.Accounts,
-	signatures []flow.TransactionSignature,
-	message []byte,
-	proposalKey flow.ProposalKey,
-) (
-	weights map[flow.Address]int,
-	proposalKeyVerified bool,
-	err error,
-) {
-	weights = make(map[flow.Address]int)
-
-	for _, txSig := range signatures {
-		accountKey, err := v.verifyAccountSignature(accounts, txSig, message)
-		if err != nil {
-			return nil, false, err
-		}
-
-		if v.sigIsForProposalKey(txSig, proposalKey) {
-			proposalKeyVerified = true
-		}
-
-		weights[txSig.Address] += accountKey.Weight
-	}
-
-	return
-}
-
 // verifyAccountSignature verifies that an account signature is valid for the
 // account and given message.
 //
@@ -180,17 +120,3 @@ func (v *TransactionSignatureVerifier) verifyAccountSignature(
 
 	return &accountKey, nil
 }
-
-func (v *TransactionSignatureVerifier) hasSufficientKeyWeight(
-	weights map[flow.Address]int,
-	address flow.Address,
-) bool {
-	return weights[address] >= v.KeyWeightThreshold
-}
-
-func (v *TransactionSignatureVerifier) sigIsForProposalKey(
-	txSig flow.TransactionSignature,
-	proposalKey flow.ProposalKey,
-) bool {
-	return txSig.Address == proposalKey.Address && txSig.KeyIndex == proposalKey.KeyIndex
-}
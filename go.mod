@@ -57,6 +57,7 @@ require (
 	golang.org/x/exp v0.0.0-20200224162631-6cc2880d07d6
 	google.golang.org/api v0.31.0
 	google.golang.org/grpc v1.31.1
+	google.golang.org/protobuf v1.25.0
 	gotest.tools v2.2.0+incompatible
 )
 
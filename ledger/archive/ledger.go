@@ -0,0 +1,297 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/module"
+)
+
+// Ledger (archive) is a ledger that can answer reads at any state it has ever seen.
+// It wraps another ledger (usually a complete ledger, which only keeps a limited number of recent tries in memory)
+// and additionally persists every register update into a badger database. Reads at states that are still known
+// by the wrapped ledger are served by it, while reads at older states are served from the archive.
+// Proofs can only be generated for the states known by the wrapped ledger.
+type Ledger struct {
+	base              ledger.Ledger
+	store             *store
+	metrics           module.LedgerMetrics
+	logger            zerolog.Logger
+	pathFinderVersion uint8
+}
+
+// NewLedger creates a new archival ledger on top of the given ledger, storing the archive in the given database.
+// The database should be dedicated to the archive, it is closed when the ledger is shut down.
+//
+// Updates are applied to the wrapped ledger before they are archived, so a crash in between leaves
+// states in the write-ahead log of the wrapped ledger which are missing from the archive. Such states
+// (as well as those the archive was created after) must be imported using ImportWAL before the
+// wrapped ledger is created, otherwise updates applied on top of them can not be archived.
+func NewLedger(db *badger.DB,
+	base ledger.Ledger,
+	metrics module.LedgerMetrics,
+	log zerolog.Logger,
+	pathFinderVer uint8) (*Ledger, error) {
+
+	l := &Ledger{
+		base:              base,
+		store:             newStore(db),
+		metrics:           metrics,
+		logger:            log.With().Str("ledger", "archive").Logger(),
+		pathFinderVersion: pathFinderVer,
+	}
+
+	// the initial state holds no registers and is the root of all states derived from it
+	err := l.store.archive(ledger.RootHash(base.InitialState()), nil, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot archive initial state: %w", err)
+	}
+
+	return l, nil
+}
+
+// ImportWAL catches the archive up with the write-ahead log (including its latest checkpoint) stored in the
+// given directory, archiving every state it contains which has not been archived yet. It is meant to be run on
+// every startup: the segments written since the last import are scanned for updates missing from the archive,
+// and the write-ahead log is only replayed if there are any, or if it has never been imported before.
+// It must not be called on a directory used by a running ledger, as it opens the write-ahead log for writing.
+func ImportWAL(db *badger.DB, dir string, capacity int, metrics module.LedgerMetrics, pathFinderVer uint8) error {
+
+	s := newStore(db)
+
+	w, err := wal.NewWAL(nil, nil, dir, capacity, pathfinder.PathByteSize, wal.SegmentSize)
+	if err != nil {
+		return fmt.Errorf("cannot create LedgerWAL: %w", err)
+	}
+	defer w.Close()
+
+	_, lastSegment, err := w.Segments()
+	if err != nil {
+		return fmt.Errorf("cannot get segments of LedgerWAL: %w", err)
+	}
+
+	importedSegment, imported, err := s.importedSegment()
+	if err != nil {
+		return fmt.Errorf("cannot get last imported segment: %w", err)
+	}
+
+	// resume from the last import, updates applied since then are usually archived already.
+	// The last imported segment is scanned again, as the write-ahead log keeps appending to it when reopened.
+	upToDate := imported
+	if imported {
+		err = w.ReplayLogsFrom(importedSegment,
+			func(update *ledger.TrieUpdate) error {
+				if !upToDate {
+					return nil
+				}
+				archived, err := s.updateArchived(updateID(update))
+				upToDate = archived
+				return err
+			},
+			func(ledger.RootHash) error {
+				return nil
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("cannot scan LedgerWAL for missing updates: %w", err)
+		}
+	}
+
+	if !upToDate {
+		err = replayWAL(s, w, dir, capacity, metrics, pathFinderVer)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.setImportedSegment(lastSegment)
+	if err != nil {
+		return fmt.Errorf("cannot record last imported segment: %w", err)
+	}
+
+	return nil
+}
+
+// replayWAL replays the write-ahead log, including its latest checkpoint, and archives every state it contains.
+// States that have already been archived are skipped.
+func replayWAL(s *store, w *wal.LedgerWAL, dir string, capacity int, metrics module.LedgerMetrics, pathFinderVer uint8) error {
+
+	forest, err := mtrie.NewForest(pathfinder.PathByteSize, dir, capacity, metrics, nil)
+	if err != nil {
+		return fmt.Errorf("cannot create forest: %w", err)
+	}
+
+	err = s.archive(forest.GetEmptyRootHash(), nil, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("cannot archive initial state: %w", err)
+	}
+
+	return w.Replay(
+		func(rebuiltTries []*trie.MTrie) error {
+			err := forest.AddTries(rebuiltTries)
+			if err != nil {
+				return fmt.Errorf("adding rebuilt tries to forest failed: %w", err)
+			}
+
+			// parents of checkpointed tries are unknown, so each of them is archived in full
+			for _, t := range rebuiltTries {
+				_, archived, err := s.version(t.RootHash())
+				if err != nil {
+					return fmt.Errorf("cannot look up checkpointed trie: %w", err)
+				}
+				if archived {
+					continue
+				}
+
+				payloads, err := t.AllPayloads()
+				if err != nil {
+					return fmt.Errorf("cannot read payloads of checkpointed trie: %w", err)
+				}
+				paths, err := pathfinder.PathsFromPayloads(payloads, pathFinderVer)
+				if err != nil {
+					return fmt.Errorf("cannot compute paths of checkpointed trie: %w", err)
+				}
+				ptrs := make([]*ledger.Payload, 0, len(payloads))
+				for i := range payloads {
+					ptrs = append(ptrs, &payloads[i])
+				}
+				err = s.archive(t.RootHash(), nil, nil, paths, ptrs)
+				if err != nil {
+					return fmt.Errorf("cannot archive checkpointed trie: %w", err)
+				}
+			}
+			return nil
+		},
+		func(update *ledger.TrieUpdate) error {
+			// the forest is updated even for archived states, as later updates may build on them
+			rootHash, err := forest.Update(update)
+			if err != nil {
+				return err
+			}
+			return s.archive(rootHash, update.RootHash, updateID(update), update.Paths, update.Payloads)
+		},
+		func(rootHash ledger.RootHash) error {
+			// the archive keeps every state, only the forest used for replaying forgets it
+			return forest.RemoveTrie(rootHash)
+		},
+	)
+}
+
+// updateID returns the ID of the given update, which identifies the state resulting from it,
+// as the update includes the state it is applied to.
+func updateID(update *ledger.TrieUpdate) []byte {
+	id := sha256.Sum256(encoding.EncodeTrieUpdate(update))
+	return id[:]
+}
+
+// Ready implements interface module.ReadyDoneAware
+func (l *Ledger) Ready() <-chan struct{} {
+	return l.base.Ready()
+}
+
+// Done implements interface module.ReadyDoneAware
+// it shuts down the wrapped ledger and closes the archive database afterwards.
+func (l *Ledger) Done() <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-l.base.Done()
+		err := l.store.db.Close()
+		if err != nil {
+			l.logger.Error().Err(err).Msg("could not close archive database")
+		}
+	}()
+	return done
+}
+
+// InitialState returns the state of an empty ledger
+func (l *Ledger) InitialState() ledger.State {
+	return l.base.InitialState()
+}
+
+// Get read the values of the given keys at the given state
+// it returns the values in the same order as given registerIDs and errors (if any)
+func (l *Ledger) Get(query *ledger.Query) (values []ledger.Value, err error) {
+	values, err = l.base.Get(query)
+	if err == nil {
+		return values, nil
+	}
+
+	version, archived, lookupErr := l.store.version(ledger.RootHash(query.State()))
+	if lookupErr != nil {
+		return nil, fmt.Errorf("cannot look up archived state: %w", lookupErr)
+	}
+	if !archived {
+		return nil, err
+	}
+
+	start := time.Now()
+	paths, err := pathfinder.KeysToPaths(query.Keys(), l.pathFinderVersion)
+	if err != nil {
+		return nil, err
+	}
+	payloads, err := l.store.read(version, paths)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read archived state %s: %w", query.State(), err)
+	}
+	values, err = pathfinder.PayloadsToValues(payloads)
+	if err != nil {
+		return nil, err
+	}
+
+	l.metrics.ReadValuesNumber(uint64(len(paths)))
+	l.metrics.ReadDuration(time.Since(start))
+
+	return values, nil
+}
+
+// Set updates the wrapped ledger given an update and archives the updated registers
+// it returns the state after update and errors (if any)
+func (l *Ledger) Set(update *ledger.Update) (newState ledger.State, err error) {
+	newState, err = l.base.Set(update)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Size() == 0 {
+		return newState, nil
+	}
+
+	trieUpdate, err := pathfinder.UpdateToTrieUpdate(update, l.pathFinderVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.store.archive(ledger.RootHash(newState), trieUpdate.RootHash, updateID(trieUpdate), trieUpdate.Paths, trieUpdate.Payloads)
+	if err != nil {
+		return nil, fmt.Errorf("cannot archive update: %w", err)
+	}
+
+	l.logger.Debug().Hex("from", update.State()).
+		Hex("to", newState[:]).
+		Msg("ledger update archived")
+
+	return newState, nil
+}
+
+// Prove provides proofs for a ledger query and errors (if any)
+// proofs are only available for the states known by the wrapped ledger.
+func (l *Ledger) Prove(query *ledger.Query) (proof ledger.Proof, err error) {
+	return l.base.Prove(query)
+}
+
+// HasState returns true if the given state has been archived
+func (l *Ledger) HasState(state ledger.State) (bool, error) {
+	_, archived, err := l.store.version(ledger.RootHash(state))
+	return archived, err
+}
@@ -0,0 +1,242 @@
+package archive_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/archive"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func key(name string) ledger.Key {
+	return ledger.NewKey([]ledger.KeyPart{ledger.NewKeyPart(0, []byte(name))})
+}
+
+func set(t *testing.T, l ledger.Ledger, state ledger.State, name string, value string) ledger.State {
+	update, err := ledger.NewUpdate(state, []ledger.Key{key(name)}, []ledger.Value{ledger.Value(value)})
+	require.NoError(t, err)
+	newState, err := l.Set(update)
+	require.NoError(t, err)
+	return newState
+}
+
+func get(t *testing.T, l ledger.Ledger, state ledger.State, names ...string) []string {
+	keys := make([]ledger.Key, 0, len(names))
+	for _, name := range names {
+		keys = append(keys, key(name))
+	}
+	query, err := ledger.NewQuery(state, keys)
+	require.NoError(t, err)
+	values, err := l.Get(query)
+	require.NoError(t, err)
+
+	ret := make([]string, 0, len(values))
+	for _, v := range values {
+		ret = append(ret, string(v))
+	}
+	return ret
+}
+
+func withArchive(t *testing.T, capacity int, f func(base *complete.Ledger, l *archive.Ledger)) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			base, err := complete.NewLedger(dir, capacity, &metrics.NoopCollector{}, zerolog.Logger{}, nil, complete.DefaultPathFinderVersion)
+			require.NoError(t, err)
+			defer base.Done()
+
+			l, err := archive.NewLedger(db, base, &metrics.NoopCollector{}, zerolog.Logger{}, complete.DefaultPathFinderVersion)
+			require.NoError(t, err)
+
+			f(base, l)
+		})
+	})
+}
+
+func TestLedger_GetEvictedState(t *testing.T) {
+	withArchive(t, 3, func(base *complete.Ledger, l *archive.Ledger) {
+
+		states := []ledger.State{l.InitialState()}
+		for i := 0; i < 10; i++ {
+			state := set(t, l, states[len(states)-1], "counter", string(rune('a'+i)))
+			states = append(states, state)
+		}
+
+		// the oldest tries have been evicted from the wrapped ledger
+		query, err := ledger.NewQuery(states[1], []ledger.Key{key("counter")})
+		require.NoError(t, err)
+		_, err = base.Get(query)
+		require.Error(t, err)
+
+		assert.Equal(t, []string{""}, get(t, l, states[0], "counter"))
+		for i := 1; i < len(states); i++ {
+			assert.Equal(t, []string{string(rune('a' + i - 1))}, get(t, l, states[i], "counter"))
+		}
+	})
+}
+
+func TestLedger_GetForkedState(t *testing.T) {
+	withArchive(t, 3, func(_ *complete.Ledger, l *archive.Ledger) {
+
+		root := set(t, l, l.InitialState(), "shared", "root")
+
+		left := set(t, l, root, "shared", "left")
+		right := set(t, l, root, "shared", "right")
+		leftChild := set(t, l, left, "other", "left child")
+		rightChild := set(t, l, right, "other", "right child")
+
+		// push the forks out of the wrapped ledger
+		state := rightChild
+		for i := 0; i < 5; i++ {
+			state = set(t, l, state, "filler", string(rune('a'+i)))
+		}
+
+		assert.Equal(t, []string{"root", ""}, get(t, l, root, "shared", "other"))
+		assert.Equal(t, []string{"left", "left child"}, get(t, l, leftChild, "shared", "other"))
+		assert.Equal(t, []string{"right", "right child"}, get(t, l, rightChild, "shared", "other"))
+		assert.Equal(t, []string{"right", "right child", "e"}, get(t, l, state, "shared", "other", "filler"))
+	})
+}
+
+func TestLedger_UnknownState(t *testing.T) {
+	withArchive(t, 10, func(_ *complete.Ledger, l *archive.Ledger) {
+		query, err := ledger.NewQuery(ledger.State(unittest.IdentifierFixture().String()), []ledger.Key{key("counter")})
+		require.NoError(t, err)
+		_, err = l.Get(query)
+		require.Error(t, err)
+	})
+}
+
+func TestImportWAL(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+
+			// build some history without archiving it
+			base, err := complete.NewLedger(dir, 100, &metrics.NoopCollector{}, zerolog.Logger{}, nil, complete.DefaultPathFinderVersion)
+			require.NoError(t, err)
+
+			states := []ledger.State{base.InitialState()}
+			for i := 0; i < 10; i++ {
+				states = append(states, set(t, base, states[len(states)-1], "counter", string(rune('a'+i))))
+			}
+			<-base.Done()
+
+			err = archive.ImportWAL(db, dir, 100, &metrics.NoopCollector{}, complete.DefaultPathFinderVersion)
+			require.NoError(t, err)
+
+			// importing twice does not duplicate anything
+			err = archive.ImportWAL(db, dir, 100, &metrics.NoopCollector{}, complete.DefaultPathFinderVersion)
+			require.NoError(t, err)
+
+			// reopen with a forest too small to hold the imported history
+			base, err = complete.NewLedger(dir, 3, &metrics.NoopCollector{}, zerolog.Logger{}, nil, complete.DefaultPathFinderVersion)
+			require.NoError(t, err)
+			defer base.Done()
+
+			l, err := archive.NewLedger(db, base, &metrics.NoopCollector{}, zerolog.Logger{}, complete.DefaultPathFinderVersion)
+			require.NoError(t, err)
+
+			for i := 1; i < len(states); i++ {
+				archived, err := l.HasState(states[i])
+				require.NoError(t, err)
+				assert.True(t, archived)
+				assert.Equal(t, []string{string(rune('a' + i - 1))}, get(t, l, states[i], "counter"))
+			}
+
+			// updates on top of imported states are archived as well
+			next := set(t, l, states[len(states)-1], "counter", "z")
+			assert.Equal(t, []string{"z"}, get(t, l, next, "counter"))
+		})
+	})
+}
+
+// TestImportWAL_CatchUp verifies that importing the write-ahead log on startup restores the states
+// which were applied to the wrapped ledger but not archived, as if the node crashed in between.
+func TestImportWAL_CatchUp(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+
+			base, err := complete.NewLedger(dir, 100, &metrics.NoopCollector{}, zerolog.Logger{}, nil, complete.DefaultPathFinderVersion)
+			require.NoError(t, err)
+			l, err := archive.NewLedger(db, base, &metrics.NoopCollector{}, zerolog.Logger{}, complete.DefaultPathFinderVersion)
+			require.NoError(t, err)
+
+			archived := set(t, l, l.InitialState(), "counter", "a")
+			// the update reaches the write-ahead log, but the node crashes before archiving it
+			missed := set(t, base, archived, "counter", "b")
+			<-base.Done()
+
+			err = archive.ImportWAL(db, dir, 100, &metrics.NoopCollector{}, complete.DefaultPathFinderVersion)
+			require.NoError(t, err)
+
+			// reopen with a forest too small to hold the history
+			base, err = complete.NewLedger(dir, 1, &metrics.NoopCollector{}, zerolog.Logger{}, nil, complete.DefaultPathFinderVersion)
+			require.NoError(t, err)
+			defer base.Done()
+			l, err = archive.NewLedger(db, base, &metrics.NoopCollector{}, zerolog.Logger{}, complete.DefaultPathFinderVersion)
+			require.NoError(t, err)
+
+			has, err := l.HasState(missed)
+			require.NoError(t, err)
+			assert.True(t, has)
+
+			// updates on top of the missed state are archived again
+			next := set(t, l, missed, "counter", "c")
+			set(t, l, next, "other", "d")
+			assert.Equal(t, []string{"b"}, get(t, l, missed, "counter"))
+			assert.Equal(t, []string{"c"}, get(t, l, next, "counter"))
+		})
+	})
+}
+
+// TestImportWAL_Resume verifies that importing the write-ahead log only replays it if updates since
+// the last import are missing from the archive.
+func TestImportWAL_Resume(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+
+			open := func() (*complete.Ledger, *archive.Ledger) {
+				base, err := complete.NewLedger(dir, 100, &metrics.NoopCollector{}, zerolog.Logger{}, nil, complete.DefaultPathFinderVersion)
+				require.NoError(t, err)
+				l, err := archive.NewLedger(db, base, &metrics.NoopCollector{}, zerolog.Logger{}, complete.DefaultPathFinderVersion)
+				require.NoError(t, err)
+				return base, l
+			}
+			importWAL := func() error {
+				return archive.ImportWAL(db, dir, 100, &metrics.NoopCollector{}, complete.DefaultPathFinderVersion)
+			}
+
+			base, l := open()
+			state := set(t, l, l.InitialState(), "counter", "a")
+			<-base.Done()
+			require.NoError(t, importWAL())
+
+			// an update is missed after the first import, so the write-ahead log is replayed
+			base, l = open()
+			state = set(t, l, state, "counter", "b")
+			missed := set(t, base, state, "counter", "c")
+			<-base.Done()
+			require.NoError(t, importWAL())
+
+			base, l = open()
+			has, err := l.HasState(missed)
+			require.NoError(t, err)
+			assert.True(t, has)
+			set(t, l, missed, "counter", "d")
+			<-base.Done()
+
+			// all updates since the last import are archived, so the write-ahead log is not replayed
+			require.NoError(t, importWAL())
+
+			base, l = open()
+			defer base.Done()
+			assert.Equal(t, []string{"c"}, get(t, l, missed, "counter"))
+		})
+	})
+}
@@ -0,0 +1,413 @@
+package archive
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
+)
+
+// key prefixes of the archive database
+const (
+	codeNextVersion  = 1 // next version to be assigned
+	codeStateVersion = 2 // root hash -> version
+	codeLineage      = 3 // version -> lineage
+	codeRegister     = 4 // path + inverted version -> encoded payload
+	codeUpdate       = 5 // update ID -> version of the state resulting from the update
+	codeImported     = 6 // last segment of the write-ahead log imported into the archive
+)
+
+// lineage places an archived state within the tree of states.
+// Depth is the distance to the root of the tree (the initial state or a trie loaded from a checkpoint)
+// and Ancestors[k] holds the version of the ancestor at distance 2^k, which allows to check whether
+// one state descends from another in a logarithmic number of lookups.
+type lineage struct {
+	Depth     uint64
+	Ancestors []uint64
+}
+
+func (l lineage) encode() []byte {
+	buf := make([]byte, 0, 8*(len(l.Ancestors)+1))
+	buf = appendUint64(buf, l.Depth)
+	for _, a := range l.Ancestors {
+		buf = appendUint64(buf, a)
+	}
+	return buf
+}
+
+func decodeLineage(data []byte) (lineage, error) {
+	if len(data) < 8 || len(data)%8 != 0 {
+		return lineage{}, fmt.Errorf("invalid lineage encoding of size %d", len(data))
+	}
+	l := lineage{Depth: binary.BigEndian.Uint64(data)}
+	for i := 8; i < len(data); i += 8 {
+		l.Ancestors = append(l.Ancestors, binary.BigEndian.Uint64(data[i:]))
+	}
+	return l, nil
+}
+
+// store persists registers of every archived state in badger.
+//
+// Every archived state is assigned a unique version, which is larger than the version of its parent.
+// The payload of each register written by a state is stored under its path and the inverted
+// version, so that seeking to a version returns the most recent writes first. As the ledger is
+// fork-aware, the most recent write might belong to another fork; lookups use the lineage of
+// states to skip those.
+type store struct {
+	sync.Mutex // serializes archiving of new states
+	db         *badger.DB
+}
+
+func newStore(db *badger.DB) *store {
+	return &store{db: db}
+}
+
+// version returns the version assigned to the given state and whether the state has been archived.
+func (s *store) version(rootHash ledger.RootHash) (uint64, bool, error) {
+	var version uint64
+	err := s.db.View(func(txn *badger.Txn) error {
+		var err error
+		version, err = retrieveVersion(txn, rootHash)
+		return err
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return version, true, nil
+}
+
+// updateArchived returns whether the state resulting from the update with the given ID
+// has been archived.
+func (s *store) updateArchived(updateID []byte) (bool, error) {
+	err := s.db.View(func(txn *badger.Txn) error {
+		_, err := txn.Get(updateKey(updateID))
+		return err
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// importedSegment returns the last segment of the write-ahead log which has been imported,
+// and whether any segment has been imported yet.
+func (s *store) importedSegment() (int, bool, error) {
+	var segment uint64
+	err := s.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte{codeImported})
+		if err != nil {
+			return err
+		}
+		val, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+		segment = binary.BigEndian.Uint64(val)
+		return nil
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return int(segment), true, nil
+}
+
+// setImportedSegment records the last segment of the write-ahead log which has been imported.
+func (s *store) setImportedSegment(segment int) error {
+	return s.db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte{codeImported}, appendUint64(nil, uint64(segment)))
+	})
+}
+
+// archive stores the given registers as written by a new state. A nil parent archives
+// the state as the root of a new tree of states, in which case the given registers must
+// hold the complete content of the state. States resulting from an update are indexed
+// by the ID of the update, which is nil for roots. Archiving an already archived state
+// only indexes it by the given update ID.
+func (s *store) archive(rootHash ledger.RootHash, parent ledger.RootHash, updateID []byte, paths []ledger.Path, payloads []*ledger.Payload) error {
+	if len(paths) != len(payloads) {
+		return fmt.Errorf("length mismatch: paths have %d elements, but payloads have %d elements", len(paths), len(payloads))
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	version, archived, err := s.version(rootHash)
+	if err != nil {
+		return fmt.Errorf("could not look up state: %w", err)
+	}
+	if archived {
+		if updateID == nil {
+			return nil
+		}
+		return s.db.Update(func(txn *badger.Txn) error {
+			return txn.Set(updateKey(updateID), appendUint64(nil, version))
+		})
+	}
+
+	var lin lineage
+	if parent != nil {
+		err = s.db.View(func(txn *badger.Txn) error {
+			parentVersion, err := retrieveVersion(txn, parent)
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				return fmt.Errorf("parent state %x is not archived", parent)
+			}
+			if err != nil {
+				return fmt.Errorf("could not look up parent state: %w", err)
+			}
+			lin, err = childLineage(txn, parentVersion)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// the version is reserved before any register is written, so that registers
+	// left behind by an interrupted archiving are never attributed to another state
+	version, err = s.reserveVersion()
+	if err != nil {
+		return fmt.Errorf("could not reserve version: %w", err)
+	}
+
+	// if a register is updated multiple times, only the last value is kept
+	latest := make(map[string]int, len(paths))
+	for i, path := range paths {
+		latest[string(path)] = i
+	}
+
+	batch := s.db.NewWriteBatch()
+	for _, i := range latest {
+		err = batch.Set(registerKey(paths[i], version), encoding.EncodePayload(payloads[i]))
+		if err != nil {
+			batch.Cancel()
+			return fmt.Errorf("could not write register: %w", err)
+		}
+	}
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("could not write registers: %w", err)
+	}
+
+	err = s.db.Update(func(txn *badger.Txn) error {
+		err := txn.Set(lineageKey(version), lin.encode())
+		if err != nil {
+			return fmt.Errorf("could not write lineage: %w", err)
+		}
+		err = txn.Set(stateKey(rootHash), appendUint64(nil, version))
+		if err != nil {
+			return fmt.Errorf("could not index state: %w", err)
+		}
+		if updateID != nil {
+			err = txn.Set(updateKey(updateID), appendUint64(nil, version))
+			if err != nil {
+				return fmt.Errorf("could not index update: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not archive state %x: %w", rootHash, err)
+	}
+
+	return nil
+}
+
+// read returns the payloads of the given paths at the state with the given version.
+// Registers that have never been written are returned as empty payloads.
+func (s *store) read(version uint64, paths []ledger.Path) ([]*ledger.Payload, error) {
+	payloads := make([]*ledger.Payload, 0, len(paths))
+	err := s.db.View(func(txn *badger.Txn) error {
+		for _, path := range paths {
+			payload, err := payloadAt(txn, path, version)
+			if err != nil {
+				return fmt.Errorf("could not read register %x: %w", path, err)
+			}
+			payloads = append(payloads, payload)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return payloads, nil
+}
+
+func (s *store) reserveVersion() (uint64, error) {
+	var version uint64
+	err := s.db.Update(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte{codeNextVersion})
+		if err == nil {
+			val, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			version = binary.BigEndian.Uint64(val)
+		} else if !errors.Is(err, badger.ErrKeyNotFound) {
+			return err
+		}
+		return txn.Set([]byte{codeNextVersion}, appendUint64(nil, version+1))
+	})
+	return version, err
+}
+
+// payloadAt returns the payload of the register at the given path, as seen by the state with the
+// given version, i.e. the payload written by the closest ancestor of the state (or the state itself).
+func payloadAt(txn *badger.Txn, path ledger.Path, version uint64) (*ledger.Payload, error) {
+	prefix := append([]byte{codeRegister}, path...)
+
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	opts.Prefix = prefix
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	for it.Seek(registerKey(path, version)); it.ValidForPrefix(prefix); it.Next() {
+		item := it.Item()
+		key := item.Key()
+		written := ^binary.BigEndian.Uint64(key[len(key)-8:])
+
+		ok, err := isAncestor(txn, written, version)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			// written on another fork
+			continue
+		}
+
+		val, err := item.ValueCopy(nil)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.DecodePayload(val)
+	}
+
+	return ledger.EmptyPayload(), nil
+}
+
+// isAncestor returns true if the state with the given ancestor version is the state
+// with the given version or one of its ancestors.
+func isAncestor(txn *badger.Txn, ancestor uint64, version uint64) (bool, error) {
+	if ancestor == version {
+		return true, nil
+	}
+	// versions increase along each branch of the tree of states
+	if ancestor > version {
+		return false, nil
+	}
+
+	a, err := retrieveLineage(txn, ancestor)
+	if err != nil {
+		return false, err
+	}
+	d, err := retrieveLineage(txn, version)
+	if err != nil {
+		return false, err
+	}
+	if a.Depth >= d.Depth {
+		return false, nil
+	}
+
+	// walk up to the depth of the ancestor, taking the largest possible jumps
+	for distance := d.Depth - a.Depth; distance > 0; {
+		k := bits.Len64(distance) - 1
+		version = d.Ancestors[k]
+		distance -= 1 << k
+		if distance == 0 {
+			break
+		}
+		d, err = retrieveLineage(txn, version)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return version == ancestor, nil
+}
+
+// childLineage returns the lineage of a new child of the state with the given version.
+func childLineage(txn *badger.Txn, parentVersion uint64) (lineage, error) {
+	parent, err := retrieveLineage(txn, parentVersion)
+	if err != nil {
+		return lineage{}, fmt.Errorf("could not retrieve parent lineage: %w", err)
+	}
+
+	lin := lineage{
+		Depth:     parent.Depth + 1,
+		Ancestors: []uint64{parentVersion},
+	}
+	for k := 1; uint64(1)<<k <= lin.Depth; k++ {
+		// the ancestor at distance 2^k is the ancestor at distance 2^(k-1) of the ancestor at distance 2^(k-1)
+		half, err := retrieveLineage(txn, lin.Ancestors[k-1])
+		if err != nil {
+			return lineage{}, fmt.Errorf("could not retrieve ancestor lineage: %w", err)
+		}
+		lin.Ancestors = append(lin.Ancestors, half.Ancestors[k-1])
+	}
+
+	return lin, nil
+}
+
+func retrieveVersion(txn *badger.Txn, rootHash ledger.RootHash) (uint64, error) {
+	item, err := txn.Get(stateKey(rootHash))
+	if err != nil {
+		return 0, err
+	}
+	val, err := item.ValueCopy(nil)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(val), nil
+}
+
+func retrieveLineage(txn *badger.Txn, version uint64) (lineage, error) {
+	item, err := txn.Get(lineageKey(version))
+	if err != nil {
+		return lineage{}, fmt.Errorf("could not get lineage of version %d: %w", version, err)
+	}
+	val, err := item.ValueCopy(nil)
+	if err != nil {
+		return lineage{}, err
+	}
+	return decodeLineage(val)
+}
+
+func stateKey(rootHash ledger.RootHash) []byte {
+	return append([]byte{codeStateVersion}, rootHash...)
+}
+
+func lineageKey(version uint64) []byte {
+	return appendUint64([]byte{codeLineage}, version)
+}
+
+func updateKey(updateID []byte) []byte {
+	return append([]byte{codeUpdate}, updateID...)
+}
+
+func registerKey(path ledger.Path, version uint64) []byte {
+	key := make([]byte, 0, 1+len(path)+8)
+	key = append(key, codeRegister)
+	key = append(key, path...)
+	return appendUint64(key, ^version)
+}
+
+func appendUint64(buf []byte, v uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	return append(buf, b[:]...)
+}
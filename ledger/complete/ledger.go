@@ -16,7 +16,6 @@ import (
 	"github.com/onflow/flow-go/ledger/common/encoding"
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
 	"github.com/onflow/flow-go/ledger/complete/mtrie"
-	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/ledger/complete/wal"
 	"github.com/onflow/flow-go/module"
@@ -47,12 +46,14 @@ type Ledger struct {
 }
 
 // NewLedger creates a new in-memory trie-backed ledger storage with persistence.
+// Forest options can be used to enable optional features of the underlying forest, such as paging.
 func NewLedger(dbDir string,
 	capacity int,
 	metrics module.LedgerMetrics,
 	log zerolog.Logger,
 	reg prometheus.Registerer,
-	pathFinderVer uint8) (*Ledger, error) {
+	pathFinderVer uint8,
+	forestOpts ...mtrie.ForestOption) (*Ledger, error) {
 
 	w, err := wal.NewWAL(nil, reg, dbDir, capacity, pathfinder.PathByteSize, wal.SegmentSize)
 	if err != nil {
@@ -61,7 +62,7 @@ func NewLedger(dbDir string,
 
 	forest, err := mtrie.NewForest(pathfinder.PathByteSize, dbDir, capacity, metrics, func(evictedTrie *trie.MTrie) error {
 		return w.RecordDelete(evictedTrie.RootHash())
-	})
+	}, forestOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create forest: %w", err)
 	}
@@ -281,7 +282,10 @@ func (l *Ledger) ExportCheckpointAt(state ledger.State,
 	// l.logger.Info().Msg("Trie is valid.")
 
 	// get all payloads
-	payloads := t.AllPayloads()
+	payloads, err := t.AllPayloads()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read payloads of the trie: %w", err)
+	}
 	payloadSize := len(payloads)
 
 	// migrate payloads
@@ -331,14 +335,9 @@ func (l *Ledger) ExportCheckpointAt(state ledger.State,
 		return nil, fmt.Errorf("failed to create a checkpoint writer: %w", err)
 	}
 
-	flatTrie, err := flattener.FlattenTrie(newTrie)
-	if err != nil {
-		return nil, fmt.Errorf("failed to flatten the trie: %w", err)
-	}
-
 	l.logger.Info().Msg("storing the checkpoint to the file")
 
-	err = wal.StoreCheckpoint(flatTrie.ToFlattenedForestWithASingleTrie(), writer)
+	err = wal.WriteCheckpoint([]*trie.MTrie{newTrie}, writer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to store the checkpoint: %w", err)
 	}
@@ -48,7 +48,8 @@ func FlattenForest(f *mtrie.Forest) (*FlattenedForest, error) {
 
 	counter := uint64(1) // start from 1, as 0 marks nil
 	for _, t := range tries {
-		for itr := NewNodeIterator(t); itr.Next(); {
+		itr := NewNodeIterator(t)
+		for itr.Next() {
 			n := itr.Value()
 			// if node not in map
 			if _, has := allNodes[n]; !has {
@@ -61,6 +62,9 @@ func FlattenForest(f *mtrie.Forest) (*FlattenedForest, error) {
 				storableNodes = append(storableNodes, storableNode)
 			}
 		}
+		if err := itr.Err(); err != nil {
+			return nil, fmt.Errorf("failed to iterate trie nodes: %w", err)
+		}
 		//fix root nodes indices
 		// since we indexed all nodes, root must be present
 		storableTrie, err := toStorableTrie(t, allNodes)
@@ -77,13 +81,21 @@ func FlattenForest(f *mtrie.Forest) (*FlattenedForest, error) {
 }
 
 func toStorableNode(node *node.Node, indexForNode node2indexMap) (*StorableNode, error) {
-	leftIndex, found := indexForNode[node.LeftChild()]
+	lChild, err := node.LeftChild()
+	if err != nil {
+		return nil, err
+	}
+	leftIndex, found := indexForNode[lChild]
 	if !found {
-		return nil, fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(node.LeftChild().Hash()))
+		return nil, fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(lChild.Hash()))
+	}
+	rChild, err := node.RightChild()
+	if err != nil {
+		return nil, err
 	}
-	rightIndex, found := indexForNode[node.RightChild()]
+	rightIndex, found := indexForNode[rChild]
 	if !found {
-		return nil, fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(node.RightChild().Hash()))
+		return nil, fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(rChild.Hash()))
 	}
 
 	storableNode := &StorableNode{
@@ -138,27 +150,35 @@ func RebuildTries(flatForest *FlattenedForest) ([]*trie.MTrie, error) {
 // The sequence must obey the DESCENDANTS-FIRST-RELATIONSHIP
 func RebuildNodes(storableNodes []*StorableNode) ([]*node.Node, error) {
 	nodes := make([]*node.Node, 0, len(storableNodes))
-	for i, snode := range storableNodes {
+	for _, snode := range storableNodes {
 		if snode == nil {
 			nodes = append(nodes, nil)
 			continue
 		}
-		if (snode.LIndex >= uint64(i)) || (snode.RIndex >= uint64(i)) {
-			return nil, fmt.Errorf("sequence of StorableNodes does not satisfy Descendents-First-Relationship")
-		}
-
-		if len(snode.Path) > 0 {
-			path := ledger.Path(snode.Path)
-			payload, err := encoding.DecodePayload(snode.EncPayload)
-			if err != nil {
-				return nil, fmt.Errorf("failed to decode a payload for an storableNode %w", err)
-			}
-			node := node.NewNode(int(snode.Height), nodes[snode.LIndex], nodes[snode.RIndex], path, payload, snode.HashValue, snode.MaxDepth, snode.RegCount)
-			nodes = append(nodes, node)
-			continue
+		node, err := rebuildNode(snode, nodes)
+		if err != nil {
+			return nil, err
 		}
-		node := node.NewNode(int(snode.Height), nodes[snode.LIndex], nodes[snode.RIndex], nil, nil, snode.HashValue, snode.MaxDepth, snode.RegCount)
 		nodes = append(nodes, node)
 	}
 	return nodes, nil
 }
+
+// rebuildNode generates the Node of a StorableNode, whose children are contained in the given
+// list of previously rebuilt nodes. The node will be appended to the list at the next index.
+func rebuildNode(snode *StorableNode, nodes []*node.Node) (*node.Node, error) {
+	i := uint64(len(nodes))
+	if (snode.LIndex >= i) || (snode.RIndex >= i) {
+		return nil, fmt.Errorf("sequence of StorableNodes does not satisfy Descendents-First-Relationship")
+	}
+
+	if len(snode.Path) > 0 {
+		path := ledger.Path(snode.Path)
+		payload, err := encoding.DecodePayload(snode.EncPayload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode a payload for an storableNode %w", err)
+		}
+		return node.NewNode(int(snode.Height), nodes[snode.LIndex], nodes[snode.RIndex], path, payload, snode.HashValue, snode.MaxDepth, snode.RegCount), nil
+	}
+	return node.NewNode(int(snode.Height), nodes[snode.LIndex], nodes[snode.RIndex], nil, nil, snode.HashValue, snode.MaxDepth, snode.RegCount), nil
+}
@@ -1,6 +1,8 @@
 package flattener
 
 import (
+	"bytes"
+
 	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 )
@@ -52,6 +54,8 @@ type NodeIterator struct {
 	// This has the advantage, that we gracefully handle tries whose root node is nil.
 	unprocessedRoot *node.Node
 	stack           []*node.Node
+	// err holds the error of loading a paged out node, which ends the iteration
+	err error
 }
 
 // NewNodeIterator returns a node NodeIterator, which iterates through all nodes
@@ -77,9 +81,12 @@ func NewNodeIterator(mTrie *trie.MTrie) *NodeIterator {
 func (i *NodeIterator) Next() bool {
 	if i.unprocessedRoot != nil {
 		// initial call to Next() for a non-empty trie
-		i.dig(i.unprocessedRoot)
+		i.err = i.dig(i.unprocessedRoot)
 		i.unprocessedRoot = nil
-		return true
+		return i.err == nil
+	}
+	if i.err != nil {
+		return false
 	}
 
 	// the current head of the stack, `n`, has been recalled
@@ -91,14 +98,35 @@ func (i *NodeIterator) Next() bool {
 		// done so already. As we decent into the left child with priority, the only case where
 		// we still need to dig into the right child is, if n is p's left child.
 		parent := i.peek()
-		if parent.LeftChild() == n {
-			i.dig(parent.RightChild())
+		if isLeftChild(parent, n) {
+			rChild, err := parent.RightChild()
+			if err != nil {
+				i.err = err
+				return false
+			}
+			i.err = i.dig(rChild)
+			return i.err == nil
 		}
 		return true
 	}
 	return false // as len(i.stack) == 0, i.e. there are no more elements to recall
 }
 
+// isLeftChild returns true if n is the left child of the parent. A paged out child can only be
+// identified by its hash, as loading it again may return another copy of the node.
+func isLeftChild(parent *node.Node, n *node.Node) bool {
+	lChild := parent.LeftChildRef()
+	if lChild == nil {
+		return false
+	}
+	return lChild == n || (lChild.IsStub() && bytes.Equal(lChild.Hash(), n.Hash()))
+}
+
+// Err returns the error which ended the iteration, if a paged out node could not be loaded.
+func (i *NodeIterator) Err() error {
+	return i.err
+}
+
 func (i *NodeIterator) Value() *node.Node {
 	if len(i.stack) == 0 {
 		return nil
@@ -117,20 +145,28 @@ func (i *NodeIterator) peek() *node.Node {
 	return i.stack[len(i.stack)-1]
 }
 
-func (i *NodeIterator) dig(n *node.Node) {
+func (i *NodeIterator) dig(n *node.Node) error {
 	if n == nil {
-		return
+		return nil
 	}
 	for {
 		i.stack = append(i.stack, n)
-		if lChild := n.LeftChild(); lChild != nil {
+		lChild, err := n.LeftChild()
+		if err != nil {
+			return err
+		}
+		if lChild != nil {
 			n = lChild
 			continue
 		}
-		if rChild := n.RightChild(); rChild != nil {
+		rChild, err := n.RightChild()
+		if err != nil {
+			return err
+		}
+		if rChild != nil {
 			n = rChild
 			continue
 		}
-		return
+		return nil
 	}
 }
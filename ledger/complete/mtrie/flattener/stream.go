@@ -0,0 +1,226 @@
+package flattener
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger/common/encoding"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+// StreamTries flattens the given tries one node at a time. For tries ordered as described below, it
+// produces the same sequence of storable nodes as FlattenForest, but instead of collecting them, it hands every storable node to nodeFn as
+// soon as it has been created. Storable tries (which are small) are returned once all nodes have been
+// streamed.
+//
+// Besides the tries themselves, only the indices of nodes shared between consecutive tries are kept
+// in memory: as a trie shares nodes with the trie it has been derived from at the same positions, the
+// shared subtries are found by walking both tries along their differences. These subtries are only
+// streamed once, their index is kept until the last trie sharing them has been streamed. Subtries
+// shared between tries which are not consecutive are streamed again, which is correct but takes more
+// space, so the tries should be ordered such that each trie follows the trie it has been derived from.
+func StreamTries(tries []*trie.MTrie, nodeFn func(*StorableNode) error) ([]*StorableTrie, error) {
+	// find the subtries shared between consecutive tries, and the last trie sharing each of them
+	shared := make(map[*node.Node]*sharedNode)
+	for i := 1; i < len(tries); i++ {
+		findShared(tries[i-1].RootNode(), tries[i].RootNode(), i, shared)
+	}
+	expiring := make([][]*node.Node, len(tries))
+	for n, sn := range shared {
+		expiring[sn.lastTrie] = append(expiring[sn.lastTrie], n)
+	}
+
+	s := &streamer{
+		nodeFn:  nodeFn,
+		shared:  shared,
+		counter: 1, // start from 1, as 0 marks nil
+	}
+	storableTries := make([]*StorableTrie, 0, len(tries))
+	for i, t := range tries {
+		rootIndex, err := s.stream(t.RootNode())
+		if err != nil {
+			return nil, err
+		}
+		storableTries = append(storableTries, &StorableTrie{
+			RootIndex: rootIndex,
+			RootHash:  t.RootHash(),
+		})
+
+		// forget the subtries which are not shared with any of the remaining tries
+		for _, n := range expiring[i] {
+			delete(shared, n)
+		}
+		expiring[i] = nil
+	}
+
+	return storableTries, nil
+}
+
+// sharedNode holds the index of a node shared between tries, once it has been streamed.
+type sharedNode struct {
+	index    uint64 // 0 until the node has been streamed
+	lastTrie int    // position of the last trie sharing the node
+}
+
+// findShared walks two tries along the positions where their nodes differ, and records the roots of
+// the subtries they share as shared by the second trie, at the given position.
+func findShared(a *node.Node, b *node.Node, trieIndex int, shared map[*node.Node]*sharedNode) {
+	if a == nil || b == nil {
+		return
+	}
+	if a == b {
+		sn, ok := shared[a]
+		if !ok {
+			sn = &sharedNode{}
+			shared[a] = sn
+		}
+		sn.lastTrie = trieIndex
+		return
+	}
+	// subtries of paged out nodes are not compared, as it would require loading them
+	if a.IsLeaf() || b.IsLeaf() || a.IsStub() || b.IsStub() {
+		return
+	}
+	findShared(a.LeftChildRef(), b.LeftChildRef(), trieIndex, shared)
+	findShared(a.RightChildRef(), b.RightChildRef(), trieIndex, shared)
+}
+
+type streamer struct {
+	nodeFn  func(*StorableNode) error
+	shared  map[*node.Node]*sharedNode
+	counter uint64
+}
+
+// stream streams the subtrie of the given node in an order which satisfies the
+// Descendents-First-Relationship, and returns the index of the node.
+func (s *streamer) stream(n *node.Node) (uint64, error) {
+	if n == nil {
+		return 0, nil
+	}
+	sn, isShared := s.shared[n]
+	if isShared && sn.index != 0 {
+		return sn.index, nil
+	}
+
+	resolved, err := n.Resolve()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load node: %w", err)
+	}
+	leftIndex, err := s.stream(resolved.LeftChildRef())
+	if err != nil {
+		return 0, err
+	}
+	rightIndex, err := s.stream(resolved.RightChildRef())
+	if err != nil {
+		return 0, err
+	}
+
+	index := s.counter
+	s.counter++
+	err = s.nodeFn(&StorableNode{
+		LIndex:     leftIndex,
+		RIndex:     rightIndex,
+		Height:     uint16(resolved.Height()),
+		Path:       resolved.Path(),
+		EncPayload: encoding.EncodePayload(resolved.Payload()),
+		HashValue:  resolved.Hash(),
+		MaxDepth:   resolved.MaxDepth(),
+		RegCount:   resolved.RegCount(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to process storable node: %w", err)
+	}
+
+	if isShared {
+		sn.index = index
+	}
+	return index, nil
+}
+
+// Rebuilder rebuilds tries from a stream of storable nodes and tries, as produced by StreamTries.
+// Storable nodes must be added in an order which satisfies the Descendents-First-Relationship,
+// and are turned into nodes right away, so the stream never needs to be held in memory.
+type Rebuilder struct {
+	nodes  []*node.Node
+	verify bool
+}
+
+// NewRebuilder returns a rebuilder without any nodes.
+func NewRebuilder() *Rebuilder {
+	return &Rebuilder{
+		nodes: []*node.Node{nil}, // 0th element is nil
+	}
+}
+
+// NewVerifyingRebuilder returns a rebuilder which does not trust the hash, max depth and register count
+// of storable nodes, but recomputes them from the node's children (or payload) and rejects mismatching nodes.
+// As children are verified before their parents, the root hash of every rebuilt trie is recomputed from scratch.
+func NewVerifyingRebuilder() *Rebuilder {
+	r := NewRebuilder()
+	r.verify = true
+	return r
+}
+
+// AddNode rebuilds the next node of the stream.
+func (r *Rebuilder) AddNode(snode *StorableNode) error {
+	n, err := rebuildNode(snode, r.nodes)
+	if err != nil {
+		return err
+	}
+	if r.verify {
+		err = verifyNode(n)
+		if err != nil {
+			return fmt.Errorf("invalid node %d: %w", len(r.nodes), err)
+		}
+	}
+	r.nodes = append(r.nodes, n)
+	return nil
+}
+
+// NodesCount returns the number of nodes rebuilt so far.
+func (r *Rebuilder) NodesCount() uint64 {
+	return uint64(len(r.nodes) - 1)
+}
+
+// Trie returns the trie of the given storable trie, whose nodes must have been added before.
+func (r *Rebuilder) Trie(storableTrie *StorableTrie) (*trie.MTrie, error) {
+	if storableTrie.RootIndex >= uint64(len(r.nodes)) {
+		return nil, fmt.Errorf("restoring trie failed: root node %d has not been rebuilt", storableTrie.RootIndex)
+	}
+	mtrie, err := trie.NewMTrie(r.nodes[storableTrie.RootIndex])
+	if err != nil {
+		return nil, fmt.Errorf("restoring trie failed: %w", err)
+	}
+	if !bytes.Equal(storableTrie.RootHash, mtrie.RootHash()) {
+		return nil, fmt.Errorf("restoring trie failed: roothash doesn't match")
+	}
+	return mtrie, nil
+}
+
+// verifyNode checks the cached values of a node against the ones computed from its children or payload.
+func verifyNode(n *node.Node) error {
+	var expected *node.Node
+	switch {
+	case n.IsLeaf():
+		if n.LeftChildRef() != nil || n.RightChildRef() != nil {
+			return fmt.Errorf("leaf has children")
+		}
+		expected = node.NewLeaf(n.Path(), n.Payload(), n.Height())
+	case n.LeftChildRef() == nil && n.RightChildRef() == nil:
+		expected = node.NewEmptyTreeRoot(n.Height())
+	default:
+		expected = node.NewInterimNode(n.Height(), n.LeftChildRef(), n.RightChildRef())
+	}
+
+	if !bytes.Equal(n.Hash(), expected.Hash()) {
+		return fmt.Errorf("hash %x does not match computed hash %x", n.Hash(), expected.Hash())
+	}
+	if n.MaxDepth() != expected.MaxDepth() {
+		return fmt.Errorf("max depth %d does not match computed max depth %d", n.MaxDepth(), expected.MaxDepth())
+	}
+	if n.RegCount() != expected.RegCount() {
+		return fmt.Errorf("register count %d does not match computed register count %d", n.RegCount(), expected.RegCount())
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package flattener_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+func TestStreamAndRebuild(t *testing.T) {
+	pathByteSize := 1
+	dir, err := ioutil.TempDir("", "test-mtrie-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	mForest, err := mtrie.NewForest(pathByteSize, dir, 5, &metrics.NoopCollector{}, nil)
+	require.NoError(t, err)
+	rootHash := mForest.GetEmptyRootHash()
+
+	paths := []ledger.Path{utils.OneBytePath(1), utils.OneBytePath(2), utils.OneBytePath(130), utils.OneBytePath(131)}
+	payloads := []*ledger.Payload{utils.LightPayload8('A', 'a'), utils.LightPayload8('B', 'b'), utils.LightPayload8('C', 'c'), utils.LightPayload8('D', 'd')}
+	rootHash, err = mForest.Update(&ledger.TrieUpdate{RootHash: rootHash, Paths: paths, Payloads: payloads})
+	require.NoError(t, err)
+
+	update := &ledger.TrieUpdate{RootHash: rootHash, Paths: []ledger.Path{utils.OneBytePath(132)}, Payloads: []*ledger.Payload{utils.LightPayload8('E', 'e')}}
+	_, err = mForest.Update(update)
+	require.NoError(t, err)
+
+	tries, err := mForest.GetTries()
+	require.NoError(t, err)
+
+	// streamed nodes are rebuilt right away
+	rebuilder := flattener.NewRebuilder()
+	streamed := []*flattener.StorableNode{nil}
+	storableTries, err := flattener.StreamTries(tries, func(n *flattener.StorableNode) error {
+		streamed = append(streamed, n)
+		return rebuilder.AddNode(n)
+	})
+	require.NoError(t, err)
+
+	// the stream is the same as the flattened forest
+	forestSequencing, err := flattener.FlattenForest(mForest)
+	require.NoError(t, err)
+	assert.Equal(t, forestSequencing.Nodes, streamed)
+	assert.Equal(t, forestSequencing.Tries, storableTries)
+	assert.Equal(t, uint64(len(streamed)-1), rebuilder.NodesCount())
+
+	rebuiltTries := make([]*trie.MTrie, 0, len(storableTries))
+	for _, storableTrie := range storableTries {
+		rebuilt, err := rebuilder.Trie(storableTrie)
+		require.NoError(t, err)
+		rebuiltTries = append(rebuiltTries, rebuilt)
+	}
+	require.Len(t, rebuiltTries, len(tries))
+	for i := range tries {
+		assert.Equal(t, tries[i].RootHash(), rebuiltTries[i].RootHash())
+		assert.True(t, rebuiltTries[i].IsAValidTrie())
+	}
+
+	// nodes must be added after their children
+	rebuilder = flattener.NewRebuilder()
+	err = rebuilder.AddNode(streamed[len(streamed)-1])
+	require.Error(t, err)
+
+	// a verifying rebuilder recomputes hashes instead of trusting them
+	rebuilder = flattener.NewVerifyingRebuilder()
+	for _, n := range streamed[1:] {
+		require.NoError(t, rebuilder.AddNode(n))
+	}
+	tampered := *streamed[1]
+	tampered.HashValue = streamed[2].HashValue
+	rebuilder = flattener.NewVerifyingRebuilder()
+	err = rebuilder.AddNode(&tampered)
+	require.Error(t, err)
+}
+
+// TestStreamForkedTries checks that tries sharing subtries with tries other than the previous one
+// are streamed completely and can be rebuilt.
+func TestStreamForkedTries(t *testing.T) {
+	pathByteSize := 1
+	dir, err := ioutil.TempDir("", "test-mtrie-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	mForest, err := mtrie.NewForest(pathByteSize, dir, 5, &metrics.NoopCollector{}, nil)
+	require.NoError(t, err)
+
+	paths := []ledger.Path{utils.OneBytePath(1), utils.OneBytePath(2), utils.OneBytePath(130), utils.OneBytePath(131)}
+	payloads := []*ledger.Payload{utils.LightPayload8('A', 'a'), utils.LightPayload8('B', 'b'), utils.LightPayload8('C', 'c'), utils.LightPayload8('D', 'd')}
+	parent, err := mForest.Update(&ledger.TrieUpdate{RootHash: mForest.GetEmptyRootHash(), Paths: paths, Payloads: payloads})
+	require.NoError(t, err)
+
+	// two tries derived from the same parent
+	left, err := mForest.Update(&ledger.TrieUpdate{RootHash: parent, Paths: paths[:1], Payloads: []*ledger.Payload{utils.LightPayload8('E', 'e')}})
+	require.NoError(t, err)
+	right, err := mForest.Update(&ledger.TrieUpdate{RootHash: parent, Paths: paths[3:], Payloads: []*ledger.Payload{utils.LightPayload8('F', 'f')}})
+	require.NoError(t, err)
+
+	var tries []*trie.MTrie
+	for _, rootHash := range []ledger.RootHash{parent, left, right} {
+		tr, err := mForest.GetTrie(rootHash)
+		require.NoError(t, err)
+		tries = append(tries, tr)
+	}
+
+	rebuilder := flattener.NewVerifyingRebuilder()
+	storableTries, err := flattener.StreamTries(tries, rebuilder.AddNode)
+	require.NoError(t, err)
+	require.Len(t, storableTries, len(tries))
+	for i, storableTrie := range storableTries {
+		rebuilt, err := rebuilder.Trie(storableTrie)
+		require.NoError(t, err)
+		assert.Equal(t, tries[i].RootHash(), rebuilt.RootHash())
+
+		expected, err := tries[i].AllPayloads()
+		require.NoError(t, err)
+		actual, err := rebuilt.AllPayloads()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, expected, actual)
+	}
+}
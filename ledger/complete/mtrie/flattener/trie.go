@@ -32,7 +32,8 @@ func FlattenTrie(trie *trie.MTrie) (*FlattenedTrie, error) {
 	allNodes[nil] = 0 // 0th element is nil
 
 	counter := uint64(1) // start from 1, as 0 marks nil
-	for itr := NewNodeIterator(trie); itr.Next(); {
+	itr := NewNodeIterator(trie)
+	for itr.Next() {
 		n := itr.Value()
 		// if node not in map
 		if _, has := allNodes[n]; !has {
@@ -45,6 +46,9 @@ func FlattenTrie(trie *trie.MTrie) (*FlattenedTrie, error) {
 			storableNodes = append(storableNodes, storableNode)
 		}
 	}
+	if err := itr.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate trie nodes: %w", err)
+	}
 	// fix root nodes indices
 	// since we indexed all nodes, root must be present
 	storableTrie, err := toStorableTrie(trie, allNodes)
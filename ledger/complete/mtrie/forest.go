@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/hashicorp/go-multierror"
 	lru "github.com/hashicorp/golang-lru"
 
 	"github.com/onflow/flow-go/ledger"
@@ -36,6 +37,29 @@ type Forest struct {
 	onTreeEvicted  func(tree *trie.MTrie) error
 	pathByteSize   int // length [bytes] of register path
 	metrics        module.LedgerMetrics
+	pager          Pager // optional, moves cold subtries out of memory
+	evictErr       error // errors of evictions triggered by the LRU cache, returned by the next Forest call
+}
+
+// Pager moves cold subtries of tries out of memory.
+type Pager interface {
+	// PageOut returns a trie equivalent to the given one, whose cold subtries have been paged out.
+	// Subtries shared with tries paged out before are left untouched.
+	PageOut(t *trie.MTrie) (*trie.MTrie, error)
+
+	// Release drops the paged out subtries of the given trie, unless they are shared with other tries.
+	// It is called once the trie has been removed from the forest.
+	Release(t *trie.MTrie) error
+}
+
+// ForestOption configures optional features of a Forest.
+type ForestOption func(*Forest)
+
+// WithPager makes the forest page cold subtries of its tries out of memory, using the given pager.
+func WithPager(pager Pager) ForestOption {
+	return func(f *Forest) {
+		f.pager = pager
+	}
 }
 
 // NewForest returns a new instance of memory forest.
@@ -45,18 +69,35 @@ type Forest struct {
 // THIS IS A ROUGH HEURISTIC as it might evict tries that are still needed.
 // Make sure you chose a sufficiently large forestCapacity, such that, when reaching the capacity, the
 // Least Recently Used trie will never be needed again.
-func NewForest(pathByteSize int, trieStorageDir string, forestCapacity int, metrics module.LedgerMetrics, onTreeEvicted func(tree *trie.MTrie) error) (*Forest, error) {
+func NewForest(pathByteSize int, trieStorageDir string, forestCapacity int, metrics module.LedgerMetrics, onTreeEvicted func(tree *trie.MTrie) error, opts ...ForestOption) (*Forest, error) {
+	// init Forest and add an empty trie
+	if pathByteSize < 1 {
+		return nil, errors.New("trie's path size [in bytes] must be positive")
+	}
+	forest := &Forest{
+		dir:            trieStorageDir,
+		forestCapacity: forestCapacity,
+		onTreeEvicted:  onTreeEvicted,
+		pathByteSize:   pathByteSize,
+		metrics:        metrics,
+	}
+	for _, apply := range opts {
+		apply(forest)
+	}
+
 	// init LRU cache as a SHORTCUT for a usage-related storage eviction policy
 	var cache *lru.Cache
 	var err error
-	if onTreeEvicted != nil {
+	if onTreeEvicted != nil || forest.pager != nil {
 		cache, err = lru.NewWithEvict(forestCapacity, func(key interface{}, value interface{}) {
 			trie, ok := value.(*trie.MTrie)
 			if !ok {
 				panic(fmt.Sprintf("cache contains item of type %T", value))
 			}
-			//TODO Log error
-			_ = onTreeEvicted(trie)
+			err := forest.evict(trie)
+			if err != nil {
+				forest.evictErr = multierror.Append(forest.evictErr, err)
+			}
 		})
 	} else {
 		cache, err = lru.New(forestCapacity)
@@ -64,18 +105,7 @@ func NewForest(pathByteSize int, trieStorageDir string, forestCapacity int, metr
 	if err != nil {
 		return nil, fmt.Errorf("cannot create forest cache: %w", err)
 	}
-
-	// init Forest and add an empty trie
-	if pathByteSize < 1 {
-		return nil, errors.New("trie's path size [in bytes] must be positive")
-	}
-	forest := &Forest{tries: cache,
-		dir:            trieStorageDir,
-		forestCapacity: forestCapacity,
-		onTreeEvicted:  onTreeEvicted,
-		pathByteSize:   pathByteSize,
-		metrics:        metrics,
-	}
+	forest.tries = cache
 
 	// add empty roothash
 	emptyTrie, err := trie.NewEmptyMTrie(pathByteSize)
@@ -203,7 +233,14 @@ func (f *Forest) Update(u *ledger.TrieUpdate) (ledger.RootHash, error) {
 	f.metrics.LatestTrieMaxDepth(uint64(newTrie.MaxDepth()))
 	f.metrics.LatestTrieMaxDepthDiff(uint64(newTrie.MaxDepth() - parentTrie.MaxDepth()))
 
-	err = f.AddTrie(newTrie)
+	if f.pager != nil {
+		newTrie, err = f.pager.PageOut(newTrie)
+		if err != nil {
+			return nil, fmt.Errorf("paging out updated trie failed: %w", err)
+		}
+	}
+
+	err = f.addTrie(newTrie)
 	if err != nil {
 		return nil, fmt.Errorf("adding updated trie to forest failed: %w", err)
 	}
@@ -354,10 +391,30 @@ func (f *Forest) AddTrie(newTrie *trie.MTrie) error {
 		return fmt.Errorf("forest has path length %d, but new trie has path length %d", f.pathByteSize, newTrie.PathLength())
 	}
 
+	if f.pager != nil {
+		var err error
+		newTrie, err = f.pager.PageOut(newTrie)
+		if err != nil {
+			return fmt.Errorf("paging out trie failed: %w", err)
+		}
+	}
+
+	return f.addTrie(newTrie)
+}
+
+func (f *Forest) addTrie(newTrie *trie.MTrie) error {
+
 	// TODO: check Thread safety
 	// TODO what is this string root hash
 	hashString := newTrie.StringRootHash()
 	if storedTrie, found := f.tries.Get(hashString); found {
+		// the new trie is dropped, its paged out nodes are held by the stored trie
+		if f.pager != nil {
+			err := f.pager.Release(newTrie)
+			if err != nil {
+				return fmt.Errorf("releasing duplicate trie failed: %w", err)
+			}
+		}
 		foo := storedTrie.(*trie.MTrie)
 		if foo.Equals(newTrie) {
 			return nil
@@ -366,16 +423,49 @@ func (f *Forest) AddTrie(newTrie *trie.MTrie) error {
 	}
 	f.tries.Add(hashString, newTrie)
 	f.metrics.ForestNumberOfTrees(uint64(f.tries.Len()))
+	err := f.evicted()
+	if err != nil {
+		return fmt.Errorf("evicting least recently used trie failed: %w", err)
+	}
 
 	return nil
 }
 
+// evict is called when a trie is removed from the forest, either explicitly or by the LRU cache.
+func (f *Forest) evict(t *trie.MTrie) error {
+	if f.onTreeEvicted != nil {
+		err := f.onTreeEvicted(t)
+		if err != nil {
+			return fmt.Errorf("eviction callback failed: %w", err)
+		}
+	}
+	if f.pager != nil {
+		err := f.pager.Release(t)
+		if err != nil {
+			return fmt.Errorf("releasing paged out nodes failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// evicted returns the errors of the evictions since its last call, and resets them.
+func (f *Forest) evicted() error {
+	err := f.evictErr
+	f.evictErr = nil
+	return err
+}
+
 // RemoveTrie removes a trie to the forest
-func (f *Forest) RemoveTrie(rootHash []byte) {
+func (f *Forest) RemoveTrie(rootHash []byte) error {
 	// TODO remove from the file as well
 	encRootHash := hex.EncodeToString(rootHash)
 	f.tries.Remove(encRootHash)
 	f.metrics.ForestNumberOfTrees(uint64(f.tries.Len()))
+	err := f.evicted()
+	if err != nil {
+		return fmt.Errorf("removing trie failed: %w", err)
+	}
+	return nil
 }
 
 // GetEmptyRootHash returns the rootHash of empty Trie
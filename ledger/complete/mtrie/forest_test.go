@@ -51,10 +51,42 @@ func TestTrieOperations(t *testing.T) {
 	require.Equal(t, forest.Size(), 2)
 
 	// Remove trie
-	forest.RemoveTrie(updatedTrie.RootHash())
+	err = forest.RemoveTrie(updatedTrie.RootHash())
+	require.NoError(t, err)
 	require.Equal(t, forest.Size(), 1)
 }
 
+// TestTrieEvictionError tests that errors of evicting tries from the Forest are returned
+func TestTrieEvictionError(t *testing.T) {
+	pathByteSize := 2 // path size of 16 bits
+
+	dir, err := ioutil.TempDir("", "test-mtrie-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	evictErr := fmt.Errorf("eviction failed")
+	forest, err := NewForest(pathByteSize, dir, 1, &metrics.NoopCollector{}, func(tree *trie.MTrie) error {
+		return evictErr
+	})
+	require.NoError(t, err)
+
+	nt, err := trie.NewEmptyMTrie(pathByteSize)
+	require.NoError(t, err)
+	p1 := pathByUint8s([]uint8{uint8(53), uint8(74)}, pathByteSize)
+	v1 := payloadBySlices([]byte{'A'}, []byte{'A'})
+	updatedTrie, err := trie.NewTrieWithUpdatedRegisters(nt, []ledger.Path{p1}, []ledger.Payload{*v1})
+	require.NoError(t, err)
+
+	// adding a trie to a full forest evicts the empty trie
+	err = forest.AddTrie(updatedTrie)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), evictErr.Error())
+
+	err = forest.RemoveTrie(updatedTrie.RootHash())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), evictErr.Error())
+}
+
 // TestTrieUpdate updates the empty trie with some values and verifies that the
 // written values can be retrieved from the updated trie.
 func TestTrieUpdate(t *testing.T) {
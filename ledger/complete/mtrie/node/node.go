@@ -25,6 +25,10 @@ import (
 //      has no children, and no key-value
 // Currently, we represent both data structures by Node instances
 //
+// Subtries can be paged out of memory. A paged out node is represented by a STUB,
+// which only holds the node's height, hash, max depth and register count, and which
+// is transparently loaded (through its Loader) when accessed as a child of its parent.
+//
 // Nodes are supposed to be used in READ-ONLY fashion. However,
 // for performance reasons, we not not copy read.
 // TODO: optimized data structures might be able to reduce memory consumption
@@ -37,6 +41,13 @@ type Node struct {
 	hashValue []byte          // hash value of node (cached)
 	maxDepth  uint16          // captures the longest path from this node to compacted leafs in the subtree
 	regCount  uint64          // number of registers allocated in the subtree
+	loader    Loader          // loads the node if it has been paged out (stubs only)
+}
+
+// Loader loads nodes that have been paged out of memory.
+type Loader interface {
+	// Load returns the node with the given height and hash.
+	Load(height int, hashValue []byte) (*Node, error)
 }
 
 // NewNode creates a new Node.
@@ -72,6 +83,18 @@ func NewNode(height int,
 	return n
 }
 
+// NewStub creates a stub for a node that has been paged out of memory.
+// The node is loaded through the given loader when it is accessed as a child of its parent.
+func NewStub(height int, hashValue []byte, maxDepth uint16, regCount uint64, loader Loader) *Node {
+	return &Node{
+		height:    height,
+		hashValue: hashValue,
+		maxDepth:  maxDepth,
+		regCount:  regCount,
+		loader:    loader,
+	}
+}
+
 // NewEmptyTreeRoot creates a compact leaf Node
 // UNCHECKED requirement: height must be non-negative
 func NewEmptyTreeRoot(height int) *Node {
@@ -165,14 +188,24 @@ func (n *Node) computeHash() []byte {
 	return common.HashInterNode(h1, h2)
 }
 
+// VerifyCachedHash verifies the cached hash values of the Node and its subtrie.
+// A paged out node that cannot be loaded fails the verification.
 func (n *Node) VerifyCachedHash() bool {
-	if n.lChild != nil {
-		if !n.lChild.VerifyCachedHash() {
+	lChild, err := n.LeftChild()
+	if err != nil {
+		return false
+	}
+	if lChild != nil {
+		if !lChild.VerifyCachedHash() {
 			return false
 		}
 	}
-	if n.rChild != nil {
-		if !n.rChild.VerifyCachedHash() {
+	rChild, err := n.RightChild()
+	if err != nil {
+		return false
+	}
+	if rChild != nil {
+		if !rChild.VerifyCachedHash() {
 			return false
 		}
 	}
@@ -206,15 +239,45 @@ func (n *Node) Path() ledger.Path { return n.path }
 // Do NOT MODIFY returned slices!
 func (n *Node) Payload() *ledger.Payload { return n.payload }
 
-// LeftChild returns the the Node's left child.
+// LeftChild returns the the Node's left child, loading it if it has been paged out.
 // Only INTERIOR nodes have children.
 // Do NOT MODIFY returned Node!
-func (n *Node) LeftChild() *Node { return n.lChild }
+func (n *Node) LeftChild() (*Node, error) { return n.lChild.Resolve() }
 
-// RightChild returns the the Node's right child.
+// RightChild returns the the Node's right child, loading it if it has been paged out.
 // Only INTERIOR nodes have children.
 // Do NOT MODIFY returned Node!
-func (n *Node) RightChild() *Node { return n.rChild }
+func (n *Node) RightChild() (*Node, error) { return n.rChild.Resolve() }
+
+// LeftChildRef returns the the Node's left child without loading it, i.e. a stub if the
+// child has been paged out. Only use it to access the hash, height, max depth and
+// register count of the child, or to share the child with another node.
+// Do NOT MODIFY returned Node!
+func (n *Node) LeftChildRef() *Node { return n.lChild }
+
+// RightChildRef returns the the Node's right child without loading it, i.e. a stub if the
+// child has been paged out. Only use it to access the hash, height, max depth and
+// register count of the child, or to share the child with another node.
+// Do NOT MODIFY returned Node!
+func (n *Node) RightChildRef() *Node { return n.rChild }
+
+// IsStub returns true if and only if the Node is a stub for a node that has been paged out.
+func (n *Node) IsStub() bool {
+	return n.loader != nil
+}
+
+// Resolve returns the Node itself, or the paged out node it stands for if the Node is a stub.
+// It is safe to call on a nil Node.
+func (n *Node) Resolve() (*Node, error) {
+	if n == nil || n.loader == nil {
+		return n, nil
+	}
+	loaded, err := n.loader.Load(n.height, n.hashValue)
+	if err != nil {
+		return nil, fmt.Errorf("could not load paged out node (height: %d, hash: %x): %w", n.height, n.hashValue, err)
+	}
+	return loaded, nil
+}
 
 // IsLeaf returns true if and only if Node is a LEAF.
 func (n *Node) IsLeaf() bool {
@@ -225,12 +288,16 @@ func (n *Node) IsLeaf() bool {
 // FmtStr provides formatted string representation of the Node and sub tree
 func (n *Node) FmtStr(prefix string, subpath string) string {
 	right := ""
-	if n.rChild != nil {
-		right = fmt.Sprintf("\n%v", n.rChild.FmtStr(prefix+"\t", subpath+"1"))
+	if rChild, err := n.RightChild(); err != nil {
+		right = fmt.Sprintf("\n%v%v: [%s] %v", prefix+"\t", n.height-1, subpath+"1", err)
+	} else if rChild != nil {
+		right = fmt.Sprintf("\n%v", rChild.FmtStr(prefix+"\t", subpath+"1"))
 	}
 	left := ""
-	if n.lChild != nil {
-		left = fmt.Sprintf("\n%v", n.lChild.FmtStr(prefix+"\t", subpath+"0"))
+	if lChild, err := n.LeftChild(); err != nil {
+		left = fmt.Sprintf("\n%v%v: [%s] %v", prefix+"\t", n.height-1, subpath+"0", err)
+	} else if lChild != nil {
+		left = fmt.Sprintf("\n%v", lChild.FmtStr(prefix+"\t", subpath+"0"))
 	}
 	payloadSize := 0
 	if n.payload != nil {
@@ -242,20 +309,34 @@ func (n *Node) FmtStr(prefix string, subpath string) string {
 }
 
 // AllPayloads returns the payload of this node and all payloads of the subtrie
-func (n *Node) AllPayloads() []ledger.Payload {
+func (n *Node) AllPayloads() ([]ledger.Payload, error) {
 	payloads := make([]ledger.Payload, 0)
 	if n.IsLeaf() {
 		payloads = append(payloads, *n.Payload())
 	}
 
-	if lChild := n.LeftChild(); lChild != nil {
-		cp := lChild.AllPayloads()
+	lChild, err := n.LeftChild()
+	if err != nil {
+		return nil, err
+	}
+	if lChild != nil {
+		cp, err := lChild.AllPayloads()
+		if err != nil {
+			return nil, err
+		}
 		payloads = append(payloads, cp...)
 	}
 
-	if rChild := n.RightChild(); rChild != nil {
-		cp := rChild.AllPayloads()
+	rChild, err := n.RightChild()
+	if err != nil {
+		return nil, err
+	}
+	if rChild != nil {
+		cp, err := rChild.AllPayloads()
+		if err != nil {
+			return nil, err
+		}
 		payloads = append(payloads, cp...)
 	}
-	return payloads
+	return payloads, nil
 }
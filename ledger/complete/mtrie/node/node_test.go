@@ -2,6 +2,7 @@ package node_test
 
 import (
 	"encoding/hex"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -120,7 +121,9 @@ func Test_AllPayloads(t *testing.T) {
 	n3 := node.NewLeaf(path, payload, 0)
 	n4 := node.NewInterimNode(1, n1, n2)
 	n5 := node.NewInterimNode(1, n4, n3)
-	require.Equal(t, len(n5.AllPayloads()), 3)
+	payloads, err := n5.AllPayloads()
+	require.NoError(t, err)
+	require.Equal(t, len(payloads), 3)
 }
 
 func Test_VerifyCachedHash(t *testing.T) {
@@ -133,3 +136,24 @@ func Test_VerifyCachedHash(t *testing.T) {
 	n5 := node.NewInterimNode(1, n4, n3)
 	require.True(t, n5.VerifyCachedHash())
 }
+
+type failingLoader struct{}
+
+func (failingLoader) Load(int, []byte) (*node.Node, error) {
+	return nil, fmt.Errorf("node is missing from the store")
+}
+
+// Test_LoadError verifies that failing to load a paged out node is reported as an error
+func Test_LoadError(t *testing.T) {
+	path := utils.TwoBytesPath(1)
+	payload := utils.LightPayload(2, 3)
+	leaf := node.NewLeaf(path, payload, 0)
+	stub := node.NewStub(0, leaf.Hash(), leaf.MaxDepth(), leaf.RegCount(), failingLoader{})
+	n := node.NewNode(1, stub, nil, nil, nil, node.NewInterimNode(1, leaf, nil).Hash(), 1, 1)
+
+	_, err := n.LeftChild()
+	require.Error(t, err)
+	_, err = n.AllPayloads()
+	require.Error(t, err)
+	require.False(t, n.VerifyCachedHash())
+}
@@ -0,0 +1,473 @@
+package pager
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/module"
+)
+
+// Pager pages cold subtries of MTries out to a key-value store and loads them back on demand.
+//
+// The top of each trie, i.e. all nodes up to the resident depth (measured in edges from the root),
+// always stays in memory. Deeper nodes are written to the store and replaced by stubs, which are
+// loaded when a read, update or proof traverses them. Loaded nodes are kept in an LRU cache of
+// hot nodes; their own children are stubs again, so only the accessed path is held in memory.
+//
+// Nodes are stored by height and hash, which makes writing them idempotent and allows sharing
+// stored subtries between tries. The store only serves as an extension of memory: it is not
+// needed to restore tries after a restart, which is done using the write-ahead log and checkpoints.
+//
+// As tries share nodes, the pager counts the references to the resident nodes and to the stored
+// nodes of the tries it has paged out. A trie holds a reference to its root node, and each node
+// holds a reference to each of its children. Once a trie has been released, all nodes which are
+// no longer referenced are dropped, and stored nodes are deleted from the store. The reference
+// counts are kept in memory only, as the store is emptied on every restart.
+type Pager struct {
+	db            *badger.DB
+	cache         *lru.Cache
+	residentDepth int
+	metrics       module.LedgerMetrics
+
+	mu sync.Mutex
+	// nodeRefs counts the references to resident interim nodes
+	nodeRefs map[*node.Node]uint32
+	// keyRefs counts the references to stored nodes, by the key of the node
+	keyRefs map[string]uint32
+}
+
+// New creates a new pager storing paged out nodes in the given database and keeping up to
+// cacheSize loaded nodes in memory. Nodes deeper than residentDepth are paged out.
+func New(db *badger.DB, cacheSize int, residentDepth int, metrics module.LedgerMetrics) (*Pager, error) {
+	if residentDepth < 0 {
+		return nil, fmt.Errorf("resident depth must not be negative, got %d", residentDepth)
+	}
+	cache, err := lru.New(cacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create node cache: %w", err)
+	}
+	return &Pager{
+		db:            db,
+		cache:         cache,
+		residentDepth: residentDepth,
+		metrics:       metrics,
+		nodeRefs:      make(map[*node.Node]uint32),
+		keyRefs:       make(map[string]uint32),
+	}, nil
+}
+
+// pageOutBatch collects the nodes written and the references added while paging out a trie,
+// the references are only applied once the nodes have been written.
+type pageOutBatch struct {
+	batch    *badger.WriteBatch
+	count    uint64
+	nodeRefs map[*node.Node]uint32
+	keyRefs  map[string]uint32
+}
+
+// PageOut returns a trie equivalent to the given one, where all nodes below the resident depth have been
+// written to the store and replaced by stubs. Subtries shared with tries which have been paged out before
+// are left untouched, so that only the nodes created by an update need to be paged out.
+// The returned trie holds references to its nodes until it is released.
+func (p *Pager) PageOut(t *trie.MTrie) (*trie.MTrie, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	b := &pageOutBatch{
+		batch:    p.db.NewWriteBatch(),
+		nodeRefs: make(map[*node.Node]uint32),
+		keyRefs:  make(map[string]uint32),
+	}
+	root, err := p.pageOut(b, t.RootNode(), 0)
+	if err == nil {
+		err = p.retain(b, root)
+	}
+	if err != nil {
+		b.batch.Cancel()
+		return nil, err
+	}
+	err = b.batch.Flush()
+	if err != nil {
+		return nil, fmt.Errorf("cannot write paged out nodes: %w", err)
+	}
+
+	for n, refs := range b.nodeRefs {
+		p.nodeRefs[n] += refs
+	}
+	for key, refs := range b.keyRefs {
+		p.keyRefs[key] += refs
+	}
+
+	p.metrics.NodesPagedOut(b.count)
+
+	if root == t.RootNode() {
+		return t, nil
+	}
+	return trie.NewMTrie(root)
+}
+
+// pageOut returns the given node, with all subtries below the resident depth replaced by stubs.
+// Nodes which are already referenced belong to tries paged out before, their subtries have
+// already been paged out.
+func (p *Pager) pageOut(b *pageOutBatch, n *node.Node, depth int) (*node.Node, error) {
+	if n == nil || n.IsStub() || n.IsLeaf() || p.nodeRefs[n]+b.nodeRefs[n] > 0 {
+		return n, nil
+	}
+
+	var lChild, rChild *node.Node
+	var err error
+	if depth == p.residentDepth {
+		lChild, err = p.stub(b, n.LeftChildRef())
+		if err != nil {
+			return nil, err
+		}
+		rChild, err = p.stub(b, n.RightChildRef())
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		lChild, err = p.pageOut(b, n.LeftChildRef(), depth+1)
+		if err != nil {
+			return nil, err
+		}
+		err = p.retain(b, lChild)
+		if err != nil {
+			return nil, err
+		}
+		rChild, err = p.pageOut(b, n.RightChildRef(), depth+1)
+		if err != nil {
+			return nil, err
+		}
+		err = p.retain(b, rChild)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if lChild == n.LeftChildRef() && rChild == n.RightChildRef() {
+		return n, nil
+	}
+	return node.NewNode(n.Height(), lChild, rChild, nil, nil, n.Hash(), n.MaxDepth(), n.RegCount()), nil
+}
+
+// retain adds a reference to the given node of a paged out trie.
+func (p *Pager) retain(b *pageOutBatch, n *node.Node) error {
+	if n == nil || n.IsLeaf() {
+		return nil
+	}
+	if n.IsStub() {
+		return p.retainKey(b, nodeKey(n.Height(), n.Hash()))
+	}
+	b.nodeRefs[n]++
+	return nil
+}
+
+// retainKey adds a reference to the stored node with the given key.
+func (p *Pager) retainKey(b *pageOutBatch, key []byte) error {
+	if p.keyRefs[string(key)]+b.keyRefs[string(key)] == 0 {
+		return fmt.Errorf("paged out node %x has already been released", key)
+	}
+	b.keyRefs[string(key)]++
+	return nil
+}
+
+// stub writes the subtrie of the given node to the store and returns a stub for it,
+// which holds a reference to the stored node.
+func (p *Pager) stub(b *pageOutBatch, n *node.Node) (*node.Node, error) {
+	if n == nil {
+		return nil, nil
+	}
+	err := p.store(b, n)
+	if err != nil {
+		return nil, err
+	}
+	if n.IsStub() {
+		return n, nil
+	}
+	return node.NewStub(n.Height(), n.Hash(), n.MaxDepth(), n.RegCount(), p), nil
+}
+
+// store adds a reference to the stored node of the given node, writing its subtrie to the store
+// unless it has already been stored.
+func (p *Pager) store(b *pageOutBatch, n *node.Node) error {
+	if n == nil {
+		return nil
+	}
+	key := nodeKey(n.Height(), n.Hash())
+	if n.IsStub() || p.keyRefs[string(key)]+b.keyRefs[string(key)] > 0 {
+		return p.retainKey(b, key)
+	}
+	err := p.store(b, n.LeftChildRef())
+	if err != nil {
+		return err
+	}
+	err = p.store(b, n.RightChildRef())
+	if err != nil {
+		return err
+	}
+	err = b.batch.Set(key, encodeNode(n))
+	if err != nil {
+		return fmt.Errorf("cannot write node: %w", err)
+	}
+	b.keyRefs[string(key)]++
+	b.count++
+	return nil
+}
+
+// Release drops the references the given trie holds to its nodes, and deletes the stored nodes
+// which are no longer referenced by any trie. The trie must have been returned by PageOut, and
+// must not be used anymore.
+func (p *Pager) Release(t *trie.MTrie) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	batch := p.db.NewWriteBatch()
+	count := uint64(0)
+	err := p.release(batch, t.RootNode(), &count)
+	if err != nil {
+		batch.Cancel()
+		return err
+	}
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("cannot delete released nodes: %w", err)
+	}
+
+	p.metrics.NodesReleased(count)
+
+	return nil
+}
+
+// release drops a reference to the given node, and to its children once it is no longer referenced.
+func (p *Pager) release(batch *badger.WriteBatch, n *node.Node, count *uint64) error {
+	if n == nil || n.IsLeaf() {
+		return nil
+	}
+	if n.IsStub() {
+		return p.releaseKey(batch, n.Height(), n.Hash(), count)
+	}
+
+	refs, ok := p.nodeRefs[n]
+	if !ok {
+		return fmt.Errorf("released node (height: %d, hash: %x) is not referenced", n.Height(), n.Hash())
+	}
+	if refs > 1 {
+		p.nodeRefs[n] = refs - 1
+		return nil
+	}
+	delete(p.nodeRefs, n)
+
+	err := p.release(batch, n.LeftChildRef(), count)
+	if err != nil {
+		return err
+	}
+	return p.release(batch, n.RightChildRef(), count)
+}
+
+// releaseKey drops a reference to the stored node, and deletes it once it is no longer referenced.
+func (p *Pager) releaseKey(batch *badger.WriteBatch, height int, hashValue []byte, count *uint64) error {
+	key := nodeKey(height, hashValue)
+	refs, ok := p.keyRefs[string(key)]
+	if !ok {
+		return fmt.Errorf("released node (height: %d, hash: %x) is not stored", height, hashValue)
+	}
+	if refs > 1 {
+		p.keyRefs[string(key)] = refs - 1
+		return nil
+	}
+
+	// the children of the node are only known from the stored node
+	var n *node.Node
+	if cached, ok := p.cache.Peek(string(key)); ok {
+		n = cached.(*node.Node)
+		p.cache.Remove(string(key))
+	} else {
+		var err error
+		n, err = p.read(key, height, hashValue)
+		if err != nil {
+			return fmt.Errorf("cannot read released node (height: %d, hash: %x): %w", height, hashValue, err)
+		}
+	}
+	delete(p.keyRefs, string(key))
+	err := batch.Delete(key)
+	if err != nil {
+		return fmt.Errorf("cannot delete node: %w", err)
+	}
+	*count++
+
+	err = p.release(batch, n.LeftChildRef(), count)
+	if err != nil {
+		return err
+	}
+	return p.release(batch, n.RightChildRef(), count)
+}
+
+// Load implements node.Loader, it returns a paged out node, whose children are stubs.
+func (p *Pager) Load(height int, hashValue []byte) (*node.Node, error) {
+	key := nodeKey(height, hashValue)
+
+	if cached, ok := p.cache.Get(string(key)); ok {
+		p.metrics.NodeCacheHit()
+		return cached.(*node.Node), nil
+	}
+	p.metrics.NodeCacheMiss()
+
+	start := time.Now()
+	n, err := p.read(key, height, hashValue)
+	if err != nil {
+		return nil, err
+	}
+
+	p.metrics.NodeLoadDuration(time.Since(start))
+	p.cache.Add(string(key), n)
+
+	return n, nil
+}
+
+// read reads a paged out node from the store, bypassing the cache.
+func (p *Pager) read(key []byte, height int, hashValue []byte) (*node.Node, error) {
+	var data []byte
+	err := p.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		data, err = item.ValueCopy(nil)
+		return err
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return nil, fmt.Errorf("node is missing from the store")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read node: %w", err)
+	}
+
+	n, err := p.decodeNode(height, hashValue, data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode node: %w", err)
+	}
+	return n, nil
+}
+
+func nodeKey(height int, hashValue []byte) []byte {
+	key := utils.AppendUint16(make([]byte, 0, 2+len(hashValue)), uint16(height))
+	return append(key, hashValue...)
+}
+
+// encodeNode encodes a node, with its children encoded as references (hash, max depth and register count).
+// The height and hash of the node are part of its key.
+func encodeNode(n *node.Node) []byte {
+	buf := make([]byte, 0)
+	buf = utils.AppendUint16(buf, n.MaxDepth())
+	buf = utils.AppendUint64(buf, n.RegCount())
+	buf = utils.AppendShortData(buf, n.Path())
+	var encPayload []byte
+	if n.Payload() != nil {
+		encPayload = encoding.EncodePayload(n.Payload())
+	}
+	buf = utils.AppendLongData(buf, encPayload)
+	buf = encodeChildRef(buf, n.LeftChildRef())
+	buf = encodeChildRef(buf, n.RightChildRef())
+	return buf
+}
+
+func encodeChildRef(buf []byte, child *node.Node) []byte {
+	if child == nil {
+		return utils.AppendUint8(buf, 0)
+	}
+	buf = utils.AppendUint8(buf, 1)
+	buf = utils.AppendShortData(buf, child.Hash())
+	buf = utils.AppendUint16(buf, child.MaxDepth())
+	return utils.AppendUint64(buf, child.RegCount())
+}
+
+func (p *Pager) decodeNode(height int, hashValue []byte, data []byte) (*node.Node, error) {
+	maxDepth, rest, err := utils.ReadUint16(data)
+	if err != nil {
+		return nil, err
+	}
+	regCount, rest, err := utils.ReadUint64(rest)
+	if err != nil {
+		return nil, err
+	}
+	path, rest, err := readShortData(rest)
+	if err != nil {
+		return nil, err
+	}
+	encPayload, rest, err := readLongData(rest)
+	if err != nil {
+		return nil, err
+	}
+	lChild, rest, err := p.decodeChildRef(height-1, rest)
+	if err != nil {
+		return nil, err
+	}
+	rChild, rest, err := p.decodeChildRef(height-1, rest)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("unexpected %d trailing bytes", len(rest))
+	}
+
+	var payload *ledger.Payload
+	if len(encPayload) > 0 {
+		payload, err = encoding.DecodePayload(encPayload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return node.NewNode(height, lChild, rChild, ledger.Path(path), payload, hashValue, maxDepth, regCount), nil
+}
+
+func (p *Pager) decodeChildRef(height int, data []byte) (*node.Node, []byte, error) {
+	present, rest, err := utils.ReadUint8(data)
+	if err != nil {
+		return nil, rest, err
+	}
+	if present == 0 {
+		return nil, rest, nil
+	}
+	hashValue, rest, err := readShortData(rest)
+	if err != nil {
+		return nil, rest, err
+	}
+	maxDepth, rest, err := utils.ReadUint16(rest)
+	if err != nil {
+		return nil, rest, err
+	}
+	regCount, rest, err := utils.ReadUint64(rest)
+	if err != nil {
+		return nil, rest, err
+	}
+	return node.NewStub(height, hashValue, maxDepth, regCount, p), rest, nil
+}
+
+// readShortData reads data prefixed by its uint16 size and returns the rest of the input after the data
+func readShortData(input []byte) ([]byte, []byte, error) {
+	size, rest, err := utils.ReadUint16(input)
+	if err != nil {
+		return nil, rest, err
+	}
+	return utils.ReadSlice(rest, int(size))
+}
+
+// readLongData reads data prefixed by its uint32 size and returns the rest of the input after the data
+func readLongData(input []byte) ([]byte, []byte, error) {
+	size, rest, err := utils.ReadUint32(input)
+	if err != nil {
+		return nil, rest, err
+	}
+	return utils.ReadSlice(rest, int(size))
+}
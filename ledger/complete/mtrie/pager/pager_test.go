@@ -0,0 +1,236 @@
+package pager_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/pager"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const pathByteSize = 2
+
+// TestPagedForest applies the same updates to a forest holding all nodes in memory and to a forest
+// paging out nodes, and checks that both produce the same tries, values and proofs.
+func TestPagedForest(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+
+			memForest, err := mtrie.NewForest(pathByteSize, dir, 100, &metrics.NoopCollector{}, nil)
+			require.NoError(t, err)
+
+			// a tiny cache forces nodes to be loaded from the store
+			p, err := pager.New(db, 4, 2, &metrics.NoopCollector{})
+			require.NoError(t, err)
+			pagedForest, err := mtrie.NewForest(pathByteSize, dir, 100, &metrics.NoopCollector{}, nil, mtrie.WithPager(p))
+			require.NoError(t, err)
+
+			rootHash := memForest.GetEmptyRootHash()
+			var allPaths []ledger.Path
+			for i := 0; i < 10; i++ {
+				paths := utils.RandomPaths(50, pathByteSize)
+				payloads := utils.RandomPayloads(len(paths), 1, 20)
+				allPaths = append(allPaths, paths...)
+
+				update := &ledger.TrieUpdate{RootHash: rootHash, Paths: paths, Payloads: payloads}
+				memRoot, err := memForest.Update(update)
+				require.NoError(t, err)
+				pagedRoot, err := pagedForest.Update(update)
+				require.NoError(t, err)
+				require.Equal(t, memRoot, pagedRoot)
+
+				rootHash = memRoot
+			}
+
+			// the paged trie only holds the top of the trie in memory
+			pagedTrie, err := pagedForest.GetTrie(rootHash)
+			require.NoError(t, err)
+			assertPagedOut(t, pagedTrie.RootNode(), 0, 2)
+
+			read := &ledger.TrieRead{RootHash: rootHash, Paths: allPaths}
+			memPayloads, err := memForest.Read(read)
+			require.NoError(t, err)
+			pagedPayloads, err := pagedForest.Read(read)
+			require.NoError(t, err)
+			require.Equal(t, len(memPayloads), len(pagedPayloads))
+			for i := range memPayloads {
+				assert.True(t, memPayloads[i].Equals(pagedPayloads[i]))
+			}
+
+			memProofs, err := memForest.Proofs(read)
+			require.NoError(t, err)
+			pagedProofs, err := pagedForest.Proofs(read)
+			require.NoError(t, err)
+			assert.True(t, memProofs.Equals(pagedProofs))
+
+			memTrie, err := memForest.GetTrie(rootHash)
+			require.NoError(t, err)
+			assertSamePayloads(t, memTrie, pagedTrie)
+			assert.True(t, pagedTrie.IsAValidTrie())
+		})
+	})
+}
+
+// TestAddTrie checks that tries added to a forest (e.g. from a checkpoint) are paged out.
+func TestAddTrie(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+
+			memForest, err := mtrie.NewForest(pathByteSize, dir, 100, &metrics.NoopCollector{}, nil)
+			require.NoError(t, err)
+
+			paths := utils.RandomPaths(100, pathByteSize)
+			update := &ledger.TrieUpdate{RootHash: memForest.GetEmptyRootHash(), Paths: paths, Payloads: utils.RandomPayloads(len(paths), 1, 20)}
+			rootHash, err := memForest.Update(update)
+			require.NoError(t, err)
+			memTrie, err := memForest.GetTrie(rootHash)
+			require.NoError(t, err)
+
+			p, err := pager.New(db, 100, 1, &metrics.NoopCollector{})
+			require.NoError(t, err)
+			pagedForest, err := mtrie.NewForest(pathByteSize, dir, 100, &metrics.NoopCollector{}, nil, mtrie.WithPager(p))
+			require.NoError(t, err)
+
+			err = pagedForest.AddTrie(memTrie)
+			require.NoError(t, err)
+
+			pagedTrie, err := pagedForest.GetTrie(rootHash)
+			require.NoError(t, err)
+			assertPagedOut(t, pagedTrie.RootNode(), 0, 1)
+
+			// the original trie is left untouched
+			assertResident(t, memTrie.RootNode())
+
+			assertSamePayloads(t, memTrie, pagedTrie)
+		})
+	})
+}
+
+// TestReleaseTries checks that the nodes of tries evicted from a forest are deleted from the store,
+// unless they are shared with the tries left in the forest.
+func TestReleaseTries(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+
+			p, err := pager.New(db, 4, 1, &metrics.NoopCollector{})
+			require.NoError(t, err)
+			pagedForest, err := mtrie.NewForest(pathByteSize, dir, 5, &metrics.NoopCollector{}, nil, mtrie.WithPager(p))
+			require.NoError(t, err)
+
+			// update the same registers over and over, so that evicted tries leave stale nodes behind
+			paths := utils.RandomPaths(50, pathByteSize)
+			rootHash := pagedForest.GetEmptyRootHash()
+			for i := 0; i < 20; i++ {
+				update := &ledger.TrieUpdate{RootHash: rootHash, Paths: paths[:10+rand.Intn(40)], Payloads: utils.RandomPayloads(50, 1, 20)}
+				update.Payloads = update.Payloads[:len(update.Paths)]
+				rootHash, err = pagedForest.Update(update)
+				require.NoError(t, err)
+
+				// the store holds exactly the paged out nodes of the tries in the forest
+				tries, err := pagedForest.GetTries()
+				require.NoError(t, err)
+				require.Equal(t, countPagedOut(t, tries, 1), countStored(t, db))
+			}
+
+			// the tries left in the forest are still complete
+			latest, err := pagedForest.GetTrie(rootHash)
+			require.NoError(t, err)
+			assert.True(t, latest.IsAValidTrie())
+
+			// removing all tries deletes all nodes
+			tries, err := pagedForest.GetTries()
+			require.NoError(t, err)
+			for _, trie := range tries {
+				err = pagedForest.RemoveTrie(trie.RootHash())
+				require.NoError(t, err)
+			}
+			assert.Equal(t, 0, countStored(t, db))
+		})
+	})
+}
+
+// countPagedOut returns the number of distinct nodes below the resident depth of the given tries.
+func countPagedOut(t *testing.T, tries []*trie.MTrie, residentDepth int) int {
+	seen := make(map[string]struct{})
+	var visit func(n *node.Node, depth int)
+	visit = func(n *node.Node, depth int) {
+		if n == nil {
+			return
+		}
+		if depth > residentDepth {
+			seen[fmt.Sprintf("%d/%x", n.Height(), n.Hash())] = struct{}{}
+		}
+		lChild, err := n.LeftChild()
+		require.NoError(t, err)
+		visit(lChild, depth+1)
+		rChild, err := n.RightChild()
+		require.NoError(t, err)
+		visit(rChild, depth+1)
+	}
+	for _, trie := range tries {
+		visit(trie.RootNode(), 0)
+	}
+	return len(seen)
+}
+
+// countStored returns the number of nodes in the store.
+func countStored(t *testing.T, db *badger.DB) int {
+	count := 0
+	err := db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			count++
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return count
+}
+
+func assertSamePayloads(t *testing.T, expected *trie.MTrie, actual *trie.MTrie) {
+	expectedPayloads, err := expected.AllPayloads()
+	require.NoError(t, err)
+	actualPayloads, err := actual.AllPayloads()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, expectedPayloads, actualPayloads)
+}
+
+// assertPagedOut checks that all nodes up to the resident depth are in memory and that deeper nodes are stubs.
+func assertPagedOut(t *testing.T, n *node.Node, depth int, residentDepth int) {
+	if n == nil {
+		return
+	}
+	if depth > residentDepth {
+		assert.True(t, n.IsStub(), "node at depth %d should be paged out", depth)
+		return
+	}
+	assert.False(t, n.IsStub(), "node at depth %d should be resident", depth)
+	assertPagedOut(t, n.LeftChildRef(), depth+1, residentDepth)
+	assertPagedOut(t, n.RightChildRef(), depth+1, residentDepth)
+}
+
+func assertResident(t *testing.T, n *node.Node) {
+	if n == nil {
+		return
+	}
+	assert.False(t, n.IsStub())
+	assertResident(t, n.LeftChildRef())
+	assertResident(t, n.RightChildRef())
+}
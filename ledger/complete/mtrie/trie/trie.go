@@ -138,7 +138,11 @@ func (mt *MTrie) read(head *node.Node, paths []ledger.Path) ([]*ledger.Payload,
 	// TODO make this parallel
 	payloads := make([]*ledger.Payload, 0)
 	if len(lpaths) > 0 {
-		p, err := mt.read(head.LeftChild(), lpaths)
+		child, err := head.LeftChild()
+		if err != nil {
+			return nil, err
+		}
+		p, err := mt.read(child, lpaths)
 		if err != nil {
 			return nil, err
 		}
@@ -146,7 +150,11 @@ func (mt *MTrie) read(head *node.Node, paths []ledger.Path) ([]*ledger.Payload,
 	}
 
 	if len(rpaths) > 0 {
-		p, err := mt.read(head.RightChild(), rpaths)
+		child, err := head.RightChild()
+		if err != nil {
+			return nil, err
+		}
+		p, err := mt.read(child, rpaths)
 		if err != nil {
 			return nil, err
 		}
@@ -193,6 +201,11 @@ func update(treeHeight int, nodeHeight int, parentNode *node.Node, paths []ledge
 	}
 
 	// from here on, we have parentNode != nil AND len(paths) > 0
+	// (the parent node is only loaded if it has been paged out now that we know it is modified)
+	parentNode, err := parentNode.Resolve()
+	if err != nil {
+		return nil, err
+	}
 	if parentNode.IsLeaf() { // parent node is a leaf, i.e. parent Trie only stores a single value in this sub-trie
 		parentPath := parentNode.Path() // Per definition, a leaf must have a non-nil path
 		overrideExistingValue := false  // true if and only if we are updating the parent Trie's leaf node value
@@ -223,9 +236,9 @@ func update(treeHeight int, nodeHeight int, parentNode *node.Node, paths []ledge
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		lChild, lErr = update(treeHeight, nodeHeight-1, parentNode.LeftChild(), lpaths, lpayloads)
+		lChild, lErr = update(treeHeight, nodeHeight-1, parentNode.LeftChildRef(), lpaths, lpayloads)
 	}()
-	rChild, rErr = update(treeHeight, nodeHeight-1, parentNode.RightChild(), rpaths, rpayloads)
+	rChild, rErr = update(treeHeight, nodeHeight-1, parentNode.RightChildRef(), rpaths, rpayloads)
 	wg.Wait()
 	if lErr != nil || rErr != nil {
 		var merr *multierror.Error
@@ -335,7 +348,7 @@ func (mt *MTrie) proofs(head *node.Node, paths []ledger.Path, proofs []*ledger.T
 	}
 
 	if len(lpaths) > 0 {
-		if rChild := head.RightChild(); rChild != nil {
+		if rChild := head.RightChildRef(); rChild != nil {
 			nodeHash := rChild.Hash()
 			isDef := bytes.Equal(nodeHash, common.GetDefaultHashForHeight(rChild.Height()))
 			if !isDef { // in proofs, we only provide non-default value hashes
@@ -348,14 +361,18 @@ func (mt *MTrie) proofs(head *node.Node, paths []ledger.Path, proofs []*ledger.T
 				}
 			}
 		}
-		err := mt.proofs(head.LeftChild(), lpaths, lproofs)
+		child, err := head.LeftChild()
+		if err != nil {
+			return err
+		}
+		err = mt.proofs(child, lpaths, lproofs)
 		if err != nil {
 			return err
 		}
 	}
 
 	if len(rpaths) > 0 {
-		if lChild := head.LeftChild(); lChild != nil {
+		if lChild := head.LeftChildRef(); lChild != nil {
 			nodeHash := lChild.Hash()
 			isDef := bytes.Equal(nodeHash, common.GetDefaultHashForHeight(lChild.Height()))
 			if !isDef { // in proofs, we only provide non-default value hashes
@@ -368,7 +385,11 @@ func (mt *MTrie) proofs(head *node.Node, paths []ledger.Path, proofs []*ledger.T
 				}
 			}
 		}
-		err := mt.proofs(head.RightChild(), rpaths, rproofs)
+		child, err := head.RightChild()
+		if err != nil {
+			return err
+		}
+		err = mt.proofs(child, rpaths, rproofs)
 		if err != nil {
 			return err
 		}
@@ -408,14 +429,22 @@ func (mt *MTrie) dumpAsJSON(n *node.Node, encoder *json.Encoder) error {
 		}
 	}
 
-	if lChild := n.LeftChild(); lChild != nil {
+	lChild, err := n.LeftChild()
+	if err != nil {
+		return err
+	}
+	if lChild != nil {
 		err := mt.dumpAsJSON(lChild, encoder)
 		if err != nil {
 			return err
 		}
 	}
 
-	if rChild := n.RightChild(); rChild != nil {
+	rChild, err := n.RightChild()
+	if err != nil {
+		return err
+	}
+	if rChild != nil {
 		err := mt.dumpAsJSON(rChild, encoder)
 		if err != nil {
 			return err
@@ -430,7 +459,7 @@ func EmptyTrieRootHash(pathByteSize int) []byte {
 }
 
 // AllPayloads returns all payloads
-func (mt *MTrie) AllPayloads() []ledger.Payload {
+func (mt *MTrie) AllPayloads() ([]ledger.Payload, error) {
 	return mt.root.AllPayloads()
 }
 
@@ -0,0 +1,355 @@
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+// Checkpoints of version 3 are written and read as a stream of sections, so that neither producing
+// nor restoring a checkpoint requires to hold all of its storable nodes in memory:
+//
+//   header:  magic bytes (2 bytes) | version (2 bytes) | checksum (4 bytes)
+//   nodes:   any number of node sections, each of them being
+//            node count (4 bytes) | storable nodes | checksum (4 bytes)
+//            and a final node section with a node count of 0, marking the end of nodes
+//   tries:   trie count (2 bytes) | storable tries | checksum (4 bytes)
+//
+// Checksums are CRC32 (Castagnoli) of all bytes of their section preceding the checksum.
+// Nodes are listed in an order which satisfies the Descendents-First-Relationship and
+// are indexed from 1 (0 meaning nil) across all node sections.
+
+// nodesPerSection is the maximum number of nodes written in a single node section,
+// it bounds the amount of memory needed to write a checkpoint.
+const nodesPerSection = 10000
+
+var crc32Table = crc32.MakeTable(crc32.Castagnoli)
+
+// WriteCheckpoint writes a checkpoint of the given tries, flattening them node by node.
+func WriteCheckpoint(tries []*trie.MTrie, writer io.Writer) error {
+	cw, err := newCheckpointWriter(writer)
+	if err != nil {
+		return err
+	}
+	storableTries, err := flattener.StreamTries(tries, cw.writeNode)
+	if err != nil {
+		return fmt.Errorf("cannot write nodes: %w", err)
+	}
+	return cw.finish(storableTries)
+}
+
+// checkpointWriter writes checkpoints of the latest version.
+type checkpointWriter struct {
+	writer io.Writer
+	nodes  []byte // encoded nodes of the current node section
+	count  uint32 // number of nodes in the current node section
+}
+
+func newCheckpointWriter(writer io.Writer) (*checkpointWriter, error) {
+	header := make([]byte, 4)
+	pos := writeUint16(header, 0, MagicBytes)
+	writeUint16(header, pos, VersionV3)
+	err := writeSection(writer, header)
+	if err != nil {
+		return nil, fmt.Errorf("cannot write checkpoint header: %w", err)
+	}
+	return &checkpointWriter{
+		writer: writer,
+		nodes:  make([]byte, 4), // room for the node count
+	}, nil
+}
+
+func (w *checkpointWriter) writeNode(storableNode *flattener.StorableNode) error {
+	w.nodes = append(w.nodes, flattener.EncodeStorableNode(storableNode)...)
+	w.count++
+	if w.count < nodesPerSection {
+		return nil
+	}
+	return w.flushNodes()
+}
+
+// flushNodes writes the current node section.
+func (w *checkpointWriter) flushNodes() error {
+	binary.BigEndian.PutUint32(w.nodes, w.count)
+	err := writeSection(w.writer, w.nodes)
+	if err != nil {
+		return fmt.Errorf("error while writing node data: %w", err)
+	}
+	w.nodes = w.nodes[:4]
+	w.count = 0
+	return nil
+}
+
+// finish writes the remaining nodes, the end of nodes and the tries.
+func (w *checkpointWriter) finish(storableTries []*flattener.StorableTrie) error {
+	if w.count > 0 {
+		err := w.flushNodes()
+		if err != nil {
+			return err
+		}
+	}
+	// empty node section marks the end of nodes
+	err := w.flushNodes()
+	if err != nil {
+		return err
+	}
+
+	if len(storableTries) > int(^uint16(0)) {
+		return fmt.Errorf("too many tries to checkpoint: %d", len(storableTries))
+	}
+	tries := make([]byte, 2)
+	writeUint16(tries, 0, uint16(len(storableTries)))
+	for _, storableTrie := range storableTries {
+		tries = append(tries, flattener.EncodeStorableTrie(storableTrie)...)
+	}
+	err = writeSection(w.writer, tries)
+	if err != nil {
+		return fmt.Errorf("error while writing trie data: %w", err)
+	}
+	return nil
+}
+
+// writeSection writes the given data followed by its checksum.
+func writeSection(writer io.Writer, data []byte) error {
+	_, err := writer.Write(data)
+	if err != nil {
+		return err
+	}
+	checksum := make([]byte, 4)
+	binary.BigEndian.PutUint32(checksum, crc32.Checksum(data, crc32Table))
+	_, err = writer.Write(checksum)
+	return err
+}
+
+// ReadCheckpoint reads a checkpoint of any version node by node, handing every storable node
+// and then every storable trie to the given functions in the order they are stored.
+// For checkpoints with checksums, an error is returned as soon as a section does not match its
+// checksum, in which case the elements of this section have already been handed over.
+func ReadCheckpoint(reader io.Reader,
+	nodeFn func(*flattener.StorableNode) error,
+	trieFn func(*flattener.StorableTrie) error) error {
+
+	sr := newSectionReader(reader)
+
+	header := make([]byte, 4)
+	_, err := io.ReadFull(sr, header)
+	if err != nil {
+		return fmt.Errorf("cannot read header bytes: %w", err)
+	}
+	magicBytes, pos := readUint16(header, 0)
+	version, _ := readUint16(header, pos)
+
+	if magicBytes != MagicBytes {
+		return fmt.Errorf("unknown file format. Magic constant %x does not match expected %x", magicBytes, MagicBytes)
+	}
+
+	switch version {
+	case VersionV1, VersionV2:
+		return readCheckpointV1(reader, nodeFn, trieFn)
+	case VersionV3:
+		err = sr.verify()
+		if err != nil {
+			return fmt.Errorf("corrupted header: %w", err)
+		}
+		return readCheckpointV3(sr, nodeFn, trieFn)
+	default:
+		return fmt.Errorf("unsupported file version %x ", version)
+	}
+}
+
+// readCheckpointV1 reads the rest of a checkpoint of version 1 or 2, after magic bytes and version.
+func readCheckpointV1(reader io.Reader,
+	nodeFn func(*flattener.StorableNode) error,
+	trieFn func(*flattener.StorableTrie) error) error {
+
+	header := make([]byte, 8+2)
+	_, err := io.ReadFull(reader, header)
+	if err != nil {
+		return fmt.Errorf("cannot read header bytes: %w", err)
+	}
+	nodesCount, pos := readUint64(header, 0)
+	triesCount, _ := readUint16(header, pos)
+
+	for i := uint64(1); i <= nodesCount; i++ {
+		storableNode, err := flattener.ReadStorableNode(reader)
+		if err != nil {
+			return fmt.Errorf("cannot read storable node %d: %w", i, err)
+		}
+		err = nodeFn(storableNode)
+		if err != nil {
+			return fmt.Errorf("cannot handle storable node %d: %w", i, err)
+		}
+	}
+
+	for i := uint16(0); i < triesCount; i++ {
+		storableTrie, err := flattener.ReadStorableTrie(reader)
+		if err != nil {
+			return fmt.Errorf("cannot read storable trie %d: %w", i, err)
+		}
+		err = trieFn(storableTrie)
+		if err != nil {
+			return fmt.Errorf("cannot handle storable trie %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// readCheckpointV3 reads the sections of a checkpoint of version 3 following the header.
+func readCheckpointV3(sr *sectionReader,
+	nodeFn func(*flattener.StorableNode) error,
+	trieFn func(*flattener.StorableTrie) error) error {
+
+	buf := make([]byte, 4)
+	index := uint64(1)
+	for {
+		_, err := io.ReadFull(sr, buf)
+		if err != nil {
+			return fmt.Errorf("cannot read node section %d: %w", index, err)
+		}
+		count := binary.BigEndian.Uint32(buf)
+		for i := uint32(0); i < count; i++ {
+			storableNode, err := flattener.ReadStorableNode(sr)
+			if err != nil {
+				return fmt.Errorf("cannot read storable node %d: %w", index, err)
+			}
+			err = nodeFn(storableNode)
+			if err != nil {
+				return fmt.Errorf("cannot handle storable node %d: %w", index, err)
+			}
+			index++
+		}
+		err = sr.verify()
+		if err != nil {
+			return fmt.Errorf("corrupted node section before node %d: %w", index, err)
+		}
+		if count == 0 {
+			break
+		}
+	}
+
+	_, err := io.ReadFull(sr, buf[:2])
+	if err != nil {
+		return fmt.Errorf("cannot read trie section: %w", err)
+	}
+	triesCount, _ := readUint16(buf, 0)
+	storableTries := make([]*flattener.StorableTrie, 0, triesCount)
+	for i := uint16(0); i < triesCount; i++ {
+		storableTrie, err := flattener.ReadStorableTrie(sr)
+		if err != nil {
+			return fmt.Errorf("cannot read storable trie %d: %w", i, err)
+		}
+		storableTries = append(storableTries, storableTrie)
+	}
+	// tries are only handed over after verification, as they are few and rebuilding them is the final step
+	err = sr.verify()
+	if err != nil {
+		return fmt.Errorf("corrupted trie section: %w", err)
+	}
+	for i, storableTrie := range storableTries {
+		err = trieFn(storableTrie)
+		if err != nil {
+			return fmt.Errorf("cannot handle storable trie %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// sectionReader computes the checksum of all data read through it, until the checksum is verified.
+type sectionReader struct {
+	source io.Reader
+	tee    io.Reader
+	crc    hash.Hash32
+}
+
+func newSectionReader(source io.Reader) *sectionReader {
+	crc := crc32.New(crc32Table)
+	return &sectionReader{
+		source: source,
+		tee:    io.TeeReader(source, crc),
+		crc:    crc,
+	}
+}
+
+func (r *sectionReader) Read(p []byte) (int, error) {
+	return r.tee.Read(p)
+}
+
+// verify reads the checksum of the current section and compares it to the checksum of the data
+// read since the last verification.
+func (r *sectionReader) verify() error {
+	buf := make([]byte, 4)
+	_, err := io.ReadFull(r.source, buf)
+	if err != nil {
+		return fmt.Errorf("cannot read checksum: %w", err)
+	}
+	expected := binary.BigEndian.Uint32(buf)
+	actual := r.crc.Sum32()
+	r.crc.Reset()
+	if expected != actual {
+		return fmt.Errorf("checksum mismatch: expected %x, computed %x", expected, actual)
+	}
+	return nil
+}
+
+// LoadCheckpointTries rebuilds the tries of a checkpoint while reading it, without holding
+// its storable nodes in memory.
+func LoadCheckpointTries(filepath string) ([]*trie.MTrie, error) {
+	return loadCheckpointTries(filepath, false)
+}
+
+// VerifyCheckpoint reads a checkpoint, validating its structure and checksums (if any), and rebuilds
+// its tries while recomputing every node hash, so that a corrupted node is detected even if the
+// checkpoint format has no checksums. Data following the tries is rejected as well. It returns the verified tries.
+func VerifyCheckpoint(filepath string) ([]*trie.MTrie, error) {
+	return loadCheckpointTries(filepath, true)
+}
+
+func loadCheckpointTries(filepath string, verify bool) ([]*trie.MTrie, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open checkpoint file %s: %w", filepath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	rebuilder := flattener.NewRebuilder()
+	if verify {
+		rebuilder = flattener.NewVerifyingRebuilder()
+	}
+
+	var tries []*trie.MTrie
+	reader := bufio.NewReader(file)
+
+	err = ReadCheckpoint(reader,
+		rebuilder.AddNode,
+		func(storableTrie *flattener.StorableTrie) error {
+			t, err := rebuilder.Trie(storableTrie)
+			if err != nil {
+				return err
+			}
+			tries = append(tries, t)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("cannot read checkpoint file %s: %w", filepath, err)
+	}
+
+	if verify {
+		_, err = reader.Peek(1)
+		if err != io.EOF {
+			return nil, fmt.Errorf("unexpected data after the tries of checkpoint file %s", filepath)
+		}
+	}
+
+	return tries, nil
+}
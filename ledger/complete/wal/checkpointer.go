@@ -21,10 +21,15 @@ import (
 
 const checkpointFilenamePrefix = "checkpoint."
 
+const discardedCheckpointSuffix = ".discarded"
+
 const MagicBytes uint16 = 0x2137
 const VersionV1 uint16 = 0x01
 const VersionV2 uint16 = 0x02
 
+// VersionV3 is the streaming checkpoint format, see WriteCheckpoint
+const VersionV3 uint16 = 0x03
+
 const RootCheckpointFilename = "root.checkpoint"
 
 type Checkpointer struct {
@@ -67,6 +72,28 @@ func (c *Checkpointer) LatestCheckpoint() (int, error) {
 	return last, nil
 }
 
+// Checkpoints returns the numbers of all checkpoints, in increasing order
+func (c *Checkpointer) Checkpoints() ([]int, error) {
+
+	files, err := fileutil.ReadDir(c.dir)
+	if err != nil {
+		return nil, err
+	}
+	checkpoints := make([]int, 0)
+	for _, fn := range files {
+		if !strings.HasPrefix(fn, checkpointFilenamePrefix) {
+			continue
+		}
+		k, err := strconv.Atoi(fn[len(checkpointFilenamePrefix):])
+		if err != nil {
+			continue
+		}
+		checkpoints = append(checkpoints, k)
+	}
+
+	return checkpoints, nil
+}
+
 // NotCheckpointedSegments - returns numbers of segments which are not checkpointed yet,
 // or -1, -1 if there are no segments
 func (c *Checkpointer) NotCheckpointedSegments() (from, to int, err error) {
@@ -133,11 +160,7 @@ func (c *Checkpointer) Checkpoint(to int, targetWriter func() (io.WriteCloser, e
 	}
 
 	err = c.wal.replay(0, to,
-		func(forestSequencing *flattener.FlattenedForest) error {
-			tries, err := flattener.RebuildTries(forestSequencing)
-			if err != nil {
-				return err
-			}
+		func(tries []*trie.MTrie) error {
 			for _, t := range tries {
 				err := forest.AddTrie(t)
 				if err != nil {
@@ -157,9 +180,9 @@ func (c *Checkpointer) Checkpoint(to int, targetWriter func() (io.WriteCloser, e
 		return fmt.Errorf("cannot replay WAL: %w", err)
 	}
 
-	forestSequencing, err := flattener.FlattenForest(forest)
+	tries, err := forest.GetTries()
 	if err != nil {
-		return fmt.Errorf("cannot get storables: %w", err)
+		return fmt.Errorf("cannot get tries: %w", err)
 	}
 
 	writer, err := targetWriter()
@@ -168,11 +191,64 @@ func (c *Checkpointer) Checkpoint(to int, targetWriter func() (io.WriteCloser, e
 	}
 	defer writer.Close()
 
-	err = StoreCheckpoint(forestSequencing, writer)
+	err = WriteCheckpoint(tries, writer)
 
 	return err
 }
 
+// VerifySegments replays the segments following the given checkpoint on top of the tries restored from it.
+// It checks that the segments directly follow the checkpoint and that every update applies to a known trie,
+// and returns the number of the last segment replayed (or the checkpoint number if there was nothing to replay).
+func (c *Checkpointer) VerifySegments(checkpoint int, tries []*trie.MTrie) (int, error) {
+
+	first, last, err := c.wal.wal.Segments()
+	if err != nil {
+		return -1, fmt.Errorf("cannot get range of segments: %w", err)
+	}
+
+	if last < checkpoint {
+		return -1, fmt.Errorf("checkpoint %d is ahead of the last segment %d", checkpoint, last)
+	}
+	if last == checkpoint {
+		return checkpoint, nil
+	}
+	if first > checkpoint+1 {
+		return -1, fmt.Errorf("gap between checkpoint %d and first segment %d", checkpoint, first)
+	}
+
+	forest, err := mtrie.NewForest(c.keyByteSize, c.dir, c.forestCapacity, &metrics.NoopCollector{}, nil)
+	if err != nil {
+		return -1, fmt.Errorf("cannot create Forest: %w", err)
+	}
+	err = forest.AddTries(tries)
+	if err != nil {
+		return -1, fmt.Errorf("cannot add checkpointed tries: %w", err)
+	}
+
+	err = c.wal.replay(checkpoint+1, last,
+		func(tries []*trie.MTrie) error {
+			return forest.AddTries(tries)
+		},
+		func(update *ledger.TrieUpdate) error {
+			_, err := forest.Update(update)
+			return err
+		},
+		func(rootHash ledger.RootHash) error {
+			return forest.RemoveTrie(rootHash)
+		}, false)
+	if err != nil {
+		return -1, fmt.Errorf("cannot replay segments %d to %d: %w", checkpoint+1, last, err)
+	}
+
+	return last, nil
+}
+
+// DiscardCheckpoint renames a checkpoint, so that it is ignored by the checkpointer while being kept for inspection.
+func (c *Checkpointer) DiscardCheckpoint(checkpoint int) error {
+	filepath := path.Join(c.dir, NumberToFilename(checkpoint))
+	return os.Rename(filepath, filepath+discardedCheckpointSuffix)
+}
+
 func NumberToFilenamePart(n int) string {
 	return fmt.Sprintf("%08d", n)
 }
@@ -233,39 +309,23 @@ func CreateCheckpointWriterForFile(filename string) (io.WriteCloser, error) {
 	}, nil
 }
 
+// StoreCheckpoint writes a checkpoint of an already flattened forest.
+// WriteCheckpoint should be preferred, as it does not require all storable nodes to be held in memory.
 func StoreCheckpoint(forestSequencing *flattener.FlattenedForest, writer io.WriteCloser) error {
-	storableNodes := forestSequencing.Nodes
-	storableTries := forestSequencing.Tries
-	header := make([]byte, 4+8+2)
-
-	pos := writeUint16(header, 0, MagicBytes)
-	pos = writeUint16(header, pos, VersionV1)
-	pos = writeUint64(header, pos, uint64(len(storableNodes)-1)) // -1 to account for 0 node meaning nil
-	writeUint16(header, pos, uint16(len(storableTries)))
-
-	_, err := writer.Write(header)
+	cw, err := newCheckpointWriter(writer)
 	if err != nil {
-		return fmt.Errorf("cannot write checkpoint header: %w", err)
+		return err
 	}
 
 	// 0 element = nil, we don't need to store it
-	for i := 1; i < len(storableNodes); i++ {
-		bytes := flattener.EncodeStorableNode(storableNodes[i])
-		_, err = writer.Write(bytes)
-		if err != nil {
-			return fmt.Errorf("error while writing node date: %w", err)
-		}
-	}
-
-	for _, storableTrie := range storableTries {
-		bytes := flattener.EncodeStorableTrie(storableTrie)
-		_, err = writer.Write(bytes)
+	for i := 1; i < len(forestSequencing.Nodes); i++ {
+		err = cw.writeNode(forestSequencing.Nodes[i])
 		if err != nil {
-			return fmt.Errorf("error while writing trie date: %w", err)
+			return err
 		}
 	}
 
-	return nil
+	return cw.finish(forestSequencing.Tries)
 }
 
 func (c *Checkpointer) LoadCheckpoint(checkpoint int) (*flattener.FlattenedForest, error) {
@@ -278,6 +338,21 @@ func (c *Checkpointer) LoadRootCheckpoint() (*flattener.FlattenedForest, error)
 	return LoadCheckpoint(filepath)
 }
 
+func (c *Checkpointer) LoadCheckpointTries(checkpoint int) ([]*trie.MTrie, error) {
+	filepath := path.Join(c.dir, NumberToFilename(checkpoint))
+	return LoadCheckpointTries(filepath)
+}
+
+func (c *Checkpointer) LoadRootCheckpointTries() ([]*trie.MTrie, error) {
+	filepath := path.Join(c.dir, RootCheckpointFilename)
+	return LoadCheckpointTries(filepath)
+}
+
+func (c *Checkpointer) VerifyCheckpoint(checkpoint int) ([]*trie.MTrie, error) {
+	filepath := path.Join(c.dir, NumberToFilename(checkpoint))
+	return VerifyCheckpoint(filepath)
+}
+
 func (c *Checkpointer) HasRootCheckpoint() (bool, error) {
 	if _, err := os.Stat(path.Join(c.dir, RootCheckpointFilename)); err == nil {
 		return true, nil
@@ -288,6 +363,8 @@ func (c *Checkpointer) HasRootCheckpoint() (bool, error) {
 	}
 }
 
+// LoadCheckpoint reads all storable nodes and tries of a checkpoint.
+// LoadCheckpointTries should be preferred to restore tries, as it does not hold storable nodes in memory.
 func LoadCheckpoint(filepath string) (*flattener.FlattenedForest, error) {
 	file, err := os.Open(filepath)
 	if err != nil {
@@ -297,52 +374,26 @@ func LoadCheckpoint(filepath string) (*flattener.FlattenedForest, error) {
 		_ = file.Close()
 	}()
 
-	reader := bufio.NewReader(file)
-
-	header := make([]byte, 4+8+2)
+	nodes := []*flattener.StorableNode{nil} // 0 index meaning nil
+	tries := make([]*flattener.StorableTrie, 0)
 
-	_, err = io.ReadFull(reader, header)
+	err = ReadCheckpoint(bufio.NewReader(file),
+		func(storableNode *flattener.StorableNode) error {
+			nodes = append(nodes, storableNode)
+			return nil
+		},
+		func(storableTrie *flattener.StorableTrie) error {
+			tries = append(tries, storableTrie)
+			return nil
+		})
 	if err != nil {
-		return nil, fmt.Errorf("cannot read header bytes: %w", err)
-	}
-
-	magicBytes, pos := readUint16(header, 0)
-	version, pos := readUint16(header, pos)
-	nodesCount, pos := readUint64(header, pos)
-	triesCount, _ := readUint16(header, pos)
-
-	if magicBytes != MagicBytes {
-		return nil, fmt.Errorf("unknown file format. Magic constant %x does not match expected %x", magicBytes, MagicBytes)
-	}
-	if version != VersionV1 && version != VersionV2 {
-		return nil, fmt.Errorf("unsupported file version %x ", version)
-	}
-
-	nodes := make([]*flattener.StorableNode, nodesCount+1) //+1 for 0 index meaning nil
-	tries := make([]*flattener.StorableTrie, triesCount)
-
-	for i := uint64(1); i <= nodesCount; i++ {
-		storableNode, err := flattener.ReadStorableNode(reader)
-		if err != nil {
-			return nil, fmt.Errorf("cannot read storable node %d: %w", i, err)
-		}
-		nodes[i] = storableNode
-	}
-
-	// TODO version ?
-	for i := uint16(0); i < triesCount; i++ {
-		storableTrie, err := flattener.ReadStorableTrie(reader)
-		if err != nil {
-			return nil, fmt.Errorf("cannot read storable trie %d: %w", i, err)
-		}
-		tries[i] = storableTrie
+		return nil, err
 	}
 
 	return &flattener.FlattenedForest{
 		Nodes: nodes,
 		Tries: tries,
 	}, nil
-
 }
 
 func writeUint16(buffer []byte, location int, value uint16) int {
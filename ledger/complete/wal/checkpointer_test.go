@@ -3,6 +3,8 @@ package wal_test
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
 	"path"
 	"testing"
 	"time"
@@ -201,7 +203,7 @@ func Test_Checkpointing(t *testing.T) {
 			require.NoError(t, err)
 
 			err = wal2.Replay(
-				func(forestSequencing *flattener.FlattenedForest) error {
+				func(tries []*trie.MTrie) error {
 					return fmt.Errorf("I should fail as there should be no checkpoints")
 				},
 				func(update *ledger.TrieUpdate) error {
@@ -236,8 +238,8 @@ func Test_Checkpointing(t *testing.T) {
 			require.NoError(t, err)
 
 			err = wal3.Replay(
-				func(forestSequencing *flattener.FlattenedForest) error {
-					return loadIntoForest(f3, forestSequencing)
+				func(tries []*trie.MTrie) error {
+					return loadIntoForest(f3, tries)
 				},
 				func(update *ledger.TrieUpdate) error {
 					return fmt.Errorf("I should fail as there should be no updates")
@@ -324,8 +326,8 @@ func Test_Checkpointing(t *testing.T) {
 			updatesLeft := 1 // there should be only one update
 
 			err = wal5.Replay(
-				func(forestSequencing *flattener.FlattenedForest) error {
-					return loadIntoForest(f5, forestSequencing)
+				func(tries []*trie.MTrie) error {
+					return loadIntoForest(f5, tries)
 				},
 				func(update *ledger.TrieUpdate) error {
 					if updatesLeft == 0 {
@@ -366,11 +368,7 @@ func Test_Checkpointing(t *testing.T) {
 	})
 }
 
-func loadIntoForest(forest *mtrie.Forest, forestSequencing *flattener.FlattenedForest) error {
-	tries, err := flattener.RebuildTries(forestSequencing)
-	if err != nil {
-		return err
-	}
+func loadIntoForest(forest *mtrie.Forest, tries []*trie.MTrie) error {
 	for _, t := range tries {
 		err := forest.AddTrie(t)
 		if err != nil {
@@ -379,3 +377,183 @@ func loadIntoForest(forest *mtrie.Forest, forestSequencing *flattener.FlattenedF
 	}
 	return nil
 }
+
+func Test_StreamingCheckpoint(t *testing.T) {
+
+	unittest.RunWithTempDir(t, func(dir string) {
+
+		f, err := mtrie.NewForest(pathByteSize, dir, size*10, metricsCollector, nil)
+		require.NoError(t, err)
+
+		// enough registers to spread nodes over several sections, with the second trie sharing nodes with the first
+		rootHash := f.GetEmptyRootHash()
+		for i := 0; i < 2; i++ {
+			paths := utils.RandomPaths(6000, pathByteSize)
+			payloads := utils.RandomPayloads(len(paths), 1, 10)
+			rootHash, err = f.Update(&ledger.TrieUpdate{RootHash: rootHash, Paths: paths, Payloads: payloads})
+			require.NoError(t, err)
+		}
+
+		tries, err := f.GetTries()
+		require.NoError(t, err)
+
+		filename := path.Join(dir, "checkpoint")
+		writer, err := realWAL.CreateCheckpointWriterForFile(filename)
+		require.NoError(t, err)
+		err = realWAL.WriteCheckpoint(tries, writer)
+		require.NoError(t, err)
+		err = writer.Close()
+		require.NoError(t, err)
+
+		t.Run("tries are restored", func(t *testing.T) {
+			loaded, err := realWAL.LoadCheckpointTries(filename)
+			require.NoError(t, err)
+			require.Len(t, loaded, len(tries))
+			for i := range tries {
+				assert.Equal(t, tries[i].RootHash(), loaded[i].RootHash())
+				assert.True(t, loaded[i].IsAValidTrie())
+			}
+		})
+
+		t.Run("storables match flattened forest", func(t *testing.T) {
+			expected, err := flattener.FlattenForest(f)
+			require.NoError(t, err)
+			flattened, err := realWAL.LoadCheckpoint(filename)
+			require.NoError(t, err)
+
+			require.Len(t, flattened.Nodes, len(expected.Nodes))
+			assert.Nil(t, flattened.Nodes[0])
+			for i := 1; i < len(expected.Nodes); i++ {
+				require.Equal(t, flattener.EncodeStorableNode(expected.Nodes[i]), flattener.EncodeStorableNode(flattened.Nodes[i]))
+			}
+			assert.Equal(t, expected.Tries, flattened.Tries)
+		})
+
+		t.Run("corruption is detected", func(t *testing.T) {
+			data, err := ioutil.ReadFile(filename)
+			require.NoError(t, err)
+			data[len(data)/2] ^= 0xff
+			corrupted := path.Join(dir, "corrupted")
+			err = ioutil.WriteFile(corrupted, data, 0644)
+			require.NoError(t, err)
+
+			_, err = realWAL.LoadCheckpointTries(corrupted)
+			require.Error(t, err)
+		})
+
+		t.Run("version 1 checkpoints can be read", func(t *testing.T) {
+			flattened, err := flattener.FlattenForest(f)
+			require.NoError(t, err)
+
+			// version 1 header: magic bytes, version, nodes count and tries count
+			data := make([]byte, 0)
+			data = utils.AppendUint16(data, realWAL.MagicBytes)
+			data = utils.AppendUint16(data, realWAL.VersionV1)
+			data = utils.AppendUint64(data, uint64(len(flattened.Nodes)-1))
+			data = utils.AppendUint16(data, uint16(len(flattened.Tries)))
+			for _, n := range flattened.Nodes[1:] {
+				data = append(data, flattener.EncodeStorableNode(n)...)
+			}
+			for _, st := range flattened.Tries {
+				data = append(data, flattener.EncodeStorableTrie(st)...)
+			}
+			v1 := path.Join(dir, "v1")
+			err = ioutil.WriteFile(v1, data, 0644)
+			require.NoError(t, err)
+
+			loaded, err := realWAL.LoadCheckpointTries(v1)
+			require.NoError(t, err)
+			require.Len(t, loaded, len(tries))
+			for i := range tries {
+				assert.Equal(t, tries[i].RootHash(), loaded[i].RootHash())
+			}
+		})
+	})
+}
+
+func Test_CheckpointVerification(t *testing.T) {
+
+	unittest.RunWithTempDir(t, func(dir string) {
+
+		wal, err := realWAL.NewWAL(nil, nil, dir, size*10, pathByteSize, segmentSize)
+		require.NoError(t, err)
+
+		f, err := mtrie.NewForest(pathByteSize, dir, size*10, metricsCollector, nil)
+		require.NoError(t, err)
+		rootHash := f.GetEmptyRootHash()
+
+		// every update fills more than a segment
+		for i := 0; i < 6; i++ {
+			keys := utils.RandomUniqueKeys(numInsPerStep, keyNumberOfParts, 1600, 1600)
+			values := utils.RandomValues(numInsPerStep, 1, valueMaxByteSize)
+			update, err := ledger.NewUpdate(rootHash, keys, values)
+			require.NoError(t, err)
+			trieUpdate, err := pathfinder.UpdateToTrieUpdate(update, pathFinderVersion)
+			require.NoError(t, err)
+			err = wal.RecordUpdate(trieUpdate)
+			require.NoError(t, err)
+			rootHash, err = f.Update(trieUpdate)
+			require.NoError(t, err)
+		}
+
+		checkpointer, err := wal.NewCheckpointer()
+		require.NoError(t, err)
+		err = checkpointer.Checkpoint(3, func() (io.WriteCloser, error) {
+			return checkpointer.CheckpointWriter(3)
+		})
+		require.NoError(t, err)
+
+		checkpoints, err := checkpointer.Checkpoints()
+		require.NoError(t, err)
+		require.Equal(t, []int{3}, checkpoints)
+
+		t.Run("valid checkpoint", func(t *testing.T) {
+			tries, err := checkpointer.VerifyCheckpoint(3)
+			require.NoError(t, err)
+
+			_, last, err := checkpointer.NotCheckpointedSegments()
+			require.NoError(t, err)
+			replayed, err := checkpointer.VerifySegments(3, tries)
+			require.NoError(t, err)
+			assert.Equal(t, last, replayed)
+		})
+
+		t.Run("segments must apply on top of the checkpoint", func(t *testing.T) {
+			// an empty trie does not hold the parent of the following updates
+			emptyTrie, err := trie.NewEmptyMTrie(pathByteSize)
+			require.NoError(t, err)
+			_, err = checkpointer.VerifySegments(3, []*trie.MTrie{emptyTrie})
+			require.Error(t, err)
+		})
+
+		t.Run("truncated checkpoint is repaired", func(t *testing.T) {
+			filename := path.Join(dir, realWAL.NumberToFilename(3))
+			info, err := os.Stat(filename)
+			require.NoError(t, err)
+			err = os.Truncate(filename, info.Size()/2)
+			require.NoError(t, err)
+
+			_, err = checkpointer.VerifyCheckpoint(3)
+			require.Error(t, err)
+
+			err = checkpointer.DiscardCheckpoint(3)
+			require.NoError(t, err)
+			checkpoints, err := checkpointer.Checkpoints()
+			require.NoError(t, err)
+			require.Empty(t, checkpoints)
+
+			err = checkpointer.Checkpoint(3, func() (io.WriteCloser, error) {
+				return checkpointer.CheckpointWriter(3)
+			})
+			require.NoError(t, err)
+
+			tries, err := checkpointer.VerifyCheckpoint(3)
+			require.NoError(t, err)
+			_, err = checkpointer.VerifySegments(3, tries)
+			require.NoError(t, err)
+		})
+
+		err = wal.Close()
+		require.NoError(t, err)
+	})
+}
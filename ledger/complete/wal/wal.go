@@ -9,7 +9,7 @@ import (
 
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/complete/mtrie"
-	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 )
 
 const SegmentSize = 32 * 1024 * 1024
@@ -75,12 +75,8 @@ func (w *LedgerWAL) RecordDelete(rootHash ledger.RootHash) error {
 
 func (w *LedgerWAL) ReplayOnForest(forest *mtrie.Forest) error {
 	return w.Replay(
-		func(forestSequencing *flattener.FlattenedForest) error {
-			rebuiltTries, err := flattener.RebuildTries(forestSequencing)
-			if err != nil {
-				return fmt.Errorf("rebuilding forest from sequenced nodes failed: %w", err)
-			}
-			err = forest.AddTries(rebuiltTries)
+		func(rebuiltTries []*trie.MTrie) error {
+			err := forest.AddTries(rebuiltTries)
 			if err != nil {
 				return fmt.Errorf("adding rebuilt tries to forest failed: %w", err)
 			}
@@ -91,14 +87,13 @@ func (w *LedgerWAL) ReplayOnForest(forest *mtrie.Forest) error {
 			return err
 		},
 		func(rootHash ledger.RootHash) error {
-			forest.RemoveTrie(rootHash)
-			return nil
+			return forest.RemoveTrie(rootHash)
 		},
 	)
 }
 
 func (w *LedgerWAL) Replay(
-	checkpointFn func(forestSequencing *flattener.FlattenedForest) error,
+	checkpointFn func(tries []*trie.MTrie) error,
 	updateFn func(update *ledger.TrieUpdate) error,
 	deleteFn func(ledger.RootHash) error,
 ) error {
@@ -109,8 +104,8 @@ func (w *LedgerWAL) Replay(
 	return w.replay(from, to, checkpointFn, updateFn, deleteFn, true)
 }
 
+// ReplayLogsOnly replays the records of all segments, without loading any checkpoint.
 func (w *LedgerWAL) ReplayLogsOnly(
-	checkpointFn func(forestSequencing *flattener.FlattenedForest) error,
 	updateFn func(update *ledger.TrieUpdate) error,
 	deleteFn func(rootHash ledger.RootHash) error,
 ) error {
@@ -118,12 +113,37 @@ func (w *LedgerWAL) ReplayLogsOnly(
 	if err != nil {
 		return err
 	}
-	return w.replay(from, to, checkpointFn, updateFn, deleteFn, false)
+	return w.replaySegments(from, to, updateFn, deleteFn)
+}
+
+// Segments returns the range of segments of the write-ahead log.
+func (w *LedgerWAL) Segments() (from, to int, err error) {
+	return w.wal.Segments()
+}
+
+// ReplayLogsFrom replays the records of the segments starting at the given one, without
+// loading any checkpoint.
+func (w *LedgerWAL) ReplayLogsFrom(
+	from int,
+	updateFn func(update *ledger.TrieUpdate) error,
+	deleteFn func(rootHash ledger.RootHash) error,
+) error {
+	first, to, err := w.wal.Segments()
+	if err != nil {
+		return err
+	}
+	if from < first {
+		from = first
+	}
+	if to < from {
+		return nil
+	}
+	return w.replaySegments(from, to, updateFn, deleteFn)
 }
 
 func (w *LedgerWAL) replay(
 	from, to int,
-	checkpointFn func(forestSequencing *flattener.FlattenedForest) error,
+	checkpointFn func(tries []*trie.MTrie) error,
 	updateFn func(update *ledger.TrieUpdate) error,
 	deleteFn func(rootHash ledger.RootHash) error,
 	useCheckpoints bool,
@@ -148,11 +168,11 @@ func (w *LedgerWAL) replay(
 		}
 
 		if latestCheckpoint != -1 && latestCheckpoint+1 >= from { //+1 to account for connected checkpoint and segments
-			forestSequencing, err := checkpointer.LoadCheckpoint(latestCheckpoint)
+			tries, err := checkpointer.LoadCheckpointTries(latestCheckpoint)
 			if err != nil {
 				return fmt.Errorf("cannot load checkpoint %d: %w", latestCheckpoint, err)
 			}
-			err = checkpointFn(forestSequencing)
+			err = checkpointFn(tries)
 			if err != nil {
 				return fmt.Errorf("error while handling checkpoint: %w", err)
 			}
@@ -174,20 +194,29 @@ func (w *LedgerWAL) replay(
 			return fmt.Errorf("cannot check root checkpoint existence: %w", err)
 		}
 		if hasRootCheckpoint {
-			flattenedForest, err := checkpointer.LoadRootCheckpoint()
+			tries, err := checkpointer.LoadRootCheckpointTries()
 			if err != nil {
 				return fmt.Errorf("cannot load root checkpoint: %w", err)
 			}
-			err = checkpointFn(flattenedForest)
+			err = checkpointFn(tries)
 			if err != nil {
 				return fmt.Errorf("error while handling root checkpoint: %w", err)
 			}
 		}
 	}
 
+	return w.replaySegments(startSegment, to, updateFn, deleteFn)
+}
+
+// replaySegments replays the records of the given range of segments.
+func (w *LedgerWAL) replaySegments(
+	from, to int,
+	updateFn func(update *ledger.TrieUpdate) error,
+	deleteFn func(rootHash ledger.RootHash) error,
+) error {
 	sr, err := prometheusWAL.NewSegmentsRangeReader(prometheusWAL.SegmentRange{
 		Dir:   w.wal.Dir(),
-		First: startSegment,
+		First: from,
 		Last:  to,
 	})
 	if err != nil {
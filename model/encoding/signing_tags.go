@@ -30,4 +30,6 @@ var (
 	ResultApprovalTag = tag("Result-Approval")
 	// SPOCKTag is used to generate SPoCK proofs
 	SPOCKTag = tag("SPoCK")
+	// SlashingEvidenceTag is used for reports of slashable offences
+	SlashingEvidenceTag = tag("Slashing-Evidence")
 )
@@ -0,0 +1,62 @@
+package flow
+
+import (
+	"github.com/onflow/flow-go/crypto"
+)
+
+// SlashingViolation is the type of a slashable offence of a consensus node.
+type SlashingViolation string
+
+const (
+	// SlashingDoubleVote is voting for different blocks at the same view.
+	SlashingDoubleVote SlashingViolation = "double_vote"
+	// SlashingDoublePropose is proposing different blocks at the same view.
+	SlashingDoublePropose SlashingViolation = "double_propose"
+	// SlashingInvalidVote is voting for a block with a view other than the
+	// view of the vote.
+	SlashingInvalidVote SlashingViolation = "invalid_vote"
+)
+
+// SignedVote is the signature of a consensus node for a block at a view. It
+// is part of votes and, as the vote of the proposer, of block proposals.
+type SignedVote struct {
+	BlockID Identifier
+	SigData crypto.Signature
+}
+
+// SlashingEvidenceBody is the self-verifiable proof of a slashable offence:
+// the signatures of the offender for the given view, which anyone can check
+// against the offender's keys, along with the headers of the signed blocks
+// where the offence depends on them, so that nodes which do not know the
+// blocks can verify the evidence.
+type SlashingEvidenceBody struct {
+	Violation  SlashingViolation
+	OffenderID Identifier
+	View       uint64
+	Votes      []SignedVote // conflicting votes ordered by block ID, or the single invalid vote
+	Headers    []Header     // headers of the voted blocks in the order of the votes, for double proposals and invalid votes
+}
+
+// ID generates a unique identifier using the evidence body
+func (b SlashingEvidenceBody) ID() Identifier {
+	return MakeID(b)
+}
+
+// SlashingEvidence is the proof of a slashable offence, as reported by a
+// consensus node.
+type SlashingEvidence struct {
+	Body        SlashingEvidenceBody
+	ReporterID  Identifier       // node reporting the offence
+	ReporterSig crypto.Signature // signature over the body by the reporter
+}
+
+// ID generates a unique identifier using the evidence body, so that reports of
+// the same offence by different nodes have the same ID.
+func (e SlashingEvidence) ID() Identifier {
+	return MakeID(e.Body)
+}
+
+// Checksum generates checksum using the full evidence content
+func (e SlashingEvidence) Checksum() Identifier {
+	return MakeID(e)
+}
@@ -0,0 +1,18 @@
+package flow
+
+// TimeoutCertificate represents a timeout certificate for a view as used by the HotStuff pacemaker.
+// A timeout certificate is a collection of timeouts for a particular view. Valid timeout certificates
+// contain signatures from a super-majority of consensus committee members, which proves that the
+// committee has given up on the view and allows replicas to advance to the next view together.
+//
+// Each signer signs the view together with the view and block ID of its own highest QC, which are
+// kept in the same order as the signers, so that the aggregated signature can be verified. The
+// highest QC is the newest QC contained in the timeouts and is verifiable by itself.
+type TimeoutCertificate struct {
+	View           uint64
+	HighestQC      *QuorumCertificate
+	SignerIDs      []Identifier
+	HighestQCViews []uint64     // view of the highest QC of each signer
+	HighestQCIDs   []Identifier // block ID of the highest QC of each signer
+	SigData        []byte
+}
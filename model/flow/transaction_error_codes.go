@@ -0,0 +1,29 @@
+package flow
+
+// Codes of the errors a transaction can fail with, as reported by the virtual
+// machine and persisted with the transaction result. They are part of the API,
+// so existing codes must never change.
+const (
+	TransactionErrCodeMissingSignature                      uint32 = 1
+	TransactionErrCodeMissingPayer                          uint32 = 2
+	TransactionErrCodeInvalidSignaturePublicKeyDoesNotExist uint32 = 3
+	TransactionErrCodeInvalidSignaturePublicKeyRevoked      uint32 = 4
+	TransactionErrCodeInvalidSignatureVerification          uint32 = 5
+
+	TransactionErrCodeInvalidProposalKeyPublicKeyDoesNotExist uint32 = 6
+	TransactionErrCodeInvalidProposalKeyPublicKeyRevoked      uint32 = 7
+	TransactionErrCodeInvalidProposalKeySequenceNumber        uint32 = 8
+	TransactionErrCodeInvalidProposalKeyMissingSignature      uint32 = 9
+
+	TransactionErrCodeInvalidHashAlgorithm uint32 = 10
+
+	TransactionErrCodeEventLimitExceeded      uint32 = 20
+	TransactionErrCodeStorageCapacityExceeded uint32 = 21
+
+	TransactionErrCodeExecution uint32 = 100
+
+	// TransactionErrCodeUnknown is reported for transactions which failed with an error
+	// whose code is not known, such as the results stored before error codes were
+	// persisted. It is not used by any virtual machine error.
+	TransactionErrCodeUnknown uint32 = 999
+)
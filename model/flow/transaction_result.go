@@ -11,14 +11,19 @@ type TransactionResult struct {
 	TransactionID Identifier
 	// ErrorMessage contains the error message of any error that may have occurred when the transaction was executed
 	ErrorMessage string
+	// ErrorCode contains the code of the virtual machine error that may have occurred when the transaction was
+	// executed, or zero if the transaction succeeded
+	ErrorCode uint32
 }
 
 // String returns the string representation of this error.
 func (t TransactionResult) String() string {
-	return fmt.Sprintf("Transaction ID: %s, Error Message: %s", t.TransactionID.String(), t.ErrorMessage)
+	return fmt.Sprintf("Transaction ID: %s, Error Code: %d, Error Message: %s", t.TransactionID.String(), t.ErrorCode, t.ErrorMessage)
 }
 
 // ID returns a canonical identifier that is guaranteed to be unique.
+// It is the ID of the transaction and does not cover the error code, which is zero for results
+// stored before error codes were persisted (see TransactionErrCodeUnknown).
 func (t TransactionResult) ID() Identifier {
 	return t.TransactionID
 }
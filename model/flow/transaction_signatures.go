@@ -0,0 +1,88 @@
+package flow
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/crypto/hash"
+)
+
+// AccountKeyWeightThreshold is the total weight of keys required to sign for an account.
+const AccountKeyWeightThreshold = 1000
+
+// NewAccountKeyHasher returns a hasher of the given algorithm, if it can be used by account keys.
+func NewAccountKeyHasher(algo hash.HashingAlgorithm) (hash.Hasher, error) {
+	switch algo {
+	case hash.SHA2_256:
+		return hash.NewSHA2_256(), nil
+	case hash.SHA3_256:
+		return hash.NewSHA3_256(), nil
+	default:
+		return nil, fmt.Errorf("invalid hashing algorithm for account keys: %s", algo)
+	}
+}
+
+// TransactionSignatureWeights holds the total weight of the keys each account signed a transaction with.
+type TransactionSignatureWeights struct {
+	Payload           map[Address]int // weights of the keys signing the payload
+	Envelope          map[Address]int // weights of the keys signing the envelope
+	ProposalKeySigned bool            // whether the proposal key signed the payload or the envelope
+}
+
+// AggregateTransactionSignatures verifies the payload and envelope signatures of the given transaction,
+// using the given function which returns the account key a signature is valid for, and aggregates
+// the weights of the signing keys of every account.
+func AggregateTransactionSignatures(
+	tx *TransactionBody,
+	verify func(signature TransactionSignature, message []byte) (*AccountPublicKey, error),
+) (*TransactionSignatureWeights, error) {
+
+	weights := &TransactionSignatureWeights{
+		Payload:  make(map[Address]int),
+		Envelope: make(map[Address]int),
+	}
+
+	aggregate := func(signatures []TransactionSignature, message []byte, accounts map[Address]int) error {
+		for _, signature := range signatures {
+			key, err := verify(signature, message)
+			if err != nil {
+				return err
+			}
+			if signature.Address == tx.ProposalKey.Address && signature.KeyIndex == tx.ProposalKey.KeyIndex {
+				weights.ProposalKeySigned = true
+			}
+			accounts[signature.Address] += key.Weight
+		}
+		return nil
+	}
+
+	err := aggregate(tx.PayloadSignatures, tx.PayloadMessage(), weights.Payload)
+	if err != nil {
+		return nil, err
+	}
+	err = aggregate(tx.EnvelopeSignatures, tx.EnvelopeMessage(), weights.Envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	return weights, nil
+}
+
+// UnsignedAccount returns the first account required to sign the given transaction whose signing keys
+// do not reach the given weight threshold, if any. Authorizers are required to sign the payload, unless
+// they are the payer, which is only required to sign the envelope.
+func (w *TransactionSignatureWeights) UnsignedAccount(tx *TransactionBody, threshold int) (Address, bool) {
+	for _, address := range tx.Authorizers {
+		if address == tx.Payer {
+			continue
+		}
+		if w.Payload[address] < threshold {
+			return address, true
+		}
+	}
+
+	if w.Envelope[tx.Payer] < threshold {
+		return tx.Payer, true
+	}
+
+	return EmptyAddress, false
+}
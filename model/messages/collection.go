@@ -39,3 +39,11 @@ type ClusterBlockVote struct {
 	View    uint64
 	SigData []byte
 }
+
+// ClusterBlockTimeout is a timeout of a node of a collection node cluster
+// from a round of cluster consensus, together with the newest QC it knows.
+type ClusterBlockTimeout struct {
+	View      uint64
+	HighestQC *flow.QuorumCertificate
+	SigData   []byte
+}
@@ -18,3 +18,11 @@ type BlockVote struct {
 	View    uint64
 	SigData []byte
 }
+
+// BlockTimeout is part of the consensus protocol and represents a consensus
+// node timing out of a given round, together with the newest QC it knows.
+type BlockTimeout struct {
+	View      uint64
+	HighestQC *flow.QuorumCertificate
+	SigData   []byte
+}
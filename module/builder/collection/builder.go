@@ -194,10 +194,16 @@ func (b *Builder) BuildOn(parentID flow.Identifier, setter func(*flow.Header) er
 		// start with the finalized reference ID (longest expiry time)
 		minRefID := refChainFinalizedID
 
+		// consider the most valuable transactions first, if configured
+		candidates := b.transactions.All()
+		if b.config.Priority != nil {
+			candidates = prioritize(candidates, b.config.Priority, limiter)
+		}
+
 		var transactions []*flow.TransactionBody
 		var totalByteSize uint64
 		var totalGas uint64
-		for _, tx := range b.transactions.All() {
+		for _, tx := range candidates {
 
 			// if we have reached maximum number of transactions, stop
 			if uint(len(transactions)) >= b.config.MaxCollectionSize {
@@ -246,7 +252,7 @@ func (b *Builder) BuildOn(parentID flow.Identifier, setter func(*flow.Header) er
 
 			// ensure the reference block is not too old
 			txID := tx.ID()
-			if refChainFinalizedHeight-refHeader.Height > uint64(flow.DefaultTransactionExpiry-b.config.ExpiryBuffer) {
+			if IsExpired(refHeader.Height, refChainFinalizedHeight, b.config.ExpiryBuffer) {
 				// the transaction is expired, it will never be valid
 				b.transactions.Rem(txID)
 				continue
@@ -334,3 +340,14 @@ func (b *Builder) BuildOn(parentID flow.Identifier, setter func(*flow.Header) er
 
 	return proposal.Header, err
 }
+
+// IsExpired returns true if a transaction with a reference block at refHeight
+// is expired with respect to the latest finalized block of the main chain at
+// finalHeight, considering the given expiry buffer. An expired transaction can
+// never be included in a collection.
+func IsExpired(refHeight uint64, finalHeight uint64, expiryBuffer uint) bool {
+	if finalHeight < refHeight {
+		return false
+	}
+	return finalHeight-refHeight > uint64(flow.DefaultTransactionExpiry-expiryBuffer)
+}
@@ -787,6 +787,113 @@ func (suite *BuilderSuite) TestBuildOn_UnlimitedPayer() {
 	}
 }
 
+// With a priority function, the transactions with the highest score should be
+// included first.
+func (suite *BuilderSuite) TestBuildOn_Priority() {
+
+	// start with an empty mempool
+	suite.ClearPool()
+
+	// create builder prioritizing by gas limit with max 10 tx/collection
+	suite.builder = builder.NewBuilder(suite.db, trace.NewNoopTracer(), suite.headers, suite.headers, suite.payloads, suite.pool,
+		builder.WithMaxCollectionSize(10),
+		builder.WithPriority(builder.ScoreByGasLimit),
+	)
+
+	// fill the pool with 100 transactions from distinct payers and gas limits
+	var prioritized []flow.Identifier
+	for i := 0; i < 100; i++ {
+		tx := unittest.TransactionBodyFixture()
+		tx.ReferenceBlockID = suite.ProtoStateRoot().ID()
+		tx.Payer = unittest.RandomAddressFixture()
+		tx.GasLimit = uint64(i + 1)
+		suite.pool.Add(&tx)
+		if i >= 90 {
+			prioritized = append(prioritized, tx.ID())
+		}
+	}
+
+	header, err := suite.builder.BuildOn(suite.genesis.ID(), noopSetter)
+	suite.Require().Nil(err)
+
+	// the collection should contain the 10 transactions with the highest gas limit
+	var built model.Block
+	err = suite.db.View(procedure.RetrieveClusterBlock(header.ID(), &built))
+	suite.Assert().Nil(err)
+	suite.Assert().Len(built.Payload.Collection.Transactions, 10)
+	suite.Assert().True(collectionContains(built.Payload.Collection, prioritized...))
+}
+
+// With a priority function, a payer with many high scoring transactions should
+// not crowd out other payers, unless it is unlimited.
+func (suite *BuilderSuite) TestBuildOn_PriorityPayerFairness() {
+
+	// start with an empty mempool
+	suite.ClearPool()
+
+	// create builder prioritizing by gas limit with max 10 tx/collection
+	unlimited := unittest.RandomAddressFixture()
+	suite.builder = builder.NewBuilder(suite.db, trace.NewNoopTracer(), suite.headers, suite.headers, suite.payloads, suite.pool,
+		builder.WithMaxCollectionSize(10),
+		builder.WithPriority(builder.ScoreByGasLimit),
+		builder.WithUnlimitedPayers(unlimited),
+	)
+
+	// fill the pool with 20 high scoring transactions from a single payer
+	payer := unittest.RandomAddressFixture()
+	create := func() *flow.TransactionBody {
+		tx := unittest.TransactionBodyFixture()
+		tx.ReferenceBlockID = suite.ProtoStateRoot().ID()
+		tx.Payer = payer
+		tx.GasLimit = 1000
+		return &tx
+	}
+	suite.FillPool(20, create)
+
+	// and 5 low scoring transactions from distinct payers
+	var others []flow.Identifier
+	for i := 0; i < 5; i++ {
+		tx := unittest.TransactionBodyFixture()
+		tx.ReferenceBlockID = suite.ProtoStateRoot().ID()
+		tx.Payer = unittest.RandomAddressFixture()
+		tx.GasLimit = 1
+		suite.pool.Add(&tx)
+		others = append(others, tx.ID())
+	}
+
+	header, err := suite.builder.BuildOn(suite.genesis.ID(), noopSetter)
+	suite.Require().Nil(err)
+
+	// every payer should have a transaction in the collection
+	var built model.Block
+	err = suite.db.View(procedure.RetrieveClusterBlock(header.ID(), &built))
+	suite.Assert().Nil(err)
+	suite.Assert().Len(built.Payload.Collection.Transactions, 10)
+	suite.Assert().True(collectionContains(built.Payload.Collection, others...))
+
+	// an unlimited payer is exempt from fairness
+	suite.ClearPool()
+	payer = unlimited
+	suite.FillPool(20, create)
+	for i := 0; i < 5; i++ {
+		tx := unittest.TransactionBodyFixture()
+		tx.ReferenceBlockID = suite.ProtoStateRoot().ID()
+		tx.Payer = unittest.RandomAddressFixture()
+		tx.GasLimit = 1
+		suite.pool.Add(&tx)
+	}
+
+	header, err = suite.builder.BuildOn(suite.genesis.ID(), noopSetter)
+	suite.Require().Nil(err)
+
+	err = suite.db.View(procedure.RetrieveClusterBlock(header.ID(), &built))
+	suite.Assert().Nil(err)
+	suite.Assert().Len(built.Payload.Collection.Transactions, 10)
+	for _, tx := range built.Payload.Collection.Transactions {
+		suite.Assert().Equal(unlimited, tx.Payer)
+	}
+}
+
 // helper to check whether a collection contains each of the given transactions.
 func collectionContains(collection flow.Collection, txIDs ...flow.Identifier) bool {
 
@@ -34,6 +34,13 @@ type Config struct {
 
 	// MaxCollectionTotalGas is the maximum of total of gas per collection (sum of maxGasLimit over transactions)
 	MaxCollectionTotalGas uint64
+
+	// Priority is the scoring function used to select the transactions of a
+	// collection. Transactions are considered by descending score, in rounds
+	// of at most one transaction per payer (see prioritize).
+	//
+	// A nil function indicates transactions are considered in mempool order.
+	Priority ScoreFunc
 }
 
 func DefaultConfig() Config {
@@ -44,6 +51,7 @@ func DefaultConfig() Config {
 		UnlimitedPayers:         make(map[flow.Address]struct{}), // no unlimited payers
 		MaxCollectionByteSize:   uint64(1750000),                 // ~1.75MB. This is slightly higher than the limit on single tx size, which is 1.5MB
 		MaxCollectionTotalGas:   uint64(1000000),                 // 1M
+		Priority:                nil,                             // mempool order
 	}
 }
 
@@ -91,3 +99,9 @@ func WithMaxCollectionTotalGas(limit uint64) Opt {
 		c.MaxCollectionTotalGas = limit
 	}
 }
+
+func WithPriority(score ScoreFunc) Opt {
+	return func(c *Config) {
+		c.Priority = score
+	}
+}
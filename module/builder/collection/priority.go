@@ -0,0 +1,105 @@
+package collection
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// names of the priority modes which can be selected by name
+const (
+	PriorityNone     = "none"
+	PriorityGasLimit = "gas-limit"
+)
+
+// NamedPriority returns the scoring function of the priority mode with the
+// given name. The "none" mode returns a nil function, which keeps the mempool
+// order.
+func NamedPriority(name string) (ScoreFunc, error) {
+	switch name {
+	case PriorityNone:
+		return nil, nil
+	case PriorityGasLimit:
+		return ScoreByGasLimit, nil
+	default:
+		return nil, fmt.Errorf("unknown priority mode: %s", name)
+	}
+}
+
+// ScoreFunc assigns a priority score to a transaction. Transactions with a
+// higher score are included in collections first.
+type ScoreFunc func(tx *flow.TransactionBody) float64
+
+// ScoreByGasLimit prioritizes transactions with a higher gas limit.
+func ScoreByGasLimit(tx *flow.TransactionBody) float64 {
+	return float64(tx.GasLimit)
+}
+
+// ScoreByPayer prioritizes transactions according to the given score of their
+// payer, for example a payer reputation. Payers without a score have score 0.
+func ScoreByPayer(scores map[flow.Address]float64) ScoreFunc {
+	return func(tx *flow.TransactionBody) float64 {
+		return scores[tx.Payer]
+	}
+}
+
+// prioritize orders the candidate transactions for a collection by descending
+// score, while being fair to payers: transactions are ordered in rounds, where
+// every payer has at most one transaction per round (its best remaining one).
+// A payer can therefore not crowd out other payers by submitting many high
+// scoring transactions. Payers which are unlimited for the rate limiter are
+// exempt from fairness and have all of their transactions in the first round.
+// Ties are broken by transaction ID, so that the order is deterministic.
+func prioritize(txs []*flow.TransactionBody, score ScoreFunc, limiter *rateLimiter) []*flow.TransactionBody {
+
+	candidates := make([]*candidate, 0, len(txs))
+	for _, tx := range txs {
+		candidates = append(candidates, &candidate{
+			tx:    tx,
+			id:    tx.ID(),
+			score: score(tx),
+		})
+	}
+
+	// rank the transactions of every payer
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].before(candidates[j])
+	})
+	ranks := make(map[flow.Address]uint)
+	for _, c := range candidates {
+		if limiter.isUnlimited(c.tx.Payer) {
+			continue
+		}
+		c.round = ranks[c.tx.Payer]
+		ranks[c.tx.Payer]++
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].round < candidates[j].round
+	})
+
+	prioritized := make([]*flow.TransactionBody, 0, len(candidates))
+	for _, c := range candidates {
+		prioritized = append(prioritized, c.tx)
+	}
+	return prioritized
+}
+
+// candidate is a transaction considered for inclusion in a prioritized collection.
+type candidate struct {
+	tx    *flow.TransactionBody
+	id    flow.Identifier
+	score float64
+	round uint
+}
+
+// before returns whether the candidate has precedence over the other one
+// within the same round.
+func (c *candidate) before(other *candidate) bool {
+	if c.score != other.score {
+		return c.score > other.score
+	}
+	return bytes.Compare(c.id[:], other.id[:]) < 0
+}
@@ -54,6 +54,12 @@ func (limiter *rateLimiter) transactionIncluded(tx *flow.TransactionBody) {
 	limiter.txIncludedCount[tx.Payer]++
 }
 
+// returns whether the payer is configured to be unaffected by rate limiting.
+func (limiter *rateLimiter) isUnlimited(payer flow.Address) bool {
+	_, unlimited := limiter.unlimited[payer]
+	return unlimited
+}
+
 // applies the rate limiting rules, returning whether the transaction should be
 // omitted from the collection under construction.
 func (limiter *rateLimiter) shouldRateLimit(tx *flow.TransactionBody) bool {
@@ -61,8 +67,7 @@ func (limiter *rateLimiter) shouldRateLimit(tx *flow.TransactionBody) bool {
 	payer := tx.Payer
 
 	// skip rate limiting if it is turned off or the payer is unlimited
-	_, isUnlimited := limiter.unlimited[payer]
-	if limiter.rate == 0 || isUnlimited {
+	if limiter.rate == 0 || limiter.isUnlimited(payer) {
 		return false
 	}
 
@@ -5,3 +5,19 @@ type ReadyDoneAware interface {
 	Ready() <-chan struct{}
 	Done() <-chan struct{}
 }
+
+// NoopReadyDoneAware is ready and done right away, it stands in for optional
+// components which are disabled.
+type NoopReadyDoneAware struct{}
+
+func (n *NoopReadyDoneAware) Ready() <-chan struct{} {
+	ready := make(chan struct{})
+	close(ready)
+	return ready
+}
+
+func (n *NoopReadyDoneAware) Done() <-chan struct{} {
+	done := make(chan struct{})
+	close(done)
+	return done
+}
@@ -0,0 +1,153 @@
+// Package grpcauth implements mutual TLS authentication of the gRPC links
+// between staked nodes. Every node presents a self-signed certificate for an
+// ephemeral TLS key, which is bound to the node's networking key by a signature
+// carried in a certificate extension. A peer is authenticated by verifying this
+// binding and looking up the networking key in the identity table of the
+// protocol state.
+package grpcauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
+)
+
+// extensionID is the object identifier of the certificate extension binding
+// the certificate to a networking key. It is taken from the private enterprise
+// arc used for experimental extensions.
+var extensionID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 53594, 1, 1}
+
+// signaturePrefix is prepended to the certificate public key signed with the
+// networking key, so that the signature can not be replayed in another context.
+const signaturePrefix = "flow-grpc-tls:"
+
+// certificateValidity is how long node certificates are valid. Certificates
+// are created at startup and only live as long as the node process.
+const certificateValidity = 10 * 365 * 24 * time.Hour
+
+// keyBinding is the content of the certificate extension.
+type keyBinding struct {
+	SigningAlgorithm int
+	PublicKey        []byte
+	Signature        []byte
+}
+
+// NodeCertificate creates a TLS certificate for a new ephemeral key, bound to
+// the given networking key of the node.
+func NodeCertificate(networkKey crypto.PrivateKey) (tls.Certificate, error) {
+
+	tlsKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("could not generate TLS key: %w", err)
+	}
+	tlsPublicKey, err := x509.MarshalPKIXPublicKey(&tlsKey.PublicKey)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("could not encode TLS public key: %w", err)
+	}
+
+	publicKey := networkKey.PublicKey()
+	signature, err := networkKey.Sign(append([]byte(signaturePrefix), tlsPublicKey...), hash.NewSHA3_256())
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("could not sign TLS public key: %w", err)
+	}
+	binding, err := asn1.Marshal(keyBinding{
+		SigningAlgorithm: int(publicKey.Algorithm()),
+		PublicKey:        publicKey.Encode(),
+		Signature:        signature,
+	})
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("could not encode key binding: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("could not generate serial number: %w", err)
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "flow node"},
+		NotBefore:    now.Add(-time.Hour), // tolerate clock skew between nodes
+		NotAfter:     now.Add(certificateValidity),
+		ExtraExtensions: []pkix.Extension{{
+			Id:    extensionID,
+			Value: binding,
+		}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &tlsKey.PublicKey, tlsKey)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("could not create certificate: %w", err)
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  tlsKey,
+	}, nil
+}
+
+// PeerNetworkKey returns the networking key bound to the certificate presented
+// by a peer during the TLS handshake. It fails if the certificate is not a
+// valid self-signed certificate or if the binding signature is invalid.
+func PeerNetworkKey(rawCerts [][]byte) (crypto.PublicKey, error) {
+
+	if len(rawCerts) != 1 {
+		return nil, fmt.Errorf("expected exactly one certificate, got %d", len(rawCerts))
+	}
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return nil, fmt.Errorf("could not parse certificate: %w", err)
+	}
+
+	now := time.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return nil, fmt.Errorf("certificate is not valid at %s", now)
+	}
+	err = cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate signature: %w", err)
+	}
+
+	var binding keyBinding
+	found := false
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(extensionID) {
+			continue
+		}
+		rest, err := asn1.Unmarshal(ext.Value, &binding)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode key binding: %w", err)
+		}
+		if len(rest) > 0 {
+			return nil, fmt.Errorf("trailing data after key binding")
+		}
+		found = true
+		break
+	}
+	if !found {
+		return nil, fmt.Errorf("certificate is not bound to a networking key")
+	}
+
+	publicKey, err := crypto.DecodePublicKey(crypto.SigningAlgorithm(binding.SigningAlgorithm), binding.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode networking key: %w", err)
+	}
+	valid, err := publicKey.Verify(binding.Signature, append([]byte(signaturePrefix), cert.RawSubjectPublicKeyInfo...), hash.NewSHA3_256())
+	if err != nil {
+		return nil, fmt.Errorf("could not verify key binding: %w", err)
+	}
+	if !valid {
+		return nil, fmt.Errorf("invalid key binding signature")
+	}
+
+	return publicKey, nil
+}
@@ -0,0 +1,114 @@
+package grpcauth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// ServerCredentials returns the transport credentials of a gRPC server which
+// requires clients to present a certificate bound to a networking key, and
+// only accepts clients whose networking key is authorized.
+func ServerCredentials(cert tls.Certificate, authorize func(key crypto.PublicKey) error) credentials.TransportCredentials {
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS13,
+		ClientAuth:   tls.RequireAnyClientCert,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			key, err := PeerNetworkKey(rawCerts)
+			if err != nil {
+				return fmt.Errorf("could not authenticate client: %w", err)
+			}
+			return authorize(key)
+		},
+	})
+}
+
+// ClientCredentials returns the transport credentials of a gRPC connection to
+// the node with the given networking key. The connection fails if the server
+// can not prove that it holds the networking key.
+func ClientCredentials(cert tls.Certificate, serverKey crypto.PublicKey) credentials.TransportCredentials {
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS13,
+		// the server certificate is self-signed and verified against the
+		// networking key below, instead of a certificate authority
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			key, err := PeerNetworkKey(rawCerts)
+			if err != nil {
+				return fmt.Errorf("could not authenticate server: %w", err)
+			}
+			if !key.Equals(serverKey) {
+				return fmt.Errorf("server networking key does not match (expected: %s, got: %s)", serverKey, key)
+			}
+			return nil
+		},
+	})
+}
+
+// StakedNodes returns an authorization function accepting the networking keys
+// of staked nodes with one of the given roles at the finalized state.
+func StakedNodes(state protocol.State, roles ...flow.Role) func(key crypto.PublicKey) error {
+	return func(key crypto.PublicKey) error {
+		identities, err := state.Final().Identities(filter.And(
+			filter.HasRole(roles...),
+			filter.HasStake(true),
+			filter.Not(filter.Ejected),
+		))
+		if err != nil {
+			return fmt.Errorf("could not get identities: %w", err)
+		}
+		for _, identity := range identities {
+			if identity.NetworkPubKey.Equals(key) {
+				return nil
+			}
+		}
+		return fmt.Errorf("networking key %s does not belong to an authorized node", key)
+	}
+}
+
+// NodeKeyByAddress returns the networking key of the node at the given gRPC
+// address. As nodes use different ports for gRPC and networking, the node is
+// identified by the host of its networking address, which must be unique.
+func NodeKeyByAddress(state protocol.State, address string) (crypto.PublicKey, error) {
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s: %w", address, err)
+	}
+
+	identities, err := state.Final().Identities(func(identity *flow.Identity) bool {
+		identityHost, _, err := net.SplitHostPort(identity.Address)
+		return err == nil && identityHost == host
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not get identities: %w", err)
+	}
+	if len(identities) != 1 {
+		return nil, fmt.Errorf("expected exactly one node with host %s, found %d", host, len(identities))
+	}
+
+	return identities[0].NetworkPubKey, nil
+}
+
+// NodeCredentials returns a function providing the client credentials of
+// connections to nodes by their gRPC address, which are resolved to nodes
+// using NodeKeyByAddress.
+func NodeCredentials(state protocol.State, cert tls.Certificate) func(address string) (credentials.TransportCredentials, error) {
+	return func(address string) (credentials.TransportCredentials, error) {
+		key, err := NodeKeyByAddress(state, address)
+		if err != nil {
+			return nil, err
+		}
+		return ClientCredentials(cert, key), nil
+	}
+}
@@ -0,0 +1,187 @@
+package grpcauth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/grpcauth"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// handshake performs a TLS handshake between the given client and server
+// credentials and returns the errors of both sides.
+func handshake(t *testing.T, client, server credentials.TransportCredentials) (error, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		defer conn.Close()
+		_, _, err = server.ServerHandshake(conn)
+		serverErr <- err
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, _, clientErr := client.ClientHandshake(ctx, "node", conn)
+	if clientErr != nil {
+		// unblock the server waiting for the rest of the handshake
+		_ = conn.Close()
+	}
+
+	return clientErr, <-serverErr
+}
+
+func TestHandshake(t *testing.T) {
+	keys, err := unittest.NetworkingKeys(3)
+	require.NoError(t, err)
+	clientKey, serverKey, otherKey := keys[0], keys[1], keys[2]
+
+	clientCert, err := grpcauth.NodeCertificate(clientKey)
+	require.NoError(t, err)
+	serverCert, err := grpcauth.NodeCertificate(serverKey)
+	require.NoError(t, err)
+	otherCert, err := grpcauth.NodeCertificate(otherKey)
+	require.NoError(t, err)
+
+	// the server only authorizes the client key
+	authorize := func(key crypto.PublicKey) error {
+		if !key.Equals(clientKey.PublicKey()) {
+			return fmt.Errorf("unauthorized")
+		}
+		return nil
+	}
+	server := grpcauth.ServerCredentials(serverCert, authorize)
+
+	t.Run("authenticated", func(t *testing.T) {
+		clientErr, serverErr := handshake(t, grpcauth.ClientCredentials(clientCert, serverKey.PublicKey()), server)
+		assert.NoError(t, clientErr)
+		assert.NoError(t, serverErr)
+	})
+
+	t.Run("unexpected server", func(t *testing.T) {
+		// the server presents its own key, not the key the client expects
+		clientErr, _ := handshake(t, grpcauth.ClientCredentials(clientCert, otherKey.PublicKey()), server)
+		assert.Error(t, clientErr)
+	})
+
+	t.Run("unauthorized client", func(t *testing.T) {
+		_, serverErr := handshake(t, grpcauth.ClientCredentials(otherCert, serverKey.PublicKey()), server)
+		assert.Error(t, serverErr)
+	})
+}
+
+func TestPeerNetworkKey(t *testing.T) {
+	networkKey, err := unittest.NetworkingKey()
+	require.NoError(t, err)
+
+	t.Run("bound certificate", func(t *testing.T) {
+		cert, err := grpcauth.NodeCertificate(networkKey)
+		require.NoError(t, err)
+
+		key, err := grpcauth.PeerNetworkKey(cert.Certificate)
+		require.NoError(t, err)
+		assert.True(t, key.Equals(networkKey.PublicKey()))
+	})
+
+	t.Run("unbound certificate", func(t *testing.T) {
+		tlsKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "flow node"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &tlsKey.PublicKey, tlsKey)
+		require.NoError(t, err)
+
+		_, err = grpcauth.PeerNetworkKey([][]byte{der})
+		assert.Error(t, err)
+	})
+
+	t.Run("binding of another certificate", func(t *testing.T) {
+		// re-use the binding extension of a node certificate for another TLS key
+		cert, err := grpcauth.NodeCertificate(networkKey)
+		require.NoError(t, err)
+		parsed, err := x509.ParseCertificate(cert.Certificate[0])
+		require.NoError(t, err)
+
+		tlsKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber:    big.NewInt(1),
+			Subject:         pkix.Name{CommonName: "flow node"},
+			NotBefore:       time.Now().Add(-time.Hour),
+			NotAfter:        time.Now().Add(time.Hour),
+			ExtraExtensions: parsed.Extensions,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &tlsKey.PublicKey, tlsKey)
+		require.NoError(t, err)
+
+		_, err = grpcauth.PeerNetworkKey([][]byte{der})
+		assert.Error(t, err)
+	})
+}
+
+func TestIdentityLookups(t *testing.T) {
+	keys, err := unittest.NetworkingKeys(3)
+	require.NoError(t, err)
+
+	access := unittest.IdentityFixture(unittest.WithRole(flow.RoleAccess), unittest.WithAddress("access:3569"), unittest.WithNetworkingKey(keys[0].PublicKey()))
+	execution := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution), unittest.WithAddress("execution:3569"), unittest.WithNetworkingKey(keys[1].PublicKey()))
+	identities := flow.IdentityList{access, execution}
+
+	snapshot := new(protocol.Snapshot)
+	snapshot.On("Identities", mock.Anything).Return(
+		func(selector flow.IdentityFilter) flow.IdentityList {
+			return identities.Filter(selector)
+		},
+		nil,
+	)
+	state := new(protocol.State)
+	state.On("Final").Return(snapshot)
+
+	t.Run("staked nodes", func(t *testing.T) {
+		authorize := grpcauth.StakedNodes(state, flow.RoleAccess)
+		assert.NoError(t, authorize(keys[0].PublicKey()))
+		assert.Error(t, authorize(keys[1].PublicKey())) // wrong role
+		assert.Error(t, authorize(keys[2].PublicKey())) // unknown node
+	})
+
+	t.Run("node key by address", func(t *testing.T) {
+		key, err := grpcauth.NodeKeyByAddress(state, "execution:9000")
+		require.NoError(t, err)
+		assert.True(t, key.Equals(keys[1].PublicKey()))
+
+		_, err = grpcauth.NodeKeyByAddress(state, "unknown:9000")
+		assert.Error(t, err)
+	})
+}
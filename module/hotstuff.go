@@ -22,6 +22,13 @@ type HotStuff interface {
 	//
 	// Votes may be submitted in any order.
 	SubmitVote(originID flow.Identifier, blockID flow.Identifier, view uint64, sigData []byte)
+
+	// SubmitTimeout submits the timeout of another replica to the HotStuff
+	// event loop. This method blocks until the timeout is accepted to the
+	// event queue.
+	//
+	// Timeouts may be submitted in any order.
+	SubmitTimeout(originID flow.Identifier, view uint64, highestQC *flow.QuorumCertificate, sigData []byte)
 }
 
 // HotStuffFollower is run by non-consensus nodes to observe the block chain
@@ -4,6 +4,7 @@ package ingress
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 
@@ -11,20 +12,22 @@ import (
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/collection/ingest"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/model/flow"
-	"github.com/onflow/flow-go/network"
 	grpcutils "github.com/onflow/flow-go/utils/grpc"
 )
 
 // Config defines the configurable options for the ingress server.
 type Config struct {
-	ListenAddr string
-	MaxMsgSize int // In bytes
+	ListenAddr  string
+	MaxMsgSize  int                              // In bytes
+	Credentials credentials.TransportCredentials // if set, clients must authenticate with these credentials
 }
 
 // Ingress implements a gRPC server with a simplified version of the Observation
@@ -42,6 +45,15 @@ func New(config Config, e *ingest.Engine, chainID flow.ChainID) *Ingress {
 	if config.MaxMsgSize == 0 {
 		config.MaxMsgSize = grpcutils.DefaultMaxMsgSize
 	}
+
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(config.MaxMsgSize),
+		grpc.MaxSendMsgSize(config.MaxMsgSize),
+	}
+	if config.Credentials != nil {
+		opts = append(opts, grpc.Creds(config.Credentials))
+	}
+
 	ingress := &Ingress{
 		unit: engine.NewUnit(),
 		handler: &handler{
@@ -49,10 +61,7 @@ func New(config Config, e *ingest.Engine, chainID flow.ChainID) *Ingress {
 			engine:                       e,
 			chainID:                      chainID,
 		},
-		server: grpc.NewServer(
-			grpc.MaxRecvMsgSize(config.MaxMsgSize),
-			grpc.MaxSendMsgSize(config.MaxMsgSize),
-		),
+		server: grpc.NewServer(opts...),
 		config: config,
 	}
 
@@ -99,10 +108,16 @@ func (i *Ingress) serve() {
 // handler implements a subset of the Observation API.
 type handler struct {
 	access.UnimplementedAccessAPIServer
-	engine  network.Engine
+	engine  transactionProcessor
 	chainID flow.ChainID
 }
 
+// transactionProcessor processes the transactions submitted by clients of the
+// ingress server, identified by their address.
+type transactionProcessor interface {
+	ProcessClientTransaction(clientAddr string, tx *flow.TransactionBody) error
+}
+
 // Ping responds to requests when the server is up.
 func (h *handler) Ping(ctx context.Context, req *access.PingRequest) (*access.PingResponse, error) {
 	return &access.PingResponse{}, nil
@@ -116,7 +131,17 @@ func (h *handler) SendTransaction(ctx context.Context, req *access.SendTransacti
 		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("failed to convert transaction: %v", err))
 	}
 
-	err = h.engine.ProcessLocal(&tx)
+	// the client address is subject to the per-origin transaction quota
+	clientAddr := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		clientAddr = clientHost(p.Addr)
+	}
+
+	err = h.engine.ProcessClientTransaction(clientAddr, &tx)
+	var quotaErr ingest.QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -125,3 +150,13 @@ func (h *handler) SendTransaction(ctx context.Context, req *access.SendTransacti
 
 	return &access.SendTransactionResponse{Id: txID[:]}, nil
 }
+
+// clientHost returns the host of a client address, so that all connections of
+// a client share the same quota.
+func clientHost(addr net.Addr) string {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
@@ -3,21 +3,27 @@ package ingress
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow/protobuf/go/flow/access"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
+	"github.com/onflow/flow-go/engine/collection/ingest"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/model/flow"
-	"github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
 func TestSubmitTransaction(t *testing.T) {
-	engine := mock.Engine{}
+	engine := processor{}
 
 	h := handler{
 		chainID: flow.Testnet,
@@ -27,7 +33,7 @@ func TestSubmitTransaction(t *testing.T) {
 	tx := unittest.TransactionBodyFixture()
 
 	t.Run("should submit transaction to engine", func(t *testing.T) {
-		engine.On("ProcessLocal", &tx).Return(nil).Once()
+		engine.On("ProcessClientTransaction", "", &tx).Return(nil).Once()
 
 		res, err := h.SendTransaction(context.Background(), &access.SendTransactionRequest{
 			Transaction: convert.TransactionToMessage(tx),
@@ -35,7 +41,7 @@ func TestSubmitTransaction(t *testing.T) {
 		require.NoError(t, err)
 
 		// should submit the transaction to the engine
-		engine.AssertCalled(t, "ProcessLocal", &tx)
+		engine.AssertCalled(t, "ProcessClientTransaction", "", &tx)
 
 		// should return the fingerprint of the submitted transaction
 		assert.Equal(t, tx.ID(), flow.HashToID(res.Id))
@@ -43,7 +49,7 @@ func TestSubmitTransaction(t *testing.T) {
 
 	t.Run("should pass through error", func(t *testing.T) {
 		expected := errors.New("error")
-		engine.On("ProcessLocal", &tx).Return(expected).Once()
+		engine.On("ProcessClientTransaction", "", &tx).Return(expected).Once()
 
 		res, err := h.SendTransaction(context.Background(), &access.SendTransactionRequest{
 			Transaction: convert.TransactionToMessage(tx),
@@ -53,9 +59,43 @@ func TestSubmitTransaction(t *testing.T) {
 		}
 
 		// should submit the transaction to the engine
-		engine.AssertCalled(t, "ProcessLocal", &tx)
+		engine.AssertCalled(t, "ProcessClientTransaction", "", &tx)
 
 		// should only return the error
 		assert.Nil(t, res)
 	})
+
+	t.Run("should return resource exhausted error if the quota is exceeded", func(t *testing.T) {
+		engine.On("ProcessClientTransaction", "", &tx).Return(fmt.Errorf("could not admit transaction: %w", ingest.QuotaExceededError{Quota: ingest.QuotaOrigin})).Once()
+
+		res, err := h.SendTransaction(context.Background(), &access.SendTransactionRequest{
+			Transaction: convert.TransactionToMessage(tx),
+		})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Nil(t, res)
+	})
+
+	t.Run("should submit transaction with client host", func(t *testing.T) {
+		engine.On("ProcessClientTransaction", "10.0.0.1", &tx).Return(nil).Once()
+
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 3569},
+		})
+		_, err := h.SendTransaction(ctx, &access.SendTransactionRequest{
+			Transaction: convert.TransactionToMessage(tx),
+		})
+		require.NoError(t, err)
+
+		engine.AssertCalled(t, "ProcessClientTransaction", "10.0.0.1", &tx)
+	})
+}
+
+// processor is a mock transaction processor.
+type processor struct {
+	mock.Mock
+}
+
+func (p *processor) ProcessClientTransaction(clientAddr string, tx *flow.TransactionBody) error {
+	ret := p.Called(clientAddr, tx)
+	return ret.Error(0)
 }
@@ -0,0 +1,259 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	builder "github.com/onflow/flow-go/module/builder/collection"
+	"github.com/onflow/flow-go/module/mempool/stdmap"
+	"github.com/onflow/flow-go/state/protocol/events"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// removalBatchSize is the maximum number of transactions removed from the
+// database within a single database transaction.
+const removalBatchSize = 1000
+
+// Transactions implements a transaction memory pool for a single epoch which
+// persists the transactions it holds, so that pending transactions survive a
+// restart of the node. Transactions are held in memory for reads and written
+// through to the database on every modification.
+//
+// Transactions subscribes to protocol events to prune transactions which have
+// expired with respect to the finalized main chain, using the same expiry rule
+// as the collection builder. The heights of reference blocks are cached, so that
+// pruning does not look up the reference block of every transaction again on
+// every finalized block.
+type Transactions struct {
+	events.Noop
+	mu           sync.Mutex
+	log          zerolog.Logger
+	db           *badger.DB
+	headers      storage.Headers
+	epoch        uint64
+	expiryBuffer uint
+	transactions *stdmap.Transactions
+	refHeights   map[flow.Identifier]uint64 // heights of the known reference blocks of held transactions
+}
+
+// NewTransactions creates a new persistent memory pool for the transactions of
+// the given epoch and reloads the transactions which were persisted before.
+// Reference blocks are looked up in the given main chain headers.
+func NewTransactions(log zerolog.Logger, db *badger.DB, headers storage.Headers, epoch uint64, limit uint, expiryBuffer uint) (*Transactions, error) {
+
+	t := &Transactions{
+		log: log.With().
+			Str("component", "persistent_transactions").
+			Uint64("epoch", epoch).
+			Logger(),
+		db:           db,
+		headers:      headers,
+		epoch:        epoch,
+		expiryBuffer: expiryBuffer,
+		transactions: stdmap.NewTransactions(limit),
+		refHeights:   make(map[flow.Identifier]uint64),
+	}
+
+	// transactions ejected from memory must not be reloaded after a restart
+	t.transactions.RegisterEjectionCallbacks(func(entity flow.Entity) {
+		err := t.removePersisted([]flow.Identifier{entity.ID()})
+		if err != nil {
+			t.log.Error().Err(err).Hex("tx_id", logging.Entity(entity)).Msg("could not remove ejected transaction")
+		}
+	})
+
+	var persisted []flow.TransactionBody
+	err := t.db.View(operation.RetrievePendingTransactions(epoch, &persisted))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve pending transactions: %w", err)
+	}
+	for i := range persisted {
+		t.transactions.Add(&persisted[i])
+	}
+
+	t.log.Info().Int("reloaded", len(persisted)).Msg("reloaded pending transactions")
+
+	return t, nil
+}
+
+// Add adds a transaction to the mempool, persisting it first.
+func (t *Transactions) Add(tx *flow.TransactionBody) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	txID := tx.ID()
+	if t.transactions.Has(txID) {
+		return false
+	}
+
+	err := operation.RetryOnConflict(t.db.Update, operation.InsertPendingTransaction(t.epoch, tx))
+	if err != nil && !errors.Is(err, storage.ErrAlreadyExists) {
+		t.log.Error().Err(err).Hex("tx_id", logging.ID(txID)).Msg("could not persist transaction")
+		return false
+	}
+
+	return t.transactions.Add(tx)
+}
+
+// Has checks whether the transaction with the given ID is in the mempool.
+func (t *Transactions) Has(txID flow.Identifier) bool {
+	return t.transactions.Has(txID)
+}
+
+// Rem removes the transaction with the given ID from the mempool and the database.
+func (t *Transactions) Rem(txID flow.Identifier) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	removed := t.transactions.Rem(txID)
+	if !removed {
+		return false
+	}
+
+	err := t.removePersisted([]flow.Identifier{txID})
+	if err != nil {
+		t.log.Error().Err(err).Hex("tx_id", logging.ID(txID)).Msg("could not remove persisted transaction")
+	}
+
+	return true
+}
+
+// ByID returns the transaction with the given ID from the mempool.
+func (t *Transactions) ByID(txID flow.Identifier) (*flow.TransactionBody, bool) {
+	return t.transactions.ByID(txID)
+}
+
+// Size returns the number of transactions in the mempool.
+func (t *Transactions) Size() uint {
+	return t.transactions.Size()
+}
+
+// All returns all transactions from the mempool.
+func (t *Transactions) All() []*flow.TransactionBody {
+	return t.transactions.All()
+}
+
+// Clear removes all transactions from the mempool and the database.
+func (t *Transactions) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.transactions.Clear()
+
+	// also remove persisted transactions which are not held in memory anymore
+	var persisted []flow.TransactionBody
+	err := t.db.View(operation.RetrievePendingTransactions(t.epoch, &persisted))
+	if err != nil {
+		t.log.Error().Err(err).Msg("could not retrieve pending transactions to clear")
+		return
+	}
+	txIDs := make([]flow.Identifier, 0, len(persisted))
+	for _, tx := range persisted {
+		txIDs = append(txIDs, tx.ID())
+	}
+
+	err = t.removePersisted(txIDs)
+	if err != nil {
+		t.log.Error().Err(err).Msg("could not clear pending transactions")
+	}
+}
+
+// Hash will return a fingerprint hash representing the contents of the
+// entire memory pool.
+func (t *Transactions) Hash() flow.Identifier {
+	return t.transactions.Hash()
+}
+
+// BlockFinalized prunes the transactions which have expired with respect to
+// the newly finalized block of the main chain.
+func (t *Transactions) BlockFinalized(block *flow.Header) {
+	err := t.PruneExpired(block.Height)
+	if err != nil {
+		t.log.Error().Err(err).Uint64("height", block.Height).Msg("could not prune expired transactions")
+	}
+}
+
+// PruneExpired removes all transactions which are expired when the latest
+// finalized block of the main chain is at the given height. Transactions whose
+// reference block is unknown are kept, as the builder does not consider them
+// expired either.
+func (t *Transactions) PruneExpired(finalHeight uint64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var expired []flow.Identifier
+	for _, tx := range t.transactions.All() {
+		refHeight, ok := t.refHeights[tx.ReferenceBlockID]
+		if !ok {
+			refHeader, err := t.headers.ByBlockID(tx.ReferenceBlockID)
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("could not retrieve reference header: %w", err)
+			}
+			refHeight = refHeader.Height
+			t.refHeights[tx.ReferenceBlockID] = refHeight
+		}
+		if builder.IsExpired(refHeight, finalHeight, t.expiryBuffer) {
+			expired = append(expired, tx.ID())
+		}
+	}
+
+	// forget the heights of expired reference blocks, which bounds the cache to
+	// the blocks within the expiry window
+	for refID, refHeight := range t.refHeights {
+		if builder.IsExpired(refHeight, finalHeight, t.expiryBuffer) {
+			delete(t.refHeights, refID)
+		}
+	}
+
+	if len(expired) == 0 {
+		return nil
+	}
+
+	for _, txID := range expired {
+		t.transactions.Rem(txID)
+	}
+	err := t.removePersisted(expired)
+	if err != nil {
+		return fmt.Errorf("could not remove expired transactions: %w", err)
+	}
+
+	t.log.Debug().Int("pruned", len(expired)).Uint64("height", finalHeight).Msg("pruned expired transactions")
+
+	return nil
+}
+
+// removePersisted removes the given transactions from the database, in batches
+// to bound the size of database transactions.
+func (t *Transactions) removePersisted(txIDs []flow.Identifier) error {
+	for len(txIDs) > 0 {
+		batch := txIDs
+		if len(batch) > removalBatchSize {
+			batch = batch[:removalBatchSize]
+		}
+		txIDs = txIDs[len(batch):]
+
+		err := operation.RetryOnConflict(t.db.Update, func(tx *badger.Txn) error {
+			for _, txID := range batch {
+				err := operation.RemovePendingTransaction(t.epoch, txID)(tx)
+				if err != nil && !errors.Is(err, storage.ErrNotFound) {
+					return fmt.Errorf("could not remove transaction %x: %w", txID, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
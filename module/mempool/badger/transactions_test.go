@@ -0,0 +1,119 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	mempool "github.com/onflow/flow-go/module/mempool/badger"
+	"github.com/onflow/flow-go/module/metrics"
+	storage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestTransactions_Reload(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		headers := storage.NewHeaders(metrics.NewNoopCollector(), db)
+
+		pool, err := mempool.NewTransactions(unittest.Logger(), db, headers, 1, 100, 0)
+		require.NoError(t, err)
+
+		tx1 := unittest.TransactionBodyFixture()
+		tx2 := unittest.TransactionBodyFixture()
+		tx3 := unittest.TransactionBodyFixture()
+		assert.True(t, pool.Add(&tx1))
+		assert.True(t, pool.Add(&tx2))
+		assert.True(t, pool.Add(&tx3))
+		assert.False(t, pool.Add(&tx1))
+		assert.True(t, pool.Rem(tx2.ID()))
+
+		// pools of other epochs are not affected
+		other, err := mempool.NewTransactions(unittest.Logger(), db, headers, 2, 100, 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint(0), other.Size())
+
+		// the remaining transactions are reloaded after a restart
+		restarted, err := mempool.NewTransactions(unittest.Logger(), db, headers, 1, 100, 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint(2), restarted.Size())
+		assert.True(t, restarted.Has(tx1.ID()))
+		assert.True(t, restarted.Has(tx3.ID()))
+		actual, ok := restarted.ByID(tx1.ID())
+		require.True(t, ok)
+		assert.Equal(t, tx1.ID(), actual.ID())
+
+		// cleared transactions are not reloaded
+		restarted.Clear()
+		assert.Equal(t, uint(0), restarted.Size())
+		restarted, err = mempool.NewTransactions(unittest.Logger(), db, headers, 1, 100, 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint(0), restarted.Size())
+	})
+}
+
+func TestTransactions_Ejection(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		headers := storage.NewHeaders(metrics.NewNoopCollector(), db)
+
+		pool, err := mempool.NewTransactions(unittest.Logger(), db, headers, 1, 2, 0)
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			tx := unittest.TransactionBodyFixture()
+			assert.True(t, pool.Add(&tx))
+		}
+		assert.Equal(t, uint(2), pool.Size())
+
+		// transactions ejected from memory are not reloaded after a restart
+		restarted, err := mempool.NewTransactions(unittest.Logger(), db, headers, 1, 100, 0)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, pool.All(), restarted.All())
+	})
+}
+
+func TestTransactions_PruneExpired(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		headers := storage.NewHeaders(metrics.NewNoopCollector(), db)
+
+		expiryBuffer := uint(10)
+		pool, err := mempool.NewTransactions(unittest.Logger(), db, headers, 1, 100, expiryBuffer)
+		require.NoError(t, err)
+
+		old := unittest.BlockHeaderFixture()
+		old.Height = 100
+		recent := unittest.BlockHeaderFixture()
+		recent.Height = 150
+		require.NoError(t, headers.Store(&old))
+		require.NoError(t, headers.Store(&recent))
+
+		expiring := unittest.TransactionBodyFixture(unittest.WithReferenceBlock(old.ID()))
+		valid := unittest.TransactionBodyFixture(unittest.WithReferenceBlock(recent.ID()))
+		unknown := unittest.TransactionBodyFixture(unittest.WithReferenceBlock(unittest.IdentifierFixture()))
+		assert.True(t, pool.Add(&expiring))
+		assert.True(t, pool.Add(&valid))
+		assert.True(t, pool.Add(&unknown))
+
+		// the transaction referencing the old block is on the verge of expiring
+		final := unittest.BlockHeaderFixture()
+		final.Height = old.Height + uint64(flow.DefaultTransactionExpiry-expiryBuffer)
+		pool.BlockFinalized(&final)
+		assert.Equal(t, uint(3), pool.Size())
+
+		// one block later, it expired
+		final.Height++
+		pool.BlockFinalized(&final)
+		assert.Equal(t, uint(2), pool.Size())
+		assert.False(t, pool.Has(expiring.ID()))
+		assert.True(t, pool.Has(valid.ID()))
+		assert.True(t, pool.Has(unknown.ID()))
+
+		// pruned transactions are not reloaded after a restart
+		restarted, err := mempool.NewTransactions(unittest.Logger(), db, headers, 1, 100, expiryBuffer)
+		require.NoError(t, err)
+		assert.Equal(t, uint(2), restarted.Size())
+		assert.False(t, restarted.Has(expiring.ID()))
+	})
+}
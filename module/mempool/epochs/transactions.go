@@ -1,6 +1,7 @@
 package epochs
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/onflow/flow-go/module/mempool"
@@ -14,38 +15,69 @@ import (
 // pools across epochs, while maintaining the property that one transaction
 // pool is only valid for a single epoch.
 type TransactionPools struct {
-	mu     sync.RWMutex
-	pools  map[uint64]mempool.Transactions
-	create func() mempool.Transactions
+	mu       sync.RWMutex
+	pools    map[uint64]mempool.Transactions
+	create   func(epoch uint64) (mempool.Transactions, error)
+	teardown func(epoch uint64, pool mempool.Transactions)
 }
 
 // NewTransactionPools returns a new set of epoch-scoped transaction pools.
-func NewTransactionPools(create func() mempool.Transactions) *TransactionPools {
+// The create function is called with the epoch counter of every new pool, and
+// the optional teardown function with every pool removed from the set.
+func NewTransactionPools(create func(epoch uint64) (mempool.Transactions, error), teardown func(epoch uint64, pool mempool.Transactions)) *TransactionPools {
 
 	pools := &TransactionPools{
-		pools:  make(map[uint64]mempool.Transactions),
-		create: create,
+		pools:    make(map[uint64]mempool.Transactions),
+		create:   create,
+		teardown: teardown,
 	}
 	return pools
 }
 
 // ForEpoch returns the transaction pool for the given pool. All calls for
-// the same epoch will return the same underlying transaction pool.
-func (t *TransactionPools) ForEpoch(epoch uint64) mempool.Transactions {
+// the same epoch will return the same underlying transaction pool, until the
+// pool is removed.
+func (t *TransactionPools) ForEpoch(epoch uint64) (mempool.Transactions, error) {
 
 	t.mu.RLock()
 	pool, exists := t.pools[epoch]
 	t.mu.RUnlock()
 	if exists {
-		return pool
+		return pool, nil
 	}
 
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	pool = t.create()
+	// the pool may have been created while we were waiting for the lock
+	pool, exists = t.pools[epoch]
+	if exists {
+		return pool, nil
+	}
+
+	pool, err := t.create(epoch)
+	if err != nil {
+		return nil, fmt.Errorf("could not create transaction pool for epoch %d: %w", epoch, err)
+	}
 	t.pools[epoch] = pool
-	return pool
+	return pool, nil
+}
+
+// Remove removes the transaction pool for the given epoch from the set, if it
+// exists, and tears it down.
+func (t *TransactionPools) Remove(epoch uint64) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pool, exists := t.pools[epoch]
+	if !exists {
+		return
+	}
+	delete(t.pools, epoch)
+	if t.teardown != nil {
+		t.teardown(epoch, pool)
+	}
 }
 
 // CombinedSize returns the sum of the sizes of all transaction pools.
@@ -1,12 +1,14 @@
 package epochs_test
 
 import (
+	"fmt"
 	"math/rand"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/mempool"
@@ -18,19 +20,22 @@ import (
 // subsequent calls to Get should return the same transaction pool
 func TestConsistency(t *testing.T) {
 
-	create := func() mempool.Transactions { return stdmap.NewTransactions(100) }
-	pools := epochs.NewTransactionPools(create)
+	create := func(_ uint64) (mempool.Transactions, error) { return stdmap.NewTransactions(100), nil }
+	pools := epochs.NewTransactionPools(create, nil)
 	epoch := rand.Uint64()
 
-	pool := pools.ForEpoch(epoch)
-	assert.Equal(t, pool, pools.ForEpoch(epoch))
+	pool, err := pools.ForEpoch(epoch)
+	require.NoError(t, err)
+	again, err := pools.ForEpoch(epoch)
+	require.NoError(t, err)
+	assert.Equal(t, pool, again)
 }
 
 // test that different epochs don't interfere, also test concurrent access
 func TestMultipleEpochs(t *testing.T) {
 
-	create := func() mempool.Transactions { return stdmap.NewTransactions(100) }
-	pools := epochs.NewTransactionPools(create)
+	create := func(_ uint64) (mempool.Transactions, error) { return stdmap.NewTransactions(100), nil }
+	pools := epochs.NewTransactionPools(create, nil)
 
 	var wg sync.WaitGroup
 	for i := 0; i < 10; i++ {
@@ -41,7 +46,8 @@ func TestMultipleEpochs(t *testing.T) {
 
 			var transactions []*flow.TransactionBody
 			for i := 0; i < 10; i++ {
-				pool := pools.ForEpoch(epoch)
+				pool, err := pools.ForEpoch(epoch)
+				assert.NoError(t, err)
 				assert.Equal(t, uint(len(transactions)), pool.Size())
 				for _, tx := range transactions {
 					assert.True(t, pool.Has(tx.ID()))
@@ -58,15 +64,16 @@ func TestMultipleEpochs(t *testing.T) {
 
 func TestCombinedSize(t *testing.T) {
 
-	create := func() mempool.Transactions { return stdmap.NewTransactions(100) }
-	pools := epochs.NewTransactionPools(create)
+	create := func(_ uint64) (mempool.Transactions, error) { return stdmap.NewTransactions(100), nil }
+	pools := epochs.NewTransactionPools(create, nil)
 
 	nEpochs := rand.Uint64() % 10
 	transactionsPerEpoch := rand.Uint64() % 10
 	expected := uint(nEpochs * transactionsPerEpoch)
 
 	for epoch := uint64(0); epoch < nEpochs; epoch++ {
-		pool := pools.ForEpoch(epoch)
+		pool, err := pools.ForEpoch(epoch)
+		require.NoError(t, err)
 		for i := 0; i < int(transactionsPerEpoch); i++ {
 			next := unittest.TransactionBodyFixture()
 			pool.Add(&next)
@@ -75,3 +82,47 @@ func TestCombinedSize(t *testing.T) {
 
 	assert.Equal(t, expected, pools.CombinedSize())
 }
+
+// removed pools are torn down and replaced by a new pool on the next access
+func TestRemove(t *testing.T) {
+
+	create := func(_ uint64) (mempool.Transactions, error) { return stdmap.NewTransactions(100), nil }
+	var removed []uint64
+	teardown := func(epoch uint64, _ mempool.Transactions) { removed = append(removed, epoch) }
+	pools := epochs.NewTransactionPools(create, teardown)
+
+	pool, err := pools.ForEpoch(1)
+	require.NoError(t, err)
+	tx := unittest.TransactionBodyFixture()
+	pool.Add(&tx)
+
+	pools.Remove(1)
+	pools.Remove(2)
+	assert.Equal(t, []uint64{1}, removed)
+	assert.Equal(t, uint(0), pools.CombinedSize())
+
+	pool, err = pools.ForEpoch(1)
+	require.NoError(t, err)
+	assert.False(t, pool.Has(tx.ID()))
+}
+
+// errors creating a pool are returned and no pool is kept for the epoch
+func TestCreateError(t *testing.T) {
+
+	fail := true
+	create := func(_ uint64) (mempool.Transactions, error) {
+		if fail {
+			return nil, fmt.Errorf("could not load pool")
+		}
+		return stdmap.NewTransactions(100), nil
+	}
+	pools := epochs.NewTransactionPools(create, nil)
+
+	_, err := pools.ForEpoch(1)
+	assert.Error(t, err)
+
+	fail = false
+	pool, err := pools.ForEpoch(1)
+	require.NoError(t, err)
+	assert.NotNil(t, pool)
+}
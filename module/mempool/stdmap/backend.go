@@ -3,11 +3,14 @@
 package stdmap
 
 import (
+	"fmt"
 	"math"
 	"sync"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/mempool"
+	"github.com/onflow/flow-go/module/metrics"
 )
 
 // Backdata implements a generic memory pool backed by a Go map.
@@ -102,8 +105,15 @@ type Backend struct {
 	sync.RWMutex
 	Backdata
 	limit             uint
+	byteLimit         uint64
+	byteSize          uint64
+	sizer             EntitySizer
+	sizes             map[flow.Identifier]uint64 // size of every entity, as measured when it was added
 	eject             EjectFunc
+	policy            EjectionPolicy
 	ejectionCallbacks []mempool.OnEjection
+	resource          string
+	metrics           module.MempoolMetrics
 }
 
 // NewBackend creates a new memory pool backend.
@@ -117,6 +127,9 @@ func NewBackend(options ...OptionFunc) *Backend {
 	for _, option := range options {
 		option(&b)
 	}
+	if b.sizer != nil {
+		b.sizes = make(map[flow.Identifier]uint64)
+	}
 	return &b
 }
 
@@ -133,6 +146,9 @@ func (b *Backend) Add(entity flow.Entity) bool {
 	b.Lock()
 	defer b.Unlock()
 	added := b.Backdata.Add(entity)
+	if added {
+		b.track(entity)
+	}
 	b.reduce()
 	return added
 }
@@ -142,15 +158,33 @@ func (b *Backend) Rem(entityID flow.Identifier) bool {
 	b.Lock()
 	defer b.Unlock()
 	removed := b.Backdata.Rem(entityID)
+	if removed {
+		b.untrack(entityID)
+	}
 	return removed
 }
 
 // Adjust will adjust the value item using the given function if the given key can be found.
 // Returns a bool which indicates whether the value was updated.
+// Adjusting an entity does not eject any entity, even if the adjusted entity exceeds the byte limit.
 func (b *Backend) Adjust(entityID flow.Identifier, f func(flow.Entity) flow.Entity) (flow.Entity, bool) {
 	b.Lock()
 	defer b.Unlock()
-	return b.Backdata.Adjust(entityID, f)
+	adjusted, ok := b.Backdata.Adjust(entityID, f)
+	if !ok {
+		return nil, false
+	}
+	if adjusted.ID() != entityID {
+		b.untrack(entityID)
+		b.track(adjusted)
+		return adjusted, true
+	}
+	// the entity keeps its place in the ejection order
+	b.resize(entityID, adjusted)
+	if b.policy != nil {
+		b.policy.Touch(entityID)
+	}
+	return adjusted, true
 }
 
 // ByID returns the given item from the pool.
@@ -158,13 +192,21 @@ func (b *Backend) ByID(entityID flow.Identifier) (flow.Entity, bool) {
 	b.RLock()
 	defer b.RUnlock()
 	entity, exists := b.Backdata.ByID(entityID)
+	if exists && b.policy != nil {
+		b.policy.Touch(entityID)
+	}
 	return entity, exists
 }
 
-// Run executes a function giving it exclusive access to the backdata
+// Run executes a function giving it exclusive access to the backdata.
+// Entities added or removed through the backdata are not measured by an entity sizer nor known to an
+// ejection policy, so backends configured with either must be modified with Add, Rem and Adjust instead.
 func (b *Backend) Run(f func(backdata map[flow.Identifier]flow.Entity) error) error {
 	b.Lock()
 	defer b.Unlock()
+	if b.policy != nil || b.sizer != nil {
+		return fmt.Errorf("cannot run on backdata of a backend tracking its entities")
+	}
 	err := f(b.Backdata.entities)
 	b.reduce()
 	return err
@@ -182,6 +224,19 @@ func (b *Backend) Limit() uint {
 	return b.limit
 }
 
+// ByteSize returns the total size of the items in the backend, as measured by its entity sizer.
+// It is always zero if the backend has no entity sizer.
+func (b *Backend) ByteSize() uint64 {
+	b.RLock()
+	defer b.RUnlock()
+	return b.byteSize
+}
+
+// ByteLimit returns the maximum total size of the items allowed in the backend, zero meaning no limit.
+func (b *Backend) ByteLimit() uint64 {
+	return b.byteLimit
+}
+
 // All returns all entities from the pool.
 func (b *Backend) All() []flow.Entity {
 	b.RLock()
@@ -193,7 +248,16 @@ func (b *Backend) All() []flow.Entity {
 func (b *Backend) Clear() {
 	b.Lock()
 	defer b.Unlock()
+	if b.policy != nil {
+		for entityID := range b.entities {
+			b.policy.Untrack(entityID)
+		}
+	}
 	b.Backdata.Clear()
+	b.byteSize = 0
+	if b.sizer != nil {
+		b.sizes = make(map[flow.Identifier]uint64)
+	}
 }
 
 // Hash will use a merkle root hash to hash all items.
@@ -210,12 +274,66 @@ func (b *Backend) RegisterEjectionCallbacks(callbacks ...mempool.OnEjection) {
 	b.ejectionCallbacks = append(b.ejectionCallbacks, callbacks...)
 }
 
+// track accounts for an entity added to the backdata.
+func (b *Backend) track(entity flow.Entity) {
+	if b.sizer != nil {
+		b.resize(entity.ID(), entity)
+	}
+	if b.policy != nil {
+		b.policy.Track(entity)
+	}
+}
+
+// untrack accounts for an entity removed from the backdata.
+func (b *Backend) untrack(entityID flow.Identifier) {
+	if b.sizer != nil {
+		b.byteSize -= b.sizes[entityID]
+		delete(b.sizes, entityID)
+	}
+	if b.policy != nil {
+		b.policy.Untrack(entityID)
+	}
+}
+
+// resize measures the size of the entity stored under the given identifier.
+func (b *Backend) resize(entityID flow.Identifier, entity flow.Entity) {
+	if b.sizer == nil {
+		return
+	}
+	size := b.sizer(entity)
+	b.byteSize = b.byteSize - b.sizes[entityID] + size
+	b.sizes[entityID] = size
+}
+
+// overflow returns the reason for ejecting entities, or an empty string if the backend is
+// within its limits.
+func (b *Backend) overflow() string {
+	if len(b.entities) > int(b.limit) {
+		return metrics.EjectionReasonEntryLimit
+	}
+	if b.byteLimit > 0 && b.byteSize > b.byteLimit && len(b.entities) > 0 {
+		return metrics.EjectionReasonByteLimit
+	}
+	return ""
+}
+
 // reduce will reduce the size of the kept entities until we are within the
 // configured memory pool size limit.
 func (b *Backend) reduce() {
 
+	// expired entities are ejected regardless of the limits
+	if expiring, ok := b.policy.(ExpiringEjectionPolicy); ok {
+		for _, entityID := range expiring.Expired() {
+			entity, ok := b.entities[entityID]
+			if !ok {
+				continue
+			}
+			b.ejectEntity(entityID, entity, metrics.EjectionReasonExpired)
+		}
+	}
+
 	// we keep reducing the cache size until we are at limit again
-	for len(b.entities) > int(b.limit) {
+	for reason := b.overflow(); reason != ""; reason = b.overflow() {
 
 		// get the key from the eject function
 		key, _ := b.eject(b.entities)
@@ -223,15 +341,26 @@ func (b *Backend) reduce() {
 		// if the key is not actually part of the map, use stupid fallback eject
 		entity, ok := b.entities[key]
 		if !ok {
-			key, _ = EjectFakeRandom(b.entities)
+			key, entity = EjectFakeRandom(b.entities)
 		}
 
-		// remove the key
-		delete(b.entities, key)
+		b.ejectEntity(key, entity, reason)
+	}
+}
 
-		// notify callback
-		for _, callback := range b.ejectionCallbacks {
-			callback(entity)
-		}
+// ejectEntity removes an entity from the backdata and notifies the ejection callbacks.
+func (b *Backend) ejectEntity(entityID flow.Identifier, entity flow.Entity, reason string) {
+
+	// remove the key
+	delete(b.entities, entityID)
+	b.untrack(entityID)
+
+	if b.metrics != nil {
+		b.metrics.MempoolEjection(b.resource, reason)
+	}
+
+	// notify callback
+	for _, callback := range b.ejectionCallbacks {
+		callback(entity)
 	}
 }
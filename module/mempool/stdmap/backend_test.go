@@ -14,6 +14,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -222,6 +224,130 @@ func TestBackend_Multiple_OnEjectionCallbacks(t *testing.T) {
 	})
 }
 
+// fakeSize is an EntitySizer measuring fake entities by their length.
+func fakeSize(entity flow.Entity) uint64 {
+	return uint64(len(entity.(fake)))
+}
+
+// TestBackend_ByteLimit verifies that the Backend keeps track of the size of its entities
+// and ejects entities as long as their total size exceeds the byte limit.
+func TestBackend_ByteLimit(t *testing.T) {
+	pool := NewBackend(WithByteLimit(10, fakeSize))
+
+	require.True(t, pool.Add(fake("AAAA")))
+	require.True(t, pool.Add(fake("BBBB")))
+	require.Equal(t, uint64(8), pool.ByteSize())
+
+	t.Run("removing an entity releases its size", func(t *testing.T) {
+		require.True(t, pool.Rem(fake("BBBB").ID()))
+		require.False(t, pool.Rem(fake("BBBB").ID()))
+		require.Equal(t, uint64(4), pool.ByteSize())
+	})
+
+	t.Run("adjusting an entity updates its size", func(t *testing.T) {
+		_, ok := pool.Adjust(fake("AAAA").ID(), func(flow.Entity) flow.Entity { return fake("CC") })
+		require.True(t, ok)
+		require.Equal(t, uint64(2), pool.ByteSize())
+	})
+
+	t.Run("entities are ejected beyond the byte limit", func(t *testing.T) {
+		require.True(t, pool.Add(fake("DDDDDDDD")))
+		require.Equal(t, uint64(10), pool.ByteSize())
+
+		pool.Add(fake("EEEE"))
+		require.LessOrEqual(t, pool.ByteSize(), uint64(10))
+
+		total := uint64(0)
+		for _, entity := range pool.All() {
+			total += fakeSize(entity)
+		}
+		require.Equal(t, total, pool.ByteSize())
+	})
+
+	t.Run("backdata cannot be modified by run", func(t *testing.T) {
+		pool.Clear()
+		require.Equal(t, uint64(0), pool.ByteSize())
+
+		called := false
+		err := pool.Run(func(backdata map[flow.Identifier]flow.Entity) error {
+			called = true
+			return nil
+		})
+		require.Error(t, err)
+		require.False(t, called)
+	})
+
+	t.Run("an entity larger than the limit does not remain", func(t *testing.T) {
+		pool.Add(fake("HHHHHHHHHHHH"))
+		require.Equal(t, uint(0), pool.Size())
+		require.Equal(t, uint64(0), pool.ByteSize())
+	})
+}
+
+// TestBackend_EjectionPolicy verifies that the Backend keeps its ejection policy informed of
+// the entities added, retrieved and removed, and ejects the entities it picks.
+func TestBackend_EjectionPolicy(t *testing.T) {
+	pool := NewBackend(WithLimit(3), WithEjectionPolicy(NewLFUEjector()))
+
+	a, b, c, d := fake("A"), fake("B"), fake("C"), fake("D")
+	pool.Add(a)
+	pool.Add(b)
+	pool.Add(c)
+
+	// c is the least frequently used entity
+	_, _ = pool.ByID(a.ID())
+	_, _ = pool.ByID(b.ID())
+	pool.Add(d)
+	require.False(t, pool.Has(c.ID()))
+
+	// d is the least frequently used entity, but it is removed
+	_, _ = pool.Adjust(a.ID(), func(entity flow.Entity) flow.Entity { return entity })
+	require.True(t, pool.Rem(d.ID()))
+	pool.Add(c)
+	pool.Add(d)
+	require.True(t, pool.Has(a.ID()))
+	require.True(t, pool.Has(b.ID()))
+	require.False(t, pool.Has(c.ID()))
+	require.True(t, pool.Has(d.ID()))
+}
+
+// TestBackend_EjectionMetrics verifies that the Backend ejects expired entities regardless of
+// its limits, and reports every ejection along with its reason.
+func TestBackend_EjectionMetrics(t *testing.T) {
+	now := time.Now()
+	ejector := NewTTLEjector(time.Minute)
+	ejector.now = func() time.Time { return now }
+
+	collector := &mock.MempoolMetrics{}
+	collector.On("MempoolEjection", "test", metrics.EjectionReasonEntryLimit).Once()
+	collector.On("MempoolEjection", "test", metrics.EjectionReasonByteLimit).Once()
+	collector.On("MempoolEjection", "test", metrics.EjectionReasonExpired).Twice()
+
+	pool := NewBackend(
+		WithLimit(3),
+		WithByteLimit(10, fakeSize),
+		WithEjectionPolicy(ejector),
+		WithEjectionMetrics("test", collector),
+	)
+
+	pool.Add(fake("A"))
+	pool.Add(fake("B"))
+	pool.Add(fake("C"))
+	pool.Add(fake("D"))
+	require.False(t, pool.Has(fake("A").ID()), "oldest entity should be ejected beyond entry limit")
+
+	require.True(t, pool.Rem(fake("C").ID()))
+	pool.Add(fake("EEEEEEEEE"))
+	require.False(t, pool.Has(fake("B").ID()), "oldest entity should be ejected beyond byte limit")
+
+	now = now.Add(2 * time.Minute)
+	pool.Add(fake("F"))
+	require.Equal(t, uint(1), pool.Size())
+	require.True(t, pool.Has(fake("F").ID()))
+
+	collector.AssertExpectations(t)
+}
+
 func addRandomEntities(t *testing.T, backend *Backend, num int) {
 	// add swarm-number of items to backend
 	wg := sync.WaitGroup{}
@@ -11,9 +11,9 @@ type ChunkDataPacks struct {
 }
 
 // NewChunkDataPacks creates a new memory pool for ChunkDataPacks.
-func NewChunkDataPacks(limit uint) (*ChunkDataPacks, error) {
+func NewChunkDataPacks(limit uint, opts ...OptionFunc) (*ChunkDataPacks, error) {
 	a := &ChunkDataPacks{
-		Backend: NewBackend(WithLimit(limit)),
+		Backend: NewBackend(append(opts, WithLimit(limit))...),
 	}
 	return a, nil
 }
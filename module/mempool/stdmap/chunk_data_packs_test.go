@@ -0,0 +1,35 @@
+package stdmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestChunkDataPacks_ByteLimit evaluates that the chunk data packs mempool, limited by the size of its
+// chunk data packs, ejects the least recently used ones once the limit is reached.
+func TestChunkDataPacks_ByteLimit(t *testing.T) {
+	cdp1 := unittest.ChunkDataPackFixture(unittest.IdentifierFixture())
+	cdp2 := unittest.ChunkDataPackFixture(unittest.IdentifierFixture())
+	cdp3 := unittest.ChunkDataPackFixture(unittest.IdentifierFixture())
+
+	limit := ChunkDataPackSize(cdp1) + ChunkDataPackSize(cdp2)
+	pool, err := NewChunkDataPacks(10,
+		WithByteLimit(limit, ChunkDataPackSize),
+		WithEjectionPolicy(NewLRUEjectionPolicy()),
+	)
+	require.NoError(t, err)
+
+	require.True(t, pool.Add(cdp1))
+	require.True(t, pool.Add(cdp2))
+	require.Equal(t, limit, pool.ByteSize())
+
+	require.True(t, pool.Add(cdp3))
+	assert.LessOrEqual(t, pool.ByteSize(), limit)
+	assert.False(t, pool.Has(cdp1.ChunkID))
+	assert.True(t, pool.Has(cdp2.ChunkID))
+	assert.True(t, pool.Has(cdp3.ChunkID))
+}
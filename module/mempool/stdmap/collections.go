@@ -12,9 +12,9 @@ type Collections struct {
 }
 
 // NewCollections creates a new memory pool for collection.
-func NewCollections(limit uint) (*Collections, error) {
+func NewCollections(limit uint, opts ...OptionFunc) (*Collections, error) {
 	c := &Collections{
-		Backend: NewBackend(WithLimit(limit)),
+		Backend: NewBackend(append(opts, WithLimit(limit))...),
 	}
 	return c, nil
 }
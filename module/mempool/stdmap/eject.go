@@ -3,9 +3,11 @@
 package stdmap
 
 import (
+	"bytes"
 	"math"
 	"math/rand"
 	"sync"
+	"time"
 
 	"github.com/onflow/flow-go/model/flow"
 )
@@ -123,3 +125,295 @@ func (q *LRUEjector) Eject(entities map[flow.Identifier]flow.Entity) (flow.Ident
 
 	return oldestID, oldestEntity
 }
+
+// lruEjectionPolicy lets the backend keep an LRUEjector informed of the entities of the memory pool,
+// so that it can be selected as the ejection policy of any memory pool.
+type lruEjectionPolicy struct {
+	*LRUEjector
+}
+
+// NewLRUEjectionPolicy returns an ejection policy evicting the oldest entity of the memory pool.
+func NewLRUEjectionPolicy() EjectionPolicy {
+	return lruEjectionPolicy{LRUEjector: NewLRUEjector()}
+}
+
+// Track starts tracking the age of an entity.
+func (p lruEjectionPolicy) Track(entity flow.Entity) {
+	p.LRUEjector.Track(entity.ID())
+}
+
+// Touch does nothing, the age of an entity does not change when it is retrieved.
+func (p lruEjectionPolicy) Touch(flow.Identifier) {}
+
+// EjectionPolicy is a stateful eject function. The backend keeps an ejection policy informed of the
+// entities added to, accessed in and removed from the memory pool, so that it can pick the entity to
+// evict upon overflow without the memory pool having to track entities itself.
+type EjectionPolicy interface {
+	// Track is called when an entity is added to the memory pool.
+	Track(entity flow.Entity)
+	// Touch is called when an entity of the memory pool is retrieved or adjusted.
+	Touch(entityID flow.Identifier)
+	// Untrack is called when an entity is removed from the memory pool, including upon ejection.
+	Untrack(entityID flow.Identifier)
+	// Eject picks the entity to evict from the given entities.
+	Eject(entities map[flow.Identifier]flow.Entity) (flow.Identifier, flow.Entity)
+}
+
+// ExpiringEjectionPolicy is an ejection policy which also evicts entities once they expire,
+// regardless of the limits of the memory pool.
+type ExpiringEjectionPolicy interface {
+	EjectionPolicy
+	// Expired returns the identifiers of the tracked entities which have expired.
+	Expired() []flow.Identifier
+}
+
+// LFUEjector ejects the least frequently used entity, i.e. the entity which has been retrieved
+// the fewest times since it was added. Among equally used entities, the oldest one is ejected.
+type LFUEjector struct {
+	sync.Mutex
+	table  map[flow.Identifier]*lfuEntry // keeps usage of entities it tracks
+	seqNum uint64                        // keeps the most recent sequence number
+}
+
+type lfuEntry struct {
+	hits   uint64
+	seqNum uint64
+}
+
+func NewLFUEjector() *LFUEjector {
+	return &LFUEjector{
+		table:  make(map[flow.Identifier]*lfuEntry),
+		seqNum: 0,
+	}
+}
+
+// Track starts tracking the usage of an entity.
+func (q *LFUEjector) Track(entity flow.Entity) {
+	q.Lock()
+	defer q.Unlock()
+
+	entityID := entity.ID()
+	if _, ok := q.table[entityID]; ok {
+		// skips adding duplicate item
+		return
+	}
+
+	q.table[entityID] = &lfuEntry{seqNum: q.seqNum}
+	q.seqNum++
+}
+
+// Touch counts a use of the entity.
+func (q *LFUEjector) Touch(entityID flow.Identifier) {
+	q.Lock()
+	defer q.Unlock()
+
+	if entry, ok := q.table[entityID]; ok {
+		entry.hits++
+	}
+}
+
+// Untrack stops tracking the usage of an entity.
+func (q *LFUEjector) Untrack(entityID flow.Identifier) {
+	q.Lock()
+	defer q.Unlock()
+
+	delete(q.table, entityID)
+}
+
+// Eject implements EjectFunc for LFUEjector. Like for the LRUEjector, ejection is O(n).
+func (q *LFUEjector) Eject(entities map[flow.Identifier]flow.Entity) (flow.Identifier, flow.Entity) {
+	q.Lock()
+	defer q.Unlock()
+
+	var least *lfuEntry
+	var leastID flow.Identifier
+	for id := range entities {
+		entry, ok := q.table[id]
+		if !ok {
+			continue
+		}
+		if least == nil || entry.hits < least.hits || (entry.hits == least.hits && entry.seqNum < least.seqNum) {
+			least = entry
+			leastID = id
+		}
+	}
+
+	leastEntity, ok := entities[leastID]
+	if least == nil || !ok {
+		leastID, leastEntity = EjectTrueRandom(entities)
+	}
+
+	delete(q.table, leastID)
+
+	return leastID, leastEntity
+}
+
+// TTLEjector ejects the oldest entity, and expires entities once they have been in the memory pool
+// for longer than a time-to-live. Retrieving an entity does not extend its life.
+type TTLEjector struct {
+	sync.Mutex
+	ttl    time.Duration
+	now    func() time.Time
+	table  map[flow.Identifier]uint64 // keeps sequence number of entities it tracks
+	queue  []ttlEntry                 // entities in the order they have been added, possibly untracked since
+	seqNum uint64                     // keeps the most recent sequence number
+}
+
+type ttlEntry struct {
+	entityID flow.Identifier
+	seqNum   uint64
+	added    time.Time
+}
+
+func NewTTLEjector(ttl time.Duration) *TTLEjector {
+	return &TTLEjector{
+		ttl:    ttl,
+		now:    time.Now,
+		table:  make(map[flow.Identifier]uint64),
+		seqNum: 0,
+	}
+}
+
+// Track records the time at which an entity has been added.
+func (q *TTLEjector) Track(entity flow.Entity) {
+	q.Lock()
+	defer q.Unlock()
+
+	entityID := entity.ID()
+	if _, ok := q.table[entityID]; ok {
+		// skips adding duplicate item
+		return
+	}
+
+	q.table[entityID] = q.seqNum
+	q.queue = append(q.queue, ttlEntry{entityID: entityID, seqNum: q.seqNum, added: q.now()})
+	q.seqNum++
+}
+
+// Touch does nothing, as the age of entities does not depend on their use.
+func (q *TTLEjector) Touch(flow.Identifier) {}
+
+// Untrack stops tracking an entity.
+func (q *TTLEjector) Untrack(entityID flow.Identifier) {
+	q.Lock()
+	defer q.Unlock()
+
+	delete(q.table, entityID)
+}
+
+// Expired returns the entities which have been added for longer than the time-to-live.
+// Expired entities are untracked. It takes a time proportional to the number of expired entities.
+func (q *TTLEjector) Expired() []flow.Identifier {
+	q.Lock()
+	defer q.Unlock()
+
+	var expired []flow.Identifier
+	now := q.now()
+	for q.prune() && now.Sub(q.queue[0].added) > q.ttl {
+		entityID := q.queue[0].entityID
+		expired = append(expired, entityID)
+		delete(q.table, entityID)
+		q.queue = q.queue[1:]
+	}
+	return expired
+}
+
+// prune drops untracked entities from the front of the queue, it returns false if the queue is empty.
+func (q *TTLEjector) prune() bool {
+	for len(q.queue) > 0 {
+		head := q.queue[0]
+		if seqNum, ok := q.table[head.entityID]; ok && seqNum == head.seqNum {
+			return true
+		}
+		q.queue = q.queue[1:]
+	}
+	return false
+}
+
+// Eject implements EjectFunc for TTLEjector. It ejects the oldest tracked entity of the pool.
+func (q *TTLEjector) Eject(entities map[flow.Identifier]flow.Entity) (flow.Identifier, flow.Entity) {
+	q.Lock()
+	defer q.Unlock()
+
+	for q.prune() {
+		oldestID := q.queue[0].entityID
+		delete(q.table, oldestID)
+		q.queue = q.queue[1:]
+
+		if oldestEntity, ok := entities[oldestID]; ok {
+			return oldestID, oldestEntity
+		}
+	}
+
+	return EjectTrueRandom(entities)
+}
+
+// HeightFunc returns the height of the block an entity refers to.
+type HeightFunc func(entity flow.Entity) uint64
+
+// HeightEjector ejects the entity referring to the lowest block height, as entities of old blocks
+// are the least likely to still be needed. Among entities of the same height, the entity with the
+// lowest identifier is ejected, so that ejection is deterministic.
+type HeightEjector struct {
+	sync.Mutex
+	height HeightFunc
+	table  map[flow.Identifier]uint64 // keeps height of entities it tracks
+}
+
+func NewHeightEjector(height HeightFunc) *HeightEjector {
+	return &HeightEjector{
+		height: height,
+		table:  make(map[flow.Identifier]uint64),
+	}
+}
+
+// Track records the height of an entity.
+func (q *HeightEjector) Track(entity flow.Entity) {
+	height := q.height(entity)
+
+	q.Lock()
+	defer q.Unlock()
+
+	q.table[entity.ID()] = height
+}
+
+// Touch does nothing, as the height of entities does not depend on their use.
+func (q *HeightEjector) Touch(flow.Identifier) {}
+
+// Untrack stops tracking an entity.
+func (q *HeightEjector) Untrack(entityID flow.Identifier) {
+	q.Lock()
+	defer q.Unlock()
+
+	delete(q.table, entityID)
+}
+
+// Eject implements EjectFunc for HeightEjector. Like for the LRUEjector, ejection is O(n).
+func (q *HeightEjector) Eject(entities map[flow.Identifier]flow.Entity) (flow.Identifier, flow.Entity) {
+	q.Lock()
+	defer q.Unlock()
+
+	found := false
+	lowest := uint64(math.MaxUint64)
+	var lowestID flow.Identifier
+	for id := range entities {
+		height, ok := q.table[id]
+		if !ok {
+			continue
+		}
+		if !found || height < lowest || (height == lowest && bytes.Compare(id[:], lowestID[:]) < 0) {
+			found = true
+			lowest = height
+			lowestID = id
+		}
+	}
+
+	lowestEntity, ok := entities[lowestID]
+	if !found || !ok {
+		lowestID, lowestEntity = EjectTrueRandom(entities)
+	}
+
+	delete(q.table, lowestID)
+
+	return lowestID, lowestEntity
+}
@@ -3,6 +3,7 @@ package stdmap
 import (
 	crand "crypto/rand"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -222,6 +223,106 @@ func TestLRUEjector_EjectAll(t *testing.T) {
 	}
 }
 
+// TestLFUEjector_Eject evaluates that the LFU ejector ejects the least retrieved item, and the
+// oldest one among equally retrieved items.
+func TestLFUEjector_Eject(t *testing.T) {
+	ejector := NewLFUEjector()
+
+	items := []fake{fake("A"), fake("B"), fake("C"), fake("D")}
+	entities := make(map[flow.Identifier]flow.Entity)
+	for _, item := range items {
+		ejector.Track(item)
+		entities[item.ID()] = item
+	}
+
+	ejector.Touch(items[0].ID())
+	ejector.Touch(items[0].ID())
+	ejector.Touch(items[1].ID())
+	ejector.Touch(items[3].ID())
+
+	// C has never been retrieved
+	id, _ := ejector.Eject(entities)
+	require.Equal(t, items[2].ID(), id)
+	delete(entities, id)
+
+	// B and D have been retrieved once, B is older
+	id, _ = ejector.Eject(entities)
+	require.Equal(t, items[1].ID(), id)
+	delete(entities, id)
+
+	// untracked items are not ejected
+	ejector.Untrack(items[3].ID())
+	id, _ = ejector.Eject(entities)
+	require.Equal(t, items[0].ID(), id)
+	require.Len(t, ejector.table, 0)
+}
+
+// TestTTLEjector_Expired evaluates that the TTL ejector expires the items tracked for longer than
+// its time-to-live, and ejects the oldest item otherwise.
+func TestTTLEjector_Expired(t *testing.T) {
+	start := time.Now()
+	now := start
+	ejector := NewTTLEjector(150 * time.Second)
+	ejector.now = func() time.Time { return now }
+
+	// tracks one item per minute
+	items := []fake{fake("A"), fake("B"), fake("C"), fake("D")}
+	entities := make(map[flow.Identifier]flow.Entity)
+	for i, item := range items {
+		now = start.Add(time.Duration(i) * time.Minute)
+		ejector.Track(item)
+		entities[item.ID()] = item
+	}
+
+	// untracked items neither expire nor are ejected
+	ejector.Untrack(items[0].ID())
+	require.Empty(t, ejector.Expired())
+
+	// B has been tracked for 151 seconds
+	now = now.Add(31 * time.Second)
+	require.Equal(t, []flow.Identifier{items[1].ID()}, ejector.Expired())
+
+	// tracking an item again does not refresh it
+	ejector.Track(items[2])
+	now = now.Add(time.Minute)
+	require.Equal(t, []flow.Identifier{items[2].ID()}, ejector.Expired())
+
+	delete(entities, items[1].ID())
+	delete(entities, items[2].ID())
+	id, _ := ejector.Eject(entities)
+	require.Equal(t, items[3].ID(), id)
+	require.Len(t, ejector.table, 0)
+}
+
+// TestHeightEjector_Eject evaluates that the height ejector ejects the items of the lowest height,
+// in order of their identifiers.
+func TestHeightEjector_Eject(t *testing.T) {
+	heights := map[flow.Identifier]uint64{
+		fake("A").ID(): 10,
+		fake("B").ID(): 5,
+		fake("C").ID(): 10,
+		fake("D").ID(): 5,
+	}
+	ejector := NewHeightEjector(func(entity flow.Entity) uint64 {
+		return heights[entity.ID()]
+	})
+
+	entities := make(map[flow.Identifier]flow.Entity)
+	for _, item := range []fake{fake("A"), fake("B"), fake("C"), fake("D")} {
+		ejector.Track(item)
+		entities[item.ID()] = item
+	}
+
+	var ejected []uint64
+	for len(entities) > 0 {
+		id, _ := ejector.Eject(entities)
+		ejected = append(ejected, heights[id])
+		delete(entities, id)
+	}
+	require.Equal(t, []uint64{5, 5, 10, 10}, ejected)
+	require.Len(t, ejector.table, 0)
+}
+
 // MockEntity is a mocked entity type used in internal testing of ejectors.
 type MockEntity struct{}
 
@@ -2,6 +2,13 @@
 
 package stdmap
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go/module"
+)
+
 // OptionFunc is a function that can be provided to the backend on creation in
 // order to set a certain custom option.
 type OptionFunc func(*Backend)
@@ -16,9 +23,74 @@ func WithLimit(limit uint) OptionFunc {
 
 // WithEject can be provided to the backend on creation in order to set a custom
 // eject function to pick the entity to be evicted upon overflow, as well as
-// hooking into it for additional cleanup work.
+// hooking into it for additional cleanup work. It replaces any ejection policy.
 func WithEject(eject EjectFunc) OptionFunc {
 	return func(be *Backend) {
 		be.eject = eject
+		be.policy = nil
+	}
+}
+
+// WithByteLimit can be provided to the backend on creation in order to limit the
+// total size of the entities in the memory pool, as measured by the given sizer.
+// A limit of zero only measures the size of the entities without limiting it.
+func WithByteLimit(limit uint64, sizer EntitySizer) OptionFunc {
+	return func(be *Backend) {
+		be.byteLimit = limit
+		be.sizer = sizer
+	}
+}
+
+// WithEjectionPolicy can be provided to the backend on creation in order to pick
+// the entity to be evicted upon overflow using an ejection policy, which the backend
+// keeps informed of the entities added to, accessed in and removed from the pool.
+func WithEjectionPolicy(policy EjectionPolicy) OptionFunc {
+	return func(be *Backend) {
+		be.eject = policy.Eject
+		be.policy = policy
+	}
+}
+
+// WithEjectionMetrics can be provided to the backend on creation in order to report
+// every ejected entity, along with the reason of its ejection, under the given resource.
+func WithEjectionMetrics(resource string, metrics module.MempoolMetrics) OptionFunc {
+	return func(be *Backend) {
+		be.resource = resource
+		be.metrics = metrics
+	}
+}
+
+// Names of the ejection policies which can be selected for a memory pool.
+const (
+	EjectionPolicyRandom = "random"
+	EjectionPolicyLRU    = "lru"
+	EjectionPolicyLFU    = "lfu"
+	EjectionPolicyTTL    = "ttl"
+	EjectionPolicyHeight = "height"
+)
+
+// WithNamedEjectionPolicy returns the option setting the ejection policy of the given name, so that the
+// policy of a memory pool can be selected by configuration. The time-to-live is only used by the TTL policy,
+// and the height function by the height policy, which is not available for memory pools without one.
+func WithNamedEjectionPolicy(name string, ttl time.Duration, height HeightFunc) (OptionFunc, error) {
+	switch name {
+	case EjectionPolicyRandom:
+		return WithEject(EjectTrueRandom), nil
+	case EjectionPolicyLRU:
+		return WithEjectionPolicy(NewLRUEjectionPolicy()), nil
+	case EjectionPolicyLFU:
+		return WithEjectionPolicy(NewLFUEjector()), nil
+	case EjectionPolicyTTL:
+		if ttl <= 0 {
+			return nil, fmt.Errorf("ejection policy %s requires a positive time-to-live", name)
+		}
+		return WithEjectionPolicy(NewTTLEjector(ttl)), nil
+	case EjectionPolicyHeight:
+		if height == nil {
+			return nil, fmt.Errorf("ejection policy %s is not available for this memory pool", name)
+		}
+		return WithEjectionPolicy(NewHeightEjector(height)), nil
+	default:
+		return nil, fmt.Errorf("unknown ejection policy: %s", name)
 	}
 }
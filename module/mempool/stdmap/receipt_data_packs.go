@@ -8,20 +8,19 @@ import (
 )
 
 // ReceiptDataPacks implements the ReceiptDataPack mempool.
-// ReceiptDataPacks has an LRU ejector, i.e., it evicts the oldest entity if
+// ReceiptDataPacks has an LRU ejector by default, i.e., it evicts the oldest entity if
 // it gets full.
 type ReceiptDataPacks struct {
 	*Backend
-	ejector *LRUEjector
 }
 
 // NewReceipts creates a new memory pool for execution receipts.
-func NewReceiptDataPacks(limit uint) (*ReceiptDataPacks, error) {
+// The given options can replace the LRU ejector with another ejection policy.
+func NewReceiptDataPacks(limit uint, opts ...OptionFunc) (*ReceiptDataPacks, error) {
 	// create the receipts memory pool with the lookup maps
-	qe := NewLRUEjector()
+	opts = append([]OptionFunc{WithEjectionPolicy(NewLRUEjectionPolicy())}, opts...)
 	r := &ReceiptDataPacks{
-		ejector: qe,
-		Backend: NewBackend(WithLimit(limit), WithEject(qe.Eject)),
+		Backend: NewBackend(append(opts, WithLimit(limit))...),
 	}
 
 	return r, nil
@@ -30,13 +29,7 @@ func NewReceiptDataPacks(limit uint) (*ReceiptDataPacks, error) {
 // Add will add the given ReceiptDataPack to the memory pool. It will return
 // false if it was already in the mempool.
 func (r *ReceiptDataPacks) Add(rdp *verification.ReceiptDataPack) bool {
-	ok := r.Backend.Add(rdp)
-	if ok {
-		// adds successfully stored receipt data pack id to the ejector
-		// The ejector externally keeps track of ids added to the mempool.
-		r.ejector.Track(rdp.ID())
-	}
-	return ok
+	return r.Backend.Add(rdp)
 }
 
 // Get returns the ReceiptDataPack and true, if the ReceiptDataPack is in the
@@ -57,13 +50,7 @@ func (r *ReceiptDataPacks) Get(rdpID flow.Identifier) (*verification.ReceiptData
 
 // Rem removes a ReceiptDataPack by ID.
 func (r *ReceiptDataPacks) Rem(rdpID flow.Identifier) bool {
-	ok := r.Backend.Rem(rdpID)
-	if ok {
-		// untracks the successfully removed entity from the ejector
-		r.ejector.Untrack(rdpID)
-	}
-
-	return ok
+	return r.Backend.Rem(rdpID)
 }
 
 // All will return all ReceiptDataPacks in the mempool.
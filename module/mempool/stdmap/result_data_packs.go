@@ -6,44 +6,30 @@ import (
 )
 
 // ResultDataPacks implements the ResultDataPacks mempool interface.
-// ResultDataPacks has an LRU ejector, i.e., it evicts the oldest entity if
+// ResultDataPacks has an LRU ejector by default, i.e., it evicts the oldest entity if
 // it gets full.
 type ResultDataPacks struct {
 	*Backend
-	ejector *LRUEjector
 }
 
 // NewResultDataPacks creates a new mempool for execution results.
-// The mempool has a FIFO ejector.
-func NewResultDataPacks(limit uint) *ResultDataPacks {
-	qe := NewLRUEjector()
+// The mempool has a FIFO ejector, which the given options can replace with another ejection policy.
+func NewResultDataPacks(limit uint, opts ...OptionFunc) *ResultDataPacks {
+	opts = append([]OptionFunc{WithEjectionPolicy(NewLRUEjectionPolicy())}, opts...)
 	return &ResultDataPacks{
-		ejector: qe,
-		Backend: NewBackend(WithLimit(limit), WithEject(qe.Eject)),
+		Backend: NewBackend(append(opts, WithLimit(limit))...),
 	}
 }
 
 // Add will add the given ResultDataPacks to the mempool. It will return
 // false if it was already in the mempool.
 func (r *ResultDataPacks) Add(rdp *verification.ResultDataPack) bool {
-	ok := r.Backend.Add(rdp)
-	if ok {
-		// adds successfully stored result data pack id to the ejector
-		// The ejector externally keeps track of ids added to the mempool.
-		r.ejector.Track(rdp.ID())
-	}
-	return ok
+	return r.Backend.Add(rdp)
 }
 
 // Rem removes a ResultDataPacks by identifier.
 func (r *ResultDataPacks) Rem(rdpID flow.Identifier) bool {
-	ok := r.Backend.Rem(rdpID)
-	if ok {
-		// untracks the successfully removed entity from the ejector
-		r.ejector.Untrack(rdpID)
-	}
-
-	return ok
+	return r.Backend.Rem(rdpID)
 }
 
 // Has returns true if a ResultDataPacks with the specified identifier exists.
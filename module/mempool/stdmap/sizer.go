@@ -0,0 +1,46 @@
+package stdmap
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EntitySizer returns the approximate number of bytes held in memory by an entity. It is used to limit
+// memory pools by the total size of their entities rather than only by their number. Sizers are meant
+// to be cheap, they only account for the variable-length data of entities on top of a fixed overhead.
+type EntitySizer func(entity flow.Entity) uint64
+
+// entityOverhead approximates the memory held by an entity besides its variable-length data.
+const entityOverhead = 128
+
+// ChunkDataPackSize is the EntitySizer of chunk data packs.
+func ChunkDataPackSize(entity flow.Entity) uint64 {
+	cdp := entity.(*flow.ChunkDataPack)
+	return entityOverhead + uint64(len(cdp.Proof)) + uint64(len(cdp.StartState))
+}
+
+// CollectionSize is the EntitySizer of collections.
+func CollectionSize(entity flow.Entity) uint64 {
+	collection := entity.(*flow.Collection)
+	size := uint64(entityOverhead)
+	for _, tx := range collection.Transactions {
+		size += TransactionBodySize(tx)
+	}
+	return size
+}
+
+// TransactionBodySize is the EntitySizer of transaction bodies.
+func TransactionBodySize(entity flow.Entity) uint64 {
+	tx := entity.(*flow.TransactionBody)
+	size := uint64(entityOverhead) + uint64(len(tx.Script))
+	for _, argument := range tx.Arguments {
+		size += uint64(len(argument))
+	}
+	size += uint64(len(tx.Authorizers) * flow.AddressLength)
+	for _, signature := range tx.PayloadSignatures {
+		size += uint64(len(signature.Signature)) + flow.AddressLength
+	}
+	for _, signature := range tx.EnvelopeSignatures {
+		size += uint64(len(signature.Signature)) + flow.AddressLength
+	}
+	return size
+}
@@ -16,9 +16,9 @@ type Times struct {
 }
 
 // NewTimes creates a new memory pool for times
-func NewTimes(limit uint) (*Times, error) {
+func NewTimes(limit uint, opts ...OptionFunc) (*Times, error) {
 	t := &Times{
-		Backend: NewBackend(WithLimit(limit)),
+		Backend: NewBackend(append(opts, WithLimit(limit))...),
 	}
 
 	return t, nil
@@ -13,9 +13,9 @@ type TransactionTimings struct {
 }
 
 // NewTransactionTimings creates a new memory pool for transaction timings
-func NewTransactionTimings(limit uint) (*TransactionTimings, error) {
+func NewTransactionTimings(limit uint, opts ...OptionFunc) (*TransactionTimings, error) {
 	t := &TransactionTimings{
-		Backend: NewBackend(WithLimit(limit)),
+		Backend: NewBackend(append(opts, WithLimit(limit))...),
 	}
 
 	return t, nil
@@ -62,6 +62,13 @@ type CacheMetrics interface {
 type MempoolMetrics interface {
 	MempoolEntries(resource string, entries uint)
 	Register(resource string, entriesFunc metrics.EntriesFunc) error
+
+	// MempoolBytes reports the total size in bytes of the entries of a mempool.
+	MempoolBytes(resource string, bytes uint64)
+	// RegisterBytes registers a function reporting the total size of the entries of a mempool.
+	RegisterBytes(resource string, bytesFunc metrics.BytesFunc) error
+	// MempoolEjection reports an entry ejected from a mempool for the given reason.
+	MempoolEjection(resource string, reason string)
 }
 
 type HotstuffMetrics interface {
@@ -89,6 +96,17 @@ type HotstuffMetrics interface {
 	// SetTimeout sets the current timeout duration
 	SetTimeout(duration time.Duration)
 
+	// SetBlockRateDelay sets the current delay for broadcasting proposals, as
+	// adjusted by the block rate controller.
+	SetBlockRateDelay(duration time.Duration)
+
+	// SetViewDuration sets the smoothed duration of the recent views.
+	SetViewDuration(duration time.Duration)
+
+	// SetTargetViewDuration sets the view duration the block rate controller
+	// is currently aiming for.
+	SetTargetViewDuration(duration time.Duration)
+
 	// CommitteeProcessingDuration measures the time which the HotStuff's core logic
 	// spends in the hotstuff.Committee component, i.e. the time determining consensus
 	// committee relations.
@@ -114,6 +132,10 @@ type CollectionMetrics interface {
 	// a tx->col span for the transaction.
 	TransactionIngested(txID flow.Identifier)
 
+	// TransactionDropped is called when a transaction is dropped by the ingest
+	// engine before validation, for example when it exceeds a quota.
+	TransactionDropped(reason string)
+
 	// ClusterBlockProposed is called when a new collection is proposed by us or
 	// any other node in the cluster.
 	ClusterBlockProposed(block *cluster.Block)
@@ -239,6 +261,21 @@ type LedgerMetrics interface {
 
 	// DiskSize records the amount of disk space used by the storage (in bytes)
 	DiskSize(uint64)
+
+	// NodeCacheHit increases a counter of paged out trie nodes found in the node cache
+	NodeCacheHit()
+
+	// NodeCacheMiss increases a counter of paged out trie nodes that had to be loaded from storage
+	NodeCacheMiss()
+
+	// NodeLoadDuration records the time it took to load a paged out trie node from storage
+	NodeLoadDuration(duration time.Duration)
+
+	// NodesPagedOut accumulates the number of trie nodes written to storage when paging out tries
+	NodesPagedOut(number uint64)
+
+	// NodesReleased accumulates the number of trie nodes deleted from storage when releasing tries
+	NodesReleased(number uint64)
 }
 
 type RuntimeMetrics interface {
@@ -286,6 +323,10 @@ type ExecutionMetrics interface {
 	// ExecutionTotalExecutedTransactions adds num to the total number of executed transactions
 	ExecutionTotalExecutedTransactions(numExecuted int)
 
+	// ExecutionTransactionsReExecuted adds num to the total number of transactions which have been
+	// re-executed because they conflicted with earlier transactions during parallel execution
+	ExecutionTransactionsReExecuted(numReExecuted int)
+
 	// ExecutionCollectionRequestSent reports when a request for a collection is sent to a collection node
 	ExecutionCollectionRequestSent()
 
@@ -315,6 +356,16 @@ type TransactionMetrics interface {
 	TransactionSubmissionFailed()
 }
 
+type ScriptMetrics interface {
+	// ScriptResultDivergent is called when the result of a script executed by the given execution node differs from
+	// the majority result of the execution nodes the script was cross-checked with
+	ScriptResultDivergent(executorID flow.Identifier)
+
+	// ScriptResultUndecided is called when the execution nodes a script was cross-checked with have no majority result,
+	// or the majority result is not confirmed by enough nodes
+	ScriptResultUndecided()
+}
+
 type PingMetrics interface {
 	// NodeReachable tracks the node availability of the node and reports it as 1 if the node was successfully pinged, 0
 	// otherwise. The nodeInfo provides additional information about the node such as the name of the node operator
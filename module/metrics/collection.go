@@ -23,6 +23,7 @@ const (
 type CollectionCollector struct {
 	tracer               *trace.OpenTracer
 	transactionsIngested prometheus.Counter       // tracks the number of ingested transactions
+	transactionsDropped  *prometheus.CounterVec   // tracks the number of transactions dropped on ingestion
 	finalizedHeight      *prometheus.GaugeVec     // tracks the finalized height
 	proposals            *prometheus.HistogramVec // tracks the number/size of PROPOSED collections
 	guarantees           *prometheus.HistogramVec // counts the number/size of FINALIZED collections
@@ -39,6 +40,12 @@ func NewCollectionCollector(tracer *trace.OpenTracer) *CollectionCollector {
 			Help:      "count of transactions ingested by this node",
 		}),
 
+		transactionsDropped: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespaceCollection,
+			Name:      "dropped_transactions_total",
+			Help:      "count of transactions dropped on ingestion by this node, by reason",
+		}, []string{LabelReason}),
+
 		finalizedHeight: promauto.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: namespaceCollection,
 			Subsystem: subsystemProposal,
@@ -73,6 +80,12 @@ func (cc *CollectionCollector) TransactionIngested(txID flow.Identifier) {
 	cc.tracer.StartSpan(txID, spanTransactionToCollection)
 }
 
+// TransactionDropped increments the count of transactions dropped on
+// ingestion for the given reason.
+func (cc *CollectionCollector) TransactionDropped(reason string) {
+	cc.transactionsDropped.With(prometheus.Labels{LabelReason: reason}).Inc()
+}
+
 // ClusterBlockProposed tracks the size and number of proposals, as well as
 // starting the collection->guarantee span.
 func (cc *CollectionCollector) ClusterBlockProposed(block *cluster.Block) {
@@ -22,6 +22,7 @@ type ExecutionCollector struct {
 	gasUsedPerBlock                  prometheus.Histogram
 	stateReadsPerBlock               prometheus.Histogram
 	totalExecutedTransactionsCounter prometheus.Counter
+	totalReExecutedTransactions      prometheus.Counter
 	lastExecutedBlockHeightGauge     prometheus.Gauge
 	stateStorageDiskTotal            prometheus.Gauge
 	storageStateCommitment           prometheus.Gauge
@@ -41,6 +42,11 @@ type ExecutionCollector struct {
 	readValuesSize                   prometheus.Gauge
 	readDuration                     prometheus.Histogram
 	readDurationPerValue             prometheus.Histogram
+	nodeCacheHits                    prometheus.Counter
+	nodeCacheMisses                  prometheus.Counter
+	nodeLoadDuration                 prometheus.Histogram
+	nodesPagedOut                    prometheus.Counter
+	nodesReleased                    prometheus.Counter
 	collectionRequestSent            prometheus.Counter
 	collectionRequestRetried         prometheus.Counter
 	transactionParseTime             prometheus.Histogram
@@ -169,6 +175,42 @@ func NewExecutionCollector(tracer *trace.OpenTracer, registerer prometheus.Regis
 		Buckets:   []float64{0.05, 0.2, 0.5, 1, 2, 5},
 	})
 
+	nodeCacheHits := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespaceExecution,
+		Subsystem: subsystemMTrie,
+		Name:      "node_cache_hits_total",
+		Help:      "number of paged out nodes found in the node cache",
+	})
+
+	nodeCacheMisses := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespaceExecution,
+		Subsystem: subsystemMTrie,
+		Name:      "node_cache_misses_total",
+		Help:      "number of paged out nodes loaded from storage",
+	})
+
+	nodeLoadDuration := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespaceExecution,
+		Subsystem: subsystemMTrie,
+		Name:      "node_load_duration",
+		Help:      "duration of loading a paged out node from storage",
+		Buckets:   []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05},
+	})
+
+	nodesPagedOut := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespaceExecution,
+		Subsystem: subsystemMTrie,
+		Name:      "nodes_paged_out_total",
+		Help:      "number of nodes written to storage when paging out tries",
+	})
+
+	nodesReleased := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespaceExecution,
+		Subsystem: subsystemMTrie,
+		Name:      "nodes_released_total",
+		Help:      "number of nodes deleted from storage when releasing tries",
+	})
+
 	collectionRequestsSent := prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: namespaceExecution,
 		Subsystem: subsystemIngestion,
@@ -227,6 +269,11 @@ func NewExecutionCollector(tracer *trace.OpenTracer, registerer prometheus.Regis
 	registerer.MustRegister(readValuesSize)
 	registerer.MustRegister(readDuration)
 	registerer.MustRegister(readDurationPerValue)
+	registerer.MustRegister(nodeCacheHits)
+	registerer.MustRegister(nodeCacheMisses)
+	registerer.MustRegister(nodeLoadDuration)
+	registerer.MustRegister(nodesPagedOut)
+	registerer.MustRegister(nodesReleased)
 	registerer.MustRegister(collectionRequestsSent)
 	registerer.MustRegister(collectionRequestsRetries)
 	registerer.MustRegister(transactionParseTime)
@@ -253,6 +300,11 @@ func NewExecutionCollector(tracer *trace.OpenTracer, registerer prometheus.Regis
 		readValuesSize:             readValuesSize,
 		readDuration:               readDuration,
 		readDurationPerValue:       readDurationPerValue,
+		nodeCacheHits:              nodeCacheHits,
+		nodeCacheMisses:            nodeCacheMisses,
+		nodeLoadDuration:           nodeLoadDuration,
+		nodesPagedOut:              nodesPagedOut,
+		nodesReleased:              nodesReleased,
 		collectionRequestSent:      collectionRequestsSent,
 		collectionRequestRetried:   collectionRequestsRetries,
 		transactionParseTime:       transactionParseTime,
@@ -283,6 +335,13 @@ func NewExecutionCollector(tracer *trace.OpenTracer, registerer prometheus.Regis
 			Help:      "the total number of transactions that have been executed",
 		}),
 
+		totalReExecutedTransactions: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Name:      "total_re_executed_transactions",
+			Help:      "the total number of transactions that have been re-executed due to conflicts during parallel execution",
+		}),
+
 		lastExecutedBlockHeightGauge: promauto.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespaceExecution,
 			Subsystem: subsystemRuntime,
@@ -364,6 +423,11 @@ func (ec *ExecutionCollector) ExecutionTotalExecutedTransactions(numberOfTx int)
 	ec.totalExecutedTransactionsCounter.Add(float64(numberOfTx))
 }
 
+// ExecutionTransactionsReExecuted reports transactions re-executed due to conflicts during parallel execution
+func (ec *ExecutionCollector) ExecutionTransactionsReExecuted(numReExecuted int) {
+	ec.totalReExecutedTransactions.Add(float64(numReExecuted))
+}
+
 // ForestApproxMemorySize records approximate memory usage of forest (all in-memory trees)
 func (ec *ExecutionCollector) ForestApproxMemorySize(bytes uint64) {
 	ec.forestApproxMemorySize.Set(float64(bytes))
@@ -444,6 +508,31 @@ func (ec *ExecutionCollector) ReadDurationPerItem(duration time.Duration) {
 	ec.readDurationPerValue.Observe(duration.Seconds())
 }
 
+// NodeCacheHit increases a counter of paged out trie nodes found in the node cache
+func (ec *ExecutionCollector) NodeCacheHit() {
+	ec.nodeCacheHits.Inc()
+}
+
+// NodeCacheMiss increases a counter of paged out trie nodes that had to be loaded from storage
+func (ec *ExecutionCollector) NodeCacheMiss() {
+	ec.nodeCacheMisses.Inc()
+}
+
+// NodeLoadDuration records the time it took to load a paged out trie node from storage
+func (ec *ExecutionCollector) NodeLoadDuration(duration time.Duration) {
+	ec.nodeLoadDuration.Observe(duration.Seconds())
+}
+
+// NodesPagedOut accumulates the number of trie nodes written to storage when paging out tries
+func (ec *ExecutionCollector) NodesPagedOut(number uint64) {
+	ec.nodesPagedOut.Add(float64(number))
+}
+
+// NodesReleased accumulates the number of trie nodes deleted from storage when releasing tries
+func (ec *ExecutionCollector) NodesReleased(number uint64) {
+	ec.nodesReleased.Add(float64(number))
+}
+
 func (ec *ExecutionCollector) ExecutionCollectionRequestSent() {
 	ec.collectionRequestSent.Inc()
 }
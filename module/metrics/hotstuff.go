@@ -14,6 +14,7 @@ const (
 	HotstuffEventTypeTimeout    = "timeout"
 	HotstuffEventTypeOnProposal = "onproposal"
 	HotstuffEventTypeOnVote     = "onvote"
+	HotstuffEventTypeOnTimeout  = "ontimeout"
 )
 
 // HotstuffCollector implements only the metrics emitted by the HotStuff core logic.
@@ -32,6 +33,9 @@ type HotstuffCollector struct {
 	skips                         prometheus.Counter
 	timeouts                      prometheus.Counter
 	timeoutDuration               prometheus.Gauge
+	blockRateDelay                prometheus.Gauge
+	viewDuration                  prometheus.Gauge
+	targetViewDuration            prometheus.Gauge
 	committeeComputationsDuration prometheus.Histogram
 	signerComputationsDuration    prometheus.Histogram
 	validatorComputationsDuration prometheus.Histogram
@@ -109,6 +113,30 @@ func NewHotstuffCollector(chain flow.ChainID) *HotstuffCollector {
 			ConstLabels: prometheus.Labels{LabelChain: chain.String()},
 		}),
 
+		blockRateDelay: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:        "block_rate_delay_seconds",
+			Namespace:   namespaceConsensus,
+			Subsystem:   subsystemHotstuff,
+			Help:        "The current delay for broadcasting proposals, as adjusted by the block rate controller",
+			ConstLabels: prometheus.Labels{LabelChain: chain.String()},
+		}),
+
+		viewDuration: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:        "view_duration_seconds",
+			Namespace:   namespaceConsensus,
+			Subsystem:   subsystemHotstuff,
+			Help:        "The smoothed duration of the recent views, as observed by the block rate controller",
+			ConstLabels: prometheus.Labels{LabelChain: chain.String()},
+		}),
+
+		targetViewDuration: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:        "target_view_duration_seconds",
+			Namespace:   namespaceConsensus,
+			Subsystem:   subsystemHotstuff,
+			Help:        "The view duration the block rate controller is currently aiming for",
+			ConstLabels: prometheus.Labels{LabelChain: chain.String()},
+		}),
+
 		committeeComputationsDuration: promauto.NewHistogram(prometheus.HistogramOpts{
 			Name:        "committee_computations_seconds",
 			Namespace:   namespaceConsensus,
@@ -189,6 +217,21 @@ func (hc *HotstuffCollector) SetTimeout(duration time.Duration) {
 	hc.timeoutDuration.Set(duration.Seconds()) // unit: seconds; with float64 precision
 }
 
+// SetBlockRateDelay sets the current delay for broadcasting proposals.
+func (hc *HotstuffCollector) SetBlockRateDelay(duration time.Duration) {
+	hc.blockRateDelay.Set(duration.Seconds()) // unit: seconds; with float64 precision
+}
+
+// SetViewDuration sets the smoothed duration of the recent views.
+func (hc *HotstuffCollector) SetViewDuration(duration time.Duration) {
+	hc.viewDuration.Set(duration.Seconds()) // unit: seconds; with float64 precision
+}
+
+// SetTargetViewDuration sets the view duration the block rate controller is aiming for.
+func (hc *HotstuffCollector) SetTargetViewDuration(duration time.Duration) {
+	hc.targetViewDuration.Set(duration.Seconds()) // unit: seconds; with float64 precision
+}
+
 // CommitteeProcessingDuration measures the time which the HotStuff's core logic
 // spends in the hotstuff.Committee component, i.e. the time determining consensus
 // committee relations.
@@ -34,6 +34,14 @@ func (c *MetricsConsumer) OnQcTriggeredViewChange(qc *flow.QuorumCertificate, ne
 	c.metrics.CountSkipped()
 }
 
+func (c *MetricsConsumer) OnTcTriggeredViewChange(tc *flow.TimeoutCertificate, newView uint64) {
+	c.metrics.CountSkipped()
+}
+
+func (c *MetricsConsumer) OnViewSyncTriggeredViewChange(timedOutView uint64, newView uint64) {
+	c.metrics.CountSkipped()
+}
+
 func (c *MetricsConsumer) OnReachedTimeout(info *model.TimerInfo) {
 	c.metrics.CountTimeout()
 }
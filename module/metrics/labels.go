@@ -10,6 +10,8 @@ const (
 	LabelNodeRole = "noderole"
 	LabelNodeInfo = "nodeinfo"
 	LabelPriority = "priority"
+
+	LabelReason = "reason"
 )
 
 const (
@@ -29,6 +31,7 @@ const (
 	EngineConsensusIngestion = "consensus_ingestion"
 	EngineMatching           = "matching"
 	EngineSynchronization    = "sync"
+	EngineSlashing           = "slashing"
 	// common
 	EngineFollower = "follower"
 )
@@ -63,12 +66,18 @@ const (
 	ResourceEpochSetup               = "epoch_setup"
 	ResourceEpochCommit              = "epoch_commit"
 	ResourceEpochStatus              = "epoch_status"
+	ResourceStateDeltas              = "state_deltas"                  // execution node, ingestion engine
+	ResourceTransactionTimings       = "transaction_timings"           // access node, transaction metrics
+	ResourceCollectionsToFinalize    = "collections_to_mark_finalized" // access node, ingestion engine
+	ResourceCollectionsToExecute     = "collections_to_mark_executed"  // access node, ingestion engine
+	ResourceBlocksToExecute          = "blocks_to_mark_executed"       // access node, ingestion engine
 )
 
 const (
 	MessageCollectionGuarantee  = "guarantee"
 	MessageBlockProposal        = "proposal"
 	MessageBlockVote            = "vote"
+	MessageBlockTimeout         = "timeout"
 	MessageExecutionReceipt     = "receipt"
 	MessageResultApproval       = "approval"
 	MessageSyncRequest          = "ping"
@@ -79,6 +88,7 @@ const (
 	MessageSyncedBlock          = "synced_block"
 	MessageClusterBlockProposal = "cluster_proposal"
 	MessageClusterBlockVote     = "cluster_vote"
+	MessageClusterBlockTimeout  = "cluster_timeout"
 	MessageClusterBlockResponse = "cluster_block_response"
 	MessageSyncedClusterBlock   = "synced_cluster_block"
 	MessageTransaction          = "transaction"
@@ -87,4 +97,5 @@ const (
 	MessageCollectionResponse   = "collection_response"
 	MessageEntityRequest        = "entity_request"
 	MessageEntityResponse       = "entity_response"
+	MessageSlashingEvidence     = "slashing_evidence"
 )
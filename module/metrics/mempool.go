@@ -12,12 +12,24 @@ import (
 
 type EntriesFunc func() uint
 
+type BytesFunc func() uint64
+
+// reasons for ejecting entities from a mempool
+const (
+	EjectionReasonEntryLimit = "entry_limit" // the mempool has more entries than its limit
+	EjectionReasonByteLimit  = "byte_limit"  // the entries of the mempool are larger than its byte limit
+	EjectionReasonExpired    = "expired"     // the entry has been in the mempool for too long
+)
+
 type MempoolCollector struct {
 	unit         *engine.Unit
 	entries      *prometheus.GaugeVec
+	bytes        *prometheus.GaugeVec
+	ejections    *prometheus.CounterVec
 	interval     time.Duration
 	delay        time.Duration
 	entriesFuncs map[string]EntriesFunc // keeps map of registered EntriesFunc of mempools
+	bytesFuncs   map[string]BytesFunc   // keeps map of registered BytesFunc of mempools
 }
 
 func NewMempoolCollector(interval time.Duration) *MempoolCollector {
@@ -27,6 +39,7 @@ func NewMempoolCollector(interval time.Duration) *MempoolCollector {
 		interval:     interval,
 		delay:        0,
 		entriesFuncs: make(map[string]EntriesFunc),
+		bytesFuncs:   make(map[string]BytesFunc),
 
 		entries: promauto.NewGaugeVec(prometheus.GaugeOpts{
 			Name:      "entries_total",
@@ -34,6 +47,20 @@ func NewMempoolCollector(interval time.Duration) *MempoolCollector {
 			Subsystem: subsystemMempool,
 			Help:      "the number of entries in the mempool",
 		}, []string{LabelResource}),
+
+		bytes: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Name:      "entries_bytes",
+			Namespace: namespaceStorage,
+			Subsystem: subsystemMempool,
+			Help:      "the total size in bytes of the entries in the mempool",
+		}, []string{LabelResource}),
+
+		ejections: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "ejections_total",
+			Namespace: namespaceStorage,
+			Subsystem: subsystemMempool,
+			Help:      "the number of entries ejected from the mempool",
+		}, []string{LabelResource, LabelReason}),
 	}
 
 	return mc
@@ -43,6 +70,16 @@ func (mc *MempoolCollector) MempoolEntries(resource string, entries uint) {
 	mc.entries.With(prometheus.Labels{LabelResource: resource}).Set(float64(entries))
 }
 
+// MempoolBytes reports the total size of the entries of a mempool.
+func (mc *MempoolCollector) MempoolBytes(resource string, bytes uint64) {
+	mc.bytes.With(prometheus.Labels{LabelResource: resource}).Set(float64(bytes))
+}
+
+// MempoolEjection reports an entry ejected from a mempool for the given reason.
+func (mc *MempoolCollector) MempoolEjection(resource string, reason string) {
+	mc.ejections.With(prometheus.Labels{LabelResource: resource, LabelReason: reason}).Inc()
+}
+
 // Register registers entriesFunc for a resource
 func (mc *MempoolCollector) Register(resource string, entriesFunc EntriesFunc) error {
 	mc.unit.Lock()
@@ -57,6 +94,20 @@ func (mc *MempoolCollector) Register(resource string, entriesFunc EntriesFunc) e
 	return nil
 }
 
+// RegisterBytes registers bytesFunc for a resource
+func (mc *MempoolCollector) RegisterBytes(resource string, bytesFunc BytesFunc) error {
+	mc.unit.Lock()
+	defer mc.unit.Unlock()
+
+	if _, ok := mc.bytesFuncs[resource]; ok {
+		return fmt.Errorf("cannot register resource bytes, already exists: %s", resource)
+	}
+
+	mc.bytesFuncs[resource] = bytesFunc
+
+	return nil
+}
+
 func (mc *MempoolCollector) Ready() <-chan struct{} {
 	mc.unit.LaunchPeriodically(mc.gaugeEntries, mc.interval, mc.delay)
 	return mc.unit.Ready()
@@ -73,4 +124,7 @@ func (mc *MempoolCollector) gaugeEntries() {
 	for r, f := range mc.entriesFuncs {
 		mc.MempoolEntries(r, f())
 	}
+	for r, f := range mc.bytesFuncs {
+		mc.MempoolBytes(r, f())
+	}
 }
@@ -31,6 +31,7 @@ const (
 const (
 	subsystemTransactionTiming     = "transaction_timing"
 	subsystemTransactionSubmission = "transaction_submission"
+	subsystemScriptExecution       = "script_execution"
 )
 
 // Collection subsystem
@@ -46,6 +46,9 @@ func (nc *NoopCollector) CacheHit(resource string)
 func (nc *NoopCollector) CacheMiss(resource string)                                              {}
 func (nc *NoopCollector) MempoolEntries(resource string, entries uint)                           {}
 func (nm *NoopCollector) Register(resource string, entriesFunc EntriesFunc) error                { return nil }
+func (nc *NoopCollector) MempoolBytes(resource string, bytes uint64)                             {}
+func (nc *NoopCollector) RegisterBytes(resource string, bytesFunc BytesFunc) error               { return nil }
+func (nc *NoopCollector) MempoolEjection(resource string, reason string)                         {}
 func (nc *NoopCollector) HotStuffBusyDuration(duration time.Duration, event string)              {}
 func (nc *NoopCollector) HotStuffIdleDuration(duration time.Duration)                            {}
 func (nc *NoopCollector) HotStuffWaitDuration(duration time.Duration, event string)              {}
@@ -54,11 +57,15 @@ func (nc *NoopCollector) SetQCView(view uint64)
 func (nc *NoopCollector) CountSkipped()                                                          {}
 func (nc *NoopCollector) CountTimeout()                                                          {}
 func (nc *NoopCollector) SetTimeout(duration time.Duration)                                      {}
+func (nc *NoopCollector) SetBlockRateDelay(duration time.Duration)                               {}
+func (nc *NoopCollector) SetViewDuration(duration time.Duration)                                 {}
+func (nc *NoopCollector) SetTargetViewDuration(duration time.Duration)                           {}
 func (nc *NoopCollector) CommitteeProcessingDuration(duration time.Duration)                     {}
 func (nc *NoopCollector) SignerProcessingDuration(duration time.Duration)                        {}
 func (nc *NoopCollector) ValidatorProcessingDuration(duration time.Duration)                     {}
 func (nc *NoopCollector) PayloadProductionDuration(duration time.Duration)                       {}
 func (nc *NoopCollector) TransactionIngested(txID flow.Identifier)                               {}
+func (nc *NoopCollector) TransactionDropped(reason string)                                       {}
 func (nc *NoopCollector) ClusterBlockProposed(*cluster.Block)                                    {}
 func (nc *NoopCollector) ClusterBlockFinalized(*cluster.Block)                                   {}
 func (nc *NoopCollector) StartCollectionToFinalized(collectionID flow.Identifier)                {}
@@ -83,6 +90,7 @@ func (nc *NoopCollector) ExecutionStateStorageDiskTotal(bytes int64)
 func (nc *NoopCollector) ExecutionStorageStateCommitment(bytes int64)                            {}
 func (nc *NoopCollector) ExecutionLastExecutedBlockHeight(height uint64)                         {}
 func (ec *NoopCollector) ExecutionTotalExecutedTransactions(numberOfTx int)                      {}
+func (nc *NoopCollector) ExecutionTransactionsReExecuted(numReExecuted int)                      {}
 func (nc *NoopCollector) ForestApproxMemorySize(bytes uint64)                                    {}
 func (nc *NoopCollector) ForestNumberOfTrees(number uint64)                                      {}
 func (nc *NoopCollector) LatestTrieRegCount(number uint64)                                       {}
@@ -109,6 +117,13 @@ func (nc *NoopCollector) TransactionFinalized(txID flow.Identifier, when time.Ti
 func (nc *NoopCollector) TransactionExecuted(txID flow.Identifier, when time.Time)               {}
 func (nc *NoopCollector) TransactionExpired(txID flow.Identifier)                                {}
 func (nc *NoopCollector) TransactionSubmissionFailed()                                           {}
+func (nc *NoopCollector) ScriptResultDivergent(executorID flow.Identifier)                       {}
+func (nc *NoopCollector) ScriptResultUndecided()                                                 {}
 func (nc *NoopCollector) ChunkDataPackRequested()                                                {}
 func (nc *NoopCollector) ExecutionSync(syncing bool)                                             {}
 func (nc *NoopCollector) DiskSize(uint64)                                                        {}
+func (nc *NoopCollector) NodeCacheHit()                                                          {}
+func (nc *NoopCollector) NodeCacheMiss()                                                         {}
+func (nc *NoopCollector) NodeLoadDuration(duration time.Duration)                                {}
+func (nc *NoopCollector) NodesPagedOut(number uint64)                                            {}
+func (nc *NoopCollector) NodesReleased(number uint64)                                            {}
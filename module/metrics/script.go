@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+type ScriptCollector struct {
+	divergent *prometheus.CounterVec
+	undecided prometheus.Counter
+}
+
+func NewScriptCollector() *ScriptCollector {
+	sc := &ScriptCollector{
+		divergent: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "divergent_results_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemScriptExecution,
+			Help:      "the number of script results of an execution node which differ from the majority result",
+		}, []string{LabelNodeID}),
+		undecided: promauto.NewCounter(prometheus.CounterOpts{
+			Name:      "undecided_results_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemScriptExecution,
+			Help:      "the number of cross-checked script executions without a confirmed majority result",
+		}),
+	}
+	return sc
+}
+
+func (sc *ScriptCollector) ScriptResultDivergent(executorID flow.Identifier) {
+	sc.divergent.With(prometheus.Labels{LabelNodeID: executorID.String()}).Inc()
+}
+
+func (sc *ScriptCollector) ScriptResultUndecided() {
+	sc.undecided.Inc()
+}
@@ -53,3 +53,24 @@ func (_m *AggregatingVerifier) VerifyMany(msg []byte, sig crypto.Signature, keys
 
 	return r0, r1
 }
+
+// VerifyManyMessages provides a mock function with given fields: msgs, sig, keys
+func (_m *AggregatingVerifier) VerifyManyMessages(msgs [][]byte, sig crypto.Signature, keys []crypto.PublicKey) (bool, error) {
+	ret := _m.Called(msgs, sig, keys)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func([][]byte, crypto.Signature, []crypto.PublicKey) bool); ok {
+		r0 = rf(msgs, sig, keys)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([][]byte, crypto.Signature, []crypto.PublicKey) error); ok {
+		r1 = rf(msgs, sig, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -24,6 +24,11 @@ func (_m *CollectionMetrics) ClusterBlockProposed(block *cluster.Block) {
 	_m.Called(block)
 }
 
+// TransactionDropped provides a mock function with given fields: reason
+func (_m *CollectionMetrics) TransactionDropped(reason string) {
+	_m.Called(reason)
+}
+
 // TransactionIngested provides a mock function with given fields: txID
 func (_m *CollectionMetrics) TransactionIngested(txID flow.Identifier) {
 	_m.Called(txID)
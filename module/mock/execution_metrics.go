@@ -64,6 +64,11 @@ func (_m *ExecutionMetrics) ExecutionTotalExecutedTransactions(numExecuted int)
 	_m.Called(numExecuted)
 }
 
+// ExecutionTransactionsReExecuted provides a mock function with given fields: numReExecuted
+func (_m *ExecutionMetrics) ExecutionTransactionsReExecuted(numReExecuted int) {
+	_m.Called(numReExecuted)
+}
+
 // FinishBlockReceivedToExecuted provides a mock function with given fields: blockID
 func (_m *ExecutionMetrics) FinishBlockReceivedToExecuted(blockID flow.Identifier) {
 	_m.Called(blockID)
@@ -99,6 +104,31 @@ func (_m *ExecutionMetrics) LatestTrieRegCountDiff(number uint64) {
 	_m.Called(number)
 }
 
+// NodeCacheHit provides a mock function with given fields:
+func (_m *ExecutionMetrics) NodeCacheHit() {
+	_m.Called()
+}
+
+// NodeCacheMiss provides a mock function with given fields:
+func (_m *ExecutionMetrics) NodeCacheMiss() {
+	_m.Called()
+}
+
+// NodeLoadDuration provides a mock function with given fields: duration
+func (_m *ExecutionMetrics) NodeLoadDuration(duration time.Duration) {
+	_m.Called(duration)
+}
+
+// NodesPagedOut provides a mock function with given fields: number
+func (_m *ExecutionMetrics) NodesPagedOut(number uint64) {
+	_m.Called(number)
+}
+
+// NodesReleased provides a mock function with given fields: number
+func (_m *ExecutionMetrics) NodesReleased(number uint64) {
+	_m.Called(number)
+}
+
 // ProofSize provides a mock function with given fields: bytes
 func (_m *ExecutionMetrics) ProofSize(bytes uint32) {
 	_m.Called(bytes)
@@ -48,6 +48,11 @@ func (_m *HotstuffMetrics) PayloadProductionDuration(duration time.Duration) {
 	_m.Called(duration)
 }
 
+// SetBlockRateDelay provides a mock function with given fields: duration
+func (_m *HotstuffMetrics) SetBlockRateDelay(duration time.Duration) {
+	_m.Called(duration)
+}
+
 // SetCurView provides a mock function with given fields: view
 func (_m *HotstuffMetrics) SetCurView(view uint64) {
 	_m.Called(view)
@@ -58,11 +63,21 @@ func (_m *HotstuffMetrics) SetQCView(view uint64) {
 	_m.Called(view)
 }
 
+// SetTargetViewDuration provides a mock function with given fields: duration
+func (_m *HotstuffMetrics) SetTargetViewDuration(duration time.Duration) {
+	_m.Called(duration)
+}
+
 // SetTimeout provides a mock function with given fields: duration
 func (_m *HotstuffMetrics) SetTimeout(duration time.Duration) {
 	_m.Called(duration)
 }
 
+// SetViewDuration provides a mock function with given fields: duration
+func (_m *HotstuffMetrics) SetViewDuration(duration time.Duration) {
+	_m.Called(duration)
+}
+
 // SignerProcessingDuration provides a mock function with given fields: duration
 func (_m *HotstuffMetrics) SignerProcessingDuration(duration time.Duration) {
 	_m.Called(duration)
@@ -48,6 +48,31 @@ func (_m *LedgerMetrics) LatestTrieRegCountDiff(number uint64) {
 	_m.Called(number)
 }
 
+// NodeCacheHit provides a mock function with given fields:
+func (_m *LedgerMetrics) NodeCacheHit() {
+	_m.Called()
+}
+
+// NodeCacheMiss provides a mock function with given fields:
+func (_m *LedgerMetrics) NodeCacheMiss() {
+	_m.Called()
+}
+
+// NodeLoadDuration provides a mock function with given fields: duration
+func (_m *LedgerMetrics) NodeLoadDuration(duration time.Duration) {
+	_m.Called(duration)
+}
+
+// NodesPagedOut provides a mock function with given fields: number
+func (_m *LedgerMetrics) NodesPagedOut(number uint64) {
+	_m.Called(number)
+}
+
+// NodesReleased provides a mock function with given fields: number
+func (_m *LedgerMetrics) NodesReleased(number uint64) {
+	_m.Called(number)
+}
+
 // ProofSize provides a mock function with given fields: bytes
 func (_m *LedgerMetrics) ProofSize(bytes uint32) {
 	_m.Called(bytes)
@@ -12,6 +12,16 @@ type MempoolMetrics struct {
 	mock.Mock
 }
 
+// MempoolBytes provides a mock function with given fields: resource, bytes
+func (_m *MempoolMetrics) MempoolBytes(resource string, bytes uint64) {
+	_m.Called(resource, bytes)
+}
+
+// MempoolEjection provides a mock function with given fields: resource, reason
+func (_m *MempoolMetrics) MempoolEjection(resource string, reason string) {
+	_m.Called(resource, reason)
+}
+
 // MempoolEntries provides a mock function with given fields: resource, entries
 func (_m *MempoolMetrics) MempoolEntries(resource string, entries uint) {
 	_m.Called(resource, entries)
@@ -30,3 +40,17 @@ func (_m *MempoolMetrics) Register(resource string, entriesFunc metrics.EntriesF
 
 	return r0
 }
+
+// RegisterBytes provides a mock function with given fields: resource, bytesFunc
+func (_m *MempoolMetrics) RegisterBytes(resource string, bytesFunc metrics.BytesFunc) error {
+	ret := _m.Called(resource, bytesFunc)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, metrics.BytesFunc) error); ok {
+		r0 = rf(resource, bytesFunc)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -60,6 +60,33 @@ func (av *AggregationVerifier) VerifyMany(msg []byte, sig crypto.Signature, keys
 	return true, nil
 }
 
+// VerifyManyMessages will verify the given aggregated signature against the given messages and
+// the provided public keys, where each message is signed by the key at the same index.
+func (av *AggregationVerifier) VerifyManyMessages(msgs [][]byte, sig crypto.Signature, keys []crypto.PublicKey) (bool, error) {
+
+	// NOTE: like VerifyMany, we split the concatenated signature into its parts and verify each
+	// of them separately against its own message
+	c := &Combiner{}
+	sigs, err := c.Split(sig)
+	if err != nil {
+		return false, fmt.Errorf("could not split signatures: %w", err)
+	}
+	if len(keys) != len(sigs) || len(msgs) != len(sigs) {
+		return false, fmt.Errorf("invalid number of messages or public keys (signatures: %d, messages: %d, keys: %d)", len(sigs), len(msgs), len(keys))
+	}
+	for i, sig := range sigs {
+		valid, err := av.Verify(msgs[i], sig, keys[i])
+		if err != nil {
+			return false, fmt.Errorf("could not verify signature (index: %d): %w", i, err)
+		}
+		if !valid {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 // AggregationProvider is an aggregating signer and verifier that can create/verify
 // signatures, as well as aggregating & verifying aggregated signatures.
 // *Important*: the aggregation verifier can only verify signatures in the context
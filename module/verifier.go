@@ -10,10 +10,12 @@ type Verifier interface {
 }
 
 // AggregatingVerifier can verify a message against a signature from either
-// a single key or many keys.
+// a single key or many keys, and can verify an aggregated signature of
+// distinct messages, one message per key.
 type AggregatingVerifier interface {
 	Verifier
 	VerifyMany(msg []byte, sig crypto.Signature, keys []crypto.PublicKey) (bool, error)
+	VerifyManyMessages(msgs [][]byte, sig crypto.Signature, keys []crypto.PublicKey) (bool, error)
 }
 
 // ThresholdVerifier can verify a message against a signature share from a
@@ -25,12 +25,16 @@ func decode(env Envelope) (interface{}, error) {
 		v = &messages.BlockProposal{}
 	case CodeBlockVote:
 		v = &messages.BlockVote{}
+	case CodeBlockTimeout:
+		v = &messages.BlockTimeout{}
 
 	// cluster consensus
 	case CodeClusterBlockProposal:
 		v = &messages.ClusterBlockProposal{}
 	case CodeClusterBlockVote:
 		v = &messages.ClusterBlockVote{}
+	case CodeClusterBlockTimeout:
+		v = &messages.ClusterBlockTimeout{}
 	case CodeClusterBlockResponse:
 		v = &messages.ClusterBlockResponse{}
 
@@ -78,6 +82,10 @@ func decode(env Envelope) (interface{}, error) {
 	case CodeEntityResponse:
 		v = &messages.EntityResponse{}
 
+	// evidence of misbehaviour
+	case CodeSlashingEvidence:
+		v = &flow.SlashingEvidence{}
+
 	// testing
 	case CodeEcho:
 		v = &message.TestMessage{}
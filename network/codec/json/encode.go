@@ -24,6 +24,8 @@ func encode(v interface{}) (*Envelope, error) {
 		code = CodeBlockProposal
 	case *messages.BlockVote:
 		code = CodeBlockVote
+	case *messages.BlockTimeout:
+		code = CodeBlockTimeout
 
 	// protocol state sync
 	case *messages.SyncRequest:
@@ -42,6 +44,8 @@ func encode(v interface{}) (*Envelope, error) {
 		code = CodeClusterBlockProposal
 	case *messages.ClusterBlockVote:
 		code = CodeClusterBlockVote
+	case *messages.ClusterBlockTimeout:
+		code = CodeClusterBlockTimeout
 	case *messages.ClusterBlockResponse:
 		code = CodeClusterBlockResponse
 
@@ -77,6 +81,10 @@ func encode(v interface{}) (*Envelope, error) {
 	case *messages.EntityResponse:
 		code = CodeEntityResponse
 
+	// evidence of misbehaviour
+	case *flow.SlashingEvidence:
+		code = CodeSlashingEvidence
+
 	// testing
 	case *message.TestMessage:
 		code = CodeEcho
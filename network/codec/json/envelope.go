@@ -11,6 +11,7 @@ const (
 	// consensus
 	CodeBlockProposal = iota + 1
 	CodeBlockVote
+	CodeBlockTimeout
 
 	// protocol state sync
 	CodeSyncRequest
@@ -22,6 +23,7 @@ const (
 	// cluster consensus
 	CodeClusterBlockProposal
 	CodeClusterBlockVote
+	CodeClusterBlockTimeout
 	CodeClusterBlockResponse
 
 	// collections, guarantees & transactions
@@ -45,6 +47,9 @@ const (
 	CodeEntityRequest
 	CodeEntityResponse
 
+	// evidence of misbehaviour
+	CodeSlashingEvidence
+
 	// testing
 	CodeEcho
 )
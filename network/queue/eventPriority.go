@@ -43,6 +43,8 @@ func getPriorityByType(message interface{}) Priority {
 		return HighPriority
 	case *messages.BlockVote:
 		return HighPriority
+	case *messages.BlockTimeout:
+		return HighPriority
 
 	// protocol state sync
 	case *messages.SyncRequest:
@@ -61,6 +63,8 @@ func getPriorityByType(message interface{}) Priority {
 		return HighPriority
 	case *messages.ClusterBlockVote:
 		return HighPriority
+	case *messages.ClusterBlockTimeout:
+		return HighPriority
 	case *messages.ClusterBlockResponse:
 		return HighPriority
 
@@ -24,6 +24,19 @@ func (d *Distributor) AddConsumer(consumer protocol.Consumer) {
 	d.subscribers = append(d.subscribers, consumer)
 }
 
+// RemoveConsumer removes the given consumer, so that it does not receive any
+// further events.
+func (d *Distributor) RemoveConsumer(consumer protocol.Consumer) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, sub := range d.subscribers {
+		if sub == consumer {
+			d.subscribers = append(d.subscribers[:i:i], d.subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
 func (d *Distributor) BlockFinalized(block *flow.Header) {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
@@ -17,6 +17,9 @@ const (
 	// codes for views with special meaning
 	codeStartedView = 10 // latest view hotstuff started
 	codeVotedView   = 11 // latest view hotstuff voted on
+	codeVotedBlock  = 12 // block hotstuff last voted for
+	codeLockedQC    = 13 // QC for the block hotstuff was locked on when it last voted
+	codeHighestQC   = 14 // highest QC hotstuff knew of when it last voted or timed out
 
 	// code for heights with special meaning
 	codeFinalizedHeight         = 20 // latest finalized block height
@@ -55,6 +58,12 @@ const (
 	codeEpochSetup  = 60 // EpochSetup service event, keyed by ID
 	codeEpochCommit = 61 // EpochCommit service event, keyed by ID
 
+	// codes for mempools persisted across restarts
+	codePendingTransaction = 70 // transactions pending inclusion in a collection, keyed by epoch and ID
+
+	// codes for evidence of misbehaviour
+	codeSlashingEvidence = 80 // evidence of slashable offences, keyed by ID
+
 	// legacy codes (should be cleaned up)
 	codeChunkDataPack                = 100
 	codeCommit                       = 101
@@ -0,0 +1,35 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// InsertSlashingEvidence inserts evidence of a slashable offence keyed by ID.
+func InsertSlashingEvidence(evidence *flow.SlashingEvidence) func(*badger.Txn) error {
+	return insert(makePrefix(codeSlashingEvidence, evidence.ID()), evidence)
+}
+
+// RetrieveSlashingEvidence retrieves evidence of a slashable offence by ID.
+func RetrieveSlashingEvidence(evidenceID flow.Identifier, evidence *flow.SlashingEvidence) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeSlashingEvidence, evidenceID), evidence)
+}
+
+// RetrieveAllSlashingEvidence retrieves all evidence of slashable offences.
+func RetrieveAllSlashingEvidence(evidence *[]flow.SlashingEvidence) func(*badger.Txn) error {
+	return traverse(makePrefix(codeSlashingEvidence), func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var val flow.SlashingEvidence
+		create := func() interface{} {
+			return &val
+		}
+		handle := func() error {
+			*evidence = append(*evidence, val)
+			return nil
+		}
+		return check, create, handle
+	})
+}
@@ -0,0 +1,43 @@
+package operation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestSlashingEvidence(t *testing.T) {
+
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		evidence1 := unittest.SlashingEvidenceFixture()
+		evidence2 := unittest.SlashingEvidenceFixture()
+
+		err := db.Update(InsertSlashingEvidence(evidence1))
+		require.Nil(t, err)
+		err = db.Update(InsertSlashingEvidence(evidence2))
+		require.Nil(t, err)
+
+		var actual flow.SlashingEvidence
+		err = db.View(RetrieveSlashingEvidence(evidence1.ID(), &actual))
+		require.Nil(t, err)
+		assert.Equal(t, *evidence1, actual)
+
+		var all []flow.SlashingEvidence
+		err = db.View(RetrieveAllSlashingEvidence(&all))
+		require.Nil(t, err)
+		assert.ElementsMatch(t, []flow.SlashingEvidence{*evidence1, *evidence2}, all)
+
+		// the same offence reported by another node is a duplicate
+		duplicate := *evidence1
+		duplicate.ReporterID = unittest.IdentifierFixture()
+		err = db.Update(InsertSlashingEvidence(&duplicate))
+		assert.True(t, errors.Is(err, storage.ErrAlreadyExists))
+	})
+}
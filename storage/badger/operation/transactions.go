@@ -15,3 +15,34 @@ func InsertTransaction(txID flow.Identifier, tx *flow.TransactionBody) func(*bad
 func RetrieveTransaction(txID flow.Identifier, tx *flow.TransactionBody) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeTransaction, txID), tx)
 }
+
+// InsertPendingTransaction inserts a transaction pending inclusion in a collection
+// of the given epoch, keyed by epoch counter and transaction fingerprint.
+func InsertPendingTransaction(epoch uint64, tx *flow.TransactionBody) func(*badger.Txn) error {
+	return insert(makePrefix(codePendingTransaction, epoch, tx.ID()), tx)
+}
+
+// RemovePendingTransaction removes a transaction pending inclusion in a collection
+// of the given epoch.
+func RemovePendingTransaction(epoch uint64, txID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codePendingTransaction, epoch, txID))
+}
+
+// RetrievePendingTransactions retrieves all transactions pending inclusion in a
+// collection of the given epoch.
+func RetrievePendingTransactions(epoch uint64, txs *[]flow.TransactionBody) func(*badger.Txn) error {
+	return traverse(makePrefix(codePendingTransaction, epoch), func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var val flow.TransactionBody
+		create := func() interface{} {
+			return &val
+		}
+		handle := func() error {
+			*txs = append(*txs, val)
+			return nil
+		}
+		return check, create, handle
+	})
+}
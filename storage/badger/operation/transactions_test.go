@@ -1,6 +1,7 @@
 package operation
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/dgraph-io/badger/v2"
@@ -8,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -24,3 +26,37 @@ func TestTransactions(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 }
+
+func TestPendingTransactions(t *testing.T) {
+
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		tx1 := unittest.TransactionBodyFixture()
+		tx2 := unittest.TransactionBodyFixture()
+		other := unittest.TransactionBodyFixture()
+
+		err := db.Update(InsertPendingTransaction(1, &tx1))
+		require.Nil(t, err)
+		err = db.Update(InsertPendingTransaction(1, &tx2))
+		require.Nil(t, err)
+		err = db.Update(InsertPendingTransaction(2, &other))
+		require.Nil(t, err)
+
+		// only transactions of the requested epoch are retrieved
+		var actual []flow.TransactionBody
+		err = db.View(RetrievePendingTransactions(1, &actual))
+		require.Nil(t, err)
+		assert.ElementsMatch(t, []flow.TransactionBody{tx1, tx2}, actual)
+
+		err = db.Update(RemovePendingTransaction(1, tx1.ID()))
+		require.Nil(t, err)
+
+		actual = nil
+		err = db.View(RetrievePendingTransactions(1, &actual))
+		require.Nil(t, err)
+		assert.Equal(t, []flow.TransactionBody{tx2}, actual)
+
+		// removing a missing transaction fails
+		err = db.Update(RemovePendingTransaction(1, tx1.ID()))
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+	})
+}
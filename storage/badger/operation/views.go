@@ -35,3 +35,48 @@ func UpdateVotedView(chainID flow.ChainID, view uint64) func(*badger.Txn) error
 func RetrieveVotedView(chainID flow.ChainID, view *uint64) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeVotedView, chainID), view)
 }
+
+// InsertVotedBlock inserts the ID of the last voted block into the database.
+func InsertVotedBlock(chainID flow.ChainID, blockID flow.Identifier) func(*badger.Txn) error {
+	return insert(makePrefix(codeVotedBlock, chainID), blockID)
+}
+
+// UpdateVotedBlock updates the ID of the last voted block in the database.
+func UpdateVotedBlock(chainID flow.ChainID, blockID flow.Identifier) func(*badger.Txn) error {
+	return update(makePrefix(codeVotedBlock, chainID), blockID)
+}
+
+// RetrieveVotedBlock retrieves the ID of the last voted block from the database.
+func RetrieveVotedBlock(chainID flow.ChainID, blockID *flow.Identifier) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeVotedBlock, chainID), blockID)
+}
+
+// InsertLockedQC inserts the locked QC into the database.
+func InsertLockedQC(chainID flow.ChainID, qc *flow.QuorumCertificate) func(*badger.Txn) error {
+	return insert(makePrefix(codeLockedQC, chainID), qc)
+}
+
+// UpdateLockedQC updates the locked QC in the database.
+func UpdateLockedQC(chainID flow.ChainID, qc *flow.QuorumCertificate) func(*badger.Txn) error {
+	return update(makePrefix(codeLockedQC, chainID), qc)
+}
+
+// RetrieveLockedQC retrieves the locked QC from the database.
+func RetrieveLockedQC(chainID flow.ChainID, qc *flow.QuorumCertificate) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeLockedQC, chainID), qc)
+}
+
+// InsertHighestQC inserts the highest QC into the database.
+func InsertHighestQC(chainID flow.ChainID, qc *flow.QuorumCertificate) func(*badger.Txn) error {
+	return insert(makePrefix(codeHighestQC, chainID), qc)
+}
+
+// UpdateHighestQC updates the highest QC in the database.
+func UpdateHighestQC(chainID flow.ChainID, qc *flow.QuorumCertificate) func(*badger.Txn) error {
+	return update(makePrefix(codeHighestQC, chainID), qc)
+}
+
+// RetrieveHighestQC retrieves the highest QC from the database.
+func RetrieveHighestQC(chainID flow.ChainID, qc *flow.QuorumCertificate) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeHighestQC, chainID), qc)
+}
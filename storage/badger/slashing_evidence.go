@@ -0,0 +1,46 @@
+package badger
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// SlashingEvidence implements persistent storage for evidence of slashable
+// offences. Evidence is rare and only read by operators, so it is not cached.
+type SlashingEvidence struct {
+	db *badger.DB
+}
+
+func NewSlashingEvidence(db *badger.DB) *SlashingEvidence {
+	return &SlashingEvidence{
+		db: db,
+	}
+}
+
+func (s *SlashingEvidence) Store(evidence *flow.SlashingEvidence) error {
+	return operation.RetryOnConflict(s.db.Update, operation.InsertSlashingEvidence(evidence))
+}
+
+func (s *SlashingEvidence) ByID(evidenceID flow.Identifier) (*flow.SlashingEvidence, error) {
+	var evidence flow.SlashingEvidence
+	err := s.db.View(operation.RetrieveSlashingEvidence(evidenceID, &evidence))
+	if err != nil {
+		return nil, err
+	}
+	return &evidence, nil
+}
+
+func (s *SlashingEvidence) All() ([]*flow.SlashingEvidence, error) {
+	var all []flow.SlashingEvidence
+	err := s.db.View(operation.RetrieveAllSlashingEvidence(&all))
+	if err != nil {
+		return nil, err
+	}
+	evidence := make([]*flow.SlashingEvidence, 0, len(all))
+	for i := range all {
+		evidence = append(evidence, &all[i])
+	}
+	return evidence, nil
+}
@@ -0,0 +1,43 @@
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+
+	badgerstorage "github.com/onflow/flow-go/storage/badger"
+)
+
+func TestSlashingEvidenceStoreAndRetrieve(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := badgerstorage.NewSlashingEvidence(db)
+
+		_, err := store.ByID(unittest.IdentifierFixture())
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+		all, err := store.All()
+		require.NoError(t, err)
+		assert.Empty(t, all)
+
+		expected := unittest.SlashingEvidenceFixture()
+		require.NoError(t, store.Store(expected))
+
+		actual, err := store.ByID(expected.ID())
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+
+		all, err = store.All()
+		require.NoError(t, err)
+		assert.Equal(t, []*flow.SlashingEvidence{expected}, all)
+
+		err = store.Store(expected)
+		assert.True(t, errors.Is(err, storage.ErrAlreadyExists))
+	})
+}
@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// SlashingEvidence represents persistent storage for evidence of slashable
+// offences.
+type SlashingEvidence interface {
+
+	// Store inserts the evidence. It returns ErrAlreadyExists if evidence of
+	// the same offence was already stored.
+	Store(evidence *flow.SlashingEvidence) error
+
+	// ByID retrieves the evidence by ID.
+	ByID(evidenceID flow.Identifier) (*flow.SlashingEvidence, error)
+
+	// All retrieves all stored evidence.
+	All() ([]*flow.SlashingEvidence, error)
+}
@@ -1058,6 +1058,22 @@ func EpochCommitFixture(opts ...func(*flow.EpochCommit)) *flow.EpochCommit {
 	return commit
 }
 
+func SlashingEvidenceFixture() *flow.SlashingEvidence {
+	return &flow.SlashingEvidence{
+		Body: flow.SlashingEvidenceBody{
+			Violation:  flow.SlashingDoubleVote,
+			OffenderID: IdentifierFixture(),
+			View:       uint64(rand.Uint32()),
+			Votes: []flow.SignedVote{
+				{BlockID: IdentifierFixture(), SigData: SignatureFixture()},
+				{BlockID: IdentifierFixture(), SigData: SignatureFixture()},
+			},
+		},
+		ReporterID:  IdentifierFixture(),
+		ReporterSig: SignatureFixture(),
+	}
+}
+
 // BootstrapFixture generates all the artifacts necessary to bootstrap the
 // protocol state.
 func BootstrapFixture(participants flow.IdentityList, opts ...func(*flow.Block)) (*flow.Block, *flow.ExecutionResult, *flow.Seal) {